@@ -26,6 +26,7 @@ var (
 		Subcommands: []*cli.Command{
 			subcmdUser,
 			subcmdRepoSyncReleases,
+			subcmdRepoConvert,
 			subcmdRegenerate,
 			subcmdAuth,
 			subcmdSendMail,
@@ -53,6 +54,8 @@ var (
 		Subcommands: []*cli.Command{
 			microcmdAuthAddOauth,
 			microcmdAuthUpdateOauth,
+			microcmdAuthAddSAML,
+			microcmdAuthUpdateSAML,
 			microcmdAuthAddLdapBindDn,
 			microcmdAuthUpdateLdapBindDn,
 			microcmdAuthAddLdapSimpleAuth,
@@ -0,0 +1,268 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/services/auth/source/saml"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	samlCLIFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Authentication name",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-url",
+			Value: "",
+			Usage: "URL of the identity provider metadata, fetched when the source is saved",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-file",
+			Value: "",
+			Usage: "Path to a file holding the identity provider metadata",
+		},
+		&cli.StringFlag{
+			Name:  "name-id-format",
+			Value: "",
+			Usage: "Name identifier format requested from the identity provider (default: persistent)",
+		},
+		&cli.BoolFlag{
+			Name:  "sign-requests",
+			Usage: "Sign the authentication requests sent to the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "sp-certificate-file",
+			Value: "",
+			Usage: "Path to the PEM certificate of the service provider (generated if not set)",
+		},
+		&cli.StringFlag{
+			Name:  "sp-private-key-file",
+			Value: "",
+			Usage: "Path to the PEM private key of the service provider (generated if not set)",
+		},
+		&cli.StringFlag{
+			Name:  "icon-url",
+			Value: "",
+			Usage: "Custom icon URL for the SAML login source",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-local-2fa",
+			Usage: "Set to true to skip local 2fa for users authenticated by this source",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-username",
+			Value: "",
+			Usage: "Attribute holding the username (defaults to the name identifier)",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-email",
+			Value: "",
+			Usage: "Attribute holding the email address (defaults to the name identifier)",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-full-name",
+			Value: "",
+			Usage: "Attribute holding the full name",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-ssh-public-key",
+			Value: "",
+			Usage: "Attribute holding the SSH public keys",
+		},
+		&cli.StringFlag{
+			Name:  "group-attribute",
+			Value: "",
+			Usage: "Attribute holding the group names",
+		},
+		&cli.StringFlag{
+			Name:  "admin-group",
+			Value: "",
+			Usage: "Group of the administrator users",
+		},
+		&cli.StringFlag{
+			Name:  "restricted-group",
+			Value: "",
+			Usage: "Group of the restricted users",
+		},
+		&cli.StringFlag{
+			Name:  "group-team-map",
+			Value: "",
+			Usage: "JSON mapping between groups and org teams",
+		},
+		&cli.BoolFlag{
+			Name:  "group-team-map-removal",
+			Usage: "Activate automatic team membership removal depending on groups",
+		},
+	}
+
+	microcmdAuthAddSAML = &cli.Command{
+		Name:   "add-saml",
+		Usage:  "Add new SAML authentication source",
+		Action: runAddSAML,
+		Flags:  samlCLIFlags,
+	}
+
+	microcmdAuthUpdateSAML = &cli.Command{
+		Name:   "update-saml",
+		Usage:  "Update existing SAML authentication source",
+		Action: runUpdateSAML,
+		Flags:  append(samlCLIFlags[:1], append([]cli.Flag{idFlag}, samlCLIFlags[1:]...)...),
+	}
+)
+
+func readSAMLFileFlag(c *cli.Context, name string) (string, error) {
+	if !c.IsSet(name) {
+		return "", nil
+	}
+	content, err := os.ReadFile(c.String(name))
+	if err != nil {
+		return "", fmt.Errorf("read --%s: %w", name, err)
+	}
+	return string(content), nil
+}
+
+// setSAMLConfig applies the flags set on the command line to the SAML configuration
+func setSAMLConfig(c *cli.Context, config *saml.Source) error {
+	if c.IsSet("idp-metadata-url") {
+		config.IdentityProviderMetadataURL = c.String("idp-metadata-url")
+	}
+	if c.IsSet("idp-metadata-file") {
+		metadata, err := readSAMLFileFlag(c, "idp-metadata-file")
+		if err != nil {
+			return err
+		}
+		config.IdentityProviderMetadata = metadata
+		config.IdentityProviderMetadataURL = ""
+	}
+	if c.IsSet("sp-certificate-file") != c.IsSet("sp-private-key-file") {
+		return errors.New("--sp-certificate-file and --sp-private-key-file must be set together")
+	}
+	if c.IsSet("sp-certificate-file") {
+		certificate, err := readSAMLFileFlag(c, "sp-certificate-file")
+		if err != nil {
+			return err
+		}
+		privateKey, err := readSAMLFileFlag(c, "sp-private-key-file")
+		if err != nil {
+			return err
+		}
+		config.ServiceProviderCertificate = certificate
+		config.ServiceProviderPrivateKey = privateKey
+	}
+	if c.IsSet("name-id-format") {
+		config.NameIDFormat = c.String("name-id-format")
+	}
+	if c.IsSet("sign-requests") {
+		config.SignRequests = c.Bool("sign-requests")
+	}
+	if c.IsSet("icon-url") {
+		config.IconURL = c.String("icon-url")
+	}
+	if c.IsSet("skip-local-2fa") {
+		config.SkipLocalTwoFA = c.Bool("skip-local-2fa")
+	}
+	if c.IsSet("attribute-username") {
+		config.AttributeUsername = c.String("attribute-username")
+	}
+	if c.IsSet("attribute-email") {
+		config.AttributeEmail = c.String("attribute-email")
+	}
+	if c.IsSet("attribute-full-name") {
+		config.AttributeFullName = c.String("attribute-full-name")
+	}
+	if c.IsSet("attribute-ssh-public-key") {
+		config.AttributeSSHPublicKey = c.String("attribute-ssh-public-key")
+	}
+	if c.IsSet("group-attribute") {
+		config.GroupAttribute = c.String("group-attribute")
+	}
+	if c.IsSet("admin-group") {
+		config.AdminGroup = c.String("admin-group")
+	}
+	if c.IsSet("restricted-group") {
+		config.RestrictedGroup = c.String("restricted-group")
+	}
+	if c.IsSet("group-team-map") {
+		config.GroupTeamMap = c.String("group-team-map")
+	}
+	if c.IsSet("group-team-map-removal") {
+		config.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
+	}
+	return nil
+}
+
+func runAddSAML(c *cli.Context) error {
+	if !c.IsSet("name") {
+		return errors.New("--name flag is missing")
+	}
+	if !c.IsSet("idp-metadata-url") && !c.IsSet("idp-metadata-file") {
+		return errors.New("--idp-metadata-url or --idp-metadata-file is required")
+	}
+
+	ctx, cancel := installSignals()
+	defer cancel()
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	config := &saml.Source{}
+	if err := setSAMLConfig(c, config); err != nil {
+		return err
+	}
+	if err := config.Initialize(ctx, nil); err != nil {
+		return err
+	}
+
+	return auth_model.CreateSource(ctx, &auth_model.Source{
+		Type:     auth_model.SAML,
+		Name:     c.String("name"),
+		IsActive: true,
+		Cfg:      config,
+	})
+}
+
+func runUpdateSAML(c *cli.Context) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	ctx, cancel := installSignals()
+	defer cancel()
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := auth_model.GetSourceByID(ctx, c.Int64("id"))
+	if err != nil {
+		return err
+	}
+	config, ok := source.Cfg.(*saml.Source)
+	if !ok {
+		return fmt.Errorf("authentication source %d is not a SAML source", source.ID)
+	}
+
+	if c.IsSet("name") {
+		source.Name = c.String("name")
+	}
+	if err := setSAMLConfig(c, config); err != nil {
+		return err
+	}
+	if err := config.Initialize(ctx, nil); err != nil {
+		return err
+	}
+	source.Cfg = config
+
+	return auth_model.UpdateSource(ctx, source)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/storage"
+	repo_service "forgejo.org/services/repository"
+
+	"github.com/urfave/cli/v2"
+)
+
+var subcmdRepoConvert = &cli.Command{
+	Name:  "repo-convert",
+	Usage: "Convert the object format or the ref storage of a repository",
+	Description: "Rewrites a repository and its wiki with another object format, updating the commit IDs stored in the database, " +
+		"or moves their references to another storage. Pushes to the repository are refused until it is done. " +
+		"The signatures of the commits and tags cannot be kept when converting the object format.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "owner",
+			Usage:    "Owner of the repository",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "Name of the repository",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "object-format",
+			Usage: "Object format to convert to: sha1 or sha256",
+		},
+		&cli.StringFlag{
+			Name:  "ref-format",
+			Usage: "Ref storage to convert to: files or reftable",
+		},
+	},
+	Action: runRepoConvert,
+}
+
+func runRepoConvert(c *cli.Context) error {
+	if !c.IsSet("object-format") && !c.IsSet("ref-format") {
+		return errors.New("You must provide the object format or the ref format to convert to")
+	}
+
+	ctx, cancel := installSignals()
+	defer cancel()
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+	// the version of git tells which formats are supported
+	if err := git.InitFull(ctx); err != nil {
+		return err
+	}
+	if err := storage.Init(); err != nil {
+		return err
+	}
+
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, c.String("owner"), c.String("name"))
+	if err != nil {
+		return err
+	}
+	if err := repo_service.ConvertRepository(ctx, repo, &repo_service.ConvertOptions{
+		RepoID:           repo.ID,
+		ObjectFormatName: c.String("object-format"),
+		RefFormat:        git.RefFormat(c.String("ref-format")),
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("%s has been converted\n", repo.FullName())
+	return nil
+}
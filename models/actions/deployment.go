@@ -0,0 +1,188 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentStatus is the status of the deployment of a job to an environment
+type DeploymentStatus int
+
+const (
+	// DeploymentStatusWaiting waits for a reviewer of the environment to approve the job
+	DeploymentStatusWaiting DeploymentStatus = iota
+	// DeploymentStatusApproved lets the job be dispatched, once the wait timer has elapsed
+	DeploymentStatusApproved
+	// DeploymentStatusRejected has been rejected by a reviewer, the job fails
+	DeploymentStatusRejected
+	// DeploymentStatusRefNotAllowed is for a ref not allowed to deploy to the environment, the job fails
+	DeploymentStatusRefNotAllowed
+)
+
+// String returns the name of the status, as used by the locale keys
+func (s DeploymentStatus) String() string {
+	switch s {
+	case DeploymentStatusWaiting:
+		return "waiting"
+	case DeploymentStatusApproved:
+		return "approved"
+	case DeploymentStatusRejected:
+		return "rejected"
+	case DeploymentStatusRefNotAllowed:
+		return "ref_not_allowed"
+	}
+	return "unknown"
+}
+
+// ActionDeployment records the deployment of an attempt of a job to an environment,
+// the deployments of an environment are its deployment history
+type ActionDeployment struct {
+	ID            int64              `xorm:"pk autoincr"`
+	RepoID        int64              `xorm:"INDEX NOT NULL"`
+	EnvironmentID int64              `xorm:"INDEX NOT NULL"`
+	RunID         int64              `xorm:"INDEX NOT NULL"`
+	JobID         int64              `xorm:"INDEX NOT NULL"` // the ID of the ActionRunJob
+	Ref           string             `xorm:"VARCHAR(255)"`
+	CommitSHA     string             `xorm:"VARCHAR(64)"`
+	TriggerUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+	Status        DeploymentStatus   `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ReviewerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	ReviewedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// DispatchAfter is the end of the wait timer of the environment, set once approved
+	DispatchAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+
+	Run         *ActionRun         `xorm:"-"`
+	Job         *ActionRunJob      `xorm:"-"`
+	Reviewer    *user_model.User   `xorm:"-"`
+	Environment *ActionEnvironment `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// IsDispatchable returns whether the job of the deployment can be dispatched to the runners
+func (d *ActionDeployment) IsDispatchable() bool {
+	return d.Status == DeploymentStatusApproved && d.DispatchAfter <= timeutil.TimeStampNow()
+}
+
+// IsCurrent returns whether the deployment gates the current attempt of its job,
+// the deployments of the previous attempts of a job which has been rerun are not
+func (d *ActionDeployment) IsCurrent() bool {
+	return d.Job != nil && d.Job.DeploymentID == d.ID
+}
+
+// LoadAttributes loads the run with its repository and trigger user, the job and the reviewer of the deployment
+func (d *ActionDeployment) LoadAttributes(ctx context.Context) error {
+	if d.Job == nil {
+		job, err := GetRunJobByID(ctx, d.JobID)
+		if err != nil {
+			return err
+		}
+		d.Job = job
+	}
+	if d.Run == nil {
+		run, err := GetRunByID(ctx, d.RunID)
+		if err != nil {
+			return err
+		}
+		d.Run = run
+	}
+	if err := d.Run.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	d.Job.Run = d.Run
+	if d.Reviewer == nil && d.ReviewerID != 0 {
+		reviewer, err := user_model.GetPossibleUserByID(ctx, d.ReviewerID)
+		if err != nil {
+			return err
+		}
+		d.Reviewer = reviewer
+	}
+	return nil
+}
+
+// FindDeploymentsOptions are the options to find the deployments of a repository
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	EnvironmentID int64
+	Status        []DeploymentStatus
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.EnvironmentID > 0 {
+		cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
+	}
+	if len(opts.Status) > 0 {
+		cond = cond.And(builder.In("status", opts.Status))
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// GetDeploymentByID returns a deployment of the repository by its ID
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	d := new(ActionDeployment)
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("deployment does not exist")
+	}
+	return d, nil
+}
+
+// InsertDeployment records the deployment of the current attempt of a job
+func InsertDeployment(ctx context.Context, d *ActionDeployment, job *ActionRunJob) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, d); err != nil {
+			return err
+		}
+		job.DeploymentID = d.ID
+		_, err := db.GetEngine(ctx).ID(job.ID).Cols("deployment_id").Update(job)
+		return err
+	})
+}
+
+// ReviewDeployment records the approval or the rejection of a waiting deployment, it returns
+// false when the deployment has been reviewed concurrently
+func ReviewDeployment(ctx context.Context, d *ActionDeployment, status DeploymentStatus, reviewerID int64, dispatchAfter timeutil.TimeStamp) (bool, error) {
+	d.Status = status
+	d.ReviewerID = reviewerID
+	d.ReviewedUnix = timeutil.TimeStampNow()
+	d.DispatchAfter = dispatchAfter
+	n, err := db.GetEngine(ctx).ID(d.ID).Where("status = ?", DeploymentStatusWaiting).
+		Cols("status", "reviewer_id", "reviewed_unix", "dispatch_after").Update(d)
+	return n == 1, err
+}
+
+// FindRunIDsOfElapsedDeployments returns the runs having a blocked job of which the deployment
+// has been approved and the wait timer has elapsed
+func FindRunIDsOfElapsedDeployments(ctx context.Context) ([]int64, error) {
+	runIDs := make([]int64, 0, 10)
+	return runIDs, db.GetEngine(ctx).Table("action_deployment").
+		Join("INNER", "action_run_job", "action_run_job.deployment_id = action_deployment.id").
+		Where(builder.Eq{"action_deployment.status": DeploymentStatusApproved}).
+		And(builder.Lte{"action_deployment.dispatch_after": timeutil.TimeStampNow()}).
+		And(builder.Eq{"action_run_job.status": StatusBlocked}).
+		Distinct("action_deployment.run_id").
+		Find(&runIDs)
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+	"xorm.io/builder"
+)
+
+// ActionEnvironment is a deployment environment of a repository, the jobs declaring it with
+// `jobs.<job_id>.environment` are only dispatched once its protection rules are satisfied
+// and they receive its secrets and variables in addition to the ones of the repository
+type ActionEnvironment struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"INDEX UNIQUE(repo_name) NOT NULL"`
+	Name   string `xorm:"UNIQUE(repo_name) NOT NULL"`
+	// ReviewerIDs are the users of which one must approve a job before it is dispatched
+	ReviewerIDs       []int64 `xorm:"JSON TEXT"`
+	PreventSelfReview bool    `xorm:"NOT NULL DEFAULT false"`
+	// WaitTimer is the number of minutes to wait before dispatching a job, once approved
+	WaitTimer int64 `xorm:"NOT NULL DEFAULT 0"`
+	// RefPatterns are the glob patterns, separated by `;`, of the refs allowed to deploy to the
+	// environment, all are allowed when empty. A pattern not starting with `refs/` is a branch name.
+	RefPatterns string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+// HasReviewers returns whether the jobs deploying to the environment must be approved
+func (env *ActionEnvironment) HasReviewers() bool {
+	return len(env.ReviewerIDs) > 0
+}
+
+// IsReviewer returns whether the user can approve the jobs deploying to the environment
+func (env *ActionEnvironment) IsReviewer(userID int64) bool {
+	return slices.Contains(env.ReviewerIDs, userID)
+}
+
+// IsRefAllowed returns whether a workflow run for the ref can deploy to the environment,
+// the patterns are matched against the full ref so that a tag cannot pass for a branch of the same name
+func (env *ActionEnvironment) IsRefAllowed(ref string) bool {
+	if strings.TrimSpace(env.RefPatterns) == "" {
+		return true
+	}
+	for _, expr := range strings.Split(env.RefPatterns, ";") {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		if !strings.HasPrefix(expr, "refs/") {
+			expr = git.BranchPrefix + expr
+		}
+		g, err := glob.Compile(expr, '/')
+		if err != nil {
+			log.Info("Invalid glob expression '%s' of environment %d (skipped): %v", expr, env.ID, err)
+			continue
+		}
+		if g.Match(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseJobEnvironment returns the name of the environment the job of a single job workflow payload
+// deploys to, declared either as `environment: name` or as `environment: {name: name, url: url}`
+func ParseJobEnvironment(workflowPayload []byte) string {
+	var workflow struct {
+		Jobs map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(workflowPayload, &workflow); err != nil {
+		return ""
+	}
+	for _, job := range workflow.Jobs {
+		switch job.Environment.Kind {
+		case yaml.ScalarNode:
+			return strings.TrimSpace(job.Environment.Value)
+		case yaml.MappingNode:
+			var env struct {
+				Name string `yaml:"name"`
+			}
+			if err := job.Environment.Decode(&env); err == nil {
+				return strings.TrimSpace(env.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// FindEnvironmentsOptions are the options to find the environments of a repository
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	RepoID int64
+	Name   string
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "name ASC"
+}
+
+// GetEnvironmentByID returns the environment of the repository by its ID
+func GetEnvironmentByID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	env := new(ActionEnvironment)
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment does not exist")
+	}
+	return env, nil
+}
+
+// GetEnvironmentByName returns the environment of the repository by its name
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env := new(ActionEnvironment)
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND name = ?", repoID, name).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment does not exist")
+	}
+	return env, nil
+}
+
+// InsertEnvironment creates an environment, its name must be unique in the repository
+func InsertEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	exists, err := db.Exist[ActionEnvironment](ctx, builder.Eq{"repo_id": env.RepoID, "name": env.Name})
+	if err != nil {
+		return err
+	} else if exists {
+		return util.NewAlreadyExistErrorf("environment %s already exists", env.Name)
+	}
+	return db.Insert(ctx, env)
+}
+
+// UpdateEnvironment updates the protection rules of an environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	_, err := db.GetEngine(ctx).ID(env.ID).Cols("reviewer_ids", "prevent_self_review", "wait_timer", "ref_patterns").Update(env)
+	return err
+}
+
+// DeleteEnvironment deletes an environment with its variables and deployments,
+// its secrets must be deleted by the caller
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &ActionVariable{RepoID: env.RepoID, EnvironmentID: env.ID}); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByBean(ctx, &ActionDeployment{EnvironmentID: env.ID}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJobEnvironment(t *testing.T) {
+	assert.Empty(t, ParseJobEnvironment([]byte("jobs:\n  build:\n    runs-on: docker\n")))
+	assert.Equal(t, "production", ParseJobEnvironment([]byte("jobs:\n  deploy:\n    environment: production\n")))
+	assert.Equal(t, "staging", ParseJobEnvironment([]byte("jobs:\n  deploy:\n    environment:\n      name: staging\n      url: https://example.com\n")))
+	assert.Empty(t, ParseJobEnvironment([]byte("{")))
+}
+
+func TestActionEnvironment_IsRefAllowed(t *testing.T) {
+	env := &ActionEnvironment{}
+	assert.True(t, env.IsRefAllowed("refs/heads/feature"))
+
+	env.RefPatterns = "main; release/*;refs/tags/v*"
+	assert.True(t, env.IsRefAllowed("refs/heads/main"))
+	assert.True(t, env.IsRefAllowed("refs/heads/release/1.0"))
+	assert.True(t, env.IsRefAllowed("refs/tags/v1.0.0"))
+	assert.False(t, env.IsRefAllowed("refs/heads/feature"))
+	assert.False(t, env.IsRefAllowed("refs/heads/release/1.0/hotfix"))
+	assert.False(t, env.IsRefAllowed("refs/tags/main"))
+	assert.False(t, env.IsRefAllowed("refs/heads/v1.0.0"))
+	assert.False(t, env.IsRefAllowed("refs/pull/1/head"))
+}
+
+func TestActionEnvironment_IsReviewer(t *testing.T) {
+	env := &ActionEnvironment{ReviewerIDs: []int64{2, 5}}
+	assert.True(t, env.HasReviewers())
+	assert.True(t, env.IsReviewer(5))
+	assert.False(t, env.IsReviewer(3))
+}
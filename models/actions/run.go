@@ -227,8 +227,11 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 			return err
 		}
 		payload, _ := v.Marshal()
+		environment := ParseJobEnvironment(payload)
 		status := StatusWaiting
-		if len(needs) > 0 || run.NeedApproval {
+		// the jobs deploying to an environment are unblocked by the job emitter once the
+		// protection rules of the environment are satisfied
+		if len(needs) > 0 || run.NeedApproval || environment != "" {
 			status = StatusBlocked
 		} else {
 			hasWaiting = true
@@ -245,6 +248,7 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 			JobID:             id,
 			Needs:             needs,
 			RunsOn:            job.RunsOn(),
+			Environment:       environment,
 			Status:            status,
 		})
 	}
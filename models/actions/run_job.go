@@ -32,12 +32,16 @@ type ActionRunJob struct {
 	JobID             string   `xorm:"VARCHAR(255)"` // job id in workflow, not job's id
 	Needs             []string `xorm:"JSON TEXT"`
 	RunsOn            []string `xorm:"JSON TEXT"`
-	TaskID            int64    // the latest task of the job
-	Status            Status   `xorm:"index"`
-	Started           timeutil.TimeStamp
-	Stopped           timeutil.TimeStamp
-	Created           timeutil.TimeStamp `xorm:"created"`
-	Updated           timeutil.TimeStamp `xorm:"updated index"`
+	// Environment is the name of the environment the job deploys to, it is dispatched once
+	// the deployment DeploymentID of its current attempt is approved
+	Environment  string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	DeploymentID int64  `xorm:"NOT NULL DEFAULT 0"`
+	TaskID       int64  // the latest task of the job
+	Status       Status `xorm:"index"`
+	Started      timeutil.TimeStamp
+	Stopped      timeutil.TimeStamp
+	Created      timeutil.TimeStamp `xorm:"created"`
+	Updated      timeutil.TimeStamp `xorm:"updated index"`
 }
 
 func init() {
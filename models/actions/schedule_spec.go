@@ -37,14 +37,20 @@ type ActionScheduleSpec struct {
 // Parse parses the spec and returns a cron.Schedule
 // Unlike the default cron parser, Parse uses UTC timezone as the default if none is specified.
 func (s *ActionScheduleSpec) Parse() (cron.Schedule, error) {
+	return ParseScheduleSpec(s.Spec)
+}
+
+// ParseScheduleSpec parses a cron spec the same way as the schedule of workflows,
+// using UTC timezone as the default if none is specified.
+func ParseScheduleSpec(spec string) (cron.Schedule, error) {
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
-	schedule, err := parser.Parse(s.Spec)
+	schedule, err := parser.Parse(spec)
 	if err != nil {
 		return nil, err
 	}
 
 	// If the spec has specified a timezone, use it
-	if strings.HasPrefix(s.Spec, "TZ=") || strings.HasPrefix(s.Spec, "CRON_TZ=") {
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
 		return schedule, nil
 	}
 
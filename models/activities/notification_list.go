@@ -145,6 +145,14 @@ func createOrUpdateIssueNotifications(ctx context.Context, issueID, commentID, n
 		return err
 	}
 
+	var comment *issues_model.Comment
+	if commentID > 0 {
+		if comment, err = issues_model.GetCommentByID(ctx, commentID); err != nil && !issues_model.IsErrCommentNotExist(err) {
+			return err
+		}
+	}
+	isRestricted := issue.IsConfidential || comment != nil && comment.IsInternal
+
 	// notify
 	for userID := range toNotify {
 		issue.Repo.Units = nil
@@ -162,6 +170,15 @@ func createOrUpdateIssueNotifications(ctx context.Context, issueID, commentID, n
 		if !issue.IsPull && !access_model.CheckRepoUnitUser(ctx, issue.Repo, user, unit.TypeIssues) {
 			continue
 		}
+		if isRestricted {
+			perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, user)
+			if err != nil {
+				return err
+			}
+			if !issue.CanBeReadBy(user, perm) || comment != nil && !comment.CanBeReadBy(issue, perm) {
+				continue
+			}
+		}
 
 		if notificationExists(notifications, issue.ID, userID) {
 			if err = updateIssueNotification(ctx, userID, issue.ID, commentID, notificationAuthorID); err != nil {
@@ -200,6 +200,22 @@ func GetMigratingTaskByID(ctx context.Context, id, doerID int64) (*Task, *migrat
 	return &task, &opts, nil
 }
 
+// GetTaskByID returns the task of the given type created by the doer
+func GetTaskByID(ctx context.Context, id, doerID int64, taskType structs.TaskType) (*Task, error) {
+	task := Task{
+		ID:     id,
+		DoerID: doerID,
+		Type:   taskType,
+	}
+	has, err := db.GetEngine(ctx).Get(&task)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrTaskDoesNotExist{id, 0, taskType}
+	}
+	return &task, nil
+}
+
 // CreateTask creates a task on database
 func CreateTask(ctx context.Context, task *Task) error {
 	return db.Insert(ctx, task)
@@ -0,0 +1,152 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of an administrative event, it is also the suffix of its locale key
+type Action string
+
+const (
+	ActionRepoVisibility Action = "repo.visibility"
+	ActionRepoTransfer   Action = "repo.transfer"
+	ActionRepoDelete     Action = "repo.delete"
+
+	ActionCollaboratorAdd    Action = "collaborator.add"
+	ActionCollaboratorAccess Action = "collaborator.access"
+	ActionCollaboratorRemove Action = "collaborator.remove"
+
+	ActionTeamCreate       Action = "team.create"
+	ActionTeamUpdate       Action = "team.update"
+	ActionTeamDelete       Action = "team.delete"
+	ActionTeamMemberAdd    Action = "team.member.add"
+	ActionTeamMemberRemove Action = "team.member.remove"
+
+	ActionBranchProtectionCreate Action = "branch_protection.create"
+	ActionBranchProtectionUpdate Action = "branch_protection.update"
+	ActionBranchProtectionDelete Action = "branch_protection.delete"
+
+	ActionPushRuleUpdate Action = "push_rule.update"
+
+	ActionSecretScanningUpdate       Action = "secret_scanning.update"
+	ActionSecretScanningAlertResolve Action = "secret_scanning.alert.resolve"
+	ActionSecretScanningAlertReopen  Action = "secret_scanning.alert.reopen"
+
+	ActionDependencyAlertDismiss Action = "dependency_alert.dismiss"
+	ActionDependencyAlertReopen  Action = "dependency_alert.reopen"
+
+	ActionSecretCreate Action = "secret.create"
+	ActionSecretUpdate Action = "secret.update"
+	ActionSecretDelete Action = "secret.delete"
+
+	ActionTokenCreate Action = "token.create"
+	ActionTokenDelete Action = "token.delete"
+
+	ActionWebhookCreate Action = "webhook.create"
+	ActionWebhookUpdate Action = "webhook.update"
+	ActionWebhookDelete Action = "webhook.delete"
+
+	ActionOrgIPAllowlist     Action = "org.ip_allowlist"
+	ActionOrgTwoFactorPolicy Action = "org.two_factor_policy"
+)
+
+// AllActions are the actions recorded in the audit log, in the order they are listed by the filters
+var AllActions = []Action{
+	ActionRepoVisibility, ActionRepoTransfer, ActionRepoDelete,
+	ActionCollaboratorAdd, ActionCollaboratorAccess, ActionCollaboratorRemove,
+	ActionTeamCreate, ActionTeamUpdate, ActionTeamDelete, ActionTeamMemberAdd, ActionTeamMemberRemove,
+	ActionBranchProtectionCreate, ActionBranchProtectionUpdate, ActionBranchProtectionDelete,
+	ActionPushRuleUpdate,
+	ActionSecretScanningUpdate, ActionSecretScanningAlertResolve, ActionSecretScanningAlertReopen,
+	ActionDependencyAlertDismiss, ActionDependencyAlertReopen,
+	ActionSecretCreate, ActionSecretUpdate, ActionSecretDelete,
+	ActionTokenCreate, ActionTokenDelete,
+	ActionWebhookCreate, ActionWebhookUpdate, ActionWebhookDelete,
+	ActionOrgIPAllowlist, ActionOrgTwoFactorPolicy,
+}
+
+// Event is an administrative event of the audit log. The names of the actor and of the target are
+// recorded along with their IDs so that the event stays meaningful once they are deleted.
+type Event struct {
+	ID        int64  `xorm:"pk autoincr" json:"id"`
+	Action    Action `xorm:"VARCHAR(64) INDEX NOT NULL" json:"action"`
+	ActorID   int64  `xorm:"INDEX NOT NULL DEFAULT 0" json:"actor_id"`
+	ActorName string `xorm:"VARCHAR(255)" json:"actor_name"`
+	IPAddress string `xorm:"VARCHAR(64)" json:"ip_address"`
+	// OwnerID is the user or the organization the target belongs to, zero for the events of the instance
+	OwnerID int64 `xorm:"INDEX NOT NULL DEFAULT 0" json:"owner_id"`
+	// RepoID is the repository the target belongs to, zero for the events of an owner
+	RepoID     int64  `xorm:"INDEX NOT NULL DEFAULT 0" json:"repo_id"`
+	TargetType string `xorm:"VARCHAR(64)" json:"target_type"`
+	TargetID   int64  `xorm:"NOT NULL DEFAULT 0" json:"target_id"`
+	TargetName string `xorm:"VARCHAR(255)" json:"target_name"`
+	// Before and After are the JSON values of the target before and after the event, if relevant
+	Before      string             `xorm:"TEXT" json:"before,omitempty"`
+	After       string             `xorm:"TEXT" json:"after,omitempty"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX" json:"created"`
+}
+
+// TableName returns the table of the events
+func (*Event) TableName() string {
+	return "audit_event"
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// FindEventsOptions are the options to find the events of the audit log
+type FindEventsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+	ActorID int64
+	Action  Action
+	Since   timeutil.TimeStamp
+	Until   timeutil.TimeStamp
+}
+
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.ActorID > 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Eq{"action": opts.Action})
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Until > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Until})
+	}
+	return cond
+}
+
+func (opts FindEventsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// InsertEvent records an event in the audit log
+func InsertEvent(ctx context.Context, e *Event) error {
+	return db.Insert(ctx, e)
+}
+
+// DeleteEventsOlderThan deletes the events recorded before the given time
+func DeleteEventsOlderThan(ctx context.Context, before timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Lt{"created_unix": before}).Delete(new(Event))
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit_test
+
+import (
+	"testing"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, e := range []*audit_model.Event{
+		{Action: audit_model.ActionRepoVisibility, ActorID: 2, OwnerID: 2, RepoID: 1, TargetType: "repository", TargetID: 1},
+		{Action: audit_model.ActionTeamCreate, ActorID: 2, OwnerID: 3, TargetType: "team", TargetID: 1},
+		{Action: audit_model.ActionTokenCreate, ActorID: 5, OwnerID: 5, TargetType: "access_token", TargetID: 1},
+	} {
+		require.NoError(t, audit_model.InsertEvent(db.DefaultContext, e))
+	}
+
+	count := func(opts audit_model.FindEventsOptions) int64 {
+		n, err := db.Count[audit_model.Event](db.DefaultContext, opts)
+		require.NoError(t, err)
+		return n
+	}
+	assert.EqualValues(t, 3, count(audit_model.FindEventsOptions{}))
+	assert.EqualValues(t, 1, count(audit_model.FindEventsOptions{RepoID: 1}))
+	assert.EqualValues(t, 1, count(audit_model.FindEventsOptions{OwnerID: 3}))
+	assert.EqualValues(t, 2, count(audit_model.FindEventsOptions{ActorID: 2}))
+	assert.EqualValues(t, 1, count(audit_model.FindEventsOptions{ActorID: 2, Action: audit_model.ActionTeamCreate}))
+
+	events, err := db.Find[audit_model.Event](db.DefaultContext, audit_model.FindEventsOptions{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, audit_model.ActionTokenCreate, events[0].Action)
+}
+
+func TestDeleteEventsOlderThan(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, audit_model.InsertEvent(db.DefaultContext, &audit_model.Event{Action: audit_model.ActionRepoDelete, RepoID: 1}))
+	require.NoError(t, audit_model.InsertEvent(db.DefaultContext, &audit_model.Event{Action: audit_model.ActionRepoDelete, RepoID: 2}))
+	// the created column is set on insert, the event is backdated afterwards
+	_, err := db.GetEngine(db.DefaultContext).Where("repo_id = ?", 2).Cols("created_unix").NoAutoTime().Update(&audit_model.Event{CreatedUnix: 1000})
+	require.NoError(t, err)
+
+	n, err := audit_model.DeleteEventsOlderThan(db.DefaultContext, timeutil.TimeStamp(2000))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	unittest.AssertNotExistsBean(t, &audit_model.Event{RepoID: 2})
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{RepoID: 1})
+}
@@ -68,9 +68,14 @@ type AccessToken struct {
 	TokenSalt      string
 	TokenLastEight string `xorm:"INDEX token_last_eight"`
 	Scope          AccessTokenScope
+	// RestrictToResources limits the repositories and organizations the token can access
+	// to its AccessTokenResource entries
+	RestrictToResources bool `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix       timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix       timeutil.TimeStamp `xorm:"INDEX updated"`
+	ExpiresUnix       timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"` // 0 if the token never expires
+	ExpiryNotified    bool               `xorm:"NOT NULL DEFAULT false"`
 	HasRecentActivity bool               `xorm:"-"`
 	HasUsed           bool               `xorm:"-"`
 }
@@ -81,6 +86,11 @@ func (t *AccessToken) AfterLoad() {
 	t.HasRecentActivity = t.UpdatedUnix.AddDuration(7*24*time.Hour) > timeutil.TimeStampNow()
 }
 
+// IsExpired returns true if the token has an expiration date which has passed
+func (t *AccessToken) IsExpired() bool {
+	return t.ExpiresUnix > 0 && t.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
 func init() {
 	db.RegisterModel(new(AccessToken), func() error {
 		if setting.SuccessfulTokensCacheSize > 0 {
@@ -169,6 +179,9 @@ func GetAccessTokenBySHA(ctx context.Context, token string) (*AccessToken, error
 			return nil, err
 		}
 		if has {
+			if accessToken.IsExpired() {
+				return nil, ErrAccessTokenNotExist{token}
+			}
 			return accessToken, nil
 		}
 		successfulAccessTokenCache.Remove(token)
@@ -185,6 +198,9 @@ func GetAccessTokenBySHA(ctx context.Context, token string) (*AccessToken, error
 	for _, t := range tokens {
 		tempHash := HashToken(token, t.TokenSalt)
 		if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(tempHash)) == 1 {
+			if t.IsExpired() {
+				return nil, ErrAccessTokenNotExist{token}
+			}
 			if successfulAccessTokenCache != nil {
 				successfulAccessTokenCache.Add(token, t.ID)
 			}
@@ -228,19 +244,23 @@ func UpdateAccessToken(ctx context.Context, t *AccessToken) error {
 
 // DeleteAccessTokenByID deletes access token by given ID.
 func DeleteAccessTokenByID(ctx context.Context, id, userID int64) error {
-	cnt, err := db.GetEngine(ctx).ID(id).Delete(&AccessToken{
-		UID: userID,
-	})
-	if err != nil {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		cnt, err := db.GetEngine(ctx).ID(id).Delete(&AccessToken{
+			UID: userID,
+		})
+		if err != nil {
+			return err
+		} else if cnt != 1 {
+			return ErrAccessTokenNotExist{}
+		}
+		_, err = db.GetEngine(ctx).Where("token_id = ?", id).Delete(new(AccessTokenResource))
 		return err
-	} else if cnt != 1 {
-		return ErrAccessTokenNotExist{}
-	}
-	return nil
+	})
 }
 
 // RegenerateAccessTokenByID regenerates access token by given ID.
-// It regenerates token and salt, as well as updates the creation time.
+// It regenerates token and salt, as well as updates the creation time. An expiring token keeps its lifetime
+// from now on, and the maximum lifetime of tokens applies to the regenerated token.
 func RegenerateAccessTokenByID(ctx context.Context, id, userID int64) (*AccessToken, error) {
 	t := &AccessToken{}
 	found, err := db.GetEngine(ctx).Where("id = ? AND uid = ?", id, userID).Get(t)
@@ -256,7 +276,38 @@ func RegenerateAccessTokenByID(ctx context.Context, id, userID int64) (*AccessTo
 	}
 
 	// Reset the creation time, token is unused
-	t.UpdatedUnix = timeutil.TimeStampNow()
+	now := timeutil.TimeStampNow()
+	if t.ExpiresUnix > 0 {
+		t.ExpiresUnix = now + max(t.ExpiresUnix-t.CreatedUnix, 0)
+	}
+	if setting.AccessTokenMaxLifetime > 0 {
+		maxExpires := now.AddDuration(setting.AccessTokenMaxLifetime)
+		if t.ExpiresUnix == 0 || t.ExpiresUnix > maxExpires {
+			t.ExpiresUnix = maxExpires
+		}
+	}
+	t.CreatedUnix = now
+	t.UpdatedUnix = now
+	t.ExpiryNotified = false
+
+	_, err = db.GetEngine(ctx).ID(t.ID).
+		Cols("token_hash", "token_salt", "token_last_eight", "created_unix", "updated_unix", "expires_unix", "expiry_notified").
+		NoAutoTime().Update(t)
+	return t, err
+}
+
+// FindAccessTokensToWarnOfExpiry returns the tokens expiring before the given time whose owner has not been warned yet
+func FindAccessTokensToWarnOfExpiry(ctx context.Context, before timeutil.TimeStamp) ([]*AccessToken, error) {
+	tokens := make([]*AccessToken, 0, 10)
+	return tokens, db.GetEngine(ctx).
+		Where("expires_unix > ? AND expires_unix <= ? AND expiry_notified = ?", timeutil.TimeStampNow(), before, false).
+		OrderBy("uid ASC, expires_unix ASC").
+		Find(&tokens)
+}
 
-	return t, UpdateAccessToken(ctx, t)
+// SetAccessTokenExpiryNotified records that the owner of the token has been warned of its expiry
+func SetAccessTokenExpiryNotified(ctx context.Context, t *AccessToken) error {
+	t.ExpiryNotified = true
+	_, err := db.GetEngine(ctx).ID(t.ID).Cols("expiry_notified").NoAutoTime().Update(t)
+	return err
 }
@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+
+	"xorm.io/builder"
+)
+
+// AccessTokenResource grants an access token restricted to resources access to a repository,
+// or to an organization and all its repositories.
+type AccessTokenResource struct {
+	ID      int64           `xorm:"pk autoincr"`
+	TokenID int64           `xorm:"INDEX NOT NULL"`
+	RepoID  int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OwnerID int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Mode    perm.AccessMode `xorm:"NOT NULL DEFAULT 1"`
+}
+
+func init() {
+	db.RegisterModel(new(AccessTokenResource))
+}
+
+// AccessTokenResources are the resources a restricted access token can access
+type AccessTokenResources []*AccessTokenResource
+
+// RepoAccessMode returns the access the resources grant to a repository of the given owner
+func (resources AccessTokenResources) RepoAccessMode(repoID, ownerID int64) perm.AccessMode {
+	mode := perm.AccessModeNone
+	for _, r := range resources {
+		if (r.RepoID != 0 && r.RepoID == repoID) || (r.OwnerID != 0 && r.OwnerID == ownerID) {
+			mode = max(mode, r.Mode)
+		}
+	}
+	return mode
+}
+
+// OwnerAccessMode returns the access the resources grant to an organization
+func (resources AccessTokenResources) OwnerAccessMode(ownerID int64) perm.AccessMode {
+	mode := perm.AccessModeNone
+	for _, r := range resources {
+		if r.OwnerID != 0 && r.OwnerID == ownerID {
+			mode = max(mode, r.Mode)
+		}
+	}
+	return mode
+}
+
+// GetAccessTokenResources returns the resources of an access token
+func GetAccessTokenResources(ctx context.Context, tokenID int64) (AccessTokenResources, error) {
+	resources := make(AccessTokenResources, 0, 5)
+	return resources, db.GetEngine(ctx).Where("token_id = ?", tokenID).Asc("id").Find(&resources)
+}
+
+// GetAccessTokenResourcesByTokenIDs returns the resources of the access tokens, by token ID
+func GetAccessTokenResourcesByTokenIDs(ctx context.Context, tokenIDs []int64) (map[int64]AccessTokenResources, error) {
+	resources := make([]*AccessTokenResource, 0, len(tokenIDs))
+	if len(tokenIDs) > 0 {
+		if err := db.GetEngine(ctx).In("token_id", tokenIDs).Asc("id").Find(&resources); err != nil {
+			return nil, err
+		}
+	}
+	byToken := make(map[int64]AccessTokenResources, len(tokenIDs))
+	for _, r := range resources {
+		byToken[r.TokenID] = append(byToken[r.TokenID], r)
+	}
+	return byToken, nil
+}
+
+// NewAccessTokenWithResources creates an access token restricted to the given resources
+func NewAccessTokenWithResources(ctx context.Context, t *AccessToken, resources AccessTokenResources) error {
+	t.RestrictToResources = true
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := NewAccessToken(ctx, t); err != nil {
+			return err
+		}
+		for _, r := range resources {
+			r.TokenID = t.ID
+		}
+		if len(resources) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, resources)
+	})
+}
+
+// DeleteAccessTokenResourcesByUserID deletes the resources of the access tokens of a user
+func DeleteAccessTokenResourcesByUserID(ctx context.Context, userID int64) error {
+	_, err := db.GetEngine(ctx).
+		In("token_id", builder.Select("id").From("access_token").Where(builder.Eq{"uid": userID})).
+		Delete(new(AccessTokenResource))
+	return err
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokenResourcesAccessMode(t *testing.T) {
+	resources := auth_model.AccessTokenResources{
+		{RepoID: 1, Mode: perm.AccessModeWrite},
+		{RepoID: 2, Mode: perm.AccessModeRead},
+	}
+	assert.Equal(t, perm.AccessModeWrite, resources.RepoAccessMode(1, 2))
+	assert.Equal(t, perm.AccessModeRead, resources.RepoAccessMode(2, 2))
+	assert.Equal(t, perm.AccessModeNone, resources.RepoAccessMode(3, 2))
+	assert.Equal(t, perm.AccessModeNone, resources.OwnerAccessMode(2))
+
+	resources = auth_model.AccessTokenResources{{OwnerID: 3, Mode: perm.AccessModeRead}}
+	assert.Equal(t, perm.AccessModeRead, resources.RepoAccessMode(3, 3))
+	assert.Equal(t, perm.AccessModeNone, resources.RepoAccessMode(1, 2))
+	assert.Equal(t, perm.AccessModeRead, resources.OwnerAccessMode(3))
+}
+
+func TestNewAccessTokenWithResources(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	token := &auth_model.AccessToken{UID: 2, Name: "Token restricted"}
+	require.NoError(t, auth_model.NewAccessTokenWithResources(db.DefaultContext, token, auth_model.AccessTokenResources{
+		{RepoID: 1, Mode: perm.AccessModeWrite},
+		{RepoID: 2, Mode: perm.AccessModeRead},
+	}))
+	token = unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: token.ID})
+	assert.True(t, token.RestrictToResources)
+
+	resources, err := auth_model.GetAccessTokenResources(db.DefaultContext, token.ID)
+	require.NoError(t, err)
+	if assert.Len(t, resources, 2) {
+		assert.Equal(t, perm.AccessModeWrite, resources.RepoAccessMode(1, 2))
+		assert.Equal(t, perm.AccessModeRead, resources.RepoAccessMode(2, 2))
+	}
+
+	require.NoError(t, auth_model.DeleteAccessTokenByID(db.DefaultContext, token.ID, 2))
+	unittest.AssertNotExistsBean(t, &auth_model.AccessTokenResource{TokenID: token.ID})
+}
+
+func TestExpiredAccessToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	token := &auth_model.AccessToken{UID: 2, Name: "Token expiring", ExpiresUnix: timeutil.TimeStampNow().Add(3600)}
+	require.NoError(t, auth_model.NewAccessToken(db.DefaultContext, token))
+	assert.False(t, token.IsExpired())
+	_, err := auth_model.GetAccessTokenBySHA(db.DefaultContext, token.Token)
+	require.NoError(t, err)
+
+	tokens, err := auth_model.FindAccessTokensToWarnOfExpiry(db.DefaultContext, timeutil.TimeStampNow().Add(7200))
+	require.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, token.ID, tokens[0].ID)
+		require.NoError(t, auth_model.SetAccessTokenExpiryNotified(db.DefaultContext, tokens[0]))
+	}
+	tokens, err = auth_model.FindAccessTokensToWarnOfExpiry(db.DefaultContext, timeutil.TimeStampNow().Add(7200))
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	token.ExpiresUnix = timeutil.TimeStampNow().Add(-1)
+	require.NoError(t, auth_model.UpdateAccessToken(db.DefaultContext, token))
+	assert.True(t, token.IsExpired())
+	_, err = auth_model.GetAccessTokenBySHA(db.DefaultContext, token.Token)
+	assert.True(t, auth_model.IsErrAccessTokenNotExist(err))
+}
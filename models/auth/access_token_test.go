@@ -5,10 +5,14 @@ package auth_test
 
 import (
 	"testing"
+	"time"
 
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -156,3 +160,30 @@ func TestRegenerateAccessTokenByID(t *testing.T) {
 	assert.Equal(t, token.Name, newToken.Name)
 	assert.Equal(t, token.Scope, newToken.Scope)
 }
+
+func TestRegenerateAccessTokenExpiry(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	token := unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: 1})
+	// an expired token with a lifetime of one day
+	token.CreatedUnix = timeutil.TimeStampNow().AddDuration(-48 * time.Hour)
+	token.ExpiresUnix = token.CreatedUnix.AddDuration(24 * time.Hour)
+	_, err := db.GetEngine(db.DefaultContext).ID(token.ID).Cols("created_unix", "expires_unix").NoAutoTime().Update(token)
+	require.NoError(t, err)
+
+	newToken, err := auth_model.RegenerateAccessTokenByID(db.DefaultContext, token.ID, token.UID)
+	require.NoError(t, err)
+	assert.False(t, newToken.IsExpired())
+	token = unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: token.ID})
+	assert.InDelta(t, int64(timeutil.TimeStampNow().AddDuration(24*time.Hour)), int64(token.ExpiresUnix), 5)
+	assert.False(t, token.HasUsed)
+
+	// a token which never expires gets the maximum lifetime
+	defer test.MockVariableValue(&setting.AccessTokenMaxLifetime, time.Hour)()
+	_, err = db.GetEngine(db.DefaultContext).ID(token.ID).Cols("expires_unix").NoAutoTime().Update(&auth_model.AccessToken{})
+	require.NoError(t, err)
+	_, err = auth_model.RegenerateAccessTokenByID(db.DefaultContext, token.ID, token.UID)
+	require.NoError(t, err)
+	token = unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: token.ID})
+	assert.InDelta(t, int64(timeutil.TimeStampNow().AddDuration(time.Hour)), int64(token.ExpiresUnix), 5)
+}
@@ -37,10 +37,13 @@ type OAuth2Application struct {
 	// https://datatracker.ietf.org/doc/html/rfc6749#section-2.1
 	// "Authorization servers MUST record the client type in the client registration details"
 	// https://datatracker.ietf.org/doc/html/rfc8252#section-8.4
-	ConfidentialClient bool               `xorm:"NOT NULL DEFAULT TRUE"`
-	RedirectURIs       []string           `xorm:"redirect_uris JSON TEXT"`
-	CreatedUnix        timeutil.TimeStamp `xorm:"INDEX created"`
-	UpdatedUnix        timeutil.TimeStamp `xorm:"INDEX updated"`
+	ConfidentialClient bool `xorm:"NOT NULL DEFAULT TRUE"`
+	// AllowDeviceGrant allows the application to be authorized with the device authorization grant
+	// https://datatracker.ietf.org/doc/html/rfc8628
+	AllowDeviceGrant bool               `xorm:"NOT NULL DEFAULT FALSE"`
+	RedirectURIs     []string           `xorm:"redirect_uris JSON TEXT"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"INDEX updated"`
 }
 
 func init() {
@@ -50,9 +53,10 @@ func init() {
 }
 
 type BuiltinOAuth2Application struct {
-	ConfigName   string
-	DisplayName  string
-	RedirectURIs []string
+	ConfigName       string
+	DisplayName      string
+	RedirectURIs     []string
+	AllowDeviceGrant bool
 }
 
 func BuiltinApplications() map[string]*BuiltinOAuth2Application {
@@ -68,9 +72,10 @@ func BuiltinApplications() map[string]*BuiltinOAuth2Application {
 		RedirectURIs: []string{"http://127.0.0.1", "https://127.0.0.1"},
 	}
 	m["d57cb8c4-630c-4168-8324-ec79935e18d4"] = &BuiltinOAuth2Application{
-		ConfigName:   "tea",
-		DisplayName:  "tea",
-		RedirectURIs: []string{"http://127.0.0.1", "https://127.0.0.1"},
+		ConfigName:       "tea",
+		DisplayName:      "tea",
+		RedirectURIs:     []string{"http://127.0.0.1", "https://127.0.0.1"},
+		AllowDeviceGrant: true,
 	}
 	return m
 }
@@ -127,9 +132,10 @@ func Init(ctx context.Context) error {
 	for clientID := range clientIDsToAdd {
 		builtinApp := builtinApps[clientID]
 		if err := db.Insert(ctx, &OAuth2Application{
-			Name:         builtinApp.DisplayName,
-			ClientID:     clientID,
-			RedirectURIs: builtinApp.RedirectURIs,
+			Name:             builtinApp.DisplayName,
+			ClientID:         clientID,
+			RedirectURIs:     builtinApp.RedirectURIs,
+			AllowDeviceGrant: builtinApp.AllowDeviceGrant,
 		}); err != nil {
 			return err
 		}
@@ -257,6 +263,7 @@ type CreateOAuth2ApplicationOptions struct {
 	Name               string
 	UserID             int64
 	ConfidentialClient bool
+	AllowDeviceGrant   bool
 	RedirectURIs       []string
 }
 
@@ -269,6 +276,7 @@ func CreateOAuth2Application(ctx context.Context, opts CreateOAuth2ApplicationOp
 		ClientID:           clientID,
 		RedirectURIs:       opts.RedirectURIs,
 		ConfidentialClient: opts.ConfidentialClient,
+		AllowDeviceGrant:   opts.AllowDeviceGrant,
 	}
 	if err := db.Insert(ctx, app); err != nil {
 		return nil, err
@@ -282,6 +290,7 @@ type UpdateOAuth2ApplicationOptions struct {
 	Name               string
 	UserID             int64
 	ConfidentialClient bool
+	AllowDeviceGrant   bool
 	RedirectURIs       []string
 }
 
@@ -308,6 +317,7 @@ func UpdateOAuth2Application(ctx context.Context, opts UpdateOAuth2ApplicationOp
 	app.Name = opts.Name
 	app.RedirectURIs = opts.RedirectURIs
 	app.ConfidentialClient = opts.ConfidentialClient
+	app.AllowDeviceGrant = opts.AllowDeviceGrant
 
 	if err = updateOAuth2Application(ctx, app); err != nil {
 		return nil, err
@@ -318,7 +328,7 @@ func UpdateOAuth2Application(ctx context.Context, opts UpdateOAuth2ApplicationOp
 }
 
 func updateOAuth2Application(ctx context.Context, app *OAuth2Application) error {
-	if _, err := db.GetEngine(ctx).ID(app.ID).UseBool("confidential_client").Update(app); err != nil {
+	if _, err := db.GetEngine(ctx).ID(app.ID).UseBool("confidential_client", "allow_device_grant").Update(app); err != nil {
 		return err
 	}
 	return nil
@@ -350,6 +360,9 @@ func deleteOAuth2Application(ctx context.Context, id, userid int64) error {
 	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2Grant)); err != nil {
 		return err
 	}
+	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2DeviceAuthorization)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -636,6 +649,7 @@ func DeleteOAuth2RelictsByUserID(ctx context.Context, userID int64) error {
 	if err := db.DeleteBeans(ctx,
 		&OAuth2Application{UID: userID},
 		&OAuth2Grant{UserID: userID},
+		&OAuth2DeviceAuthorization{UserID: userID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
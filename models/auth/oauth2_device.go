@@ -0,0 +1,208 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// OAuth2DeviceAuthorizationStatus is the status of a device authorization request
+type OAuth2DeviceAuthorizationStatus int
+
+const (
+	// OAuth2DeviceAuthorizationPending waits for the user to enter the user code
+	OAuth2DeviceAuthorizationPending OAuth2DeviceAuthorizationStatus = iota
+	// OAuth2DeviceAuthorizationApproved has been approved by the user, the device can obtain its tokens
+	OAuth2DeviceAuthorizationApproved
+	// OAuth2DeviceAuthorizationDenied has been denied by the user
+	OAuth2DeviceAuthorizationDenied
+)
+
+// userCodeChars are the characters of user codes, consonants without the ones easily confused with each other,
+// as recommended by https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+const userCodeChars = "BCDFGHJKLMNPQRSTVWXZ"
+
+// OAuth2DeviceAuthorization is a pending authorization request of a device (RFC 8628), the user approves it by
+// entering the user code displayed by the device and the device polls the token endpoint with the device code
+type OAuth2DeviceAuthorization struct {
+	ID             int64                           `xorm:"pk autoincr"`
+	ApplicationID  int64                           `xorm:"INDEX NOT NULL"`
+	DeviceCodeHash string                          `xorm:"UNIQUE NOT NULL"`
+	UserCode       string                          `xorm:"UNIQUE NOT NULL"`
+	Scope          string                          `xorm:"TEXT"`
+	Status         OAuth2DeviceAuthorizationStatus `xorm:"NOT NULL DEFAULT 0"`
+	UserID         int64                           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	GrantID        int64                           `xorm:"NOT NULL DEFAULT 0"`
+	// Interval is the minimum number of seconds between two polls of the device, it increases each time
+	// the device polls too fast
+	Interval       int64              `xorm:"NOT NULL DEFAULT 5"`
+	LastPolledUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	ValidUntil     timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(OAuth2DeviceAuthorization))
+}
+
+// TableName sets the table name to `oauth2_device_authorization`
+func (d *OAuth2DeviceAuthorization) TableName() string {
+	return "oauth2_device_authorization"
+}
+
+// IsExpired returns whether the device authorization cannot be used anymore
+func (d *OAuth2DeviceAuthorization) IsExpired() bool {
+	return d.ValidUntil <= timeutil.TimeStampNow()
+}
+
+// FormattedUserCode returns the user code as displayed to the user, e.g. BCDF-GHJK
+func (d *OAuth2DeviceAuthorization) FormattedUserCode() string {
+	return d.UserCode[:len(d.UserCode)/2] + "-" + d.UserCode[len(d.UserCode)/2:]
+}
+
+func hashDeviceCode(deviceCode string) string {
+	h := sha256.Sum256([]byte(deviceCode))
+	return hex.EncodeToString(h[:])
+}
+
+func generateUserCode() (string, error) {
+	var code strings.Builder
+	for range 8 {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeChars))))
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(userCodeChars[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// NormalizeUserCode removes the separators and the case of a user code entered by a user
+func NormalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(userCode)))
+}
+
+// CreateOAuth2DeviceAuthorization creates a device authorization request of the application and returns it
+// with the device code, of which only a hash is stored
+func CreateOAuth2DeviceAuthorization(ctx context.Context, app *OAuth2Application, scope string) (*OAuth2DeviceAuthorization, string, error) {
+	// Add a prefix to the base32, this is in order to make it easier
+	// for code scanners to grab sensitive tokens.
+	deviceCode := "gtd_" + base32Lower.EncodeToString(util.CryptoRandomBytes(32))
+
+	d := &OAuth2DeviceAuthorization{
+		ApplicationID:  app.ID,
+		DeviceCodeHash: hashDeviceCode(deviceCode),
+		Scope:          scope,
+		Interval:       setting.OAuth2.DeviceCodePollingInterval,
+		ValidUntil:     timeutil.TimeStampNow().Add(setting.OAuth2.DeviceCodeExpirationTime),
+	}
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("valid_until <= ?", timeutil.TimeStampNow()).Delete(new(OAuth2DeviceAuthorization)); err != nil {
+			return err
+		}
+		// user codes are short, retry in the unlikely case of a collision with a pending request
+		for range 5 {
+			userCode, err := generateUserCode()
+			if err != nil {
+				return err
+			}
+			exists, err := db.GetEngine(ctx).Where("user_code = ?", userCode).Exist(new(OAuth2DeviceAuthorization))
+			if err != nil {
+				return err
+			}
+			if !exists {
+				d.UserCode = userCode
+				return db.Insert(ctx, d)
+			}
+		}
+		return fmt.Errorf("cannot generate a unique user code")
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return d, deviceCode, nil
+}
+
+// GetOAuth2DeviceAuthorizationByUserCode returns the pending device authorization of a user code
+func GetOAuth2DeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*OAuth2DeviceAuthorization, error) {
+	d := new(OAuth2DeviceAuthorization)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{
+		"user_code": NormalizeUserCode(userCode),
+		"status":    OAuth2DeviceAuthorizationPending,
+	}).And("valid_until > ?", timeutil.TimeStampNow()).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("device authorization does not exist")
+	}
+	return d, nil
+}
+
+// GetOAuth2DeviceAuthorizationByDeviceCode returns the device authorization of a device code, expired or not
+func GetOAuth2DeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (*OAuth2DeviceAuthorization, error) {
+	d := new(OAuth2DeviceAuthorization)
+	has, err := db.GetEngine(ctx).Where("device_code_hash = ?", hashDeviceCode(deviceCode)).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("device authorization does not exist")
+	}
+	return d, nil
+}
+
+// Approve records the approval of the device authorization by the user, through the grant of the application
+func (d *OAuth2DeviceAuthorization) Approve(ctx context.Context, grant *OAuth2Grant) error {
+	d.Status = OAuth2DeviceAuthorizationApproved
+	d.UserID = grant.UserID
+	d.GrantID = grant.ID
+	_, err := db.GetEngine(ctx).ID(d.ID).Where("status = ?", OAuth2DeviceAuthorizationPending).Cols("status", "user_id", "grant_id").Update(d)
+	return err
+}
+
+// Deny records the denial of the device authorization by the user
+func (d *OAuth2DeviceAuthorization) Deny(ctx context.Context, userID int64) error {
+	d.Status = OAuth2DeviceAuthorizationDenied
+	d.UserID = userID
+	_, err := db.GetEngine(ctx).ID(d.ID).Where("status = ?", OAuth2DeviceAuthorizationPending).Cols("status", "user_id").Update(d)
+	return err
+}
+
+// Poll records a poll of the device and reports whether it polled faster than its interval,
+// in which case the interval is increased by 5 seconds as required by RFC 8628
+func (d *OAuth2DeviceAuthorization) Poll(ctx context.Context) (slowDown bool, err error) {
+	now := timeutil.TimeStampNow()
+	cols := []string{"last_polled_unix"}
+	if d.LastPolledUnix != 0 && now < d.LastPolledUnix.Add(d.Interval) {
+		slowDown = true
+		d.Interval += 5
+		cols = append(cols, "interval")
+	}
+	d.LastPolledUnix = now
+	_, err = db.GetEngine(ctx).ID(d.ID).Cols(cols...).Update(d)
+	return slowDown, err
+}
+
+// Invalidate deletes the device authorization so that its device code cannot be used twice
+func (d *OAuth2DeviceAuthorization) Invalidate(ctx context.Context) (bool, error) {
+	deleted, err := db.GetEngine(ctx).ID(d.ID).NoAutoCondition().Delete(d)
+	return deleted > 0, err
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDFGHJK", auth_model.NormalizeUserCode(" bcdf-ghjk "))
+	assert.Equal(t, "BCDFGHJK", auth_model.NormalizeUserCode("BCDF GHJK"))
+}
+
+func TestOAuth2DeviceAuthorization(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 2})
+
+	d, deviceCode, err := auth_model.CreateOAuth2DeviceAuthorization(db.DefaultContext, app, "read:user")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(deviceCode, "gtd_"))
+	assert.Len(t, d.UserCode, 8)
+	assert.Regexp(t, `^[A-Z]{4}-[A-Z]{4}$`, d.FormattedUserCode())
+	assert.False(t, d.IsExpired())
+
+	// the user code is found whatever the case and the separators entered by the user
+	found, err := auth_model.GetOAuth2DeviceAuthorizationByUserCode(db.DefaultContext, strings.ToLower(d.FormattedUserCode()))
+	require.NoError(t, err)
+	assert.Equal(t, d.ID, found.ID)
+
+	found, err = auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, deviceCode)
+	require.NoError(t, err)
+	assert.Equal(t, d.ID, found.ID)
+	_, err = auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, "gtd_unknown")
+	require.Error(t, err)
+
+	// polling faster than the interval slows the device down
+	slowDown, err := found.Poll(db.DefaultContext)
+	require.NoError(t, err)
+	assert.False(t, slowDown)
+	slowDown, err = found.Poll(db.DefaultContext)
+	require.NoError(t, err)
+	assert.True(t, slowDown)
+	assert.Equal(t, d.Interval+5, found.Interval)
+
+	grant := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Grant{ID: 1})
+	require.NoError(t, found.Approve(db.DefaultContext, grant))
+	found, err = auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, deviceCode)
+	require.NoError(t, err)
+	assert.Equal(t, auth_model.OAuth2DeviceAuthorizationApproved, found.Status)
+	assert.Equal(t, grant.ID, found.GrantID)
+
+	// an approved device authorization cannot be approved again with its user code
+	_, err = auth_model.GetOAuth2DeviceAuthorizationByUserCode(db.DefaultContext, d.UserCode)
+	require.Error(t, err)
+
+	deleted, err := found.Invalidate(db.DefaultContext)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = found.Invalidate(db.DefaultContext)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
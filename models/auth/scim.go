@@ -0,0 +1,267 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ScimToken authenticates the SCIM provisioning requests of an identity provider.
+// The users provisioned with it are linked to its authentication source.
+type ScimToken struct {
+	ID             int64 `xorm:"pk autoincr"`
+	SourceID       int64 `xorm:"INDEX NOT NULL"`
+	Name           string
+	Token          string `xorm:"-"`
+	TokenHash      string `xorm:"UNIQUE"` // sha256 of token
+	TokenSalt      string
+	TokenLastEight string `xorm:"INDEX token_last_eight"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// ScimGroup is a group provisioned through SCIM, mapped to organization teams
+// by the group team mapping of its authentication source.
+type ScimGroup struct {
+	ID          int64  `xorm:"pk autoincr"`
+	SourceID    int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	DisplayName string `xorm:"UNIQUE(s) NOT NULL"`
+	ExternalID  string
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// ScimGroupMember is the membership of a user in a SCIM group
+type ScimGroupMember struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ScimToken))
+	db.RegisterModel(new(ScimGroup))
+	db.RegisterModel(new(ScimGroupMember))
+}
+
+// ErrScimGroupNotExist represents a "ScimGroupNotExist" kind of error.
+type ErrScimGroupNotExist struct {
+	ID int64
+}
+
+// IsErrScimGroupNotExist checks if an error is a ErrScimGroupNotExist.
+func IsErrScimGroupNotExist(err error) bool {
+	_, ok := err.(ErrScimGroupNotExist)
+	return ok
+}
+
+func (err ErrScimGroupNotExist) Error() string {
+	return fmt.Sprintf("scim group does not exist [id: %d]", err.ID)
+}
+
+func (err ErrScimGroupNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrScimGroupAlreadyExist represents a "ScimGroupAlreadyExist" kind of error.
+type ErrScimGroupAlreadyExist struct {
+	DisplayName string
+}
+
+// IsErrScimGroupAlreadyExist checks if an error is a ErrScimGroupAlreadyExist.
+func IsErrScimGroupAlreadyExist(err error) bool {
+	_, ok := err.(ErrScimGroupAlreadyExist)
+	return ok
+}
+
+func (err ErrScimGroupAlreadyExist) Error() string {
+	return fmt.Sprintf("scim group already exists [display_name: %s]", err.DisplayName)
+}
+
+func (err ErrScimGroupAlreadyExist) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// NewScimToken creates a new SCIM token for the source; the token itself is only available in t.Token.
+func NewScimToken(ctx context.Context, t *ScimToken) error {
+	salt, err := util.CryptoRandomString(10)
+	if err != nil {
+		return err
+	}
+	t.TokenSalt = salt
+	t.Token = hex.EncodeToString(util.CryptoRandomBytes(20))
+	t.TokenHash = HashToken(t.Token, t.TokenSalt)
+	t.TokenLastEight = t.Token[len(t.Token)-8:]
+	_, err = db.GetEngine(ctx).Insert(t)
+	return err
+}
+
+// GetScimToken returns the SCIM token matching the given token value
+func GetScimToken(ctx context.Context, token string) (*ScimToken, error) {
+	if len(token) != 40 {
+		return nil, util.NewNotExistErrorf("scim token does not exist")
+	}
+	var tokens []ScimToken
+	if err := db.GetEngine(ctx).Where("token_last_eight = ?", token[len(token)-8:]).Find(&tokens); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		tempHash := HashToken(token, t.TokenSalt)
+		if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(tempHash)) == 1 {
+			return &t, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("scim token does not exist")
+}
+
+// UpdateScimTokenLastUsed records that the token has just been used
+func UpdateScimTokenLastUsed(ctx context.Context, t *ScimToken) error {
+	_, err := db.GetEngine(ctx).ID(t.ID).Cols("updated_unix").Update(t)
+	return err
+}
+
+// FindScimTokens returns the SCIM tokens of the source
+func FindScimTokens(ctx context.Context, sourceID int64) ([]*ScimToken, error) {
+	tokens := make([]*ScimToken, 0, 2)
+	return tokens, db.GetEngine(ctx).Where("source_id = ?", sourceID).Asc("id").Find(&tokens)
+}
+
+// DeleteScimToken deletes the SCIM token of the source
+func DeleteScimToken(ctx context.Context, sourceID, id int64) error {
+	cnt, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).ID(id).Delete(new(ScimToken))
+	if err != nil {
+		return err
+	} else if cnt != 1 {
+		return util.NewNotExistErrorf("scim token does not exist")
+	}
+	return nil
+}
+
+// DeleteScimBySourceID deletes the SCIM tokens and groups of the source
+func DeleteScimBySourceID(ctx context.Context, sourceID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Delete(new(ScimToken)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).In("group_id", builder.Select("id").From("scim_group").Where(builder.Eq{"source_id": sourceID})).Delete(new(ScimGroupMember)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Delete(new(ScimGroup))
+		return err
+	})
+}
+
+// FindScimGroups returns a window of the SCIM groups of the source matching the condition, with their total count
+func FindScimGroups(ctx context.Context, sourceID int64, cond builder.Cond, skip, take int) ([]*ScimGroup, int64, error) {
+	groups := make([]*ScimGroup, 0, take)
+	sess := db.GetEngine(ctx).Where(builder.Eq{"source_id": sourceID})
+	if cond != nil {
+		sess = sess.And(cond)
+	}
+	count, err := sess.OrderBy("id ASC").Limit(take, skip).FindAndCount(&groups)
+	return groups, count, err
+}
+
+// GetScimGroup returns the SCIM group of the source with the given ID
+func GetScimGroup(ctx context.Context, sourceID, id int64) (*ScimGroup, error) {
+	group := &ScimGroup{}
+	has, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).ID(id).Get(group)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrScimGroupNotExist{ID: id}
+	}
+	return group, nil
+}
+
+func isScimGroupNameUsed(ctx context.Context, group *ScimGroup) (bool, error) {
+	return db.GetEngine(ctx).Where("source_id = ? AND display_name = ? AND id != ?", group.SourceID, group.DisplayName, group.ID).Exist(new(ScimGroup))
+}
+
+// CreateScimGroup inserts a new SCIM group
+func CreateScimGroup(ctx context.Context, group *ScimGroup) error {
+	if used, err := isScimGroupNameUsed(ctx, group); err != nil {
+		return err
+	} else if used {
+		return ErrScimGroupAlreadyExist{DisplayName: group.DisplayName}
+	}
+	_, err := db.GetEngine(ctx).Insert(group)
+	return err
+}
+
+// UpdateScimGroup updates the display name and the external ID of a SCIM group
+func UpdateScimGroup(ctx context.Context, group *ScimGroup) error {
+	if used, err := isScimGroupNameUsed(ctx, group); err != nil {
+		return err
+	} else if used {
+		return ErrScimGroupAlreadyExist{DisplayName: group.DisplayName}
+	}
+	_, err := db.GetEngine(ctx).ID(group.ID).Cols("display_name", "external_id").Update(group)
+	return err
+}
+
+// DeleteScimGroup deletes a SCIM group and its memberships
+func DeleteScimGroup(ctx context.Context, group *ScimGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("group_id = ?", group.ID).Delete(new(ScimGroupMember)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(group.ID).Delete(new(ScimGroup))
+		return err
+	})
+}
+
+// GetScimGroupMemberIDs returns the IDs of the members of the SCIM group
+func GetScimGroupMemberIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("scim_group_member").Where("group_id = ?", groupID).Asc("user_id").Cols("user_id").Find(&ids)
+}
+
+// SetScimGroupMembers replaces the members of the SCIM group
+func SetScimGroupMembers(ctx context.Context, groupID int64, userIDs container.Set[int64]) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := GetScimGroupMemberIDs(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		currentSet := container.SetOf(current...)
+		for _, id := range current {
+			if !userIDs.Contains(id) {
+				if _, err := db.GetEngine(ctx).Delete(&ScimGroupMember{GroupID: groupID, UserID: id}); err != nil {
+					return err
+				}
+			}
+		}
+		for id := range userIDs {
+			if !currentSet.Contains(id) {
+				if err := db.Insert(ctx, &ScimGroupMember{GroupID: groupID, UserID: id}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// GetScimGroupsByUserID returns the SCIM groups of the source the user is a member of
+func GetScimGroupsByUserID(ctx context.Context, sourceID, userID int64) ([]*ScimGroup, error) {
+	groups := make([]*ScimGroup, 0, 10)
+	return groups, db.GetEngine(ctx).
+		Join("INNER", "scim_group_member", "scim_group_member.group_id = scim_group.id").
+		Where("scim_group.source_id = ? AND scim_group_member.user_id = ?", sourceID, userID).
+		Asc("scim_group.id").
+		Find(&groups)
+}
@@ -0,0 +1,281 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AlertState is the state of a vulnerability alert
+type AlertState int
+
+const (
+	// AlertStateOpen is a vulnerable dependency which is still to be updated
+	AlertStateOpen AlertState = iota
+	// AlertStateFixed is a dependency which is not vulnerable anymore, updated or removed
+	AlertStateFixed
+	// AlertStateDismissed is a vulnerable dependency which has been accepted, see DismissReason
+	AlertStateDismissed
+)
+
+// Name returns the name of the state, used by the translations
+func (s AlertState) Name() string {
+	switch s {
+	case AlertStateFixed:
+		return "fixed"
+	case AlertStateDismissed:
+		return "dismissed"
+	}
+	return "open"
+}
+
+// Reasons to dismiss an alert
+const (
+	DismissReasonTolerableRisk = "tolerable_risk"
+	DismissReasonNotUsed       = "not_used"
+	DismissReasonInaccurate    = "inaccurate"
+	DismissReasonNoBandwidth   = "no_bandwidth"
+)
+
+// DismissReasons are the reasons an alert can be dismissed for
+var DismissReasons = []string{DismissReasonTolerableRisk, DismissReasonNotUsed, DismissReasonInaccurate, DismissReasonNoBandwidth}
+
+// Alert is a vulnerability affecting a dependency of a repository, reported once whatever the number of
+// manifests and versions of the dependency
+type Alert struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// VulnerabilityID is the ID of the vulnerability in the OSV database
+	VulnerabilityID string             `xorm:"UNIQUE(s) VARCHAR(50) NOT NULL"`
+	Ecosystem       depgraph.Ecosystem `xorm:"UNIQUE(s) VARCHAR(20) NOT NULL"`
+	PackageName     string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	// Versions are the affected versions the repository depends on
+	Versions []string `xorm:"TEXT JSON"`
+	// Manifests are the paths of the files declaring the affected versions
+	Manifests     []string           `xorm:"TEXT JSON"`
+	FixedVersions []string           `xorm:"TEXT JSON"`
+	Aliases       []string           `xorm:"TEXT JSON"`
+	Summary       string             `xorm:"TEXT"`
+	Level         osv.Level          `xorm:"INDEX NOT NULL DEFAULT 0"`
+	State         AlertState         `xorm:"INDEX NOT NULL DEFAULT 0"`
+	DismissReason string             `xorm:"VARCHAR(20)"`
+	DismisserID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Comment       string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created INDEX"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	ClosedUnix    timeutil.TimeStamp
+
+	Repo      *repo_model.Repository `xorm:"-"`
+	Dismisser *user_model.User       `xorm:"-"`
+}
+
+// TableName sets the name of the table, alert alone is too generic
+func (*Alert) TableName() string {
+	return "dependency_alert"
+}
+
+func init() {
+	db.RegisterModel(new(Alert))
+}
+
+// IsOpen returns whether the alert is still to be handled
+func (a *Alert) IsOpen() bool {
+	return a.State == AlertStateOpen
+}
+
+// HTMLURL returns the URL of the alert in the list of the alerts of its repository
+func (a *Alert) HTMLURL() string {
+	if a.Repo == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/settings/dependency_graph#alert-%d", a.Repo.HTMLURL(), a.ID)
+}
+
+// VulnerabilityURL returns the page of the vulnerability on osv.dev
+func (a *Alert) VulnerabilityURL() string {
+	return "https://osv.dev/vulnerability/" + a.VulnerabilityID
+}
+
+// key identifies the alerts of a repository
+func (a *Alert) key() string {
+	return a.VulnerabilityID + "\x00" + string(a.Ecosystem) + "\x00" + a.PackageName
+}
+
+// LoadAttributes loads the repository and the dismisser of the alert
+func (a *Alert) LoadAttributes(ctx context.Context) (err error) {
+	if a.Repo == nil {
+		if a.Repo, err = repo_model.GetRepositoryByID(ctx, a.RepoID); err != nil {
+			return err
+		}
+	}
+	if a.DismisserID > 0 && a.Dismisser == nil {
+		if a.Dismisser, err = user_model.GetPossibleUserByID(ctx, a.DismisserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AlertList is a list of alerts
+type AlertList []*Alert
+
+// LoadAttributes loads the repositories and the dismissers of the alerts
+func (alerts AlertList) LoadAttributes(ctx context.Context) error {
+	repos := make(map[int64]*repo_model.Repository)
+	for _, a := range alerts {
+		a.Repo = repos[a.RepoID]
+		if err := a.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		repos[a.RepoID] = a.Repo
+	}
+	return nil
+}
+
+// FindAlertsOptions are the options to list the alerts of a repository or of the repositories of an owner
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64
+	// State filters the alerts by state if IsFiltered is set
+	State      AlertState
+	IsFiltered bool
+}
+
+// ToConds implements db.FindOptions
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.In("repo_id", builder.Select("id").From("repository").Where(builder.Eq{"owner_id": opts.OwnerID})))
+	}
+	if opts.IsFiltered {
+		cond = cond.And(builder.Eq{"state": opts.State})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder, the most severe and recent alerts first
+func (opts FindAlertsOptions) ToOrders() string {
+	return "level DESC, created_unix DESC, id DESC"
+}
+
+// FindAlerts returns a page of alerts and their total number
+func FindAlerts(ctx context.Context, opts FindAlertsOptions) (AlertList, int64, error) {
+	alerts, count, err := db.FindAndCount[Alert](ctx, opts)
+	return alerts, count, err
+}
+
+// CountOpenAlerts returns the number of alerts of a repository which are still to be handled
+func CountOpenAlerts(ctx context.Context, repoID int64) (int64, error) {
+	return db.Count[Alert](ctx, FindAlertsOptions{RepoID: repoID, State: AlertStateOpen, IsFiltered: true})
+}
+
+// GetAlertByID returns an alert of a repository
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	a := &Alert{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("dependency alert %d does not exist", id)
+	}
+	return a, nil
+}
+
+// UpdateAlerts makes the alerts of a repository match the vulnerabilities affecting its dependencies: the
+// alerts are created, updated or opened again if they had been fixed, and the open alerts which do not
+// match anymore are fixed. It returns the alerts which have been opened.
+func UpdateAlerts(ctx context.Context, repoID int64, matches []*Alert) ([]*Alert, error) {
+	var opened []*Alert
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		var existing []*Alert
+		if err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Find(&existing); err != nil {
+			return err
+		}
+		byKey := make(map[string]*Alert, len(existing))
+		for _, a := range existing {
+			byKey[a.key()] = a
+		}
+
+		now := timeutil.TimeStampNow()
+		matched := make(map[int64]bool, len(matches))
+		for _, m := range matches {
+			m.RepoID = repoID
+			a, ok := byKey[m.key()]
+			if !ok {
+				if err := db.Insert(ctx, m); err != nil {
+					return err
+				}
+				opened = append(opened, m)
+				continue
+			}
+			matched[a.ID] = true
+
+			a.Versions, a.Manifests, a.FixedVersions = m.Versions, m.Manifests, m.FixedVersions
+			a.Aliases, a.Summary, a.Level = m.Aliases, m.Summary, m.Level
+			cols := []string{"versions", "manifests", "fixed_versions", "aliases", "summary", "level"}
+			if a.State == AlertStateFixed {
+				a.State = AlertStateOpen
+				a.ClosedUnix = 0
+				cols = append(cols, "state", "closed_unix")
+				opened = append(opened, a)
+			}
+			if _, err := db.GetEngine(ctx).ID(a.ID).Cols(cols...).Update(a); err != nil {
+				return err
+			}
+		}
+
+		for _, a := range existing {
+			if a.State != AlertStateOpen || matched[a.ID] {
+				continue
+			}
+			a.State = AlertStateFixed
+			a.ClosedUnix = now
+			if _, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "closed_unix").Update(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return opened, err
+}
+
+// DismissAlert dismisses an alert
+func DismissAlert(ctx context.Context, a *Alert, doerID int64, reason, comment string) error {
+	if !util.SliceContainsString(DismissReasons, reason) {
+		return util.NewInvalidArgumentErrorf("invalid dismiss reason %q", reason)
+	}
+	a.State = AlertStateDismissed
+	a.DismissReason = reason
+	a.DismisserID = doerID
+	a.Comment = comment
+	a.ClosedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "dismiss_reason", "dismisser_id", "comment", "closed_unix").Update(a)
+	return err
+}
+
+// ReopenAlert opens again a dismissed alert
+func ReopenAlert(ctx context.Context, a *Alert) error {
+	a.State = AlertStateOpen
+	a.DismissReason = ""
+	a.DismisserID = 0
+	a.Comment = ""
+	a.ClosedUnix = 0
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "dismiss_reason", "dismisser_id", "comment", "closed_unix").Update(a)
+	return err
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph_test
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	depgraph_model "forgejo.org/models/depgraph"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceGraph(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := depgraph_model.GetGraph(db.DefaultContext, 1)
+	require.ErrorIs(t, err, util.ErrNotExist)
+
+	require.NoError(t, depgraph_model.ReplaceGraph(db.DefaultContext, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", 1, []*depgraph_model.Dependency{
+		{Manifest: "package-lock.json", Ecosystem: depgraph.EcosystemNpm, Name: "lodash", Version: "4.17.20", Direct: true},
+		{Manifest: "package-lock.json", Ecosystem: depgraph.EcosystemNpm, Name: "react"},
+	}))
+	require.NoError(t, depgraph_model.ReplaceGraph(db.DefaultContext, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", 1, []*depgraph_model.Dependency{
+		{Manifest: "package-lock.json", Ecosystem: depgraph.EcosystemNpm, Name: "lodash", Version: "4.17.21", Direct: true},
+	}))
+
+	g, err := depgraph_model.GetGraph(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, g.Manifests)
+
+	deps, total, err := depgraph_model.FindDependencies(db.DefaultContext, depgraph_model.FindDependenciesOptions{RepoID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	assert.Equal(t, "4.17.21", deps[0].Version)
+
+	ids, err := depgraph_model.GetGraphRepoIDs(db.DefaultContext)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+}
+
+func TestSaveVulnerability(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	v := &osv.Vulnerability{
+		ID:       "PYSEC-2021-1",
+		Modified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Summary:  "Remote code execution",
+		Affected: []*osv.Affected{
+			{Package: osv.Package{Ecosystem: "PyPI", Name: "Some_Package"}},
+			{Package: osv.Package{Ecosystem: "Debian:12", Name: "python3-some-package"}},
+		},
+	}
+	changed, err := depgraph_model.SaveVulnerability(db.DefaultContext, v)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	unittest.AssertCount(t, &depgraph_model.VulnerablePackage{}, 1)
+	unittest.AssertExistsAndLoadBean(t, &depgraph_model.VulnerablePackage{Ecosystem: depgraph.EcosystemPyPI, Name: "some-package"})
+
+	// the same version is not imported again
+	changed, err = depgraph_model.SaveVulnerability(db.DefaultContext, v)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	vulns, err := depgraph_model.FindVulnerabilities(db.DefaultContext, depgraph.EcosystemPyPI, []string{"other", "some-package"})
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "Remote code execution", vulns[0].Summary)
+
+	// a vulnerability affecting no supported ecosystem is not kept
+	v.Modified = v.Modified.Add(time.Hour)
+	v.Affected = v.Affected[1:]
+	changed, err = depgraph_model.SaveVulnerability(db.DefaultContext, v)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	unittest.AssertCount(t, &depgraph_model.Vulnerability{}, 0)
+	unittest.AssertCount(t, &depgraph_model.VulnerablePackage{}, 0)
+}
+
+func TestUpdateAlerts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newAlert := func(id string) *depgraph_model.Alert {
+		return &depgraph_model.Alert{
+			VulnerabilityID: id,
+			Ecosystem:       depgraph.EcosystemNpm,
+			PackageName:     "lodash",
+			Versions:        []string{"4.17.20"},
+			Manifests:       []string{"package-lock.json"},
+			FixedVersions:   []string{"4.17.21"},
+			Level:           osv.LevelHigh,
+		}
+	}
+
+	opened, err := depgraph_model.UpdateAlerts(db.DefaultContext, 1, []*depgraph_model.Alert{newAlert("GHSA-1"), newAlert("GHSA-2")})
+	require.NoError(t, err)
+	assert.Len(t, opened, 2)
+
+	opened, err = depgraph_model.UpdateAlerts(db.DefaultContext, 1, []*depgraph_model.Alert{newAlert("GHSA-1")})
+	require.NoError(t, err)
+	assert.Empty(t, opened)
+	fixed := unittest.AssertExistsAndLoadBean(t, &depgraph_model.Alert{RepoID: 1, VulnerabilityID: "GHSA-2"})
+	assert.Equal(t, depgraph_model.AlertStateFixed, fixed.State)
+
+	alert := unittest.AssertExistsAndLoadBean(t, &depgraph_model.Alert{RepoID: 1, VulnerabilityID: "GHSA-1"})
+	require.ErrorIs(t, depgraph_model.DismissAlert(db.DefaultContext, alert, 2, "unknown", ""), util.ErrInvalidArgument)
+	require.NoError(t, depgraph_model.DismissAlert(db.DefaultContext, alert, 2, depgraph_model.DismissReasonNotUsed, "test only"))
+
+	// the fixed alert is opened again, the dismissed one stays dismissed
+	opened, err = depgraph_model.UpdateAlerts(db.DefaultContext, 1, []*depgraph_model.Alert{newAlert("GHSA-1"), newAlert("GHSA-2")})
+	require.NoError(t, err)
+	require.Len(t, opened, 1)
+	assert.Equal(t, "GHSA-2", opened[0].VulnerabilityID)
+	alert = unittest.AssertExistsAndLoadBean(t, &depgraph_model.Alert{ID: alert.ID})
+	assert.Equal(t, depgraph_model.AlertStateDismissed, alert.State)
+
+	alerts, total, err := depgraph_model.FindAlerts(db.DefaultContext, depgraph_model.FindAlertsOptions{OwnerID: 2, State: depgraph_model.AlertStateOpen, IsFiltered: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.NoError(t, alerts.LoadAttributes(db.DefaultContext))
+	assert.Equal(t, int64(1), alerts[0].Repo.ID)
+
+	require.NoError(t, depgraph_model.ReopenAlert(db.DefaultContext, alert))
+	count, err := depgraph_model.CountOpenAlerts(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+}
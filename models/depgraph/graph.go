@@ -0,0 +1,144 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+const insertBatchSize = 100
+
+// Graph is the state of the dependency graph of a repository
+type Graph struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// CommitID is the commit of the default branch the manifests have been parsed at
+	CommitID string `xorm:"VARCHAR(64)"`
+	// Manifests is the number of manifests parsed
+	Manifests   int
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the name of the table, graph alone is too generic
+func (*Graph) TableName() string {
+	return "dependency_graph"
+}
+
+// Dependency is a package the default branch of a repository depends on
+type Dependency struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"INDEX NOT NULL"`
+	// Manifest is the path of the file declaring the dependency
+	Manifest  string             `xorm:"TEXT NOT NULL"`
+	Ecosystem depgraph.Ecosystem `xorm:"VARCHAR(20) INDEX(package) NOT NULL"`
+	// Name is normalized like the names of the vulnerable packages
+	Name        string `xorm:"VARCHAR(255) INDEX(package) NOT NULL"`
+	Version     string `xorm:"VARCHAR(255)"`
+	Requirement string `xorm:"TEXT"`
+	Direct      bool   `xorm:"NOT NULL DEFAULT false"`
+	Development bool   `xorm:"NOT NULL DEFAULT false"`
+}
+
+// TableName sets the name of the table, dependency alone is too generic
+func (*Dependency) TableName() string {
+	return "dependency_graph_dependency"
+}
+
+func init() {
+	db.RegisterModel(new(Graph))
+	db.RegisterModel(new(Dependency))
+}
+
+// GetGraph returns the state of the dependency graph of a repository
+func GetGraph(ctx context.Context, repoID int64) (*Graph, error) {
+	g := &Graph{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(g)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("the dependency graph of the repository %d does not exist", repoID)
+	}
+	return g, nil
+}
+
+// ReplaceGraph replaces the dependencies of a repository by those of the manifests of a commit
+func ReplaceGraph(ctx context.Context, repoID int64, commitID string, manifests int, deps []*Dependency) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(Dependency)); err != nil {
+			return err
+		}
+		for _, d := range deps {
+			d.ID = 0
+			d.RepoID = repoID
+		}
+		// large lockfiles list thousands of packages, more than the parameters of a statement
+		for i := 0; i < len(deps); i += insertBatchSize {
+			if err := db.Insert(ctx, deps[i:min(i+insertBatchSize, len(deps))]); err != nil {
+				return err
+			}
+		}
+
+		g := &Graph{RepoID: repoID, CommitID: commitID, Manifests: manifests}
+		has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Exist(new(Graph))
+		if err != nil {
+			return err
+		}
+		if has {
+			_, err = db.GetEngine(ctx).Where("repo_id = ?", repoID).Cols("commit_id", "manifests").Update(g)
+			return err
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+// FindDependenciesOptions are the options to list the dependencies of a repository
+type FindDependenciesOptions struct {
+	db.ListOptions
+	RepoID int64
+	// Keyword filters the dependencies by name
+	Keyword string
+	// IsVersioned only lists the dependencies whose exact version is known
+	IsVersioned bool
+}
+
+// ToConds implements db.FindOptions
+func (opts FindDependenciesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"name", opts.Keyword})
+	}
+	if opts.IsVersioned {
+		cond = cond.And(builder.Neq{"version": ""})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindDependenciesOptions) ToOrders() string {
+	return "manifest, ecosystem, name, version"
+}
+
+// FindDependencies returns a page of the dependencies of a repository and their total number
+func FindDependencies(ctx context.Context, opts FindDependenciesOptions) ([]*Dependency, int64, error) {
+	return db.FindAndCount[Dependency](ctx, opts)
+}
+
+// GetGraphRepoIDs returns the IDs of the repositories having a dependency graph
+func GetGraphRepoIDs(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	if err := db.GetEngine(ctx).Table("dependency_graph").Cols("repo_id").Find(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+	"slices"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// findBatchSize is the number of packages whose vulnerabilities are searched at once
+const findBatchSize = 500
+
+// Vulnerability is a vulnerability of the imported OSV database affecting packages of the supported ecosystems
+type Vulnerability struct {
+	ID int64 `xorm:"pk autoincr"`
+	// OSVID is the ID of the vulnerability in the database, like GHSA-xxxx-xxxx-xxxx
+	OSVID        string             `xorm:"'osv_id' VARCHAR(50) UNIQUE NOT NULL"`
+	ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// Content is the vulnerability in the OSV format
+	Content string `xorm:"LONGTEXT NOT NULL"`
+}
+
+// TableName sets the name of the table
+func (*Vulnerability) TableName() string {
+	return "osv_vulnerability"
+}
+
+// VulnerablePackage is a package affected by a vulnerability, to find the vulnerabilities of a dependency
+type VulnerablePackage struct {
+	ID              int64              `xorm:"pk autoincr"`
+	VulnerabilityID int64              `xorm:"INDEX NOT NULL"`
+	Ecosystem       depgraph.Ecosystem `xorm:"VARCHAR(20) INDEX(package) NOT NULL"`
+	Name            string             `xorm:"VARCHAR(255) INDEX(package) NOT NULL"`
+}
+
+// TableName sets the name of the table
+func (*VulnerablePackage) TableName() string {
+	return "osv_vulnerable_package"
+}
+
+func init() {
+	db.RegisterModel(new(Vulnerability))
+	db.RegisterModel(new(VulnerablePackage))
+}
+
+// Ecosystems are the ecosystems the dependency graph supports
+var Ecosystems = []depgraph.Ecosystem{
+	depgraph.EcosystemNpm,
+	depgraph.EcosystemCargo,
+	depgraph.EcosystemComposer,
+	depgraph.EcosystemPyPI,
+	depgraph.EcosystemMaven,
+	depgraph.EcosystemGo,
+}
+
+// vulnerablePackages returns the packages of the supported ecosystems a vulnerability affects
+func vulnerablePackages(v *osv.Vulnerability) []*VulnerablePackage {
+	seen := make(container.Set[VulnerablePackage])
+	var packages []*VulnerablePackage
+	for _, a := range v.Affected {
+		ecosystem := depgraph.Ecosystem(a.Package.Ecosystem)
+		if !slices.Contains(Ecosystems, ecosystem) || a.Package.Name == "" {
+			continue
+		}
+		p := VulnerablePackage{Ecosystem: ecosystem, Name: depgraph.NormalizeName(ecosystem, a.Package.Name)}
+		if seen.Add(p) {
+			packages = append(packages, &p)
+		}
+	}
+	return packages
+}
+
+// SaveVulnerability imports a vulnerability of the OSV database, unless the same or a more recent version of it
+// has already been imported, and returns whether it changed. The vulnerabilities withdrawn or affecting none of
+// the supported ecosystems are deleted.
+func SaveVulnerability(ctx context.Context, v *osv.Vulnerability) (bool, error) {
+	packages := vulnerablePackages(v)
+	changed := false
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		existing := &Vulnerability{}
+		has, err := db.GetEngine(ctx).Where("osv_id = ?", v.ID).Get(existing)
+		if err != nil {
+			return err
+		}
+
+		if v.IsWithdrawn() || len(packages) == 0 {
+			if !has {
+				return nil
+			}
+			changed = true
+			if _, err := db.GetEngine(ctx).Where("vulnerability_id = ?", existing.ID).Delete(new(VulnerablePackage)); err != nil {
+				return err
+			}
+			_, err = db.GetEngine(ctx).ID(existing.ID).Delete(new(Vulnerability))
+			return err
+		}
+
+		modified := timeutil.TimeStamp(v.Modified.Unix())
+		if has && existing.ModifiedUnix >= modified {
+			return nil
+		}
+		changed = true
+
+		content, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		vuln := &Vulnerability{OSVID: v.ID, ModifiedUnix: modified, Content: string(content)}
+		if has {
+			vuln.ID = existing.ID
+			if _, err := db.GetEngine(ctx).ID(vuln.ID).Cols("modified_unix", "content").Update(vuln); err != nil {
+				return err
+			}
+			if _, err := db.GetEngine(ctx).Where("vulnerability_id = ?", vuln.ID).Delete(new(VulnerablePackage)); err != nil {
+				return err
+			}
+		} else if err := db.Insert(ctx, vuln); err != nil {
+			return err
+		}
+
+		for _, p := range packages {
+			p.VulnerabilityID = vuln.ID
+		}
+		return db.Insert(ctx, packages)
+	})
+	return changed, err
+}
+
+// CountVulnerabilities returns the number of vulnerabilities imported
+func CountVulnerabilities(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(new(Vulnerability))
+}
+
+// FindVulnerabilities returns the vulnerabilities affecting some versions of the packages of an ecosystem
+func FindVulnerabilities(ctx context.Context, ecosystem depgraph.Ecosystem, names []string) ([]*osv.Vulnerability, error) {
+	var vulns []*Vulnerability
+	seen := make(container.Set[int64])
+	for i := 0; i < len(names); i += findBatchSize {
+		var batch []*Vulnerability
+		if err := db.GetEngine(ctx).Where(builder.In("id",
+			builder.Select("vulnerability_id").From("osv_vulnerable_package").
+				Where(builder.Eq{"ecosystem": ecosystem}.And(builder.In("name", names[i:min(i+findBatchSize, len(names))]))),
+		)).OrderBy("osv_id").Find(&batch); err != nil {
+			return nil, err
+		}
+		for _, vuln := range batch {
+			// a vulnerability affecting several packages may be in several batches
+			if seen.Add(vuln.ID) {
+				vulns = append(vulns, vuln)
+			}
+		}
+	}
+
+	decoded := make([]*osv.Vulnerability, 0, len(vulns))
+	for _, vuln := range vulns {
+		v := &osv.Vulnerability{}
+		if err := json.Unmarshal([]byte(vuln.Content), v); err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, v)
+	}
+	return decoded, nil
+}
@@ -98,6 +98,36 @@ var migrations = []*Migration{
 	NewMigration("Add public key information to `FederatedUser` and `FederationHost`", AddPublicKeyInformationForFederation),
 	// v29 -> v30
 	NewMigration("Migrate `User.NormalizedFederatedURI` column to extract port & schema into FederatedHost", MigrateNormalizedFederatedURI),
+	// v30 -> v31
+	NewMigration("Add `issue_redirect` table", AddIssueRedirectTable),
+	// v31 -> v32
+	NewMigration("Add `issue_schedule` table", AddIssueScheduleTable),
+	// v32 -> v33
+	NewMigration("Add `service_desk` and `service_desk_issue` tables", AddServiceDeskTables),
+	// v33 -> v34
+	NewMigration("Add `scim_token`, `scim_group` and `scim_group_member` tables", AddScimTables),
+	// v34 -> v35
+	NewMigration("Add expiration and resource restrictions to access tokens", AddAccessTokenExpiryAndResources),
+	// v35 -> v36
+	NewMigration("Add the OAuth2 device authorization grant", AddOAuth2DeviceAuthorization),
+	// v36 -> v37
+	NewMigration("Add deployment environments to Actions", AddActionsEnvironments),
+	// v37 -> v38
+	NewMigration("Add the audit log", AddAuditEvents),
+	// v38 -> v39
+	NewMigration("Add the IP allowlists of organizations", AddOrgIPAllowlist),
+	// v39 -> v40
+	NewMigration("Add the two-factor authentication policies of organizations", AddOrgTwoFactorPolicy),
+	// v40 -> v41
+	NewMigration("Add push rules", AddPushRules),
+	// v41 -> v42
+	NewMigration("Add secret scanning settings and alerts", AddSecretScanning),
+	// v42 -> v43
+	NewMigration("Add dependency graphs, vulnerabilities and dependency alerts", AddDependencyGraph),
+	// v43 -> v44
+	NewMigration("Add confidential issues and internal comments", AddConfidentialIssuesAndInternalComments),
+	// v44 -> v45
+	NewMigration("Add the reminder of the two-factor authentication policies of organizations", AddOrgTwoFactorPolicyReminder),
 }
 
 // GetCurrentDBVersion returns the current Forgejo database version.
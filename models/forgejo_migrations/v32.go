@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueScheduleTable(x *xorm.Engine) error {
+	type IssueSchedule struct {
+		ID            int64 `xorm:"pk autoincr"`
+		RepoID        int64 `xorm:"INDEX NOT NULL"`
+		DoerID        int64 `xorm:"NOT NULL"`
+		Name          string
+		TemplateFile  string
+		Spec          string
+		Labels        []string `xorm:"TEXT JSON"`
+		TeamID        int64
+		RotationIndex int
+		IsActive      bool               `xorm:"INDEX NOT NULL DEFAULT true"`
+		Next          timeutil.TimeStamp `xorm:"INDEX"`
+		Prev          timeutil.TimeStamp
+		LastIssueID   int64
+		LastError     string             `xorm:"TEXT"`
+		Created       timeutil.TimeStamp `xorm:"created"`
+		Updated       timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(IssueSchedule))
+}
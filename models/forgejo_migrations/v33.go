@@ -0,0 +1,31 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddServiceDeskTables(x *xorm.Engine) error {
+	type ServiceDesk struct {
+		ID        int64              `xorm:"pk autoincr"`
+		RepoID    int64              `xorm:"UNIQUE NOT NULL"`
+		Key       string             `xorm:"UNIQUE NOT NULL"`
+		IsEnabled bool               `xorm:"NOT NULL DEFAULT false"`
+		Created   timeutil.TimeStamp `xorm:"created"`
+		Updated   timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ServiceDeskIssue struct {
+		ID        int64 `xorm:"pk autoincr"`
+		IssueID   int64 `xorm:"UNIQUE NOT NULL"`
+		Email     string
+		Name      string
+		Token     string             `xorm:"UNIQUE NOT NULL"`
+		MessageID string             `xorm:"TEXT"`
+		Created   timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(ServiceDesk), new(ServiceDeskIssue))
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddScimTables(x *xorm.Engine) error {
+	type ScimToken struct {
+		ID             int64 `xorm:"pk autoincr"`
+		SourceID       int64 `xorm:"INDEX NOT NULL"`
+		Name           string
+		TokenHash      string `xorm:"UNIQUE"`
+		TokenSalt      string
+		TokenLastEight string             `xorm:"INDEX token_last_eight"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+	type ScimGroup struct {
+		ID          int64  `xorm:"pk autoincr"`
+		SourceID    int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		DisplayName string `xorm:"UNIQUE(s) NOT NULL"`
+		ExternalID  string
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ScimGroupMember struct {
+		ID      int64 `xorm:"pk autoincr"`
+		GroupID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	}
+	return x.Sync(new(ScimToken), new(ScimGroup), new(ScimGroupMember))
+}
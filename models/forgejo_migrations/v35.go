@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAccessTokenExpiryAndResources(x *xorm.Engine) error {
+	type AccessToken struct {
+		RestrictToResources bool               `xorm:"NOT NULL DEFAULT false"`
+		ExpiresUnix         timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ExpiryNotified      bool               `xorm:"NOT NULL DEFAULT false"`
+	}
+	type AccessTokenResource struct {
+		ID      int64 `xorm:"pk autoincr"`
+		TokenID int64 `xorm:"INDEX NOT NULL"`
+		RepoID  int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+		OwnerID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Mode    int   `xorm:"NOT NULL DEFAULT 1"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(AccessToken)); err != nil {
+		return err
+	}
+	return x.Sync(new(AccessTokenResource))
+}
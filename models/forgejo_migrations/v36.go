@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddOAuth2DeviceAuthorization(x *xorm.Engine) error {
+	type oauth2Application struct {
+		ID               int64
+		AllowDeviceGrant bool `xorm:"NOT NULL DEFAULT FALSE"`
+	}
+	type oauth2DeviceAuthorization struct {
+		ID             int64              `xorm:"pk autoincr"`
+		ApplicationID  int64              `xorm:"INDEX NOT NULL"`
+		DeviceCodeHash string             `xorm:"UNIQUE NOT NULL"`
+		UserCode       string             `xorm:"UNIQUE NOT NULL"`
+		Scope          string             `xorm:"TEXT"`
+		Status         int                `xorm:"NOT NULL DEFAULT 0"`
+		UserID         int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		GrantID        int64              `xorm:"NOT NULL DEFAULT 0"`
+		Interval       int64              `xorm:"NOT NULL DEFAULT 5"`
+		LastPolledUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		ValidUntil     timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(oauth2Application)); err != nil {
+		return err
+	}
+	if err := x.Sync(new(oauth2DeviceAuthorization)); err != nil {
+		return err
+	}
+
+	// tea, the builtin application of the command line client, is a public client allowed to use the device grant
+	_, err := x.Exec("UPDATE `oauth2_application` SET allow_device_grant = ? WHERE client_id = ?", true, "d57cb8c4-630c-4168-8324-ec79935e18d4")
+	return err
+}
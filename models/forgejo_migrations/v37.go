@@ -0,0 +1,71 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsEnvironments(x *xorm.Engine) error {
+	type actionEnvironment struct {
+		ID                int64              `xorm:"pk autoincr"`
+		RepoID            int64              `xorm:"INDEX UNIQUE(repo_name) NOT NULL"`
+		Name              string             `xorm:"UNIQUE(repo_name) NOT NULL"`
+		ReviewerIDs       []int64            `xorm:"JSON TEXT"`
+		PreventSelfReview bool               `xorm:"NOT NULL DEFAULT false"`
+		WaitTimer         int64              `xorm:"NOT NULL DEFAULT 0"`
+		RefPatterns       string             `xorm:"TEXT"`
+		CreatedUnix       timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	}
+	type actionDeployment struct {
+		ID            int64              `xorm:"pk autoincr"`
+		RepoID        int64              `xorm:"INDEX NOT NULL"`
+		EnvironmentID int64              `xorm:"INDEX NOT NULL"`
+		RunID         int64              `xorm:"INDEX NOT NULL"`
+		JobID         int64              `xorm:"INDEX NOT NULL"`
+		Ref           string             `xorm:"VARCHAR(255)"`
+		CommitSHA     string             `xorm:"VARCHAR(64)"`
+		TriggerUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+		Status        int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ReviewerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		ReviewedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		DispatchAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	}
+	type actionRunJob struct {
+		ID           int64
+		Environment  string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+		DeploymentID int64  `xorm:"NOT NULL DEFAULT 0"`
+	}
+	// the unique indexes of the secrets and the variables are recreated to include the environment
+	type secret struct {
+		ID            int64
+		OwnerID       int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64              `xorm:"UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		Data          string             `xorm:"LONGTEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+	type actionVariable struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OwnerID       int64              `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64              `xorm:"UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		Data          string             `xorm:"LONGTEXT NOT NULL"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(actionRunJob)); err != nil {
+		return err
+	}
+	return x.Sync(new(actionEnvironment), new(actionDeployment), new(secret), new(actionVariable))
+}
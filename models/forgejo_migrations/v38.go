@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAuditEvents(x *xorm.Engine) error {
+	type auditEvent struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Action      string             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+		ActorID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ActorName   string             `xorm:"VARCHAR(255)"`
+		IPAddress   string             `xorm:"VARCHAR(64)"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		TargetType  string             `xorm:"VARCHAR(64)"`
+		TargetID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		TargetName  string             `xorm:"VARCHAR(255)"`
+		Before      string             `xorm:"TEXT"`
+		After       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+	return x.Sync(new(auditEvent))
+}
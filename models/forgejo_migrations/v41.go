@@ -0,0 +1,27 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPushRules(x *xorm.Engine) error {
+	type pushRule struct {
+		ID                   int64              `xorm:"pk autoincr"`
+		OwnerID              int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		RepoID               int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		AuthorEmailPattern   string             `xorm:"TEXT"`
+		CommitMessagePattern string             `xorm:"TEXT"`
+		RequireSignOff       bool               `xorm:"NOT NULL DEFAULT false"`
+		MaxFileSize          int64              `xorm:"NOT NULL DEFAULT 0"`
+		ForbiddenPaths       string             `xorm:"TEXT"`
+		SecretPatterns       string             `xorm:"TEXT"`
+		CreatedUnix          timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix          timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(pushRule))
+}
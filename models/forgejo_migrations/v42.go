@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSecretScanning(x *xorm.Engine) error {
+	type secretScanningSetting struct {
+		ID             int64              `xorm:"pk autoincr"`
+		OwnerID        int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		RepoID         int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		Mode           int                `xorm:"NOT NULL DEFAULT 0"`
+		CustomPatterns string             `xorm:"TEXT"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+	type secretScanningAlert struct {
+		ID           int64  `xorm:"pk autoincr"`
+		RepoID       int64  `xorm:"UNIQUE(s) NOT NULL"`
+		SecretHash   string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+		PatternID    string `xorm:"VARCHAR(50) NOT NULL"`
+		PatternName  string `xorm:"TEXT"`
+		MaskedSecret string
+		CommitID     string `xorm:"VARCHAR(64)"`
+		Path         string `xorm:"TEXT"`
+		Line         int
+		PusherID     int64
+		BypasserID   int64
+		BypassReason string             `xorm:"TEXT"`
+		State        int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Resolution   string             `xorm:"VARCHAR(20)"`
+		ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		Comment      string             `xorm:"TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created INDEX"`
+		UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+		ResolvedUnix timeutil.TimeStamp
+	}
+	return x.Sync(new(secretScanningSetting), new(secretScanningAlert))
+}
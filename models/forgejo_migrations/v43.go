@@ -0,0 +1,64 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddDependencyGraph(x *xorm.Engine) error {
+	type dependencyGraph struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"UNIQUE NOT NULL"`
+		CommitID    string `xorm:"VARCHAR(64)"`
+		Manifests   int
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	type dependencyGraphDependency struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"INDEX NOT NULL"`
+		Manifest    string `xorm:"TEXT NOT NULL"`
+		Ecosystem   string `xorm:"VARCHAR(20) INDEX(package) NOT NULL"`
+		Name        string `xorm:"VARCHAR(255) INDEX(package) NOT NULL"`
+		Version     string `xorm:"VARCHAR(255)"`
+		Requirement string `xorm:"TEXT"`
+		Direct      bool   `xorm:"NOT NULL DEFAULT false"`
+		Development bool   `xorm:"NOT NULL DEFAULT false"`
+	}
+	type osvVulnerability struct {
+		ID           int64              `xorm:"pk autoincr"`
+		OSVID        string             `xorm:"'osv_id' VARCHAR(50) UNIQUE NOT NULL"`
+		ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		Content      string             `xorm:"LONGTEXT NOT NULL"`
+	}
+	type osvVulnerablePackage struct {
+		ID              int64  `xorm:"pk autoincr"`
+		VulnerabilityID int64  `xorm:"INDEX NOT NULL"`
+		Ecosystem       string `xorm:"VARCHAR(20) INDEX(package) NOT NULL"`
+		Name            string `xorm:"VARCHAR(255) INDEX(package) NOT NULL"`
+	}
+	type dependencyAlert struct {
+		ID              int64              `xorm:"pk autoincr"`
+		RepoID          int64              `xorm:"UNIQUE(s) NOT NULL"`
+		VulnerabilityID string             `xorm:"UNIQUE(s) VARCHAR(50) NOT NULL"`
+		Ecosystem       string             `xorm:"UNIQUE(s) VARCHAR(20) NOT NULL"`
+		PackageName     string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		Versions        []string           `xorm:"TEXT JSON"`
+		Manifests       []string           `xorm:"TEXT JSON"`
+		FixedVersions   []string           `xorm:"TEXT JSON"`
+		Aliases         []string           `xorm:"TEXT JSON"`
+		Summary         string             `xorm:"TEXT"`
+		Level           int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		State           int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		DismissReason   string             `xorm:"VARCHAR(20)"`
+		DismisserID     int64              `xorm:"NOT NULL DEFAULT 0"`
+		Comment         string             `xorm:"TEXT"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"created INDEX"`
+		UpdatedUnix     timeutil.TimeStamp `xorm:"updated"`
+		ClosedUnix      timeutil.TimeStamp
+	}
+	return x.Sync(new(dependencyGraph), new(dependencyGraphDependency), new(osvVulnerability), new(osvVulnerablePackage), new(dependencyAlert))
+}
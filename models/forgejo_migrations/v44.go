@@ -0,0 +1,31 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddConfidentialIssuesAndInternalComments(x *xorm.Engine) error {
+	type Issue struct {
+		IsConfidential bool `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+	type Comment struct {
+		IsInternal bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	syncOptions := xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}
+	if _, err := x.SyncWithOptions(syncOptions, new(Issue)); err != nil {
+		return err
+	}
+	if _, err := x.SyncWithOptions(syncOptions, new(Comment)); err != nil {
+		return err
+	}
+
+	// the issues created from emails were only allowed in private repositories, they are now confidential
+	_, err := x.Exec("UPDATE `issue` SET is_confidential = ? WHERE id IN (SELECT issue_id FROM `service_desk_issue`)", true)
+	return err
+}
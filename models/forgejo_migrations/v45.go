@@ -0,0 +1,19 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package forgejo_migrations //nolint:revive
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddOrgTwoFactorPolicyReminder(x *xorm.Engine) error {
+	type orgTwoFactorPolicy struct {
+		ReminderSent bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(orgTwoFactorPolicy))
+	return err
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/gobwas/glob"
+)
+
+// PushRule is a set of constraints on the commits pushed to the repositories of an organization, or
+// to a single repository, which are checked before the references are updated
+type PushRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	RepoID  int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	// AuthorEmailPattern is a regular expression the email addresses of the authors must match
+	AuthorEmailPattern string `xorm:"TEXT"`
+	// CommitMessagePattern is a regular expression the messages of the commits must match
+	CommitMessagePattern string `xorm:"TEXT"`
+	// RequireSignOff requires the messages of the commits to be signed off by their author (DCO)
+	RequireSignOff bool `xorm:"NOT NULL DEFAULT false"`
+	// MaxFileSize is the maximum size in bytes of the files added or modified, 0 for no limit
+	MaxFileSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ForbiddenPaths are the glob patterns of the files which cannot be added or modified, one per
+	// line. A pattern without a slash matches the name of a file in any directory, e.g. *.exe
+	ForbiddenPaths string `xorm:"TEXT"`
+	// SecretPatterns are the regular expressions the files added or modified must not match, one per line
+	SecretPatterns string             `xorm:"TEXT"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+
+	authorEmail    *regexp.Regexp   `xorm:"-"`
+	commitMessage  *regexp.Regexp   `xorm:"-"`
+	forbiddenPaths []glob.Glob      `xorm:"-"`
+	secrets        []*regexp.Regexp `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(PushRule))
+}
+
+// IsEmpty returns whether the rule does not constrain anything
+func (r *PushRule) IsEmpty() bool {
+	return r.AuthorEmailPattern == "" && r.CommitMessagePattern == "" && !r.RequireSignOff &&
+		r.MaxFileSize <= 0 && strings.TrimSpace(r.ForbiddenPaths) == "" && strings.TrimSpace(r.SecretPatterns) == ""
+}
+
+// patternLines returns the non empty lines of a list of patterns
+func patternLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// LoadPatterns compiles the patterns of the rule, returning an invalid argument error for the first invalid one
+func (r *PushRule) LoadPatterns() error {
+	var err error
+	r.authorEmail, r.commitMessage, r.forbiddenPaths, r.secrets = nil, nil, nil, nil
+	if r.AuthorEmailPattern != "" {
+		if r.authorEmail, err = regexp.Compile(r.AuthorEmailPattern); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid author email pattern %q: %v", r.AuthorEmailPattern, err)
+		}
+	}
+	if r.CommitMessagePattern != "" {
+		if r.commitMessage, err = regexp.Compile(r.CommitMessagePattern); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid commit message pattern %q: %v", r.CommitMessagePattern, err)
+		}
+	}
+	for _, pattern := range patternLines(r.ForbiddenPaths) {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid forbidden path %q: %v", pattern, err)
+		}
+		r.forbiddenPaths = append(r.forbiddenPaths, g)
+	}
+	for _, pattern := range patternLines(r.SecretPatterns) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid secret pattern %q: %v", pattern, err)
+		}
+		r.secrets = append(r.secrets, re)
+	}
+	return nil
+}
+
+// MatchAuthorEmail returns whether the email address of an author is allowed
+func (r *PushRule) MatchAuthorEmail(email string) bool {
+	return r.authorEmail == nil || r.authorEmail.MatchString(email)
+}
+
+// MatchCommitMessage returns whether the message of a commit is allowed
+func (r *PushRule) MatchCommitMessage(message string) bool {
+	return r.commitMessage == nil || r.commitMessage.MatchString(message)
+}
+
+// IsSignedOff returns whether the message of a commit has a Signed-off-by trailer with the email address of its author
+func IsSignedOff(message, authorEmail string) bool {
+	for _, line := range strings.Split(message, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && strings.EqualFold(name, "Signed-off-by") && strings.HasSuffix(strings.TrimSpace(value), "<"+authorEmail+">") {
+			return true
+		}
+	}
+	return false
+}
+
+// ForbiddenPath returns the pattern forbidding the path of a file, empty if it is allowed
+func (r *PushRule) ForbiddenPath(treePath string) string {
+	patterns := patternLines(r.ForbiddenPaths)
+	for i, g := range r.forbiddenPaths {
+		p := treePath
+		if !strings.Contains(patterns[i], "/") {
+			p = path.Base(treePath)
+		}
+		if g.Match(p) {
+			return patterns[i]
+		}
+	}
+	return ""
+}
+
+// HasSecretPatterns returns whether the content of the files has to be checked
+func (r *PushRule) HasSecretPatterns() bool {
+	return len(r.secrets) > 0
+}
+
+// FindSecret returns the pattern the content of a file matches, empty if none
+func (r *PushRule) FindSecret(content []byte) string {
+	patterns := patternLines(r.SecretPatterns)
+	for i, re := range r.secrets {
+		if re.Match(content) {
+			return patterns[i]
+		}
+	}
+	return ""
+}
+
+// GetPushRule returns the push rule of an organization, or of a repository if repoID is set,
+// an empty one if it has never been set
+func GetPushRule(ctx context.Context, ownerID, repoID int64) (*PushRule, error) {
+	r := &PushRule{OwnerID: ownerID, RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Get(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SavePushRule validates and creates or updates a push rule
+func SavePushRule(ctx context.Context, r *PushRule) error {
+	if err := r.LoadPatterns(); err != nil {
+		return err
+	}
+	if r.ID == 0 {
+		return db.Insert(ctx, r)
+	}
+	_, err := db.GetEngine(ctx).ID(r.ID).AllCols().Update(r)
+	return err
+}
+
+// GetPushRulesForRepo returns the push rules applying to a repository, those of its owner first,
+// with their patterns compiled
+func GetPushRulesForRepo(ctx context.Context, repo *repo_model.Repository) ([]*PushRule, error) {
+	var rules []*PushRule
+	if err := db.GetEngine(ctx).Where("(owner_id = ? AND repo_id = 0) OR (owner_id = 0 AND repo_id = ?)", repo.OwnerID, repo.ID).
+		OrderBy("repo_id").Find(&rules); err != nil {
+		return nil, err
+	}
+	nonEmpty := rules[:0]
+	for _, r := range rules {
+		if r.IsEmpty() {
+			continue
+		}
+		if err := r.LoadPatterns(); err != nil {
+			return nil, err
+		}
+		nonEmpty = append(nonEmpty, r)
+	}
+	return nonEmpty, nil
+}
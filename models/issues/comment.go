@@ -116,6 +116,10 @@ const (
 	CommentTypeUnpin // 37 unpin Issue
 
 	CommentTypeAggregator // 38 Aggregator of comments
+
+	CommentTypeTransfer // 39 Issue transferred from another repository
+
+	CommentTypePullRequestEditCommits // 40 Commits of the PR head branch edited from the web
 )
 
 var commentStrings = []string{
@@ -158,6 +162,8 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"action_aggregator",
+	"transfer",
+	"pull_edit_commits",
 }
 
 func (t CommentType) String() string {
@@ -307,6 +313,9 @@ type Comment struct {
 	ReviewID    int64   `xorm:"index"`
 	Invalidated bool
 
+	// IsInternal limits reading the comment to the users with write access to the issues, it is never mailed
+	IsInternal bool `xorm:"NOT NULL DEFAULT false"`
+
 	// Reference an issue or pull from another comment, issue or PR
 	// All information is about the origin of the reference
 	RefRepoID    int64                 `xorm:"index"` // Repo where the referencing
@@ -853,6 +862,8 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 		RefIsPull:        opts.RefIsPull,
 		IsForcePush:      opts.IsForcePush,
 		Invalidated:      opts.Invalidated,
+		OriginalAuthor:   opts.OriginalAuthor,
+		IsInternal:       opts.IsInternal,
 	}
 	if opts.Issue.NoAutoTime {
 		// Preload the comment with the Issue containing the forced update
@@ -1041,6 +1052,8 @@ type CreateCommentOptions struct {
 	RefIsPull        bool
 	IsForcePush      bool
 	Invalidated      bool
+	OriginalAuthor   string // name of the external author, e.g. the reporter of a service desk issue
+	IsInternal       bool
 }
 
 // GetCommentByID returns the comment by given ID.
@@ -1069,6 +1082,9 @@ type FindCommentsOptions struct {
 	IssueIDs    []int64
 	Invalidated optional.Option[bool]
 	IsPull      optional.Option[bool]
+	IsInternal  optional.Option[bool]
+	// Confidential limits the comments to the ones of the confidential issues a user can read
+	Confidential *ConfidentialFilter
 }
 
 // ToConds implements FindOptions interface
@@ -1106,6 +1122,12 @@ func (opts FindCommentsOptions) ToConds() builder.Cond {
 	if opts.IsPull.Has() {
 		cond = cond.And(builder.Eq{"issue.is_pull": opts.IsPull.Value()})
 	}
+	if opts.IsInternal.Has() {
+		cond = cond.And(builder.Eq{"comment.is_internal": opts.IsInternal.Value()})
+	}
+	if opts.Confidential != nil {
+		cond = cond.And(opts.Confidential.toCond())
+	}
 	return cond
 }
 
@@ -1113,7 +1135,7 @@ func (opts FindCommentsOptions) ToConds() builder.Cond {
 func FindComments(ctx context.Context, opts *FindCommentsOptions) (CommentList, error) {
 	comments := make([]*Comment, 0, 10)
 	sess := db.GetEngine(ctx).Where(opts.ToConds())
-	if opts.RepoID > 0 || opts.IsPull.Has() {
+	if opts.RepoID > 0 || opts.IsPull.Has() || opts.Confidential != nil {
 		sess.Join("INNER", "issue", "issue.id = comment.issue_id")
 	}
 
@@ -1132,7 +1154,7 @@ func FindComments(ctx context.Context, opts *FindCommentsOptions) (CommentList,
 // CountComments count all comments according options by ignoring pagination
 func CountComments(ctx context.Context, opts *FindCommentsOptions) (int64, error) {
 	sess := db.GetEngine(ctx).Where(opts.ToConds())
-	if opts.RepoID > 0 {
+	if opts.RepoID > 0 || opts.Confidential != nil {
 		sess.Join("INNER", "issue", "issue.id = comment.issue_id")
 	}
 	return sess.Count(&Comment{})
@@ -1265,6 +1287,77 @@ func CreateAutoMergeComment(ctx context.Context, typ CommentType, pr *PullReques
 	return comment, err
 }
 
+// CreateEditCommitsComment records in the timeline of the pull request that its head branch was rewritten
+// from oldCommitID to newCommitID, the previous head being kept at backupRef
+func CreateEditCommitsComment(ctx context.Context, pr *PullRequest, doer *user_model.User, oldCommitID, newCommitID, backupRef string) (*Comment, error) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	return CreateComment(ctx, &CreateCommentOptions{
+		Type:    CommentTypePullRequestEditCommits,
+		Doer:    doer,
+		Repo:    pr.BaseRepo,
+		Issue:   pr.Issue,
+		OldRef:  oldCommitID,
+		NewRef:  newCommitID,
+		Content: backupRef,
+	})
+}
+
+// HasEditCommitsComment reports whether the force-push from oldCommitID to newCommitID is an edit
+// of the commits of the pull request, which already has its entry in the timeline
+func HasEditCommitsComment(ctx context.Context, issueID int64, oldCommitID, newCommitID string) (bool, error) {
+	return db.GetEngine(ctx).Exist(&Comment{
+		IssueID: issueID,
+		Type:    CommentTypePullRequestEditCommits,
+		OldRef:  oldCommitID,
+		NewRef:  newCommitID,
+	})
+}
+
+// GetPullRequestRevisions returns the commits the head of the pull request pointed to and that are recorded in
+// its timeline: the heads after each push, the commits before and after each force-push or edit of its commits,
+// and the reviewed commits
+func GetPullRequestRevisions(ctx context.Context, issueID int64) (container.Set[string], error) {
+	revisions := make(container.Set[string])
+
+	comments := make([]*Comment, 0, 10)
+	if err := db.GetEngine(ctx).Where("issue_id = ?", issueID).
+		In("type", CommentTypePullRequestPush, CommentTypePullRequestEditCommits).
+		Find(&comments); err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		if c.Type == CommentTypePullRequestEditCommits {
+			revisions.AddMultiple(c.OldRef, c.NewRef)
+			continue
+		}
+		var data PushActionContent
+		if err := json.Unmarshal([]byte(c.Content), &data); err != nil {
+			log.Error("Unmarshal push comment %d: %v", c.ID, err)
+			continue
+		}
+		// the commits of a force-push are its old and new heads, those of another push are the pushed commits
+		// from the oldest to the new head
+		if data.IsForcePush {
+			revisions.AddMultiple(data.CommitIDs...)
+		} else if len(data.CommitIDs) > 0 {
+			revisions.Add(data.CommitIDs[len(data.CommitIDs)-1])
+		}
+	}
+
+	commitIDs := make([]string, 0, 10)
+	if err := db.GetEngine(ctx).Table("review").Where("issue_id = ? AND commit_id != ''", issueID).
+		Distinct("commit_id").Find(&commitIDs); err != nil {
+		return nil, err
+	}
+	revisions.AddMultiple(commitIDs...)
+	return revisions, nil
+}
+
 // RemapExternalUser ExternalUserRemappable interface
 func (c *Comment) RemapExternalUser(externalName string, externalID, userID int64) error {
 	c.OriginalAuthor = externalName
@@ -134,3 +134,38 @@ func Test_UpdateIssueNumComments(t *testing.T) {
 	issue2 = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
 	assert.Equal(t, 1, issue2.NumComments)
 }
+
+func TestGetPullRequestRevisions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	require.NoError(t, db.Insert(db.DefaultContext, []*issues_model.Comment{
+		{
+			Type:    issues_model.CommentTypePullRequestPush,
+			IssueID: issue.ID,
+			Content: `{"is_force_push":true,"commit_ids":["1111111111111111111111111111111111111111","2222222222222222222222222222222222222222"]}`,
+		},
+		{
+			Type:    issues_model.CommentTypePullRequestPush,
+			IssueID: issue.ID,
+			Content: `{"is_force_push":false,"commit_ids":["3333333333333333333333333333333333333333","5555555555555555555555555555555555555555"]}`,
+		},
+		{
+			Type:    issues_model.CommentTypePullRequestEditCommits,
+			IssueID: issue.ID,
+			OldRef:  "2222222222222222222222222222222222222222",
+			NewRef:  "4444444444444444444444444444444444444444",
+		},
+	}))
+
+	revisions, err := issues_model.GetPullRequestRevisions(db.DefaultContext, issue.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"1111111111111111111111111111111111111111",
+		"2222222222222222222222222222222222222222",
+		"4444444444444444444444444444444444444444",
+		"5555555555555555555555555555555555555555",
+		"8091a55037cd59e47293aca02981b5a67076b364",
+		"4a357436d925b5c974181ff12a994538ddc5a269",
+	}, revisions.Values())
+}
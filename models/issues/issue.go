@@ -133,6 +133,9 @@ type Issue struct {
 	// with write access
 	IsLocked bool `xorm:"NOT NULL DEFAULT false"`
 
+	// IsConfidential limits reading the issue to its poster and to the users with write access to the issues
+	IsConfidential bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 }
@@ -0,0 +1,107 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"slices"
+
+	"forgejo.org/models/db"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// CanBeReadBy returns whether a user with the given permission on the repository of the issue can read it.
+// A confidential issue can only be read by its poster and by the users who can write the issues, the permission
+// to read the issues is not checked.
+func (issue *Issue) CanBeReadBy(doer *user_model.User, perm access_model.Permission) bool {
+	if !issue.IsConfidential || perm.CanWriteIssuesOrPulls(issue.IsPull) {
+		return true
+	}
+	return doer != nil && doer.ID == issue.PosterID
+}
+
+// CanBeReadBy returns whether a user with the given permission on the repository of the issue of the comment can
+// read it, an internal comment can only be read by the users who can write the issues
+func (c *Comment) CanBeReadBy(issue *Issue, perm access_model.Permission) bool {
+	return !c.IsInternal || perm.CanWriteIssuesOrPulls(issue.IsPull)
+}
+
+// AttachmentCanBeReadBy returns whether a user with the given permission on the repository of an attachment can
+// read it, the attachments of confidential issues and of internal comments are only readable like them
+func AttachmentCanBeReadBy(ctx context.Context, attach *repo_model.Attachment, doer *user_model.User, perm access_model.Permission) (bool, error) {
+	if attach.IssueID == 0 {
+		return true, nil
+	}
+	issue, err := GetIssueByID(ctx, attach.IssueID)
+	if err != nil {
+		return false, err
+	}
+	if !issue.CanBeReadBy(doer, perm) {
+		return false, nil
+	}
+	if attach.CommentID == 0 {
+		return true, nil
+	}
+	comment, err := GetCommentByID(ctx, attach.CommentID)
+	if err != nil {
+		return false, err
+	}
+	return comment.CanBeReadBy(issue, perm), nil
+}
+
+// SetIssueConfidential makes an issue confidential or not
+func SetIssueConfidential(ctx context.Context, issue *Issue, isConfidential bool) error {
+	issue.IsConfidential = isConfidential
+	_, err := db.GetEngine(ctx).ID(issue.ID).Cols("is_confidential").NoAutoTime().Update(issue)
+	return err
+}
+
+// ConfidentialFilter limits a search of issues to the confidential issues a user can read
+type ConfidentialFilter struct {
+	// PosterID is the user, who can read the confidential issues they posted, 0 for an anonymous user
+	PosterID int64
+	// RepoIDs are the repositories whose confidential issues can all be read by the user
+	RepoIDs []int64
+}
+
+// Allows returns whether the filter keeps an issue, a nil filter keeps all of them
+func (f *ConfidentialFilter) Allows(issue *Issue) bool {
+	return f == nil || !issue.IsConfidential || slices.Contains(f.RepoIDs, issue.RepoID) ||
+		f.PosterID > 0 && f.PosterID == issue.PosterID
+}
+
+func (f *ConfidentialFilter) toCond() builder.Cond {
+	cond := builder.NewCond().Or(builder.Eq{"issue.is_confidential": false})
+	if len(f.RepoIDs) > 0 {
+		cond = cond.Or(builder.In("issue.repo_id", f.RepoIDs))
+	}
+	if f.PosterID > 0 {
+		cond = cond.Or(builder.Eq{"issue.poster_id": f.PosterID})
+	}
+	return cond
+}
+
+func applyConfidentialCondition(sess *xorm.Session, opts *IssuesOptions) {
+	if opts.Confidential != nil {
+		sess.And(opts.Confidential.toCond())
+	}
+}
+
+// GetConfidentialIssueRepoIDs returns the repositories having confidential issues, among repoIDs if it is not nil
+func GetConfidentialIssueRepoIDs(ctx context.Context, repoIDs []int64) ([]int64, error) {
+	cond := builder.NewCond().And(builder.Eq{"is_confidential": true})
+	if repoIDs != nil {
+		if len(repoIDs) == 0 {
+			return nil, nil
+		}
+		cond = cond.And(builder.In("repo_id", repoIDs))
+	}
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("issue").Where(cond).Distinct("repo_id").Find(&ids)
+}
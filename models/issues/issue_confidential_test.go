@@ -0,0 +1,146 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCanBeReadBy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: issue.RepoID})
+	poster := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: issue.PosterID})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	permOf := func(user *user_model.User) access_model.Permission {
+		perm, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, user)
+		require.NoError(t, err)
+		return perm
+	}
+
+	assert.True(t, issue.CanBeReadBy(reader, permOf(reader)))
+	assert.True(t, issue.CanBeReadBy(nil, permOf(nil)))
+
+	require.NoError(t, issues_model.SetIssueConfidential(db.DefaultContext, issue, true))
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	assert.True(t, issue.IsConfidential)
+
+	assert.True(t, issue.CanBeReadBy(owner, permOf(owner)))
+	assert.True(t, issue.CanBeReadBy(poster, permOf(poster)))
+	assert.False(t, issue.CanBeReadBy(reader, permOf(reader)))
+	assert.False(t, issue.CanBeReadBy(nil, permOf(nil)))
+
+	comment := &issues_model.Comment{IssueID: issue.ID, IsInternal: true}
+	assert.True(t, comment.CanBeReadBy(issue, permOf(owner)))
+	assert.False(t, comment.CanBeReadBy(issue, permOf(reader)))
+	comment.IsInternal = false
+	assert.True(t, comment.CanBeReadBy(issue, permOf(reader)))
+}
+
+func TestAttachmentCanBeReadBy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: issue.RepoID})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	canRead := func(attach *repo_model.Attachment, user *user_model.User) bool {
+		perm, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, user)
+		require.NoError(t, err)
+		ok, err := issues_model.AttachmentCanBeReadBy(db.DefaultContext, attach, user, perm)
+		require.NoError(t, err)
+		return ok
+	}
+	issueAttach := &repo_model.Attachment{RepoID: repo.ID, IssueID: issue.ID}
+	commentAttach := &repo_model.Attachment{RepoID: repo.ID, IssueID: issue.ID, CommentID: 2}
+	releaseAttach := &repo_model.Attachment{RepoID: repo.ID, ReleaseID: 1}
+
+	assert.True(t, canRead(issueAttach, reader))
+	assert.True(t, canRead(commentAttach, reader))
+
+	_, err := db.GetEngine(db.DefaultContext).ID(2).Cols("is_internal").NoAutoTime().Update(&issues_model.Comment{IsInternal: true})
+	require.NoError(t, err)
+	assert.True(t, canRead(issueAttach, reader))
+	assert.False(t, canRead(commentAttach, reader))
+	assert.True(t, canRead(commentAttach, owner))
+
+	require.NoError(t, issues_model.SetIssueConfidential(db.DefaultContext, issue, true))
+	assert.False(t, canRead(issueAttach, reader))
+	assert.True(t, canRead(issueAttach, owner))
+	assert.True(t, canRead(releaseAttach, reader))
+}
+
+func TestConfidentialFilter(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issues_model.SetIssueConfidential(db.DefaultContext, issue, true))
+
+	repoIDs, err := issues_model.GetConfidentialIssueRepoIDs(db.DefaultContext, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{issue.RepoID}, repoIDs)
+	repoIDs, err = issues_model.GetConfidentialIssueRepoIDs(db.DefaultContext, []int64{2})
+	require.NoError(t, err)
+	assert.Empty(t, repoIDs)
+
+	findIDs := func(filter *issues_model.ConfidentialFilter) []int64 {
+		issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{issue.RepoID},
+			IsPull:       optional.Some(false),
+			Confidential: filter,
+		})
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	for _, filter := range []*issues_model.ConfidentialFilter{
+		nil,
+		{PosterID: issue.PosterID},
+		{RepoIDs: []int64{issue.RepoID}},
+	} {
+		assert.Contains(t, findIDs(filter), issue.ID)
+		assert.True(t, filter.Allows(issue))
+	}
+	for _, filter := range []*issues_model.ConfidentialFilter{
+		{},
+		{PosterID: 5, RepoIDs: []int64{2}},
+	} {
+		ids := findIDs(filter)
+		assert.NotContains(t, ids, issue.ID)
+		assert.NotEmpty(t, ids)
+		assert.False(t, filter.Allows(issue))
+	}
+
+	stats, err := issues_model.GetIssueStats(db.DefaultContext, &issues_model.IssuesOptions{
+		RepoIDs:      []int64{issue.RepoID},
+		IsPull:       optional.Some(false),
+		Confidential: &issues_model.ConfidentialFilter{},
+	})
+	require.NoError(t, err)
+	all, err := issues_model.GetIssueStats(db.DefaultContext, &issues_model.IssuesOptions{
+		RepoIDs: []int64{issue.RepoID},
+		IsPull:  optional.Some(false),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, all.OpenCount-1, stats.OpenCount)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrIssueRedirectNotExist represents a "IssueRedirectNotExist" kind of error.
+type ErrIssueRedirectNotExist struct {
+	RepoID int64
+	Index  int64
+}
+
+// IsErrIssueRedirectNotExist checks if an error is a ErrIssueRedirectNotExist.
+func IsErrIssueRedirectNotExist(err error) bool {
+	_, ok := err.(ErrIssueRedirectNotExist)
+	return ok
+}
+
+func (err ErrIssueRedirectNotExist) Error() string {
+	return fmt.Sprintf("issue redirect does not exist [repo_id: %d, index: %d]", err.RepoID, err.Index)
+}
+
+func (err ErrIssueRedirectNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// IssueRedirect represents that an issue index of a repository should be redirected
+// to an issue which has been transferred to another repository
+type IssueRedirect struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE(s)"`
+	Index       int64              `xorm:"UNIQUE(s)"`
+	IssueID     int64              `xorm:"INDEX"` // issueID to redirect to
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueRedirect))
+}
+
+// LookupIssueRedirect returns the ID of the issue which used to have the given index in the repository
+func LookupIssueRedirect(ctx context.Context, repoID, index int64) (int64, error) {
+	redirect := &IssueRedirect{RepoID: repoID, Index: index}
+	if has, err := db.GetEngine(ctx).Get(redirect); err != nil {
+		return 0, err
+	} else if !has {
+		return 0, ErrIssueRedirectNotExist{RepoID: repoID, Index: index}
+	}
+	return redirect.IssueID, nil
+}
+
+// GetIssueByIndexOrRedirect returns the issue with the given index in the repository,
+// following the redirect left by a transfer if there is no such issue anymore.
+// The returned issue may belong to another repository.
+func GetIssueByIndexOrRedirect(ctx context.Context, repoID, index int64) (*Issue, error) {
+	issue, err := GetIssueByIndex(ctx, repoID, index)
+	if !IsErrIssueNotExist(err) {
+		return issue, err
+	}
+	issueID, redirectErr := LookupIssueRedirect(ctx, repoID, index)
+	if redirectErr != nil {
+		if IsErrIssueRedirectNotExist(redirectErr) {
+			return nil, err
+		}
+		return nil, redirectErr
+	}
+	return GetIssueByID(ctx, issueID)
+}
+
+// newIssueRedirect records that the issue used to have the given index in the repository
+func newIssueRedirect(ctx context.Context, repoID, index, issueID int64) error {
+	if _, err := db.GetEngine(ctx).Delete(&IssueRedirect{RepoID: repoID, Index: index}); err != nil {
+		return err
+	}
+	return db.Insert(ctx, &IssueRedirect{
+		RepoID:  repoID,
+		Index:   index,
+		IssueID: issueID,
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrIssueScheduleNotExist represents a "IssueScheduleNotExist" kind of error.
+type ErrIssueScheduleNotExist struct {
+	ID     int64
+	RepoID int64
+}
+
+// IsErrIssueScheduleNotExist checks if an error is a ErrIssueScheduleNotExist.
+func IsErrIssueScheduleNotExist(err error) bool {
+	_, ok := err.(ErrIssueScheduleNotExist)
+	return ok
+}
+
+func (err ErrIssueScheduleNotExist) Error() string {
+	return fmt.Sprintf("issue schedule does not exist [id: %d, repo_id: %d]", err.ID, err.RepoID)
+}
+
+func (err ErrIssueScheduleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// IssueSchedule periodically creates an issue from an issue template of the repository
+type IssueSchedule struct {
+	ID     int64                  `xorm:"pk autoincr"`
+	RepoID int64                  `xorm:"INDEX NOT NULL"`
+	Repo   *repo_model.Repository `xorm:"-"`
+	// DoerID is the user who created the schedule and who is the poster of the issues
+	DoerID int64 `xorm:"NOT NULL"`
+	Name   string
+	// TemplateFile is the path of the issue template in the default branch
+	TemplateFile string
+	// Spec is a cron spec, see actions_model.ParseScheduleSpec
+	Spec string
+	// Labels are the names of the labels added to the ones of the template
+	Labels []string `xorm:"TEXT JSON"`
+	// TeamID is the team whose members are assigned in turn, 0 for none
+	TeamID        int64
+	RotationIndex int
+	IsActive      bool `xorm:"INDEX NOT NULL DEFAULT true"`
+
+	// Next is the next time an issue is created
+	Next timeutil.TimeStamp `xorm:"INDEX"`
+	// Prev is the last time an issue was created, or zero if never
+	Prev timeutil.TimeStamp
+	// LastIssueID is the last issue created by the schedule
+	LastIssueID int64
+	// LastError is the reason why the last issue could not be created
+	LastError string `xorm:"TEXT"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSchedule))
+}
+
+// LoadRepo loads the repository of the schedule
+func (s *IssueSchedule) LoadRepo(ctx context.Context) (err error) {
+	if s.Repo == nil {
+		s.Repo, err = repo_model.GetRepositoryByID(ctx, s.RepoID)
+	}
+	return err
+}
+
+// FindIssueSchedulesOptions represents the options to find issue schedules
+type FindIssueSchedulesOptions struct {
+	db.ListOptions
+	RepoID   int64
+	IsActive bool
+	// Next finds the schedules which are due at this time
+	Next int64
+	// AfterID finds the schedules with a greater ID, to page through them while they are updated
+	AfterID int64
+}
+
+func (opts FindIssueSchedulesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.IsActive {
+		cond = cond.And(builder.Eq{"is_active": true})
+	}
+	if opts.Next > 0 {
+		cond = cond.And(builder.Lte{"next": opts.Next})
+	}
+	if opts.AfterID > 0 {
+		cond = cond.And(builder.Gt{"id": opts.AfterID})
+	}
+	return cond
+}
+
+func (opts FindIssueSchedulesOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// GetIssueScheduleByID returns the issue schedule of the repository with the given id
+func GetIssueScheduleByID(ctx context.Context, repoID, id int64) (*IssueSchedule, error) {
+	s := &IssueSchedule{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueScheduleNotExist{ID: id, RepoID: repoID}
+	}
+	return s, nil
+}
+
+// CreateIssueSchedule inserts a new issue schedule
+func CreateIssueSchedule(ctx context.Context, s *IssueSchedule) error {
+	return db.Insert(ctx, s)
+}
+
+// UpdateIssueSchedule updates the given columns of an issue schedule
+func UpdateIssueSchedule(ctx context.Context, s *IssueSchedule, cols ...string) error {
+	sess := db.GetEngine(ctx).ID(s.ID)
+	if len(cols) > 0 {
+		sess.Cols(cols...)
+	}
+	_, err := sess.Update(s)
+	return err
+}
+
+// DeleteIssueSchedule deletes an issue schedule of the repository
+func DeleteIssueSchedule(ctx context.Context, repoID, id int64) error {
+	n, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&IssueSchedule{})
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrIssueScheduleNotExist{ID: id, RepoID: repoID}
+	}
+	return nil
+}
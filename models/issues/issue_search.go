@@ -40,6 +40,10 @@ type IssuesOptions struct { //nolint
 	IsPull             optional.Option[bool]
 	LabelIDs           []int64
 	IncludedLabelNames []string
+	// AnyLabelIDGroups are groups of labels the issues have at least one of each
+	AnyLabelIDGroups [][]int64
+	// Confidential limits the confidential issues to the ones a user can read, nil for all of them
+	Confidential       *ConfidentialFilter
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
 	SortType           string
@@ -156,6 +160,12 @@ func applyLabelsCondition(sess *xorm.Session, opts *IssuesOptions) {
 	if len(opts.ExcludedLabelNames) > 0 {
 		sess.And(builder.NotIn("issue.id", BuildLabelNamesIssueIDsCondition(opts.ExcludedLabelNames)))
 	}
+
+	if len(opts.LabelIDs) == 0 || opts.LabelIDs[0] != 0 {
+		for _, group := range opts.AnyLabelIDGroups {
+			sess.In("issue.id", builder.Select("issue_id").From("issue_label").Where(builder.In("label_id", group)))
+		}
+	}
 }
 
 func applyMilestoneCondition(sess *xorm.Session, opts *IssuesOptions) {
@@ -272,6 +282,8 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyLabelsCondition(sess, opts)
 
+	applyConfidentialCondition(sess, opts)
+
 	if opts.User != nil {
 		cond := issuePullAccessibleRepoCond("issue.repo_id", opts.User.ID, opts.Org, opts.Team, opts.IsPull.Value())
 		// If AllPublic was set, then also consider all issues in public
@@ -184,6 +184,8 @@ func applyIssuesOptions(sess *xorm.Session, opts *IssuesOptions, issueIDs []int6
 		sess.And("issue.is_pull=?", opts.IsPull.Value())
 	}
 
+	applyConfidentialCondition(sess, opts)
+
 	return sess
 }
 
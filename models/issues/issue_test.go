@@ -198,6 +198,12 @@ func TestIssues(t *testing.T) {
 			},
 			[]int64{5}, // issue without label 1 but with label 2.
 		},
+		{
+			issues_model.IssuesOptions{
+				AnyLabelIDGroups: [][]int64{{1, 2}, {4, 5}},
+			},
+			[]int64{2}, // issue with label 1 or 2, and with label 4 or 5
+		},
 		{
 			issues_model.IssuesOptions{
 				RepoCond: builder.In("repo_id", 1),
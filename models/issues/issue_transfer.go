@@ -0,0 +1,146 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+)
+
+// TransferIssueOptions describes how an issue is moved to another repository
+type TransferIssueOptions struct {
+	Doer    *user_model.User
+	NewRepo *repo_model.Repository
+	// Labels are the labels of the new repository or its owner replacing the current labels of the issue
+	Labels []*Label
+	// MilestoneID is the milestone of the new repository replacing the current one, 0 for none
+	MilestoneID int64
+	// RemovedAssigneeIDs are the users which are unassigned because they can't be assigned in the new repository
+	RemovedAssigneeIDs []int64
+}
+
+// TransferIssue moves an issue to another repository with a new index.
+// Everything attached to the issue by its ID, like comments, reactions, attachments,
+// subscriptions and tracked time, follows it. A redirect is left for the old index.
+// The caller is responsible for checking permissions and for mapping labels and milestone.
+func TransferIssue(ctx context.Context, issue *Issue, opts *TransferIssueOptions) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		oldRepo := issue.Repo
+		oldIndex := issue.Index
+		oldMilestoneID := issue.MilestoneID
+		oldLabels := issue.Labels
+
+		if issue.IsPinned() {
+			if err := issue.Unpin(ctx, opts.Doer); err != nil {
+				return err
+			}
+		}
+
+		newIndex, err := db.GetNextResourceIndex(ctx, "issue_index", opts.NewRepo.ID)
+		if err != nil {
+			return err
+		}
+		issue.RepoID = opts.NewRepo.ID
+		issue.Repo = opts.NewRepo
+		issue.Index = newIndex
+		issue.MilestoneID = opts.MilestoneID
+		issue.PinOrder = 0
+		if _, err := db.GetEngine(ctx).ID(issue.ID).Cols("repo_id", "index", "milestone_id", "pin_order").Update(issue); err != nil {
+			return err
+		}
+		if err := newIssueRedirect(ctx, oldRepo.ID, oldIndex, issue.ID); err != nil {
+			return err
+		}
+
+		// labels
+		if _, err := db.GetEngine(ctx).Delete(&IssueLabel{IssueID: issue.ID}); err != nil {
+			return err
+		}
+		for _, label := range opts.Labels {
+			if err := db.Insert(ctx, &IssueLabel{IssueID: issue.ID, LabelID: label.ID}); err != nil {
+				return err
+			}
+		}
+		for _, label := range append(oldLabels, opts.Labels...) {
+			if err := updateLabelCols(ctx, label, "num_issues", "num_closed_issue"); err != nil {
+				return err
+			}
+		}
+		issue.Labels = opts.Labels
+
+		// milestones
+		if oldMilestoneID > 0 {
+			if err := UpdateMilestoneCounters(ctx, oldMilestoneID); err != nil {
+				return err
+			}
+		}
+		if opts.MilestoneID > 0 {
+			if err := UpdateMilestoneCounters(ctx, opts.MilestoneID); err != nil {
+				return err
+			}
+		}
+
+		// assignees
+		if len(opts.RemovedAssigneeIDs) > 0 {
+			if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).In("assignee_id", opts.RemovedAssigneeIDs).Delete(&IssueAssignees{}); err != nil {
+				return err
+			}
+		}
+
+		// a project of the old owner or repository can't contain the issue anymore
+		if err := issue.LoadProject(ctx); err != nil {
+			return err
+		}
+		if issue.Project != nil && !issue.Project.CanBeAccessedByOwnerRepo(opts.NewRepo.OwnerID, opts.NewRepo) {
+			if _, err := db.GetEngine(ctx).Delete(&project_model.ProjectIssue{IssueID: issue.ID}); err != nil {
+				return err
+			}
+			issue.Project = nil
+		}
+
+		// rows which denormalize the repository of the issue
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Cols("repo_id").Update(&repo_model.Attachment{RepoID: opts.NewRepo.ID}); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Table("notification").Where("issue_id = ?", issue.ID).Update(map[string]any{"repo_id": opts.NewRepo.ID}); err != nil {
+			return err
+		}
+		// cross references from other issues point to the issue by ID, only the repository is cached
+		if _, err := db.GetEngine(ctx).Where("ref_issue_id = ?", issue.ID).Cols("ref_repo_id").Update(&Comment{RefRepoID: opts.NewRepo.ID}); err != nil {
+			return err
+		}
+
+		for _, repoID := range []int64{oldRepo.ID, opts.NewRepo.ID} {
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, false); err != nil {
+				return err
+			}
+			if err := repo_model.UpdateRepoIssueNumbers(ctx, repoID, false, true); err != nil {
+				return err
+			}
+		}
+
+		if _, err := CreateComment(ctx, &CreateCommentOptions{
+			Type:   CommentTypeTransfer,
+			Doer:   opts.Doer,
+			Repo:   opts.NewRepo,
+			Issue:  issue,
+			OldRef: fmt.Sprintf("%s#%d", oldRepo.FullName(), oldIndex),
+			NewRef: fmt.Sprintf("%s#%d", opts.NewRepo.FullName(), newIndex),
+		}); err != nil {
+			return err
+		}
+		return nil
+	})
+}
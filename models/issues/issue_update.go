@@ -765,12 +765,27 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&IssueRedirect{})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = sess.In("issue_id", issueIDs).Delete(&ServiceDeskIssue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("id", issueIDs).Delete(&Issue{})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Redirects of issues which have been transferred to other repositories
+	if _, err := sess.Where("repo_id = ?", repoID).Delete(&IssueRedirect{}); err != nil {
+		return nil, err
+	}
+
 	return attachmentPaths, err
 }
 
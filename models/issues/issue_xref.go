@@ -197,12 +197,15 @@ func (issue *Issue) updateCrossReferenceList(list []*crossReference, xref *cross
 func (issue *Issue) verifyReferencedIssue(stdCtx context.Context, ctx *crossReferencesContext, repo *repo_model.Repository,
 	ref references.IssueReference,
 ) (*Issue, references.XRefAction, error) {
-	refIssue := &Issue{RepoID: repo.ID, Index: ref.Index}
 	refAction := ref.Action
-	e := db.GetEngine(stdCtx)
 
-	if has, _ := e.Get(refIssue); !has {
-		return nil, references.XRefActionNone, nil
+	// the referenced issue may have been transferred to another repository since
+	refIssue, err := GetIssueByIndexOrRedirect(stdCtx, repo.ID, ref.Index)
+	if err != nil {
+		if IsErrIssueNotExist(err) {
+			return nil, references.XRefActionNone, nil
+		}
+		return nil, references.XRefActionNone, err
 	}
 	if err := refIssue.LoadRepo(stdCtx); err != nil {
 		return nil, references.XRefActionNone, err
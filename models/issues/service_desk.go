@@ -0,0 +1,163 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ServiceDeskTokenPrefix prefixes the incoming email tokens of service desks
+const ServiceDeskTokenPrefix = "desk-"
+
+// ErrServiceDeskNotExist represents a "ServiceDeskNotExist" kind of error.
+type ErrServiceDeskNotExist struct {
+	RepoID int64
+	Key    string
+}
+
+// IsErrServiceDeskNotExist checks if an error is a ErrServiceDeskNotExist.
+func IsErrServiceDeskNotExist(err error) bool {
+	_, ok := err.(ErrServiceDeskNotExist)
+	return ok
+}
+
+func (err ErrServiceDeskNotExist) Error() string {
+	return fmt.Sprintf("service desk does not exist [repo_id: %d, key: %s]", err.RepoID, err.Key)
+}
+
+func (err ErrServiceDeskNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ServiceDesk is the email address of a repository turning emails of external senders into issues
+type ServiceDesk struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// Key identifies the service desk in its address, it is regenerated to stop spam
+	Key       string             `xorm:"UNIQUE NOT NULL"`
+	IsEnabled bool               `xorm:"NOT NULL DEFAULT false"`
+	Created   timeutil.TimeStamp `xorm:"created"`
+	Updated   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// ServiceDeskIssue links an issue to the external reporter who created it by email
+type ServiceDeskIssue struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE NOT NULL"`
+	Email   string
+	Name    string
+	// Token identifies the issue in the address the reporter replies to
+	Token string `xorm:"UNIQUE NOT NULL"`
+	// MessageID is the Message-ID of the email which created the issue, used to thread the replies
+	MessageID string             `xorm:"TEXT"`
+	Created   timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ServiceDesk))
+	db.RegisterModel(new(ServiceDeskIssue))
+}
+
+func newServiceDeskKey() string {
+	// the key is part of an email address, so it is lower case and has no separator
+	return hex.EncodeToString(util.CryptoRandomBytes(10))
+}
+
+// Address returns the email address of the service desk
+func (desk *ServiceDesk) Address() string {
+	return strings.Replace(setting.IncomingEmail.ReplyToAddress, setting.IncomingEmail.TokenPlaceholder, ServiceDeskTokenPrefix+desk.Key, 1)
+}
+
+// ReplyAddress returns the email address the reporter of a service desk issue replies to
+func (desk *ServiceDesk) ReplyAddress(deskIssue *ServiceDeskIssue) string {
+	return strings.Replace(setting.IncomingEmail.ReplyToAddress, setting.IncomingEmail.TokenPlaceholder, ServiceDeskTokenPrefix+desk.Key+"-"+deskIssue.Token, 1)
+}
+
+// DisplayName returns the name of the reporter shown as the author of the issue and its comments
+func (deskIssue *ServiceDeskIssue) DisplayName() string {
+	if deskIssue.Name == "" {
+		return deskIssue.Email
+	}
+	return fmt.Sprintf("%s <%s>", deskIssue.Name, deskIssue.Email)
+}
+
+// GetServiceDeskByRepoID returns the service desk of a repository
+func GetServiceDeskByRepoID(ctx context.Context, repoID int64) (*ServiceDesk, error) {
+	desk := &ServiceDesk{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(desk)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrServiceDeskNotExist{RepoID: repoID}
+	}
+	return desk, nil
+}
+
+// GetServiceDeskByKey returns the service desk with the given key
+func GetServiceDeskByKey(ctx context.Context, key string) (*ServiceDesk, error) {
+	desk := &ServiceDesk{}
+	has, err := db.GetEngine(ctx).Where("`key` = ?", strings.ToLower(key)).Get(desk)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrServiceDeskNotExist{Key: key}
+	}
+	return desk, nil
+}
+
+// SetServiceDeskEnabled enables or disables the service desk of a repository, creating it if needed
+func SetServiceDeskEnabled(ctx context.Context, repoID int64, isEnabled bool) (*ServiceDesk, error) {
+	desk, err := GetServiceDeskByRepoID(ctx, repoID)
+	if err != nil {
+		if !IsErrServiceDeskNotExist(err) {
+			return nil, err
+		}
+		desk = &ServiceDesk{RepoID: repoID, Key: newServiceDeskKey(), IsEnabled: isEnabled}
+		return desk, db.Insert(ctx, desk)
+	}
+	desk.IsEnabled = isEnabled
+	_, err = db.GetEngine(ctx).ID(desk.ID).Cols("is_enabled").Update(desk)
+	return desk, err
+}
+
+// RegenerateServiceDeskKey changes the address of a service desk, the previous one doesn't work anymore
+func RegenerateServiceDeskKey(ctx context.Context, desk *ServiceDesk) error {
+	desk.Key = newServiceDeskKey()
+	_, err := db.GetEngine(ctx).ID(desk.ID).Cols("key").Update(desk)
+	return err
+}
+
+// CreateServiceDeskIssue records the external reporter of an issue
+func CreateServiceDeskIssue(ctx context.Context, deskIssue *ServiceDeskIssue) error {
+	deskIssue.Token = hex.EncodeToString(util.CryptoRandomBytes(10))
+	return db.Insert(ctx, deskIssue)
+}
+
+// GetServiceDeskIssue returns the external reporter of an issue, or nil if the issue was not created by email
+func GetServiceDeskIssue(ctx context.Context, issueID int64) (*ServiceDeskIssue, error) {
+	deskIssue := &ServiceDeskIssue{}
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(deskIssue)
+	if err != nil || !has {
+		return nil, err
+	}
+	return deskIssue, nil
+}
+
+// GetServiceDeskIssueByToken returns the service desk issue the reporter replies to, or nil if there is none
+func GetServiceDeskIssueByToken(ctx context.Context, token string) (*ServiceDeskIssue, error) {
+	deskIssue := &ServiceDeskIssue{}
+	has, err := db.GetEngine(ctx).Where("token = ?", strings.ToLower(token)).Get(deskIssue)
+	if err != nil || !has {
+		return nil, err
+	}
+	return deskIssue, nil
+}
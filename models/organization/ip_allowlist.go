@@ -0,0 +1,128 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// IPAllowlist restricts the access to the repositories, the packages and the API of an organization
+// to a set of networks, once enabled
+type IPAllowlist struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OrgID   int64 `xorm:"UNIQUE NOT NULL"`
+	Enabled bool  `xorm:"NOT NULL DEFAULT false"`
+	// Ranges are the allowed networks, one CIDR range or IP address per line
+	Ranges      string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	matcher *hostmatcher.HostMatchList `xorm:"-"`
+}
+
+// TableName returns the table of the IP allowlists
+func (*IPAllowlist) TableName() string {
+	return "org_ip_allowlist"
+}
+
+func init() {
+	db.RegisterModel(new(IPAllowlist))
+}
+
+// NormalizeIPAllowlistRanges validates the ranges of an IP allowlist, separated by commas or new lines,
+// and returns them one per line. A single IP address is allowed as the range of its own.
+func NormalizeIPAllowlistRanges(ranges string) (string, error) {
+	fields := strings.FieldsFunc(ranges, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r' || r == ' ' || r == '\t'
+	})
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if ip := net.ParseIP(field); ip != nil {
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			normalized = append(normalized, (&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}).String())
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(field)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("invalid IP range %q", field)
+		}
+		normalized = append(normalized, ipNet.String())
+	}
+	return strings.Join(normalized, "\n"), nil
+}
+
+// IsAllowed reports whether the address, with or without a port, belongs to the allowed networks.
+// Every address is allowed while the allowlist is disabled.
+func (l *IPAllowlist) IsAllowed(remoteAddr string) bool {
+	if !l.Enabled {
+		return true
+	}
+	if l.matcher == nil {
+		l.matcher = hostmatcher.ParseHostMatchList("organization IP allowlist", strings.ReplaceAll(l.Ranges, "\n", ","))
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && l.matcher.MatchIPAddr(ip)
+}
+
+// GetIPAllowlist returns the IP allowlist of an organization, a disabled one if it has never been set
+func GetIPAllowlist(ctx context.Context, orgID int64) (*IPAllowlist, error) {
+	l := &IPAllowlist{OrgID: orgID}
+	if _, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Get(l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SaveIPAllowlist creates or updates the IP allowlist of an organization
+func SaveIPAllowlist(ctx context.Context, l *IPAllowlist) error {
+	l.matcher = nil
+	if l.ID == 0 {
+		return db.Insert(ctx, l)
+	}
+	_, err := db.GetEngine(ctx).ID(l.ID).Cols("enabled", "ranges").Update(l)
+	return err
+}
+
+// IsIPAllowed reports whether the address may access the repositories, the packages and the API
+// of the owner, which is only restricted for an organization with an enabled IP allowlist
+func IsIPAllowed(ctx context.Context, owner *user_model.User, remoteAddr string) (bool, error) {
+	if owner == nil || !owner.IsOrganization() {
+		return true, nil
+	}
+	l, err := GetIPAllowlist(ctx, owner.ID)
+	if err != nil {
+		return false, err
+	}
+	return l.IsAllowed(remoteAddr), nil
+}
+
+// FindIPAllowlistDeniedOrgIDs returns the organizations whose enabled IP allowlist does not allow the address
+func FindIPAllowlistDeniedOrgIDs(ctx context.Context, remoteAddr string) ([]int64, error) {
+	lists := make([]*IPAllowlist, 0, 10)
+	if err := db.GetEngine(ctx).Where("enabled = ?", true).Find(&lists); err != nil {
+		return nil, err
+	}
+	orgIDs := make([]int64, 0, len(lists))
+	for _, l := range lists {
+		if !l.IsAllowed(remoteAddr) {
+			orgIDs = append(orgIDs, l.OrgID)
+		}
+	}
+	return orgIDs, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIPAllowlistRanges(t *testing.T) {
+	ranges, err := organization.NormalizeIPAllowlistRanges("192.0.2.1, 198.51.100.0/24\n\n2001:db8::1 10.1.2.3/8")
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1/32\n198.51.100.0/24\n2001:db8::1/128\n10.0.0.0/8", ranges)
+
+	ranges, err = organization.NormalizeIPAllowlistRanges("  ")
+	require.NoError(t, err)
+	assert.Empty(t, ranges)
+
+	_, err = organization.NormalizeIPAllowlistRanges("192.0.2.0/24, example.com")
+	require.Error(t, err)
+}
+
+func TestIPAllowlist_IsAllowed(t *testing.T) {
+	l := &organization.IPAllowlist{Ranges: "192.0.2.0/24\n2001:db8::/32"}
+	assert.True(t, l.IsAllowed("203.0.113.1"), "a disabled allowlist allows everything")
+
+	l.Enabled = true
+	assert.True(t, l.IsAllowed("192.0.2.10"))
+	assert.True(t, l.IsAllowed("192.0.2.10:2222"))
+	assert.True(t, l.IsAllowed("[2001:db8::5]:443"))
+	assert.False(t, l.IsAllowed("203.0.113.1"))
+	assert.False(t, l.IsAllowed("[2001:db9::5]:443"))
+	assert.False(t, l.IsAllowed("not an address"))
+}
+
+func TestIsIPAllowed(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	allowed, err := organization.IsIPAllowed(db.DefaultContext, org, "203.0.113.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	l, err := organization.GetIPAllowlist(db.DefaultContext, org.ID)
+	require.NoError(t, err)
+	l.Enabled = true
+	l.Ranges = "192.0.2.0/24"
+	require.NoError(t, organization.SaveIPAllowlist(db.DefaultContext, l))
+
+	allowed, err = organization.IsIPAllowed(db.DefaultContext, org, "203.0.113.1")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = organization.IsIPAllowed(db.DefaultContext, org, "192.0.2.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// the allowlists only apply to organizations
+	allowed, err = organization.IsIPAllowed(db.DefaultContext, user, "203.0.113.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	l.Ranges = "203.0.113.0/24"
+	require.NoError(t, organization.SaveIPAllowlist(db.DefaultContext, l))
+	allowed, err = organization.IsIPAllowed(db.DefaultContext, org, "203.0.113.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -196,7 +196,7 @@ func (t *Team) UnitAccessMode(ctx context.Context, tp unit.Type) perm.AccessMode
 // IsUsableTeamName tests if a name could be as team name
 func IsUsableTeamName(name string) error {
 	switch name {
-	case "new":
+	case "new", "-":
 		return db.ErrNameReserved{Name: name}
 	default:
 		return nil
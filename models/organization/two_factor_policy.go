@@ -0,0 +1,115 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// TwoFactorPolicy requires the members of an organization to enable two-factor authentication,
+// the members who did not enroll lose access to its resources once the grace period is over
+type TwoFactorPolicy struct {
+	ID       int64 `xorm:"pk autoincr"`
+	OrgID    int64 `xorm:"UNIQUE NOT NULL"`
+	Required bool  `xorm:"NOT NULL DEFAULT false"`
+	// GraceUntil is the end of the grace period given to the members to enroll
+	GraceUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// ReminderSent records that the members who did not enroll have been reminded of the end of the grace period
+	ReminderSent bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName returns the table of the two-factor authentication policies
+func (*TwoFactorPolicy) TableName() string {
+	return "org_two_factor_policy"
+}
+
+func init() {
+	db.RegisterModel(new(TwoFactorPolicy))
+}
+
+// IsEnforced reports whether the policy is required and its grace period is over
+func (p *TwoFactorPolicy) IsEnforced() bool {
+	return p.Required && timeutil.TimeStampNow() >= p.GraceUntil
+}
+
+// InGracePeriod reports whether the policy is required but the members may still enroll
+func (p *TwoFactorPolicy) InGracePeriod() bool {
+	return p.Required && timeutil.TimeStampNow() < p.GraceUntil
+}
+
+// GetTwoFactorPolicy returns the two-factor authentication policy of an organization,
+// one not requiring it if it has never been set
+func GetTwoFactorPolicy(ctx context.Context, orgID int64) (*TwoFactorPolicy, error) {
+	p := &TwoFactorPolicy{OrgID: orgID}
+	if _, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Get(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SaveTwoFactorPolicy creates or updates the two-factor authentication policy of an organization
+func SaveTwoFactorPolicy(ctx context.Context, p *TwoFactorPolicy) error {
+	if p.ID == 0 {
+		return db.Insert(ctx, p)
+	}
+	_, err := db.GetEngine(ctx).ID(p.ID).Cols("required", "grace_until", "reminder_sent").Update(p)
+	return err
+}
+
+// FindTwoFactorPolicies returns the policies of the organizations requiring two-factor authentication
+func FindTwoFactorPolicies(ctx context.Context) ([]*TwoFactorPolicy, error) {
+	policies := make([]*TwoFactorPolicy, 0, 10)
+	return policies, db.GetEngine(ctx).Where("required = ?", true).OrderBy("org_id").Find(&policies)
+}
+
+// FindTwoFactorPoliciesToRemind returns the policies whose grace period ends before the given time
+// and whose members have not been reminded yet
+func FindTwoFactorPoliciesToRemind(ctx context.Context, before timeutil.TimeStamp) ([]*TwoFactorPolicy, error) {
+	policies := make([]*TwoFactorPolicy, 0, 10)
+	return policies, db.GetEngine(ctx).
+		Where("required = ? AND reminder_sent = ?", true, false).
+		And("grace_until > ? AND grace_until <= ?", timeutil.TimeStampNow(), before).
+		OrderBy("org_id").
+		Find(&policies)
+}
+
+// GetTwoFactorNonCompliantMembers returns the members of an organization without two-factor authentication
+func GetTwoFactorNonCompliantMembers(ctx context.Context, orgID int64) ([]*user_model.User, error) {
+	users := make([]*user_model.User, 0, 10)
+	return users, db.GetEngine(ctx).
+		Where(builder.In("`user`.id", builder.Select("uid").From("org_user").Where(builder.Eq{"org_id": orgID}))).
+		And(builder.NotIn("`user`.id", builder.Select("uid").From("two_factor"))).
+		And(builder.NotIn("`user`.id", builder.Select("user_id").From("webauthn_credential"))).
+		OrderBy("`user`.lower_name").
+		Find(&users)
+}
+
+// IsTwoFactorCompliant reports whether the user satisfies the two-factor authentication policy of the owner,
+// which only applies to the members of an organization requiring it once its grace period is over
+func IsTwoFactorCompliant(ctx context.Context, owner, doer *user_model.User) (bool, error) {
+	if owner == nil || doer == nil || !owner.IsOrganization() {
+		return true, nil
+	}
+	p, err := GetTwoFactorPolicy(ctx, owner.ID)
+	if err != nil {
+		return false, err
+	}
+	if !p.IsEnforced() {
+		return true, nil
+	}
+	isMember, err := IsOrganizationMember(ctx, owner.ID, doer.ID)
+	if err != nil || !isMember {
+		return !isMember, err
+	}
+	return auth_model.HasTwoFactorByUID(ctx, doer.ID)
+}
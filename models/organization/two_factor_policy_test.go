@@ -0,0 +1,83 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	member := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	nonMember := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	members, err := organization.GetTwoFactorNonCompliantMembers(db.DefaultContext, org.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 3)
+
+	compliant, err := organization.IsTwoFactorCompliant(db.DefaultContext, org, member)
+	require.NoError(t, err)
+	assert.True(t, compliant, "not required")
+
+	p, err := organization.GetTwoFactorPolicy(db.DefaultContext, org.ID)
+	require.NoError(t, err)
+	p.Required = true
+	p.GraceUntil = timeutil.TimeStamp(time.Now().Add(time.Hour).Unix())
+	require.NoError(t, organization.SaveTwoFactorPolicy(db.DefaultContext, p))
+	assert.True(t, p.InGracePeriod())
+
+	policies, err := organization.FindTwoFactorPoliciesToRemind(db.DefaultContext, timeutil.TimeStampNow())
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+	policies, err = organization.FindTwoFactorPoliciesToRemind(db.DefaultContext, timeutil.TimeStamp(time.Now().Add(2*time.Hour).Unix()))
+	require.NoError(t, err)
+	assert.Len(t, policies, 1)
+
+	compliant, err = organization.IsTwoFactorCompliant(db.DefaultContext, org, member)
+	require.NoError(t, err)
+	assert.True(t, compliant, "in the grace period")
+
+	p.GraceUntil = timeutil.TimeStamp(time.Now().Add(-time.Hour).Unix())
+	require.NoError(t, organization.SaveTwoFactorPolicy(db.DefaultContext, p))
+	assert.True(t, p.IsEnforced())
+
+	compliant, err = organization.IsTwoFactorCompliant(db.DefaultContext, org, member)
+	require.NoError(t, err)
+	assert.False(t, compliant)
+
+	compliant, err = organization.IsTwoFactorCompliant(db.DefaultContext, org, nonMember)
+	require.NoError(t, err)
+	assert.True(t, compliant, "the policy only applies to the members")
+
+	compliant, err = organization.IsTwoFactorCompliant(db.DefaultContext, org, nil)
+	require.NoError(t, err)
+	assert.True(t, compliant, "the policy only applies to the signed in members")
+
+	require.NoError(t, db.Insert(db.DefaultContext, &auth_model.TwoFactor{UID: member.ID}))
+	compliant, err = organization.IsTwoFactorCompliant(db.DefaultContext, org, member)
+	require.NoError(t, err)
+	assert.True(t, compliant)
+
+	members, err = organization.GetTwoFactorNonCompliantMembers(db.DefaultContext, org.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	policies, err = organization.FindTwoFactorPolicies(db.DefaultContext)
+	require.NoError(t, err)
+	if assert.Len(t, policies, 1) {
+		assert.Equal(t, org.ID, policies[0].OrgID)
+	}
+}
@@ -248,3 +248,13 @@ func RecalculateAccesses(ctx context.Context, repo *repo_model.Repository) error
 	}
 	return refreshAccesses(ctx, repo, accessMap)
 }
+
+// GetRepoIDsByAccessMode returns the IDs of the repositories a user has at least the given access mode to, the
+// repositories the user owns are not included
+func GetRepoIDsByAccessMode(ctx context.Context, userID int64, mode perm.AccessMode) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("access").
+		Where("user_id = ? AND mode >= ?", userID, mode).
+		Cols("repo_id").
+		Find(&repoIDs)
+}
@@ -10,6 +10,7 @@ import (
 	perm_model "forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
 
@@ -125,3 +126,41 @@ func TestRepository_RecalculateAccesses2(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, has)
 }
+
+func TestGetRepoIDsByAccessMode(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repoIDs, err := access_model.GetRepoIDsByAccessMode(db.DefaultContext, 4, perm_model.AccessModeWrite)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{3, 4, 40}, repoIDs)
+
+	repoIDs, err = access_model.GetRepoIDsByAccessMode(db.DefaultContext, 4, perm_model.AccessModeAdmin)
+	require.NoError(t, err)
+	assert.Empty(t, repoIDs)
+}
+
+func TestPermissionLimitTo(t *testing.T) {
+	units := []*repo_model.RepoUnit{{Type: unit.TypeCode}, {Type: unit.TypeIssues}}
+
+	p := access_model.Permission{AccessMode: perm_model.AccessModeAdmin, Units: units}
+	p.LimitTo(perm_model.AccessModeRead)
+	assert.Equal(t, perm_model.AccessModeRead, p.AccessMode)
+	assert.True(t, p.CanRead(unit.TypeCode))
+	assert.False(t, p.CanWrite(unit.TypeIssues))
+	assert.False(t, p.IsAdmin())
+
+	p = access_model.Permission{
+		AccessMode: perm_model.AccessModeRead,
+		Units:      units,
+		UnitsMode: map[unit.Type]perm_model.AccessMode{
+			unit.TypeCode:   perm_model.AccessModeRead,
+			unit.TypeIssues: perm_model.AccessModeWrite,
+		},
+	}
+	p.LimitTo(perm_model.AccessModeWrite)
+	assert.True(t, p.CanWrite(unit.TypeIssues))
+	assert.False(t, p.CanWrite(unit.TypeCode))
+	p.LimitTo(perm_model.AccessModeNone)
+	assert.False(t, p.HasAccess())
+	assert.False(t, p.CanRead(unit.TypeCode))
+}
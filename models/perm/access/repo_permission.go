@@ -51,6 +51,26 @@ func (p *Permission) HasAccess() bool {
 	return len(p.UnitsMode) > 0
 }
 
+// LimitTo lowers the access to the repository and to each of its units to at most the given mode
+func (p *Permission) LimitTo(mode perm_model.AccessMode) {
+	if p.UnitsMode == nil {
+		p.UnitsMode = make(map[unit.Type]perm_model.AccessMode, len(p.Units))
+		for _, u := range p.Units {
+			if p.AccessMode > perm_model.AccessModeNone {
+				p.UnitsMode[u.Type] = p.AccessMode
+			}
+		}
+	}
+	p.AccessMode = min(p.AccessMode, mode)
+	for unitType, unitMode := range p.UnitsMode {
+		if unitMode = min(unitMode, mode); unitMode == perm_model.AccessModeNone {
+			delete(p.UnitsMode, unitType)
+		} else {
+			p.UnitsMode[unitType] = unitMode
+		}
+	}
+}
+
 // UnitAccessMode returns current user accessmode to the specify unit of the repository
 func (p *Permission) UnitAccessMode(unitType unit.Type) perm_model.AccessMode {
 	if p.UnitsMode == nil {
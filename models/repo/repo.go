@@ -122,6 +122,7 @@ const (
 	RepositoryBeingMigrated                           // repository is migrating
 	RepositoryPendingTransfer                         // repository pending in ownership transfer state
 	RepositoryBroken                                  // repository is in a permanently broken state
+	RepositoryBeingConverted                          // repository is converting its object format or reference storage
 )
 
 // Repository represents a git repository.
@@ -273,6 +274,11 @@ func (repo *Repository) IsBeingCreated() bool {
 	return repo.IsBeingMigrated()
 }
 
+// IsBeingConverted indicates that the git repository is being rewritten and cannot be pushed to
+func (repo *Repository) IsBeingConverted() bool {
+	return repo.Status == RepositoryBeingConverted
+}
+
 // IsBroken indicates that repository is broken
 func (repo *Repository) IsBroken() bool {
 	return repo.Status == RepositoryBroken
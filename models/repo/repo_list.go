@@ -182,6 +182,9 @@ type SearchRepoOptions struct {
 	HasMilestones optional.Option[bool]
 	// LowerNames represents valid lower names to restrict to
 	LowerNames []string
+	// ExcludeOwnerIDs are the owners whose repositories are left out, like the organizations
+	// whose IP allowlist denies the request
+	ExcludeOwnerIDs []int64
 	// When specified true, apply some filters over the conditions:
 	// - Don't show forks, when opts.Fork is OptionalBoolNone.
 	// - Do not display repositories that don't have a description, an icon and topics.
@@ -358,6 +361,10 @@ func SearchRepositoryCondition(opts *SearchRepoOptions) builder.Cond {
 		cond = cond.And(builder.Eq{"is_private": opts.IsPrivate.Value()})
 	}
 
+	if len(opts.ExcludeOwnerIDs) > 0 {
+		cond = cond.And(builder.NotIn("owner_id", opts.ExcludeOwnerIDs))
+	}
+
 	if opts.Template.Has() {
 		cond = cond.And(builder.Eq{"is_template": opts.Template.Value()})
 	}
@@ -111,6 +111,38 @@ func GetRepoAssignees(ctx context.Context, repo *Repository) (_ []*user_model.Us
 	return users, nil
 }
 
+// GetRepoAdmins returns all active users with admin access to the repository, including its owner
+// if it is not an organization
+func GetRepoAdmins(ctx context.Context, repo *Repository) ([]*user_model.User, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	e := db.GetEngine(ctx)
+	userIDs := make([]int64, 0, 10)
+	if err := e.Table("access").
+		Where("repo_id = ? AND mode >= ?", repo.ID, perm.AccessModeAdmin).
+		Select("user_id").
+		Find(&userIDs); err != nil {
+		return nil, err
+	}
+
+	uniqueUserIDs := make(container.Set[int64])
+	uniqueUserIDs.AddMultiple(userIDs...)
+	if !repo.Owner.IsOrganization() {
+		uniqueUserIDs.Add(repo.OwnerID)
+	}
+
+	users := make([]*user_model.User, 0, len(uniqueUserIDs))
+	if err := e.In("id", uniqueUserIDs.Values()).
+		Where(builder.Eq{"`user`.is_active": true}).
+		OrderBy(user_model.GetOrderByName()).
+		Find(&users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // GetReviewers get all users can be requested to review:
 // * for private repositories this returns all users that have read access or higher to the repository.
 // * for public repositories this returns all users that have read access or higher to the repository,
@@ -15,6 +15,24 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestGetRepoAdmins(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the owner of a repository of a user
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	users, err := repo_model.GetRepoAdmins(db.DefaultContext, repo2)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(2), users[0].ID)
+
+	// the owners of an organization, not its members with write access
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	users, err = repo_model.GetRepoAdmins(db.DefaultContext, repo3)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(2), users[0].ID)
+}
+
 func TestRepoAssignees(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 
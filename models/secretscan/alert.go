@@ -0,0 +1,234 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AlertState is the state of a secret scanning alert
+type AlertState int
+
+const (
+	// AlertStateOpen is a secret which is still to be handled
+	AlertStateOpen AlertState = iota
+	// AlertStateResolved is a secret which has been handled, see Resolution
+	AlertStateResolved
+)
+
+// Resolutions of the alerts
+const (
+	ResolutionRevoked       = "revoked"
+	ResolutionFalsePositive = "false_positive"
+	ResolutionUsedInTests   = "used_in_tests"
+	ResolutionWontFix       = "wont_fix"
+)
+
+// Resolutions are the reasons an alert can be resolved for
+var Resolutions = []string{ResolutionRevoked, ResolutionFalsePositive, ResolutionUsedInTests, ResolutionWontFix}
+
+// Alert is a secret found in a repository, reported once whatever the number of files and
+// commits it appears in
+type Alert struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// SecretHash is the SHA-256 hash of the secret, which is not stored
+	SecretHash   string `xorm:"UNIQUE(s) VARCHAR(64) NOT NULL"`
+	PatternID    string `xorm:"VARCHAR(50) NOT NULL"`
+	PatternName  string `xorm:"TEXT"`
+	MaskedSecret string
+	// CommitID, Path and Line are the first location the secret has been found at
+	CommitID string `xorm:"VARCHAR(64)"`
+	Path     string `xorm:"TEXT"`
+	Line     int
+	// PusherID is the user who pushed the secret, 0 if it has been found by scanning the history
+	PusherID int64
+	// BypasserID is the user who pushed the secret despite the push protection, with BypassReason
+	BypasserID   int64
+	BypassReason string             `xorm:"TEXT"`
+	State        AlertState         `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Resolution   string             `xorm:"VARCHAR(20)"`
+	ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Comment      string             `xorm:"TEXT"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created INDEX"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	ResolvedUnix timeutil.TimeStamp
+
+	Repo     *repo_model.Repository `xorm:"-"`
+	Pusher   *user_model.User       `xorm:"-"`
+	Bypasser *user_model.User       `xorm:"-"`
+	Resolver *user_model.User       `xorm:"-"`
+}
+
+// TableName sets the name of the table, alert alone is too generic
+func (*Alert) TableName() string {
+	return "secret_scanning_alert"
+}
+
+func init() {
+	db.RegisterModel(new(Alert))
+}
+
+// IsOpen returns whether the alert is still to be handled
+func (a *Alert) IsOpen() bool {
+	return a.State == AlertStateOpen
+}
+
+// HTMLURL returns the URL of the alert in the list of the alerts of its repository
+func (a *Alert) HTMLURL() string {
+	if a.Repo == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/settings/secret_scanning#alert-%d", a.Repo.HTMLURL(), a.ID)
+}
+
+// LoadAttributes loads the repository and the users of the alert
+func (a *Alert) LoadAttributes(ctx context.Context) (err error) {
+	if a.Repo == nil {
+		if a.Repo, err = repo_model.GetRepositoryByID(ctx, a.RepoID); err != nil {
+			return err
+		}
+	}
+	for _, u := range []struct {
+		id   int64
+		user **user_model.User
+	}{
+		{a.PusherID, &a.Pusher},
+		{a.BypasserID, &a.Bypasser},
+		{a.ResolverID, &a.Resolver},
+	} {
+		if u.id > 0 && *u.user == nil {
+			if *u.user, err = user_model.GetPossibleUserByID(ctx, u.id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AlertList is a list of alerts
+type AlertList []*Alert
+
+// LoadAttributes loads the repositories and the users of the alerts
+func (alerts AlertList) LoadAttributes(ctx context.Context) error {
+	repos := make(map[int64]*repo_model.Repository)
+	for _, a := range alerts {
+		a.Repo = repos[a.RepoID]
+		if err := a.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		repos[a.RepoID] = a.Repo
+	}
+	return nil
+}
+
+// FindAlertsOptions are the options to list the alerts of a repository or of the repositories of an owner
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64
+	// State filters the alerts by state if IsFiltered is set
+	State      AlertState
+	IsFiltered bool
+}
+
+// ToConds implements db.FindOptions
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.In("repo_id", builder.Select("id").From("repository").Where(builder.Eq{"owner_id": opts.OwnerID})))
+	}
+	if opts.IsFiltered {
+		cond = cond.And(builder.Eq{"state": opts.State})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder, the most recent alerts first
+func (opts FindAlertsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// FindAlerts returns a page of alerts and their total number
+func FindAlerts(ctx context.Context, opts FindAlertsOptions) (AlertList, int64, error) {
+	alerts, count, err := db.FindAndCount[Alert](ctx, opts)
+	return alerts, count, err
+}
+
+// CountOpenAlerts returns the number of alerts of a repository which are still to be handled
+func CountOpenAlerts(ctx context.Context, repoID int64) (int64, error) {
+	return db.Count[Alert](ctx, FindAlertsOptions{RepoID: repoID, State: AlertStateOpen, IsFiltered: true})
+}
+
+// GetAlertByID returns an alert of a repository
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	a := &Alert{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("secret scanning alert %d does not exist", id)
+	}
+	return a, nil
+}
+
+// InsertNewAlerts inserts the alerts for the secrets which have not been reported in their repository
+// yet and returns them
+func InsertNewAlerts(ctx context.Context, alerts []*Alert) ([]*Alert, error) {
+	var inserted []*Alert
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, a := range alerts {
+			exists, err := db.GetEngine(ctx).Where("repo_id = ? AND secret_hash = ?", a.RepoID, a.SecretHash).Exist(new(Alert))
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			if err := db.Insert(ctx, a); err != nil {
+				return err
+			}
+			inserted = append(inserted, a)
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+// ResolveAlert resolves an alert, doerID is 0 when the secret has been revoked automatically
+func ResolveAlert(ctx context.Context, a *Alert, doerID int64, resolution, comment string) error {
+	if !util.SliceContainsString(Resolutions, resolution) {
+		return util.NewInvalidArgumentErrorf("invalid resolution %q", resolution)
+	}
+	a.State = AlertStateResolved
+	a.Resolution = resolution
+	a.ResolverID = doerID
+	a.Comment = comment
+	a.ResolvedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "resolution", "resolver_id", "comment", "resolved_unix").Update(a)
+	return err
+}
+
+// ReopenAlert opens again a resolved alert
+func ReopenAlert(ctx context.Context, a *Alert) error {
+	a.State = AlertStateOpen
+	a.Resolution = ""
+	a.ResolverID = 0
+	a.Comment = ""
+	a.ResolvedUnix = 0
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("state", "resolution", "resolver_id", "comment", "resolved_unix").Update(a)
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/secretscan"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRepoConfig(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	cfg, err := secretscan_model.GetRepoConfig(db.DefaultContext, repo)
+	require.NoError(t, err)
+	assert.Equal(t, secretscan_model.ModeDisabled, cfg.Mode)
+	assert.Len(t, cfg.Patterns, len(secretscan.BuiltinPatterns))
+
+	orgSetting, err := secretscan_model.GetSetting(db.DefaultContext, repo.OwnerID, 0)
+	require.NoError(t, err)
+	orgSetting.Mode = secretscan_model.ModeBlock
+	orgSetting.CustomPatterns = "acme_[a-z]{8}"
+	require.NoError(t, secretscan_model.SaveSetting(db.DefaultContext, orgSetting))
+
+	cfg, err = secretscan_model.GetRepoConfig(db.DefaultContext, repo)
+	require.NoError(t, err)
+	assert.Equal(t, secretscan_model.ModeBlock, cfg.Mode)
+	require.Len(t, cfg.Patterns, len(secretscan.BuiltinPatterns)+1)
+	assert.Equal(t, "acme_[a-z]{8}", cfg.Patterns[len(cfg.Patterns)-1].Name)
+
+	// the repository overrides the mode of its owner and adds its own patterns
+	repoSetting, err := secretscan_model.GetSetting(db.DefaultContext, 0, repo.ID)
+	require.NoError(t, err)
+	repoSetting.Mode = secretscan_model.ModeAlert
+	repoSetting.CustomPatterns = "("
+	require.ErrorIs(t, secretscan_model.SaveSetting(db.DefaultContext, repoSetting), util.ErrInvalidArgument)
+	repoSetting.CustomPatterns = "internal_[0-9]{6}"
+	require.NoError(t, secretscan_model.SaveSetting(db.DefaultContext, repoSetting))
+
+	cfg, err = secretscan_model.GetRepoConfig(db.DefaultContext, repo)
+	require.NoError(t, err)
+	assert.Equal(t, secretscan_model.ModeAlert, cfg.Mode)
+	assert.Len(t, cfg.Patterns, len(secretscan.BuiltinPatterns)+2)
+}
+
+func TestAlerts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newAlert := func(hash string) *secretscan_model.Alert {
+		return &secretscan_model.Alert{RepoID: 1, SecretHash: hash, PatternID: secretscan.PatternPrivateKey, Path: "id_rsa", Line: 1}
+	}
+	inserted, err := secretscan_model.InsertNewAlerts(db.DefaultContext, []*secretscan_model.Alert{newAlert("a"), newAlert("b")})
+	require.NoError(t, err)
+	assert.Len(t, inserted, 2)
+
+	// the secrets already reported are skipped
+	inserted, err = secretscan_model.InsertNewAlerts(db.DefaultContext, []*secretscan_model.Alert{newAlert("b"), newAlert("c")})
+	require.NoError(t, err)
+	require.Len(t, inserted, 1)
+	assert.Equal(t, "c", inserted[0].SecretHash)
+
+	count, err := secretscan_model.CountOpenAlerts(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+
+	alert, err := secretscan_model.GetAlertByID(db.DefaultContext, 1, inserted[0].ID)
+	require.NoError(t, err)
+	require.ErrorIs(t, secretscan_model.ResolveAlert(db.DefaultContext, alert, 2, "unknown", ""), util.ErrInvalidArgument)
+	require.NoError(t, secretscan_model.ResolveAlert(db.DefaultContext, alert, 2, secretscan_model.ResolutionFalsePositive, "test fixture"))
+
+	alerts, count, err := secretscan_model.FindAlerts(db.DefaultContext, secretscan_model.FindAlertsOptions{
+		OwnerID:    2,
+		State:      secretscan_model.AlertStateOpen,
+		IsFiltered: true,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	require.NoError(t, alerts.LoadAttributes(db.DefaultContext))
+	assert.Equal(t, "repo1", alerts[0].Repo.Name)
+
+	require.NoError(t, secretscan_model.ReopenAlert(db.DefaultContext, alert))
+	count, err = secretscan_model.CountOpenAlerts(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+
+	_, err = secretscan_model.GetAlertByID(db.DefaultContext, 2, alert.ID)
+	require.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/secretscan"
+	"forgejo.org/modules/timeutil"
+)
+
+// Mode is what happens when a secret is found in a push
+type Mode int
+
+const (
+	// ModeDefault uses the mode of the owner of the repository, disabled if it has none
+	ModeDefault Mode = iota
+	// ModeDisabled does not scan the pushes
+	ModeDisabled
+	// ModeAlert accepts the pushes and raises alerts for the secrets they contain
+	ModeAlert
+	// ModeBlock rejects the pushes containing secrets unless the pusher bypasses the protection with a reason
+	ModeBlock
+)
+
+// Modes are the modes in the order they are listed
+var Modes = []Mode{ModeDefault, ModeDisabled, ModeAlert, ModeBlock}
+
+// Name returns the name of the mode, used by the translations
+func (m Mode) Name() string {
+	switch m {
+	case ModeDisabled:
+		return "disabled"
+	case ModeAlert:
+		return "alert"
+	case ModeBlock:
+		return "block"
+	}
+	return "default"
+}
+
+// IsValid returns whether the mode is a known one
+func (m Mode) IsValid() bool {
+	return m >= ModeDefault && m <= ModeBlock
+}
+
+// IsEnabled returns whether the pushes are scanned
+func (m Mode) IsEnabled() bool {
+	return m == ModeAlert || m == ModeBlock
+}
+
+// Setting configures secret scanning for the repositories of an organization, or for a single repository
+type Setting struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	RepoID  int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	Mode    Mode  `xorm:"NOT NULL DEFAULT 0"`
+	// CustomPatterns are regular expressions matching secrets specific to the owner, one per line
+	CustomPatterns string             `xorm:"TEXT"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the name of the table, setting alone is too generic
+func (*Setting) TableName() string {
+	return "secret_scanning_setting"
+}
+
+func init() {
+	db.RegisterModel(new(Setting))
+}
+
+// GetSetting returns the secret scanning setting of an organization, or of a repository if repoID
+// is set, an empty one if it has never been set
+func GetSetting(ctx context.Context, ownerID, repoID int64) (*Setting, error) {
+	s := &Setting{OwnerID: ownerID, RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Get(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SaveSetting validates and creates or updates a secret scanning setting
+func SaveSetting(ctx context.Context, s *Setting) error {
+	if _, err := secretscan.ParseCustomPatterns(s.CustomPatterns); err != nil {
+		return err
+	}
+	if s.ID == 0 {
+		return db.Insert(ctx, s)
+	}
+	_, err := db.GetEngine(ctx).ID(s.ID).AllCols().Update(s)
+	return err
+}
+
+// RepoConfig is the secret scanning configuration applying to a repository
+type RepoConfig struct {
+	Mode     Mode
+	Patterns []*secretscan.Pattern
+}
+
+// GetRepoConfig returns the secret scanning configuration of a repository: its own mode, or the one of
+// its owner by default, and the builtin patterns followed by the custom ones of its owner and its own
+func GetRepoConfig(ctx context.Context, repo *repo_model.Repository) (*RepoConfig, error) {
+	var settings []*Setting
+	if err := db.GetEngine(ctx).Where("(owner_id = ? AND repo_id = 0) OR (owner_id = 0 AND repo_id = ?)", repo.OwnerID, repo.ID).
+		OrderBy("repo_id").Find(&settings); err != nil {
+		return nil, err
+	}
+
+	cfg := &RepoConfig{Mode: ModeDisabled, Patterns: secretscan.BuiltinPatterns}
+	for _, s := range settings {
+		if s.Mode != ModeDefault {
+			cfg.Mode = s.Mode
+		}
+		custom, err := secretscan.ParseCustomPatterns(s.CustomPatterns)
+		if err != nil {
+			return nil, err
+		}
+		if len(custom) > 0 {
+			cfg.Patterns = append(cfg.Patterns[:len(cfg.Patterns):len(cfg.Patterns)], custom...)
+		}
+	}
+	return cfg, nil
+}
@@ -299,6 +299,12 @@ func (w *Webhook) HasPackageEvent() bool {
 		(w.ChooseEvents && w.Package)
 }
 
+// HasSecretScanningAlertEvent returns if hook enabled secret scanning alert event.
+func (w *Webhook) HasSecretScanningAlertEvent() bool {
+	return w.SendEverything ||
+		(w.ChooseEvents && w.SecretScanningAlert)
+}
+
 // HasPullRequestReviewRequestEvent returns true if hook enabled pull request review request event.
 func (w *Webhook) HasPullRequestReviewRequestEvent() bool {
 	return w.SendEverything ||
@@ -336,6 +342,7 @@ func (w *Webhook) EventCheckers() []struct {
 		{w.HasRepositoryEvent, webhook_module.HookEventRepository},
 		{w.HasReleaseEvent, webhook_module.HookEventRelease},
 		{w.HasPackageEvent, webhook_module.HookEventPackage},
+		{w.HasSecretScanningAlertEvent, webhook_module.HookEventSecretScanningAlert},
 		{w.HasPullRequestReviewRequestEvent, webhook_module.HookEventPullRequestReviewRequest},
 	}
 }
@@ -0,0 +1,103 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// lockedPackage is a [[package]] table of a TOML lockfile
+type lockedPackage struct {
+	values map[string]string
+	// dependencies are the items of the dependencies array
+	dependencies []string
+}
+
+// parseLockedPackages reads the [[package]] tables of Cargo.lock and poetry.lock, which are written by
+// their tools with one key per line and arrays of strings spanning several lines
+func parseLockedPackages(content []byte) ([]*lockedPackage, error) {
+	var packages []*lockedPackage
+	var current *lockedPackage
+	inDependencies := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if inDependencies {
+			if strings.HasPrefix(line, "]") {
+				inDependencies = false
+				continue
+			}
+			if s, err := strconv.Unquote(strings.TrimSuffix(line, ",")); err == nil {
+				current.dependencies = append(current.dependencies, s)
+			}
+			continue
+		}
+		if line[0] == '[' {
+			current = nil
+			if line == "[[package]]" {
+				current = &lockedPackage{values: make(map[string]string)}
+				packages = append(packages, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "dependencies" && value == "[" {
+			inDependencies = true
+			continue
+		}
+		if s, err := strconv.Unquote(value); err == nil {
+			current.values[key] = s
+		}
+	}
+	return packages, scanner.Err()
+}
+
+// https://doc.rust-lang.org/cargo/guide/cargo-toml-vs-cargo-lock.html
+func parseCargoLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseLockedPackages(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// the crates of the project have no source, their dependencies are the direct ones
+	direct := make(map[string]bool)
+	for _, p := range packages {
+		if p.values["source"] == "" {
+			for _, d := range p.dependencies {
+				name, _, _ := strings.Cut(d, " ")
+				direct[name] = true
+			}
+		}
+	}
+
+	var deps []*Dependency
+	for _, p := range packages {
+		if p.values["source"] == "" || p.values["name"] == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemCargo,
+			Name:        p.values["name"],
+			Version:     p.values["version"],
+			Requirement: p.values["version"],
+			Direct:      direct[p.values["name"]],
+		})
+	}
+	return deps, nil
+}
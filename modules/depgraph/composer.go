@@ -0,0 +1,91 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"strings"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/packages/composer"
+)
+
+// isComposerPackage returns whether a requirement is a package, rather than PHP or one of its extensions
+func isComposerPackage(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// https://getcomposer.org/doc/04-schema.md#package-links
+func parseComposerJSON(content []byte) ([]*Dependency, error) {
+	var metadata composer.Metadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, group := range []struct {
+		requirements map[string]string
+		development  bool
+	}{
+		{metadata.Require, false},
+		{metadata.RequireDev, true},
+	} {
+		for _, name := range sortedKeys(group.requirements) {
+			if !isComposerPackage(name) {
+				continue
+			}
+			deps = append(deps, &Dependency{
+				Ecosystem:   EcosystemComposer,
+				Name:        name,
+				Version:     pinnedVersion(group.requirements[name]),
+				Requirement: group.requirements[name],
+				Direct:      true,
+				Development: group.development,
+			})
+		}
+	}
+	return deps, nil
+}
+
+// https://getcomposer.org/doc/01-basic-usage.md#commit-your-composer-lock-file-to-version-control
+func parseComposerLock(content []byte) ([]*Dependency, error) {
+	type lockedPackage struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Packages    []lockedPackage `json:"packages"`
+		PackagesDev []lockedPackage `json:"packages-dev"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, group := range []struct {
+		packages    []lockedPackage
+		development bool
+	}{
+		{lock.Packages, false},
+		{lock.PackagesDev, true},
+	} {
+		for _, p := range group.packages {
+			if !isComposerPackage(p.Name) || p.Version == "" {
+				continue
+			}
+			// branches are locked as dev-<branch>, they have no version
+			version := p.Version
+			if strings.HasPrefix(version, "dev-") {
+				version = ""
+			}
+			deps = append(deps, &Dependency{
+				Ecosystem:   EcosystemComposer,
+				Name:        p.Name,
+				Version:     strings.TrimPrefix(version, "v"),
+				Requirement: p.Version,
+				Development: group.development,
+			})
+		}
+	}
+	return deps, nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/hashicorp/go-version"
+	"gopkg.in/yaml.v3"
+)
+
+// UpdateConfigPaths are the paths of the configuration of the dependency updates of a repository
+var UpdateConfigPaths = []string{".forgejo/dependency-updates.yaml", ".forgejo/dependency-updates.yml"}
+
+// UpdateType is the kind of change of version of an update, following semantic versioning
+type UpdateType string
+
+const (
+	UpdateTypeMajor UpdateType = "major"
+	UpdateTypeMinor UpdateType = "minor"
+	UpdateTypePatch UpdateType = "patch"
+)
+
+// Schedule intervals
+const (
+	IntervalDaily   = "daily"
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+)
+
+const defaultPullRequestsLimit = 5
+
+// Schedule is when the dependencies are checked for updates
+type Schedule struct {
+	// Interval is daily, weekly or monthly, on the first day of the month
+	Interval string `yaml:"interval"`
+	// Day is the day of the week of the weekly updates
+	Day string `yaml:"day"`
+}
+
+// UpdateGroup gathers the updates of the dependencies matching its patterns in a single pull request
+type UpdateGroup struct {
+	Name     string   `yaml:"name"`
+	Patterns []string `yaml:"patterns"`
+	// UpdateTypes restricts the group to these types of updates, the others have their own pull requests
+	UpdateTypes []UpdateType `yaml:"update_types"`
+
+	globs []glob.Glob
+}
+
+// IgnoreRule excludes updates of the dependencies matching its pattern: all of them, or those to some versions
+// or of some types only
+type IgnoreRule struct {
+	Dependency  string       `yaml:"dependency"`
+	Versions    []string     `yaml:"versions"`
+	UpdateTypes []UpdateType `yaml:"update_types"`
+
+	glob        glob.Glob
+	constraints []version.Constraints
+}
+
+// UpdateConfig is the configuration of the dependency updates of a repository
+type UpdateConfig struct {
+	Schedule Schedule `yaml:"schedule"`
+	// Limit is the maximum number of pull requests opened at the same time
+	Limit int `yaml:"open_pull_requests_limit"`
+	// Ecosystems and Directories restrict the updates to some ecosystems and to the manifests in some directories
+	Ecosystems  []Ecosystem    `yaml:"ecosystems"`
+	Directories []string       `yaml:"directories"`
+	Labels      []string       `yaml:"labels"`
+	Groups      []*UpdateGroup `yaml:"groups"`
+	Ignore      []*IgnoreRule  `yaml:"ignore"`
+}
+
+func isValidUpdateType(t UpdateType) bool {
+	return t == UpdateTypeMajor || t == UpdateTypeMinor || t == UpdateTypePatch
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), day) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// ParseUpdateConfig parses and validates the configuration of the dependency updates of a repository
+func ParseUpdateConfig(content []byte) (*UpdateConfig, error) {
+	cfg := &UpdateConfig{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.Schedule.Interval == "" {
+		cfg.Schedule.Interval = IntervalWeekly
+	}
+	switch cfg.Schedule.Interval {
+	case IntervalDaily, IntervalMonthly:
+	case IntervalWeekly:
+		if cfg.Schedule.Day == "" {
+			cfg.Schedule.Day = "monday"
+		}
+		if _, ok := parseWeekday(cfg.Schedule.Day); !ok {
+			return nil, fmt.Errorf("invalid schedule day %q", cfg.Schedule.Day)
+		}
+	default:
+		return nil, fmt.Errorf("invalid schedule interval %q", cfg.Schedule.Interval)
+	}
+	if cfg.Limit < 0 {
+		return nil, fmt.Errorf("invalid open_pull_requests_limit %d", cfg.Limit)
+	} else if cfg.Limit == 0 {
+		cfg.Limit = defaultPullRequestsLimit
+	}
+	for i, dir := range cfg.Directories {
+		cfg.Directories[i] = strings.Trim(dir, "/")
+	}
+
+	names := make(map[string]bool, len(cfg.Groups))
+	for _, g := range cfg.Groups {
+		if g.Name == "" || names[g.Name] {
+			return nil, fmt.Errorf("group names must be set and unique, %q is not", g.Name)
+		}
+		names[g.Name] = true
+		if len(g.Patterns) == 0 {
+			return nil, fmt.Errorf("group %q has no patterns", g.Name)
+		}
+		for _, pattern := range g.Patterns {
+			gl, err := glob.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q of group %q: %w", pattern, g.Name, err)
+			}
+			g.globs = append(g.globs, gl)
+		}
+		for _, t := range g.UpdateTypes {
+			if !isValidUpdateType(t) {
+				return nil, fmt.Errorf("invalid update type %q of group %q", t, g.Name)
+			}
+		}
+	}
+
+	for _, rule := range cfg.Ignore {
+		gl, err := glob.Compile(rule.Dependency)
+		if rule.Dependency == "" || err != nil {
+			return nil, fmt.Errorf("invalid ignored dependency %q", rule.Dependency)
+		}
+		rule.glob = gl
+		for _, v := range rule.Versions {
+			c, err := version.NewConstraint(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ignored versions %q of %q: %w", v, rule.Dependency, err)
+			}
+			rule.constraints = append(rule.constraints, c)
+		}
+		for _, t := range rule.UpdateTypes {
+			if !isValidUpdateType(t) {
+				return nil, fmt.Errorf("invalid update type %q of ignored dependency %q", t, rule.Dependency)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// IsDue returns whether the dependencies are to be checked for updates on the day of t
+func (cfg *UpdateConfig) IsDue(t time.Time) bool {
+	switch cfg.Schedule.Interval {
+	case IntervalMonthly:
+		return t.Day() == 1
+	case IntervalWeekly:
+		day, _ := parseWeekday(cfg.Schedule.Day)
+		return t.Weekday() == day
+	}
+	return true
+}
+
+// Includes returns whether the dependencies of an ecosystem declared by a manifest are updated
+func (cfg *UpdateConfig) Includes(manifest string, ecosystem Ecosystem) bool {
+	if len(cfg.Ecosystems) > 0 && !slices.Contains(cfg.Ecosystems, ecosystem) {
+		return false
+	}
+	if len(cfg.Directories) == 0 {
+		return true
+	}
+	dir := ""
+	if i := strings.LastIndex(manifest, "/"); i >= 0 {
+		dir = manifest[:i]
+	}
+	return slices.Contains(cfg.Directories, dir)
+}
+
+// IsIgnored returns whether the update of a dependency to a version is excluded
+func (cfg *UpdateConfig) IsIgnored(name, newVersion string, t UpdateType) bool {
+	for _, rule := range cfg.Ignore {
+		if !rule.glob.Match(name) {
+			continue
+		}
+		if len(rule.Versions) == 0 && len(rule.UpdateTypes) == 0 {
+			return true
+		}
+		if slices.Contains(rule.UpdateTypes, t) {
+			return true
+		}
+		if v, err := version.NewVersion(newVersion); err == nil {
+			for _, c := range rule.constraints {
+				if c.Check(v) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Group returns the name of the group the update of a dependency belongs to, empty if it has its own pull request
+func (cfg *UpdateConfig) Group(name string, t UpdateType) string {
+	for _, g := range cfg.Groups {
+		if len(g.UpdateTypes) > 0 && !slices.Contains(g.UpdateTypes, t) {
+			continue
+		}
+		for _, gl := range g.globs {
+			if gl.Match(name) {
+				return g.Name
+			}
+		}
+	}
+	return ""
+}
+
+// GetUpdateType returns the type of the update from a version to a newer one
+func GetUpdateType(current, latest string) UpdateType {
+	c, err1 := version.NewVersion(current)
+	l, err2 := version.NewVersion(latest)
+	if err1 != nil || err2 != nil {
+		return UpdateTypeMajor
+	}
+	cs, ls := c.Segments(), l.Segments()
+	if cs[0] != ls[0] {
+		return UpdateTypeMajor
+	}
+	if len(cs) < 2 || len(ls) < 2 || cs[1] != ls[1] {
+		return UpdateTypeMinor
+	}
+	return UpdateTypePatch
+}
+
+// LatestVersion returns the most recent stable version newer than the current one and not ignored, empty if
+// there is none
+func (cfg *UpdateConfig) LatestVersion(name, current string, versions []string) string {
+	cur, err := version.NewVersion(current)
+	if err != nil {
+		return ""
+	}
+	var candidates []*version.Version
+	raw := make(map[*version.Version]string)
+	for _, s := range versions {
+		v, err := version.NewVersion(s)
+		// prereleases are only proposed to the dependencies already using one
+		if err != nil || v.Metadata() == "incompatible" || (v.Prerelease() != "" && cur.Prerelease() == "") || !v.GreaterThan(cur) {
+			continue
+		}
+		candidates = append(candidates, v)
+		raw[v] = s
+	}
+	slices.SortFunc(candidates, func(a, b *version.Version) int { return b.Compare(a) })
+	for _, v := range candidates {
+		if !cfg.IsIgnored(name, raw[v], GetUpdateType(current, raw[v])) {
+			return raw[v]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Ecosystem is a package ecosystem, named like in the OSV schema
+type Ecosystem string
+
+const (
+	EcosystemNpm      Ecosystem = "npm"
+	EcosystemCargo    Ecosystem = "crates.io"
+	EcosystemComposer Ecosystem = "Packagist"
+	EcosystemPyPI     Ecosystem = "PyPI"
+	EcosystemMaven    Ecosystem = "Maven"
+	EcosystemGo       Ecosystem = "Go"
+)
+
+// Dependency is a package a project depends on
+type Dependency struct {
+	Ecosystem Ecosystem
+	Name      string
+	// Version is the exact version used, empty if the manifest only gives a requirement
+	Version string
+	// Requirement is the version or range of versions declared in the manifest
+	Requirement string
+	// Direct is whether the project declares the dependency, rather than another dependency requiring it
+	Direct bool
+	// Development is whether the dependency is only needed to develop the project
+	Development bool
+}
+
+type parser func(content []byte) ([]*Dependency, error)
+
+// manifest is a kind of file declaring dependencies
+type manifest struct {
+	parse parser
+	// lockfile is the name of the file listing the exact versions of the dependencies, parsed instead
+	// of the manifest when both are in the same directory
+	lockfile string
+}
+
+var manifests = map[string]*manifest{
+	"package.json":      {parse: parsePackageJSON, lockfile: "package-lock.json"},
+	"package-lock.json": {parse: parsePackageLock},
+	"Cargo.lock":        {parse: parseCargoLock},
+	"composer.json":     {parse: parseComposerJSON, lockfile: "composer.lock"},
+	"composer.lock":     {parse: parseComposerLock},
+	"requirements.txt":  {parse: parseRequirements},
+	"Pipfile.lock":      {parse: parsePipfileLock},
+	"poetry.lock":       {parse: parsePoetryLock},
+	"pom.xml":           {parse: parsePom},
+	"go.mod":            {parse: parseGoMod},
+}
+
+// IsManifest returns whether a file declares dependencies and can be parsed
+func IsManifest(filePath string) bool {
+	_, ok := manifests[path.Base(filePath)]
+	return ok
+}
+
+// SelectManifests returns the manifests to parse among files: all of them but those whose lockfile is
+// in the same directory
+func SelectManifests(filePaths []string) []string {
+	exists := make(map[string]bool, len(filePaths))
+	for _, p := range filePaths {
+		exists[p] = true
+	}
+	selected := make([]string, 0, len(filePaths))
+	for _, p := range filePaths {
+		m, ok := manifests[path.Base(p)]
+		if !ok {
+			continue
+		}
+		if m.lockfile != "" && exists[path.Join(path.Dir(p), m.lockfile)] {
+			continue
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+// Parse returns the dependencies declared in a manifest
+func Parse(filePath string, content []byte) ([]*Dependency, error) {
+	m, ok := manifests[path.Base(filePath)]
+	if !ok {
+		return nil, nil
+	}
+	return m.parse(content)
+}
+
+// NormalizeName returns the name of a package as compared between the manifests and the vulnerabilities
+func NormalizeName(ecosystem Ecosystem, name string) string {
+	switch ecosystem {
+	case EcosystemPyPI:
+		return normalizePyPIName(name)
+	case EcosystemComposer:
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// validNames are the patterns of the names of the packages of the ecosystems, as their registries accept them
+var validNames = map[Ecosystem]*regexp.Regexp{
+	// https://github.com/npm/validate-npm-package-name, upper case letters are allowed for the former packages
+	EcosystemNpm:   regexp.MustCompile(`^(@[A-Za-z0-9~-][A-Za-z0-9._~-]*/)?[A-Za-z0-9~-][A-Za-z0-9._~-]*$`),
+	EcosystemCargo: regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`),
+	// https://getcomposer.org/doc/04-schema.md#name
+	EcosystemComposer: regexp.MustCompile(`(?i)^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]|-{1,2})?[a-z0-9]+)*$`),
+	// https://packaging.python.org/en/latest/specifications/name-normalization/
+	EcosystemPyPI:  regexp.MustCompile(`(?i)^([a-z0-9]|[a-z0-9][a-z0-9._-]*[a-z0-9])$`),
+	EcosystemMaven: regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*:[A-Za-z0-9_-][A-Za-z0-9._-]*$`),
+	// https://go.dev/ref/mod#go-mod-file-ident
+	EcosystemGo: regexp.MustCompile(`^[A-Za-z0-9_~+-][A-Za-z0-9._~+-]*(/[A-Za-z0-9_~+-][A-Za-z0-9._~+-]*)*$`),
+}
+
+// IsValidName returns whether the name of a package is valid in its ecosystem, only the valid names can be
+// looked up in the registries
+func IsValidName(ecosystem Ecosystem, name string) bool {
+	pattern, ok := validNames[ecosystem]
+	return ok && len(name) <= 256 && pattern.MatchString(name)
+}
+
+// pinnedVersion returns the version a requirement allows if it allows a single one, empty otherwise
+func pinnedVersion(requirement string) string {
+	v := strings.TrimSpace(requirement)
+	v = strings.TrimPrefix(v, "==")
+	v = strings.TrimPrefix(v, "=")
+	v = strings.TrimSpace(v)
+	if v == "" || strings.ContainsAny(v, "^~<>=*|, ") {
+		return ""
+	}
+	for _, part := range strings.Split(v, ".") {
+		if part == "x" || part == "X" {
+			return ""
+		}
+	}
+	if v[0] < '0' || v[0] > '9' {
+		if v[0] != 'v' || len(v) == 1 || v[1] < '0' || v[1] > '9' {
+			return ""
+		}
+	}
+	return v
+}
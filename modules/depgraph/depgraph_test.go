@@ -0,0 +1,295 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectManifests(t *testing.T) {
+	assert.Equal(t, []string{"package-lock.json", "web/package.json", "go.mod", "api/requirements.txt"}, SelectManifests([]string{
+		"package.json",
+		"package-lock.json",
+		"web/package.json",
+		"README.md",
+		"go.mod",
+		"api/requirements.txt",
+	}))
+	assert.True(t, IsManifest("a/b/Cargo.lock"))
+	assert.False(t, IsManifest("Cargo.toml"))
+}
+
+func TestPinnedVersion(t *testing.T) {
+	for requirement, version := range map[string]string{
+		"1.2.3":       "1.2.3",
+		"=1.2.3":      "1.2.3",
+		"== 1.2.3":    "1.2.3",
+		"v1.2.3":      "v1.2.3",
+		"1.0.0-fix.1": "1.0.0-fix.1",
+		"^1.2.3":      "",
+		"~1.2":        "",
+		">=1.0,<2":    "",
+		"1.x":         "",
+		"*":           "",
+		"latest":      "",
+		"${version}":  "",
+		"[1.0,2.0)":   "",
+	} {
+		assert.Equal(t, version, pinnedVersion(requirement), requirement)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("package.json", func(t *testing.T) {
+		deps, err := Parse("package.json", []byte(`{
+	"name": "app",
+	"repository": "github:forgejo/app",
+	"dependencies": {"lodash": "4.17.20", "react": "^18.0.0"},
+	"devDependencies": {"eslint": "9.0.0"}
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemNpm, Name: "lodash", Version: "4.17.20", Requirement: "4.17.20", Direct: true},
+			{Ecosystem: EcosystemNpm, Name: "react", Requirement: "^18.0.0", Direct: true},
+			{Ecosystem: EcosystemNpm, Name: "eslint", Version: "9.0.0", Requirement: "9.0.0", Direct: true, Development: true},
+		}, deps)
+	})
+
+	t.Run("package-lock.json", func(t *testing.T) {
+		deps, err := Parse("package-lock.json", []byte(`{
+	"lockfileVersion": 3,
+	"packages": {
+		"": {"name": "app", "dependencies": {"express": "^4.0.0"}},
+		"node_modules/express": {"version": "4.17.1"},
+		"node_modules/express/node_modules/qs": {"version": "6.7.0"},
+		"node_modules/@types/node": {"version": "20.0.0", "dev": true},
+		"node_modules/local": {"resolved": "packages/local", "link": true}
+	}
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemNpm, Name: "@types/node", Version: "20.0.0", Requirement: "20.0.0", Development: true},
+			{Ecosystem: EcosystemNpm, Name: "express", Version: "4.17.1", Requirement: "4.17.1", Direct: true},
+			{Ecosystem: EcosystemNpm, Name: "qs", Version: "6.7.0", Requirement: "6.7.0"},
+		}, deps)
+
+		deps, err = Parse("package-lock.json", []byte(`{
+	"lockfileVersion": 1,
+	"dependencies": {
+		"express": {"version": "4.17.1", "dependencies": {"qs": {"version": "6.7.0"}}},
+		"qs": {"version": "6.7.0"}
+	}
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemNpm, Name: "express", Version: "4.17.1", Requirement: "4.17.1"},
+			{Ecosystem: EcosystemNpm, Name: "qs", Version: "6.7.0", Requirement: "6.7.0"},
+		}, deps)
+	})
+
+	t.Run("Cargo.lock", func(t *testing.T) {
+		deps, err := Parse("Cargo.lock", []byte(`# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+ "time 0.1.45",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.100"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "abc"
+
+[[package]]
+name = "time"
+version = "0.1.45"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = [
+ "libc",
+]
+
+[[package]]
+name = "libc"
+version = "0.2.150"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemCargo, Name: "serde", Version: "1.0.100", Requirement: "1.0.100", Direct: true},
+			{Ecosystem: EcosystemCargo, Name: "time", Version: "0.1.45", Requirement: "0.1.45", Direct: true},
+			{Ecosystem: EcosystemCargo, Name: "libc", Version: "0.2.150", Requirement: "0.2.150"},
+		}, deps)
+	})
+
+	t.Run("composer", func(t *testing.T) {
+		deps, err := Parse("composer.json", []byte(`{
+	"name": "acme/app",
+	"license": "MIT",
+	"require": {"php": ">=8.1", "ext-json": "*", "guzzlehttp/guzzle": "7.4.0"},
+	"require-dev": {"phpunit/phpunit": "^10.0"}
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemComposer, Name: "guzzlehttp/guzzle", Version: "7.4.0", Requirement: "7.4.0", Direct: true},
+			{Ecosystem: EcosystemComposer, Name: "phpunit/phpunit", Requirement: "^10.0", Direct: true, Development: true},
+		}, deps)
+
+		deps, err = Parse("composer.lock", []byte(`{
+	"packages": [{"name": "guzzlehttp/guzzle", "version": "7.4.0"}, {"name": "acme/fork", "version": "dev-main"}],
+	"packages-dev": [{"name": "phpunit/phpunit", "version": "v10.1.0"}]
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemComposer, Name: "guzzlehttp/guzzle", Version: "7.4.0", Requirement: "7.4.0"},
+			{Ecosystem: EcosystemComposer, Name: "acme/fork", Requirement: "dev-main"},
+			{Ecosystem: EcosystemComposer, Name: "phpunit/phpunit", Version: "10.1.0", Requirement: "v10.1.0", Development: true},
+		}, deps)
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		deps, err := Parse("requirements.txt", []byte(`# production
+-r base.txt
+Django==3.2.0 ; python_version >= "3.8"
+requests[security] >= 2.0  # any recent one
+Flask_Cors==3.0.9
+git+https://example.com/lib.git
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemPyPI, Name: "django", Version: "3.2.0", Requirement: "==3.2.0", Direct: true},
+			{Ecosystem: EcosystemPyPI, Name: "requests", Requirement: ">= 2.0", Direct: true},
+			{Ecosystem: EcosystemPyPI, Name: "flask-cors", Version: "3.0.9", Requirement: "==3.0.9", Direct: true},
+		}, deps)
+
+		deps, err = Parse("Pipfile.lock", []byte(`{
+	"_meta": {"hash": {"sha256": "abc"}},
+	"default": {"jinja2": {"version": "==2.11.2"}},
+	"develop": {"pytest": {"version": "==7.0.0"}}
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemPyPI, Name: "jinja2", Version: "2.11.2", Requirement: "==2.11.2"},
+			{Ecosystem: EcosystemPyPI, Name: "pytest", Version: "7.0.0", Requirement: "==7.0.0", Development: true},
+		}, deps)
+
+		deps, err = Parse("poetry.lock", []byte(`[[package]]
+name = "PyYAML"
+version = "5.3"
+description = "YAML parser and emitter for Python"
+optional = false
+
+[[package]]
+name = "pytest"
+version = "7.0.0"
+category = "dev"
+
+[metadata]
+lock-version = "1.1"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemPyPI, Name: "pyyaml", Version: "5.3", Requirement: "5.3"},
+			{Ecosystem: EcosystemPyPI, Name: "pytest", Version: "7.0.0", Requirement: "7.0.0", Development: true},
+		}, deps)
+	})
+
+	t.Run("pom.xml", func(t *testing.T) {
+		deps, err := Parse("pom.xml", []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project>
+	<groupId>org.acme</groupId>
+	<artifactId>app</artifactId>
+	<dependencies>
+		<dependency>
+			<groupId>com.fasterxml.jackson.core</groupId>
+			<artifactId>jackson-databind</artifactId>
+			<version>2.13.0</version>
+		</dependency>
+		<dependency>
+			<groupId>org.acme</groupId>
+			<artifactId>lib</artifactId>
+			<version>${project.version}</version>
+		</dependency>
+	</dependencies>
+</project>`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemMaven, Name: "com.fasterxml.jackson.core:jackson-databind", Version: "2.13.0", Requirement: "2.13.0", Direct: true},
+			{Ecosystem: EcosystemMaven, Name: "org.acme:lib", Requirement: "${project.version}", Direct: true},
+		}, deps)
+	})
+
+	t.Run("go.mod", func(t *testing.T) {
+		deps, err := Parse("go.mod", []byte(`module example.com/app
+
+go 1.24
+
+require golang.org/x/text v0.3.7
+
+require (
+	github.com/gin-gonic/gin v1.9.0
+	golang.org/x/net v0.17.0 // indirect
+)
+
+replace golang.org/x/text => ../text
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: EcosystemGo, Name: "golang.org/x/text", Version: "0.3.7", Requirement: "v0.3.7", Direct: true},
+			{Ecosystem: EcosystemGo, Name: "github.com/gin-gonic/gin", Version: "1.9.0", Requirement: "v1.9.0", Direct: true},
+			{Ecosystem: EcosystemGo, Name: "golang.org/x/net", Version: "0.17.0", Requirement: "v0.17.0"},
+		}, deps)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse("package.json", []byte(`{`))
+		require.Error(t, err)
+
+		deps, err := Parse("README.md", []byte(`# app`))
+		require.NoError(t, err)
+		assert.Empty(t, deps)
+	})
+}
+
+func TestIsValidName(t *testing.T) {
+	for ecosystem, names := range map[Ecosystem][]string{
+		EcosystemNpm:      {"lodash", "@babel/core", "JSONStream"},
+		EcosystemCargo:    {"serde_json", "tokio-util"},
+		EcosystemComposer: {"symfony/http-kernel", "Monolog/Monolog"},
+		EcosystemPyPI:     {"Flask_Cors", "zope.interface", "x"},
+		EcosystemMaven:    {"org.apache.commons:commons-lang3", "junit:junit"},
+		EcosystemGo:       {"github.com/Masterminds/semver/v3", "golang.org/x/mod", "gopkg.in/yaml.v3"},
+	} {
+		for _, name := range names {
+			assert.True(t, IsValidName(ecosystem, name), "%s %s", ecosystem, name)
+		}
+	}
+	for ecosystem, names := range map[Ecosystem][]string{
+		EcosystemNpm:         {"", "../../admin", "@scope/../x", "a/b", "a?b", "a#b", "@scope/name/extra"},
+		EcosystemCargo:       {"../x", "a/b"},
+		EcosystemComposer:    {"vendor", "vendor/../package", "vendor/package/extra", "vendor/package?x"},
+		EcosystemPyPI:        {"../x", "a/b", "a%2F"},
+		EcosystemMaven:       {"org.apache", "..:x", "org..apache:x", "org/apache:x", "org.apache:..", "org.apache:a/b"},
+		EcosystemGo:          {"../x", "example.com/../x", "example.com/./x", "/example.com", "example.com/a?b", "example.com//x"},
+		Ecosystem("unknown"): {"x"},
+	} {
+		for _, name := range names {
+			assert.False(t, IsValidName(ecosystem, name), "%s %s", ecosystem, name)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "flask-cors", NormalizeName(EcosystemPyPI, "Flask_Cors"))
+	assert.Equal(t, "zope-interface", NormalizeName(EcosystemPyPI, "zope.interface"))
+	assert.Equal(t, "symfony/http-kernel", NormalizeName(EcosystemComposer, "Symfony/HTTP-Kernel"))
+	assert.Equal(t, "github.com/Masterminds/semver", NormalizeName(EcosystemGo, "github.com/Masterminds/semver"))
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// editableManifests are the manifests whose requirements can be updated without running a package manager, the
+// lockfiles need one to be regenerated
+var editableManifests = map[string]bool{
+	"package.json":     true,
+	"composer.json":    true,
+	"requirements.txt": true,
+	"pom.xml":          true,
+	"go.mod":           true,
+}
+
+// requirementOperators are the operators of the requirements allowing a version and its compatible updates,
+// kept when the version is updated
+var requirementOperators = []string{"^", "~=", "~", "==", "="}
+
+// ErrRequirementNotFound means that a requirement to update is not in a manifest
+var ErrRequirementNotFound = errors.New("requirement not found")
+
+// IsEditable returns whether the requirements of a manifest can be updated
+func IsEditable(filePath string) bool {
+	return editableManifests[path.Base(filePath)]
+}
+
+// splitRequirement returns the operator and the version of a requirement of a single version, or of a version
+// and its compatible updates
+func splitRequirement(requirement string) (operator, v string) {
+	requirement = strings.TrimSpace(requirement)
+	for _, op := range requirementOperators {
+		if strings.HasPrefix(requirement, op) {
+			operator = op
+			break
+		}
+	}
+	// the operators like ^ and ~ are not allowed by pinnedVersion
+	return operator, pinnedVersion(strings.TrimPrefix(requirement, operator))
+}
+
+// RequirementVersion returns the version a requirement is based on if it can be updated, empty otherwise
+func RequirementVersion(requirement string) string {
+	_, v := splitRequirement(requirement)
+	return strings.TrimPrefix(v, "v")
+}
+
+// UpdateRequirement returns the requirement updated to a newer version, keeping its operator and its "v" prefix
+func UpdateRequirement(requirement, newVersion string) string {
+	operator, v := splitRequirement(requirement)
+	newVersion = strings.TrimPrefix(newVersion, "v")
+	if strings.HasPrefix(v, "v") {
+		newVersion = "v" + newVersion
+	}
+	return operator + newVersion
+}
+
+var pomDependencyPattern = regexp.MustCompile(`(?s)<dependency>.*?</dependency>`)
+
+// EditManifest replaces the requirement of a dependency in a manifest
+func EditManifest(filePath string, content []byte, name, requirement, newRequirement string) ([]byte, error) {
+	var pattern *regexp.Regexp
+	switch path.Base(filePath) {
+	case "package.json", "composer.json":
+		pattern = regexp.MustCompile(`("` + regexp.QuoteMeta(name) + `"\s*:\s*")` + regexp.QuoteMeta(requirement) + `(")`)
+	case "requirements.txt":
+		// the names differing by case and separators are the same, https://peps.python.org/pep-0503/#normalized-names
+		parts := strings.Split(normalizePyPIName(name), "-")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		pattern = regexp.MustCompile(`(?im)^(\s*` + strings.Join(parts, `[-_.]+`) + `\s*(?:\[[^\]]*\])?\s*)` + regexp.QuoteMeta(requirement) + `(\s|;|$)`)
+	case "go.mod":
+		pattern = regexp.MustCompile(`(?m)^(\s*(?:require\s+)?` + regexp.QuoteMeta(name) + `\s+)` + regexp.QuoteMeta(requirement) + `(\s|$)`)
+	case "pom.xml":
+		groupID, artifactID, ok := strings.Cut(name, ":")
+		if !ok {
+			return nil, ErrRequirementNotFound
+		}
+		groupPattern := regexp.MustCompile(`<groupId>\s*` + regexp.QuoteMeta(groupID) + `\s*</groupId>`)
+		artifactPattern := regexp.MustCompile(`<artifactId>\s*` + regexp.QuoteMeta(artifactID) + `\s*</artifactId>`)
+		versionPattern := regexp.MustCompile(`<version>\s*` + regexp.QuoteMeta(requirement) + `\s*</version>`)
+		found := false
+		edited := pomDependencyPattern.ReplaceAllFunc(content, func(dependency []byte) []byte {
+			if !groupPattern.Match(dependency) || !artifactPattern.Match(dependency) || !versionPattern.Match(dependency) {
+				return dependency
+			}
+			found = true
+			return versionPattern.ReplaceAllLiteral(dependency, []byte("<version>"+newRequirement+"</version>"))
+		})
+		if !found {
+			return nil, ErrRequirementNotFound
+		}
+		return edited, nil
+	default:
+		return nil, ErrRequirementNotFound
+	}
+
+	if !pattern.Match(content) {
+		return nil, ErrRequirementNotFound
+	}
+	return pattern.ReplaceAll(content, []byte("${1}"+strings.ReplaceAll(newRequirement, "$", "$$")+"${2}")), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// https://go.dev/ref/mod#go-mod-file-require
+func parseGoMod(content []byte) ([]*Dependency, error) {
+	var deps []*Dependency
+	inRequire := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		comment := ""
+		if i := strings.Index(line, "//"); i >= 0 {
+			line, comment = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+2:])
+		}
+
+		if inRequire {
+			if line == ")" {
+				inRequire = false
+				continue
+			}
+		} else {
+			directive, rest, _ := strings.Cut(line, " ")
+			if directive != "require" {
+				continue
+			}
+			rest = strings.TrimSpace(rest)
+			if rest == "(" {
+				inRequire = true
+				continue
+			}
+			line = rest
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemGo,
+			Name:      strings.Trim(fields[0], `"`),
+			// the Go ecosystem of OSV has no v prefix
+			Version:     strings.TrimPrefix(fields[1], "v"),
+			Requirement: fields[1],
+			Direct:      comment != "indirect",
+		})
+	}
+	return deps, scanner.Err()
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"bytes"
+
+	"forgejo.org/modules/packages/maven"
+)
+
+// https://maven.apache.org/pom.html#Dependencies
+func parsePom(content []byte) ([]*Dependency, error) {
+	metadata, err := maven.ParsePackageMetaData(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(metadata.Dependencies))
+	for _, d := range metadata.Dependencies {
+		if d.GroupID == "" || d.ArtifactID == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: EcosystemMaven,
+			Name:      d.GroupID + ":" + d.ArtifactID,
+			// versions inherited from a parent or given by a property are unknown
+			Version:     pinnedVersion(d.Version),
+			Requirement: d.Version,
+			Direct:      true,
+		})
+	}
+	return deps, nil
+}
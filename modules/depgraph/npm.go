@@ -0,0 +1,138 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"sort"
+	"strings"
+
+	"forgejo.org/modules/json"
+)
+
+// https://docs.npmjs.com/cli/configuring-npm/package-json#dependencies
+func parsePackageJSON(content []byte) ([]*Dependency, error) {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, group := range []struct {
+		requirements map[string]string
+		development  bool
+	}{
+		{pkg.Dependencies, false},
+		{pkg.OptionalDependencies, false},
+		{pkg.DevDependencies, true},
+	} {
+		for _, name := range sortedKeys(group.requirements) {
+			deps = append(deps, &Dependency{
+				Ecosystem:   EcosystemNpm,
+				Name:        name,
+				Version:     pinnedVersion(group.requirements[name]),
+				Requirement: group.requirements[name],
+				Direct:      true,
+				Development: group.development,
+			})
+		}
+	}
+	return deps, nil
+}
+
+type packageLockDependency struct {
+	Version      string                            `json:"version"`
+	Dev          bool                              `json:"dev"`
+	Link         bool                              `json:"link"`
+	Dependencies map[string]*packageLockDependency `json:"dependencies"`
+}
+
+// https://docs.npmjs.com/cli/configuring-npm/package-lock-json
+func parsePackageLock(content []byte) ([]*Dependency, error) {
+	var lock struct {
+		// Packages is set from the version 2 of the format, by path in node_modules
+		Packages map[string]struct {
+			Name                 string            `json:"name"`
+			Version              string            `json:"version"`
+			Dev                  bool              `json:"dev"`
+			Link                 bool              `json:"link"`
+			Dependencies         map[string]string `json:"dependencies"`
+			DevDependencies      map[string]string `json:"devDependencies"`
+			OptionalDependencies map[string]string `json:"optionalDependencies"`
+		} `json:"packages"`
+		// Dependencies is the only list in the version 1 of the format, as a tree
+		Dependencies map[string]*packageLockDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	direct := make(map[string]bool)
+	seen := make(map[string]bool)
+	var deps []*Dependency
+	add := func(name string, dep *packageLockDependency) {
+		if dep.Link || dep.Version == "" || seen[name+"@"+dep.Version] {
+			return
+		}
+		seen[name+"@"+dep.Version] = true
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemNpm,
+			Name:        name,
+			Version:     dep.Version,
+			Requirement: dep.Version,
+			Direct:      direct[name],
+			Development: dep.Dev,
+		})
+	}
+
+	if len(lock.Packages) > 0 {
+		if root, ok := lock.Packages[""]; ok {
+			for _, requirements := range []map[string]string{root.Dependencies, root.DevDependencies, root.OptionalDependencies} {
+				for name := range requirements {
+					direct[name] = true
+				}
+			}
+		}
+		for _, p := range sortedKeys(lock.Packages) {
+			_, name, ok := strings.Cut(p, "node_modules/")
+			if !ok {
+				// the project itself or one of its workspaces
+				continue
+			}
+			if i := strings.LastIndex(name, "/node_modules/"); i >= 0 {
+				name = name[i+len("/node_modules/"):]
+			}
+			pkg := lock.Packages[p]
+			if pkg.Name != "" {
+				// an alias of the package
+				name = pkg.Name
+			}
+			add(name, &packageLockDependency{Version: pkg.Version, Dev: pkg.Dev, Link: pkg.Link})
+		}
+		return deps, nil
+	}
+
+	// the tree of the version 1 does not tell which dependencies are direct
+	var walk func(tree map[string]*packageLockDependency)
+	walk = func(tree map[string]*packageLockDependency) {
+		for _, name := range sortedKeys(tree) {
+			add(name, tree[name])
+			walk(tree[name].Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return deps, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
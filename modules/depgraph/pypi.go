@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"forgejo.org/modules/json"
+)
+
+var (
+	// https://peps.python.org/pep-0508/#names
+	requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*([^;]*)`)
+	pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+)
+
+// normalizePyPIName normalizes the name of a Python package, https://peps.python.org/pep-0503/#normalized-names
+func normalizePyPIName(name string) string {
+	return strings.ToLower(pypiNameSeparators.ReplaceAllString(name, "-"))
+}
+
+// https://pip.pypa.io/en/stable/reference/requirements-file-format/
+func parseRequirements(content []byte) ([]*Dependency, error) {
+	var deps []*Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// options like -r other.txt, comments and URLs
+		if line == "" || line[0] == '-' || line[0] == '#' || strings.Contains(line, "://") {
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		requirement := strings.TrimSpace(m[3])
+		version := ""
+		if strings.HasPrefix(requirement, "==") && !strings.Contains(requirement, ",") {
+			version = pinnedVersion(requirement)
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemPyPI,
+			Name:        normalizePyPIName(m[1]),
+			Version:     version,
+			Requirement: requirement,
+			Direct:      true,
+		})
+	}
+	return deps, scanner.Err()
+}
+
+// https://pipenv.pypa.io/en/latest/pipfile.html#pipfile-lock
+func parsePipfileLock(content []byte) ([]*Dependency, error) {
+	var lock struct {
+		Default map[string]struct {
+			Version string `json:"version"`
+		} `json:"default"`
+		Develop map[string]struct {
+			Version string `json:"version"`
+		} `json:"develop"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, name := range sortedKeys(lock.Default) {
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemPyPI,
+			Name:        normalizePyPIName(name),
+			Version:     pinnedVersion(lock.Default[name].Version),
+			Requirement: lock.Default[name].Version,
+		})
+	}
+	for _, name := range sortedKeys(lock.Develop) {
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemPyPI,
+			Name:        normalizePyPIName(name),
+			Version:     pinnedVersion(lock.Develop[name].Version),
+			Requirement: lock.Develop[name].Version,
+			Development: true,
+		})
+	}
+	return deps, nil
+}
+
+// https://python-poetry.org/docs/basic-usage/#committing-your-poetrylock-file-to-version-control
+func parsePoetryLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseLockedPackages(content)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(packages))
+	for _, p := range packages {
+		if p.values["name"] == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem:   EcosystemPyPI,
+			Name:        normalizePyPIName(p.values["name"]),
+			Version:     p.values["version"],
+			Requirement: p.values["version"],
+			// older versions of poetry tell which packages are only for development
+			Development: p.values["category"] == "dev",
+		})
+	}
+	return deps, nil
+}
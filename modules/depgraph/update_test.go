@@ -0,0 +1,154 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdateConfig(t *testing.T) {
+	cfg, err := ParseUpdateConfig([]byte(`
+schedule:
+  interval: weekly
+  day: friday
+ecosystems: [npm]
+directories: [/web/]
+groups:
+  - name: lint
+    patterns: ["eslint*", "@typescript-eslint/*"]
+  - name: minor
+    patterns: ["*"]
+    update_types: [minor, patch]
+ignore:
+  - dependency: legacy
+  - dependency: react
+    update_types: [major]
+  - dependency: lodash
+    versions: [">= 5.0"]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.Limit)
+
+	friday := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+	assert.True(t, cfg.IsDue(friday))
+	assert.False(t, cfg.IsDue(friday.AddDate(0, 0, 1)))
+
+	assert.True(t, cfg.Includes("web/package.json", EcosystemNpm))
+	assert.False(t, cfg.Includes("package.json", EcosystemNpm))
+	assert.False(t, cfg.Includes("web/composer.json", EcosystemComposer))
+
+	assert.Equal(t, "lint", cfg.Group("@typescript-eslint/parser", UpdateTypeMajor))
+	assert.Equal(t, "minor", cfg.Group("express", UpdateTypePatch))
+	assert.Empty(t, cfg.Group("express", UpdateTypeMajor))
+
+	assert.True(t, cfg.IsIgnored("legacy", "1.0.1", UpdateTypePatch))
+	assert.True(t, cfg.IsIgnored("react", "19.0.0", UpdateTypeMajor))
+	assert.False(t, cfg.IsIgnored("react", "18.3.0", UpdateTypeMinor))
+	assert.Equal(t, "4.18.0", cfg.LatestVersion("lodash", "4.17.20", []string{"4.17.21", "5.0.0", "4.18.0", "5.0.0-beta.1", "3.0.0"}))
+	assert.Equal(t, "18.3.0", cfg.LatestVersion("react", "18.2.0", []string{"18.3.0", "19.0.0"}))
+	assert.Empty(t, cfg.LatestVersion("react", "18.3.0", []string{"18.3.0", "19.0.0"}))
+
+	cfg, err = ParseUpdateConfig([]byte(`schedule: {interval: monthly}`))
+	require.NoError(t, err)
+	assert.True(t, cfg.IsDue(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, cfg.IsDue(friday))
+
+	for _, invalid := range []string{
+		`schedule: {interval: hourly}`,
+		`schedule: {day: someday}`,
+		`groups: [{name: a}]`,
+		`groups: [{name: a, patterns: ["*"]}, {name: a, patterns: ["*"]}]`,
+		`ignore: [{dependency: a, versions: ["not a constraint"]}]`,
+		`ignore: [{dependency: a, update_types: [huge]}]`,
+		`open_pull_requests_limit: -1`,
+	} {
+		_, err := ParseUpdateConfig([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGetUpdateType(t *testing.T) {
+	assert.Equal(t, UpdateTypeMajor, GetUpdateType("1.2.3", "2.0.0"))
+	assert.Equal(t, UpdateTypeMinor, GetUpdateType("1.2.3", "1.3.0"))
+	assert.Equal(t, UpdateTypePatch, GetUpdateType("1.2.3", "1.2.4"))
+	assert.Equal(t, UpdateTypeMinor, GetUpdateType("0.3.7", "v0.4.0"))
+}
+
+func TestUpdateRequirement(t *testing.T) {
+	for requirement, updated := range map[string]string{
+		"1.2.3":   "2.0.0",
+		"^1.2.3":  "^2.0.0",
+		"~1.2":    "~2.0.0",
+		"==1.2.3": "==2.0.0",
+		"~=1.2":   "~=2.0.0",
+		"v1.2.3":  "v2.0.0",
+	} {
+		assert.NotEmpty(t, RequirementVersion(requirement), requirement)
+		assert.Equal(t, updated, UpdateRequirement(requirement, "2.0.0"), requirement)
+	}
+	for _, requirement := range []string{">=1.0", "1.x", "*", "latest", "${version}", "^1.0 || ^2.0"} {
+		assert.Empty(t, RequirementVersion(requirement), requirement)
+	}
+}
+
+func TestEditManifest(t *testing.T) {
+	edited, err := EditManifest("package.json", []byte(`{
+	"dependencies": {"lodash": "^4.17.20", "lodash.merge": "^4.17.20"}
+}`), "lodash", "^4.17.20", "^4.17.21")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+	"dependencies": {"lodash": "^4.17.21", "lodash.merge": "^4.17.20"}
+}`, string(edited))
+
+	edited, err = EditManifest("requirements.txt", []byte(`Flask_Cors==3.0.9 ; python_version >= "3.8"
+flask==3.0.9
+`), "flask-cors", "==3.0.9", "==4.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, `Flask_Cors==4.0.0 ; python_version >= "3.8"
+flask==3.0.9
+`, string(edited))
+
+	edited, err = EditManifest("go.mod", []byte(`module example.com/app
+
+require golang.org/x/text v0.3.7
+
+require (
+	golang.org/x/text/v2 v0.3.7
+)
+`), "golang.org/x/text", "v0.3.7", "v0.3.8")
+	require.NoError(t, err)
+	assert.Equal(t, `module example.com/app
+
+require golang.org/x/text v0.3.8
+
+require (
+	golang.org/x/text/v2 v0.3.7
+)
+`, string(edited))
+
+	edited, err = EditManifest("pom.xml", []byte(`<dependencies>
+	<dependency>
+		<groupId>org.acme</groupId>
+		<artifactId>lib</artifactId>
+		<version>1.0</version>
+	</dependency>
+	<dependency>
+		<groupId>org.acme</groupId>
+		<artifactId>other</artifactId>
+		<version>1.0</version>
+	</dependency>
+</dependencies>`), "org.acme:other", "1.0", "1.1")
+	require.NoError(t, err)
+	assert.Contains(t, string(edited), "<artifactId>lib</artifactId>\n\t\t<version>1.0</version>")
+	assert.Contains(t, string(edited), "<artifactId>other</artifactId>\n\t\t<version>1.1</version>")
+
+	_, err = EditManifest("package.json", []byte(`{"dependencies": {"lodash": "^4.17.20"}}`), "lodash", "4.17.20", "4.17.21")
+	assert.ErrorIs(t, err, ErrRequirementNotFound)
+	assert.True(t, IsEditable("web/package.json"))
+	assert.False(t, IsEditable("package-lock.json"))
+}
@@ -38,6 +38,9 @@ var (
 	InvertedGitFlushEnv    bool // 2.43.1
 	SupportCheckAttrOnBare bool // >= 2.40
 	SupportGitMergeTree    bool // >= 2.38
+	SupportGitMaintenance  bool // >= 2.34, multi-pack-index bitmaps
+	SupportBundleURI       bool // >= 2.40, uploadpack.advertiseBundleURIs
+	SupportReftable        bool // >= 2.46, git refs migrate
 
 	HasSSHExecutable bool
 
@@ -191,6 +194,9 @@ func InitFull(ctx context.Context) (err error) {
 
 	InvertedGitFlushEnv = CheckGitVersionEqual("2.43.1") == nil
 	SupportGitMergeTree = CheckGitVersionAtLeast("2.38") == nil
+	SupportGitMaintenance = CheckGitVersionAtLeast("2.34") == nil
+	SupportBundleURI = CheckGitVersionAtLeast("2.40") == nil
+	SupportReftable = CheckGitVersionAtLeast("2.46") == nil
 
 	if setting.LFS.StartServer {
 		if CheckGitVersionAtLeast("2.1.2") != nil {
@@ -20,6 +20,8 @@ const (
 	AgitTitle       = Key("title")
 	AgitDescription = Key("description")
 
+	SecretScanningBypass = Key("secret_scanning.bypass")
+
 	envPrefix = "GIT_PUSH_OPTION"
 	EnvCount  = envPrefix + "_COUNT"
 	EnvFormat = envPrefix + "_%d"
@@ -70,6 +72,7 @@ func (o *gitPushOptions) Parse(data string) bool {
 	case AgitForcePush:
 	case AgitTitle:
 	case AgitDescription:
+	case SecretScanningBypass:
 	default:
 		return false
 	}
@@ -66,6 +66,16 @@ func IsRepoURLAccessible(ctx context.Context, url string) bool {
 
 // InitRepository initializes a new Git repository.
 func InitRepository(ctx context.Context, repoPath string, bare bool, objectFormatName string) error {
+	refFormat := RefFormatFiles
+	if setting.Repository.DefaultRefFormat == string(RefFormatReftable) {
+		refFormat = RefFormatReftable
+	}
+	return initRepository(ctx, repoPath, bare, objectFormatName, refFormat)
+}
+
+// initRepository initializes a new Git repository storing its references with refFormat,
+// which only applies if Git supports reftable
+func initRepository(ctx context.Context, repoPath string, bare bool, objectFormatName string, refFormat RefFormat) error {
 	err := os.MkdirAll(repoPath, os.ModePerm)
 	if err != nil {
 		return err
@@ -79,6 +89,9 @@ func InitRepository(ctx context.Context, repoPath string, bare bool, objectForma
 	if SupportHashSha256 {
 		cmd.AddOptionValues("--object-format", objectFormatName)
 	}
+	if SupportReftable {
+		cmd.AddOptionValues("--ref-format", string(refFormat))
+	}
 
 	if bare {
 		cmd.AddArguments("--bare")
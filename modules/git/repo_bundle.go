@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CreateFullBundle writes a bundle of all the branches and tags of the repository
+func (repo *Repository) CreateFullBundle(ctx context.Context, out io.Writer) error {
+	var stderr strings.Builder
+	if err := NewCommand(ctx, "bundle", "create", "--quiet", "-", "--branches", "--tags").Run(&RunOpts{
+		Dir:    repo.Path,
+		Stdout: out,
+		Stderr: &stderr,
+	}); err != nil {
+		return ConcatenateError(err, stderr.String())
+	}
+	return nil
+}
+
+// bundleURIKeys are the configuration keys advertising a bundle, see git-config(1) bundle.*
+var bundleURIKeys = []string{"uploadpack.advertiseBundleURIs", "bundle.version", "bundle.mode", "bundle.full.uri"}
+
+// SetBundleURI advertises the bundle at uri to the clients cloning the repository with protocol v2,
+// clients with transfer.bundleURI enabled download it before fetching the remaining objects.
+// An empty uri stops advertising any bundle.
+func SetBundleURI(ctx context.Context, repoPath, uri string) error {
+	for _, key := range bundleURIKeys {
+		// exit code 5 means the key was not set
+		if err := NewCommand(ctx, "config", "--local", "--unset-all").AddDynamicArguments(key).Run(&RunOpts{Dir: repoPath}); err != nil && !IsErrorExitCode(err, 5) {
+			return fmt.Errorf("unset %s: %w", key, err)
+		}
+	}
+	if uri == "" {
+		return nil
+	}
+	for i, value := range []string{"true", "1", "all", uri} {
+		if err := NewCommand(ctx, "config", "--local").AddDynamicArguments(bundleURIKeys[i], value).Run(&RunOpts{Dir: repoPath}); err != nil {
+			return fmt.Errorf("set %s: %w", bundleURIKeys[i], err)
+		}
+	}
+	return nil
+}
+
+// GetBundleURI returns the URI of the bundle advertised by the repository, empty if none
+func GetBundleURI(ctx context.Context, repoPath string) (string, error) {
+	return getLocalConfig(ctx, repoPath, "bundle.full.uri", "")
+}
+
+// bundleNameKey is the configuration key recording the file name of the current bundle of the repository,
+// so it is known without listing the bundle storage
+const bundleNameKey = "forgejo.bundle"
+
+// SetBundleName records the file name of the current bundle of the repository, an empty name removes it
+func SetBundleName(ctx context.Context, repoPath, name string) error {
+	if name == "" {
+		// exit code 5 means the key was not set
+		if err := NewCommand(ctx, "config", "--local", "--unset-all", bundleNameKey).Run(&RunOpts{Dir: repoPath}); err != nil && !IsErrorExitCode(err, 5) {
+			return fmt.Errorf("unset %s: %w", bundleNameKey, err)
+		}
+		return nil
+	}
+	if err := NewCommand(ctx, "config", "--local", bundleNameKey).AddDynamicArguments(name).Run(&RunOpts{Dir: repoPath}); err != nil {
+		return fmt.Errorf("set %s: %w", bundleNameKey, err)
+	}
+	return nil
+}
+
+// GetBundleName returns the file name of the current bundle of the repository, empty if none
+func GetBundleName(ctx context.Context, repoPath string) (string, error) {
+	return getLocalConfig(ctx, repoPath, bundleNameKey, "")
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFullBundle(t *testing.T) {
+	repo, err := openRepositoryWithDefaultContext(filepath.Join(testReposDir, "repo1_bare"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, repo.CreateFullBundle(t.Context(), &buf))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("# v2 git bundle\n")))
+	assert.Contains(t, buf.String(), " refs/heads/master\n")
+	assert.Contains(t, buf.String(), " refs/tags/test\n")
+}
+
+func TestSetBundleURI(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, InitRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name()))
+
+	require.NoError(t, SetBundleURI(t.Context(), repoPath, "https://example.com/bundle/1.bundle"))
+	uri, err := GetBundleURI(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/bundle/1.bundle", uri)
+
+	require.NoError(t, SetBundleURI(t.Context(), repoPath, ""))
+	uri, err = GetBundleURI(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, uri)
+}
+
+func TestSetBundleName(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, InitRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name()))
+
+	name, err := GetBundleName(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, name)
+
+	require.NoError(t, SetBundleName(t.Context(), repoPath, "1.bundle"))
+	name, err = GetBundleName(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "1.bundle", name)
+
+	require.NoError(t, SetBundleName(t.Context(), repoPath, ""))
+	require.NoError(t, SetBundleName(t.Context(), repoPath, ""))
+	name, err = GetBundleName(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
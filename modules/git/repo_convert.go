@@ -0,0 +1,151 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RefFormat is the storage backend of the references of a repository
+type RefFormat string
+
+const (
+	RefFormatFiles    RefFormat = "files"    // loose files and packed-refs
+	RefFormatReftable RefFormat = "reftable" // binary tables, faster for repositories with many references
+)
+
+// IsValid returns whether the reference storage backend is known
+func (f RefFormat) IsValid() bool {
+	return f == RefFormatFiles || f == RefFormatReftable
+}
+
+// GetRefFormat returns the storage backend of the references of the repository
+func GetRefFormat(ctx context.Context, repoPath string) (RefFormat, error) {
+	stdout, _, err := NewCommand(ctx, "config", "--local", "--get", "extensions.refstorage").RunStdString(&RunOpts{Dir: repoPath})
+	if err != nil {
+		if IsErrorExitCode(err, 1) {
+			return RefFormatFiles, nil
+		}
+		return "", err
+	}
+	return RefFormat(strings.TrimSpace(stdout)), nil
+}
+
+// MigrateRefFormat moves the references of the repository to another storage backend in place
+func MigrateRefFormat(ctx context.Context, repoPath string, format RefFormat) error {
+	if !SupportReftable {
+		return errors.New("migrating the references requires Git >= 2.46")
+	}
+	if !format.IsValid() {
+		return fmt.Errorf("invalid ref format: %s", format)
+	}
+	var stderr strings.Builder
+	if err := NewCommand(ctx, "refs", "migrate").AddOptionFormat("--ref-format=%s", string(format)).Run(&RunOpts{
+		Dir:    repoPath,
+		Stderr: &stderr,
+	}); err != nil {
+		return ConcatenateError(err, stderr.String())
+	}
+	return nil
+}
+
+// ConvertObjectFormat rewrites all the references of the repository at repoPath into a new bare
+// repository at targetPath using objectFormat, and returns the new IDs of the commits by their old IDs.
+// The new repository keeps the reference storage of the former one.
+// The signatures of the tags are stripped because they cannot be valid anymore.
+func ConvertObjectFormat(ctx context.Context, repoPath, targetPath string, objectFormat ObjectFormat) (map[string]string, error) {
+	refFormat, err := GetRefFormat(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("GetRefFormat: %w", err)
+	}
+	if err := initRepository(ctx, targetPath, true, objectFormat.Name(), refFormat); err != nil {
+		return nil, fmt.Errorf("InitRepository: %w", err)
+	}
+
+	marksDir, err := os.MkdirTemp("", "forgejo-convert")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(marksDir)
+	oldMarks := filepath.Join(marksDir, "old.marks")
+	newMarks := filepath.Join(marksDir, "new.marks")
+
+	rd, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var stderr strings.Builder
+		err := NewCommand(ctx, "fast-export", "--all", "--signed-tags=strip", "--reencode=no").
+			AddOptionFormat("--export-marks=%s", oldMarks).
+			Run(&RunOpts{Dir: repoPath, Stdout: w, Stderr: &stderr})
+		if err != nil {
+			err = ConcatenateError(err, stderr.String())
+		}
+		_ = w.CloseWithError(err)
+		done <- err
+	}()
+	var stderr strings.Builder
+	importErr := NewCommand(ctx, "fast-import", "--quiet").
+		AddOptionFormat("--export-marks=%s", newMarks).
+		Run(&RunOpts{Dir: targetPath, Stdin: rd, Stderr: &stderr})
+	_ = rd.CloseWithError(importErr)
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("fast-export: %w", err)
+	}
+	if importErr != nil {
+		return nil, fmt.Errorf("fast-import: %w", ConcatenateError(importErr, stderr.String()))
+	}
+
+	if branch, err := GetDefaultBranch(ctx, repoPath); err == nil {
+		if err := NewCommand(ctx, "symbolic-ref", "HEAD").AddDynamicArguments(BranchPrefix + branch).Run(&RunOpts{Dir: targetPath}); err != nil {
+			return nil, fmt.Errorf("symbolic-ref: %w", err)
+		}
+	}
+
+	oldIDs, err := readMarks(oldMarks)
+	if err != nil {
+		return nil, err
+	}
+	newIDs, err := readMarks(newMarks)
+	if err != nil {
+		return nil, err
+	}
+	// fast-import keeps the marks of fast-export, only the commits are marked in both files
+	mapping := make(map[string]string, len(oldIDs))
+	for mark, oldID := range oldIDs {
+		if newID, ok := newIDs[mark]; ok {
+			mapping[oldID] = newID
+		}
+	}
+	return mapping, nil
+}
+
+// readMarks reads the object IDs of a marks file of fast-export or fast-import by their mark
+func readMarks(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing is exported from an empty repository
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	marks := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		mark, id, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || !strings.HasPrefix(mark, ":") {
+			return nil, fmt.Errorf("invalid line in marks file %s: %q", path, scanner.Text())
+		}
+		marks[mark] = id
+	}
+	return marks, scanner.Err()
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertObjectFormat(t *testing.T) {
+	if !SupportHashSha256 {
+		t.Skip("skipping because installed Git version doesn't support SHA256")
+	}
+	targetPath := filepath.Join(t.TempDir(), "repo.git")
+	mapping, err := ConvertObjectFormat(t.Context(), filepath.Join(testReposDir, "repo1_bare"), targetPath, Sha256ObjectFormat)
+	require.NoError(t, err)
+
+	newID, ok := mapping["ce064814f4a0d337b333e646ece456cd39fab612"]
+	require.True(t, ok)
+	assert.Len(t, newID, Sha256ObjectFormat.FullLength())
+
+	repo, err := openRepositoryWithDefaultContext(targetPath)
+	require.NoError(t, err)
+	defer repo.Close()
+	objectFormat, err := repo.GetObjectFormat()
+	require.NoError(t, err)
+	assert.Equal(t, Sha256ObjectFormat, objectFormat)
+	commitID, err := repo.GetBranchCommitID("master")
+	require.NoError(t, err)
+	assert.Equal(t, newID, commitID)
+	branch, err := GetDefaultBranch(t.Context(), targetPath)
+	require.NoError(t, err)
+	assert.Equal(t, "master", branch)
+
+	// empty repositories are converted too
+	emptyPath := filepath.Join(t.TempDir(), "empty.git")
+	require.NoError(t, InitRepository(t.Context(), emptyPath, true, Sha1ObjectFormat.Name()))
+	mapping, err = ConvertObjectFormat(t.Context(), emptyPath, filepath.Join(t.TempDir(), "empty256.git"), Sha256ObjectFormat)
+	require.NoError(t, err)
+	assert.Empty(t, mapping)
+}
+
+func TestConvertObjectFormatKeepsRefFormat(t *testing.T) {
+	if !SupportHashSha256 || !SupportReftable {
+		t.Skip("skipping because installed Git version doesn't support SHA256 or reftable")
+	}
+	defer test.MockVariableValue(&setting.Repository.DefaultRefFormat, string(RefFormatFiles))()
+
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, InitRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name()))
+	require.NoError(t, MigrateRefFormat(t.Context(), repoPath, RefFormatReftable))
+	targetPath := filepath.Join(t.TempDir(), "repo256.git")
+	_, err := ConvertObjectFormat(t.Context(), repoPath, targetPath, Sha256ObjectFormat)
+	require.NoError(t, err)
+	format, err := GetRefFormat(t.Context(), targetPath)
+	require.NoError(t, err)
+	assert.Equal(t, RefFormatReftable, format)
+
+	// the default ref format of the instance doesn't apply either
+	defer test.MockVariableValue(&setting.Repository.DefaultRefFormat, string(RefFormatReftable))()
+	repoPath = filepath.Join(t.TempDir(), "files.git")
+	require.NoError(t, initRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name(), RefFormatFiles))
+	targetPath = filepath.Join(t.TempDir(), "files256.git")
+	_, err = ConvertObjectFormat(t.Context(), repoPath, targetPath, Sha256ObjectFormat)
+	require.NoError(t, err)
+	format, err = GetRefFormat(t.Context(), targetPath)
+	require.NoError(t, err)
+	assert.Equal(t, RefFormatFiles, format)
+}
+
+func TestReadMarks(t *testing.T) {
+	marks, err := readMarks(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, marks)
+}
+
+func TestMigrateRefFormat(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, InitRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name()))
+	format, err := GetRefFormat(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, RefFormatFiles, format)
+
+	if !SupportReftable {
+		require.Error(t, MigrateRefFormat(t.Context(), repoPath, RefFormatReftable))
+		return
+	}
+	require.NoError(t, MigrateRefFormat(t.Context(), repoPath, RefFormatReftable))
+	format, err = GetRefFormat(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, RefFormatReftable, format)
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceOptions are the tasks run by RunMaintenance, they speed up the clones and fetches of large repositories
+type MaintenanceOptions struct {
+	// LooseObjects packs the loose objects and deletes those already packed
+	LooseObjects bool
+	// IncrementalRepack repacks the small packs together without rewriting the whole repository
+	IncrementalRepack bool
+	// MultiPackIndex writes a multi-pack-index with a reachability bitmap over all the packs
+	MultiPackIndex bool
+	// CommitGraph writes an incremental commit-graph with changed-path Bloom filters
+	CommitGraph bool
+
+	Timeout time.Duration
+}
+
+// RunMaintenance runs the selected maintenance tasks on a bare repository
+func RunMaintenance(ctx context.Context, repoPath string, opts MaintenanceOptions) error {
+	if !SupportGitMaintenance {
+		return errors.New("repository maintenance requires Git >= 2.34")
+	}
+	runOpts := &RunOpts{Timeout: opts.Timeout, Dir: repoPath}
+
+	if opts.LooseObjects {
+		if err := NewCommand(ctx, "maintenance", "run", "--task=loose-objects").Run(runOpts); err != nil {
+			return fmt.Errorf("loose-objects: %w", err)
+		}
+	}
+
+	if opts.IncrementalRepack || opts.MultiPackIndex {
+		// both fail on a repository without packs
+		health, err := GetPackHealth(ctx, repoPath)
+		if err != nil {
+			return err
+		}
+		if health.Packs > 0 {
+			if opts.IncrementalRepack {
+				if err := NewCommand(ctx, "maintenance", "run", "--task=incremental-repack").Run(runOpts); err != nil {
+					return fmt.Errorf("incremental-repack: %w", err)
+				}
+			}
+			if opts.MultiPackIndex {
+				if err := NewCommand(ctx, "multi-pack-index", "write", "--bitmap").Run(runOpts); err != nil {
+					return fmt.Errorf("multi-pack-index: %w", err)
+				}
+			}
+		}
+	}
+
+	if opts.CommitGraph {
+		if err := NewCommand(ctx, "commit-graph", "write", "--reachable", "--changed-paths", "--split").Run(runOpts); err != nil {
+			return fmt.Errorf("commit-graph: %w", err)
+		}
+	}
+	return nil
+}
+
+// PackHealth describes how the objects of a repository are stored
+type PackHealth struct {
+	LooseObjects   int64
+	LooseSize      int64
+	PackedObjects  int64
+	Packs          int64
+	PackSize       int64
+	PrunePackable  int64
+	GarbageFiles   int64
+	GarbageSize    int64
+	CommitGraph    bool
+	BloomFilters   bool
+	MultiPackIndex bool
+	Bitmap         bool
+}
+
+// GetPackHealth returns the object statistics of a bare repository and which acceleration structures it has
+func GetPackHealth(ctx context.Context, repoPath string) (*PackHealth, error) {
+	stdout, _, runErr := NewCommand(ctx, "count-objects", "-v").RunStdBytes(&RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return nil, fmt.Errorf("count-objects: %w", runErr)
+	}
+	health, err := parseCountObjects(bytes.NewReader(stdout))
+	if err != nil {
+		return nil, err
+	}
+
+	objectsPath := filepath.Join(repoPath, "objects")
+	if health.CommitGraph, health.BloomFilters, err = readCommitGraphInfo(objectsPath); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(objectsPath, "pack"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		health.MultiPackIndex = health.MultiPackIndex || name == "multi-pack-index"
+		health.Bitmap = health.Bitmap || strings.HasSuffix(name, ".bitmap")
+	}
+	return health, nil
+}
+
+// parseCountObjects parses the output of git count-objects -v, whose sizes are in KiB
+func parseCountObjects(r io.Reader) (*PackHealth, error) {
+	health := &PackHealth{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected count-objects value %q for %s", value, key)
+		}
+		switch key {
+		case "count":
+			health.LooseObjects = n
+		case "size":
+			health.LooseSize = n * 1024
+		case "in-pack":
+			health.PackedObjects = n
+		case "packs":
+			health.Packs = n
+		case "size-pack":
+			health.PackSize = n * 1024
+		case "prune-packable":
+			health.PrunePackable = n
+		case "garbage":
+			health.GarbageFiles = n
+		case "size-garbage":
+			health.GarbageSize = n * 1024
+		}
+	}
+	return health, scanner.Err()
+}
+
+// readCommitGraphInfo tells whether the repository has a commit-graph, single or split,
+// and whether its most recent layer has changed-path Bloom filters
+func readCommitGraphInfo(objectsPath string) (hasGraph, hasBloomFilters bool, err error) {
+	graphPath := filepath.Join(objectsPath, "info", "commit-graph")
+	chain, err := os.ReadFile(filepath.Join(objectsPath, "info", "commit-graphs", "commit-graph-chain"))
+	if err == nil {
+		lines := strings.Fields(string(chain))
+		if len(lines) == 0 {
+			return false, false, nil
+		}
+		graphPath = filepath.Join(objectsPath, "info", "commit-graphs", "graph-"+lines[len(lines)-1]+".graph")
+	} else if !os.IsNotExist(err) {
+		return false, false, err
+	}
+
+	f, err := os.Open(graphPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	defer f.Close()
+	hasBloomFilters, err = hasCommitGraphChunk(f, "BIDX")
+	return true, hasBloomFilters, err
+}
+
+// hasCommitGraphChunk reads the chunk table of a commit-graph file to find the chunk of the given ID
+func hasCommitGraphChunk(r io.Reader, chunkID string) (bool, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return false, fmt.Errorf("commit-graph header: %w", err)
+	}
+	if string(header[:4]) != "CGPH" {
+		return false, errors.New("commit-graph header: bad signature")
+	}
+	// the table has an entry of a 4-byte ID and an 8-byte offset per chunk, followed by a terminating entry
+	entry := make([]byte, 12)
+	for range int(header[6]) {
+		if _, err := io.ReadFull(r, entry); err != nil {
+			return false, fmt.Errorf("commit-graph chunk table: %w", err)
+		}
+		if string(entry[:4]) == chunkID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
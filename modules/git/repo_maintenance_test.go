@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCountObjects(t *testing.T) {
+	health, err := parseCountObjects(strings.NewReader("count: 9\nsize: 36\nin-pack: 12\npacks: 2\nsize-pack: 3\nprune-packable: 1\ngarbage: 0\nsize-garbage: 0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &PackHealth{
+		LooseObjects:  9,
+		LooseSize:     36 * 1024,
+		PackedObjects: 12,
+		Packs:         2,
+		PackSize:      3 * 1024,
+		PrunePackable: 1,
+	}, health)
+
+	_, err = parseCountObjects(strings.NewReader("count: many\n"))
+	require.Error(t, err)
+}
+
+func TestRunMaintenance(t *testing.T) {
+	if !SupportGitMaintenance {
+		t.Skip("git maintenance is not supported")
+	}
+	repoPath := filepath.Join(t.TempDir(), "repo1.git")
+	require.NoError(t, Clone(t.Context(), filepath.Join(testReposDir, "repo1_bare"), repoPath, CloneRepoOptions{
+		Bare:    true,
+		Quiet:   true,
+		Timeout: time.Minute,
+	}))
+
+	health, err := GetPackHealth(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.False(t, health.CommitGraph)
+	assert.False(t, health.MultiPackIndex)
+
+	require.NoError(t, RunMaintenance(t.Context(), repoPath, MaintenanceOptions{
+		LooseObjects:      true,
+		IncrementalRepack: true,
+		MultiPackIndex:    true,
+		CommitGraph:       true,
+	}))
+
+	health, err = GetPackHealth(t.Context(), repoPath)
+	require.NoError(t, err)
+	assert.Zero(t, health.LooseObjects)
+	assert.Positive(t, health.Packs)
+	assert.True(t, health.CommitGraph)
+	assert.True(t, health.BloomFilters)
+	assert.True(t, health.MultiPackIndex)
+	assert.True(t, health.Bitmap)
+}
+
+func TestPartialClonePolicy(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, InitRepository(t.Context(), repoPath, true, Sha1ObjectFormat.Name()))
+
+	for _, policy := range []PartialClonePolicy{PartialCloneBlobNone, PartialCloneDisabled, PartialCloneDefault} {
+		require.NoError(t, SetPartialClonePolicy(t.Context(), repoPath, policy))
+		got, err := GetPartialClonePolicy(t.Context(), repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, policy, got)
+	}
+
+	require.Error(t, SetPartialClonePolicy(t.Context(), repoPath, "tree:0"))
+}
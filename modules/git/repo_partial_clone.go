@@ -0,0 +1,93 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PartialClonePolicy restricts the object filters a repository accepts from partial clones and fetches,
+// it is stored in the configuration of the repository which git upload-pack enforces
+type PartialClonePolicy string
+
+const (
+	PartialCloneDefault  PartialClonePolicy = ""          // the instance setting applies
+	PartialCloneBlobNone PartialClonePolicy = "blob:none" // only --filter=blob:none is accepted
+	PartialCloneDisabled PartialClonePolicy = "disabled"  // no filter is accepted
+)
+
+// IsValid returns whether the policy is known
+func (p PartialClonePolicy) IsValid() bool {
+	switch p {
+	case PartialCloneDefault, PartialCloneBlobNone, PartialCloneDisabled:
+		return true
+	}
+	return false
+}
+
+// getLocalConfig returns the value of a key of the repository configuration, empty if unset.
+// If valueType is not empty, the value is canonicalized as this type, e.g. "bool".
+func getLocalConfig(ctx context.Context, repoPath, key, valueType string) (string, error) {
+	cmd := NewCommand(ctx, "config", "--local")
+	if valueType != "" {
+		cmd.AddOptionFormat("--type=%s", valueType)
+	}
+	stdout, _, err := cmd.AddArguments("--get").AddDynamicArguments(key).RunStdString(&RunOpts{Dir: repoPath})
+	if err != nil {
+		if IsErrorExitCode(err, 1) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get %s: %w", key, err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// GetPartialClonePolicy returns the partial clone policy of a repository
+func GetPartialClonePolicy(ctx context.Context, repoPath string) (PartialClonePolicy, error) {
+	allowFilter, err := getLocalConfig(ctx, repoPath, "uploadpack.allowFilter", "bool")
+	if err != nil {
+		return "", err
+	}
+	if allowFilter == "false" {
+		return PartialCloneDisabled, nil
+	}
+	allowAny, err := getLocalConfig(ctx, repoPath, "uploadpackfilter.allow", "bool")
+	if err != nil {
+		return "", err
+	}
+	if allowAny == "false" {
+		return PartialCloneBlobNone, nil
+	}
+	return PartialCloneDefault, nil
+}
+
+// SetPartialClonePolicy writes the partial clone policy to the configuration of a repository
+func SetPartialClonePolicy(ctx context.Context, repoPath string, policy PartialClonePolicy) error {
+	if !policy.IsValid() {
+		return fmt.Errorf("unknown partial clone policy %q", policy)
+	}
+	for _, key := range []string{"uploadpack.allowFilter", "uploadpackfilter.allow", "uploadpackfilter.blob:none.allow"} {
+		// exit code 5 means the key was not set
+		if err := NewCommand(ctx, "config", "--local", "--unset-all").AddDynamicArguments(key).Run(&RunOpts{Dir: repoPath}); err != nil && !IsErrorExitCode(err, 5) {
+			return fmt.Errorf("unset %s: %w", key, err)
+		}
+	}
+
+	var values [][2]string
+	switch policy {
+	case PartialCloneBlobNone:
+		// the instance setting still decides whether filters are allowed at all
+		values = [][2]string{{"uploadpackfilter.allow", "false"}, {"uploadpackfilter.blob:none.allow", "true"}}
+	case PartialCloneDisabled:
+		values = [][2]string{{"uploadpack.allowFilter", "false"}}
+	}
+	for _, kv := range values {
+		if err := NewCommand(ctx, "config", "--local").AddDynamicArguments(kv[0], kv[1]).Run(&RunOpts{Dir: repoPath}); err != nil {
+			return fmt.Errorf("set %s: %w", kv[0], err)
+		}
+	}
+	return nil
+}
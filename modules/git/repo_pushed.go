@@ -0,0 +1,324 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"forgejo.org/modules/container"
+)
+
+// PushedCommit is a commit of a push which is not reachable from the references of the repository yet
+type PushedCommit struct {
+	ID          string
+	ParentIDs   []string
+	AuthorEmail string
+	Message     string
+}
+
+// MaxPushedCommits is the number of commits of a push which are checked, the most recent ones,
+// so that pushing a whole history doesn't keep the push waiting
+const MaxPushedCommits = 1000
+
+// GetPushedCommits returns the most recent MaxPushedCommits commits reachable from newCommitID and not from any
+// reference of the repository, env gives access to the quarantined objects of a push being received
+func GetPushedCommits(ctx context.Context, repoPath string, env []string, newCommitID string) ([]*PushedCommit, error) {
+	stdout, _, runErr := NewCommand(ctx, "log", "-z", "--format=%H%x00%P%x00%ae%x00%B").
+		AddOptionFormat("--max-count=%d", MaxPushedCommits).
+		AddDynamicArguments(newCommitID).AddArguments("--not", "--all").
+		RunStdString(&RunOpts{Dir: repoPath, Env: env})
+	if runErr != nil {
+		return nil, runErr
+	}
+	return parsePushedCommits(stdout)
+}
+
+// GetMergedCommits returns the most recent MaxPushedCommits commits reachable from newCommitID and not from any
+// branch or tag of the repository. Unlike GetPushedCommits, it includes the commits of the pull requests from forks
+// merged by a push, which are only reachable from the pull request references of the repository.
+func GetMergedCommits(ctx context.Context, repoPath string, env []string, newCommitID string) ([]*PushedCommit, error) {
+	stdout, _, runErr := NewCommand(ctx, "log", "-z", "--format=%H%x00%P%x00%ae%x00%B").
+		AddOptionFormat("--max-count=%d", MaxPushedCommits).
+		AddDynamicArguments(newCommitID).AddArguments("--not", "--branches", "--tags").
+		RunStdString(&RunOpts{Dir: repoPath, Env: env})
+	if runErr != nil {
+		return nil, runErr
+	}
+	return parsePushedCommits(stdout)
+}
+
+// WalkAllCommits calls fn with the commits reachable from any reference of the repository, in batches of at most
+// batchSize commits which are read from git as they are needed. The batches go from the oldest commits to the most
+// recent ones, and each batch is ordered like GetPushedCommits returns the commits, the most recent first.
+func WalkAllCommits(ctx context.Context, repoPath string, batchSize int, fn func(commits []*PushedCommit) error) error {
+	rd, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var stderr strings.Builder
+		err := NewCommand(ctx, "log", "-z", "--format=%H%x00%P%x00%ae%x00%B", "--reverse", "--all").Run(&RunOpts{
+			Dir:    repoPath,
+			Stdout: w,
+			Stderr: &stderr,
+		})
+		if err != nil {
+			err = ConcatenateError(err, stderr.String())
+		}
+		_ = w.CloseWithError(err)
+		done <- err
+	}()
+
+	err := readCommitBatches(bufio.NewReader(rd), batchSize, fn)
+	_ = rd.CloseWithError(err)
+	if runErr := <-done; err == nil && runErr != nil {
+		return runErr
+	}
+	return err
+}
+
+// readCommitBatches reads the output of git log -z --reverse in batches of at most batchSize commits,
+// each reversed to start with the most recent commit
+func readCommitBatches(rd *bufio.Reader, batchSize int, fn func(commits []*PushedCommit) error) error {
+	batch := make([]*PushedCommit, 0, batchSize)
+	fields := make([]string, 0, 4)
+	for {
+		field, err := rd.ReadString(0)
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return err
+		}
+		// the commits are separated by a NUL, the message of the last one may be empty
+		if !eof || field != "" || len(fields) > 0 {
+			fields = append(fields, strings.TrimSuffix(field, "\x00"))
+		}
+		if len(fields) == 4 {
+			batch = append(batch, newPushedCommit(fields))
+			fields = fields[:0]
+		}
+		if len(batch) == batchSize || (eof && len(batch) > 0) {
+			slices.Reverse(batch)
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]*PushedCommit, 0, batchSize)
+		}
+		if eof {
+			if len(fields) > 0 {
+				return fmt.Errorf("unexpected output of git log: %d trailing fields", len(fields))
+			}
+			return nil
+		}
+	}
+}
+
+func parsePushedCommits(stdout string) ([]*PushedCommit, error) {
+	if stdout == "" {
+		return nil, nil
+	}
+	fields := strings.Split(strings.TrimSuffix(stdout, "\x00"), "\x00")
+	if len(fields)%4 != 0 {
+		return nil, fmt.Errorf("unexpected output of git log: %d fields", len(fields))
+	}
+	commits := make([]*PushedCommit, 0, len(fields)/4)
+	for i := 0; i < len(fields); i += 4 {
+		commits = append(commits, newPushedCommit(fields[i:i+4]))
+	}
+	return commits, nil
+}
+
+// newPushedCommit returns the commit of the fields %H, %P, %ae and %B of git log
+func newPushedCommit(fields []string) *PushedCommit {
+	return &PushedCommit{
+		ID:          fields[0],
+		ParentIDs:   strings.Fields(fields[1]),
+		AuthorEmail: fields[2],
+		Message:     fields[3],
+	}
+}
+
+// MaxScannedBlobSize is the size of the largest blobs read by ReadPushedBlobs, larger ones are usually not text
+const MaxScannedBlobSize = 1 << 20
+
+// PushedBlob is a file added or modified by a commit of a push
+type PushedBlob struct {
+	CommitID string
+	Path     string
+	BlobID   string
+	Size     int64
+}
+
+// GetPushedBlobs returns the files added or modified by commits compared to their first parent, the commits are
+// the most recent first like GetPushedCommits returns them and the files are in the order they were committed
+func GetPushedBlobs(ctx context.Context, repoPath string, env []string, commits []*PushedCommit) ([]*PushedBlob, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	// a single diff-tree compares each commit given on a line of its input with the parent following it
+	var input strings.Builder
+	for i := len(commits) - 1; i >= 0; i-- {
+		input.WriteString(commits[i].ID)
+		if len(commits[i].ParentIDs) > 0 {
+			input.WriteString(" " + commits[i].ParentIDs[0])
+		}
+		input.WriteByte('\n')
+	}
+	stdout, _, runErr := NewCommand(ctx, "diff-tree", "--stdin", "-r", "-z", "--raw", "--root", "--no-renames", "--diff-filter=AMT").
+		RunStdString(&RunOpts{Dir: repoPath, Env: env, Stdin: strings.NewReader(input.String())})
+	if runErr != nil {
+		return nil, runErr
+	}
+	blobs, err := parsePushedBlobs(stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	blobIDs := make(container.Set[string], len(blobs))
+	for _, b := range blobs {
+		blobIDs.Add(b.BlobID)
+	}
+	sizes, err := GetBlobSizes(ctx, repoPath, env, blobIDs.Values())
+	if err != nil {
+		return nil, fmt.Errorf("GetBlobSizes: %w", err)
+	}
+	for _, b := range blobs {
+		b.Size = sizes[b.BlobID]
+	}
+	return blobs, nil
+}
+
+// parsePushedBlobs parses the raw output of diff-tree --stdin -z, "<commit id>\0" followed by
+// ":<old mode> <new mode> <old id> <new id> <status>\0<path>\0" for each file of the commit
+func parsePushedBlobs(stdout string) ([]*PushedBlob, error) {
+	if stdout == "" {
+		return nil, nil
+	}
+	fields := strings.Split(strings.TrimSuffix(stdout, "\x00"), "\x00")
+	var blobs []*PushedBlob
+	var commitID string
+	for i := 0; i < len(fields); i++ {
+		if !strings.HasPrefix(fields[i], ":") {
+			commitID = fields[i]
+			continue
+		}
+		meta := strings.Fields(strings.TrimPrefix(fields[i], ":"))
+		if len(meta) != 5 || commitID == "" || i+1 == len(fields) {
+			return nil, fmt.Errorf("unexpected output of git diff-tree: %q", fields[i])
+		}
+		i++
+		// submodules are not files of the repository
+		if meta[1] == "160000" {
+			continue
+		}
+		blobs = append(blobs, &PushedBlob{CommitID: commitID, Path: fields[i], BlobID: meta[3]})
+	}
+	return blobs, nil
+}
+
+// ReadPushedBlobs calls fn with the content of each blob no larger than MaxScannedBlobSize, once at the first
+// file it is the content of
+func ReadPushedBlobs(ctx context.Context, repoPath string, env []string, blobs []*PushedBlob, fn func(blob *PushedBlob, content []byte) error) error {
+	firsts := make(map[string]*PushedBlob, len(blobs))
+	var blobIDs []string
+	for _, b := range blobs {
+		if _, ok := firsts[b.BlobID]; ok || b.Size > MaxScannedBlobSize {
+			continue
+		}
+		firsts[b.BlobID] = b
+		blobIDs = append(blobIDs, b.BlobID)
+	}
+	return ReadBlobs(ctx, repoPath, env, blobIDs, func(blobID string, content []byte) error {
+		return fn(firsts[blobID], content)
+	})
+}
+
+// GetBlobSizes returns the sizes of blobs by their ID
+func GetBlobSizes(ctx context.Context, repoPath string, env, blobIDs []string) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(blobIDs))
+	if len(blobIDs) == 0 {
+		return sizes, nil
+	}
+	stdout, _, runErr := NewCommand(ctx, "cat-file", "--batch-check=%(objectname) %(objectsize)").RunStdString(&RunOpts{
+		Dir:   repoPath,
+		Env:   env,
+		Stdin: strings.NewReader(strings.Join(blobIDs, "\n") + "\n"),
+	})
+	if runErr != nil {
+		return nil, runErr
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		id, size, ok := strings.Cut(line, " ")
+		if !ok {
+			// "<id> missing"
+			continue
+		}
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			continue
+		}
+		sizes[id] = n
+	}
+	return sizes, nil
+}
+
+// ReadBlobs calls fn with the content of each blob, in the order of their IDs
+func ReadBlobs(ctx context.Context, repoPath string, env, blobIDs []string, fn func(blobID string, content []byte) error) error {
+	if len(blobIDs) == 0 {
+		return nil
+	}
+	rd, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var stderr strings.Builder
+		err := NewCommand(ctx, "cat-file", "--batch").Run(&RunOpts{
+			Dir:    repoPath,
+			Env:    env,
+			Stdin:  strings.NewReader(strings.Join(blobIDs, "\n") + "\n"),
+			Stdout: w,
+			Stderr: &stderr,
+		})
+		if err != nil {
+			err = ConcatenateError(err, stderr.String())
+		}
+		_ = w.CloseWithError(err)
+		done <- err
+	}()
+
+	err := readBatchBlobs(bufio.NewReader(rd), len(blobIDs), fn)
+	_ = rd.CloseWithError(err)
+	if runErr := <-done; err == nil && runErr != nil {
+		return runErr
+	}
+	return err
+}
+
+func readBatchBlobs(rd *bufio.Reader, count int, fn func(blobID string, content []byte) error) error {
+	var buf bytes.Buffer
+	for range count {
+		id, typ, size, err := ReadBatchLine(rd)
+		if err != nil {
+			if IsErrNotExist(err) {
+				continue
+			}
+			return err
+		}
+		buf.Reset()
+		// the content is followed by a new line
+		if _, err := io.CopyN(&buf, rd, size+1); err != nil {
+			return err
+		}
+		if typ != "blob" {
+			continue
+		}
+		if err := fn(string(id), buf.Bytes()[:size]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePushedCommits(t *testing.T) {
+	commits, err := parsePushedCommits("")
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+
+	commits, err = parsePushedCommits("aaa\x00bbb ccc\x00a@example.com\x00Merge\n\nSigned-off-by: A <a@example.com>\n\x00ddd\x00\x00b@example.com\x00Initial\n")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, &PushedCommit{ID: "aaa", ParentIDs: []string{"bbb", "ccc"}, AuthorEmail: "a@example.com", Message: "Merge\n\nSigned-off-by: A <a@example.com>\n"}, commits[0])
+	assert.Equal(t, &PushedCommit{ID: "ddd", ParentIDs: []string{}, AuthorEmail: "b@example.com", Message: "Initial\n"}, commits[1])
+
+	_, err = parsePushedCommits("aaa\x00bbb")
+	require.Error(t, err)
+}
+
+func TestParsePushedBlobs(t *testing.T) {
+	blobs, err := parsePushedBlobs("")
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+
+	blobs, err = parsePushedBlobs("aaa\x00:000000 100644 0000 1111 A\x00a.txt\x00:000000 160000 0000 2222 A\x00sub\x00bbb\x00:100644 100644 1111 3333 M\x00:odd name\x00")
+	require.NoError(t, err)
+	assert.Equal(t, []*PushedBlob{
+		{CommitID: "aaa", Path: "a.txt", BlobID: "1111"},
+		{CommitID: "bbb", Path: ":odd name", BlobID: "3333"},
+	}, blobs)
+
+	_, err = parsePushedBlobs(":000000 100644 0000 1111 A\x00a.txt\x00")
+	require.Error(t, err)
+	_, err = parsePushedBlobs("aaa\x00:000000 100644 0000 1111 A\x00")
+	require.Error(t, err)
+}
+
+func TestReadBlobs(t *testing.T) {
+	repoPath := filepath.Join(testReposDir, "repo1_bare")
+	sizes, err := GetBlobSizes(t.Context(), repoPath, nil, []string{"6c493ff740f9380390d5c9ddef4af18697ac9375", "0000000000000000000000000000000000000001"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"6c493ff740f9380390d5c9ddef4af18697ac9375": 6}, sizes)
+
+	contents := map[string]string{}
+	require.NoError(t, ReadBlobs(t.Context(), repoPath, nil, []string{"6c493ff740f9380390d5c9ddef4af18697ac9375", "0000000000000000000000000000000000000001", "e2129701f1a4d54dc44f03c93bca0a2aec7c5449"}, func(blobID string, content []byte) error {
+		contents[blobID] = string(content)
+		return nil
+	}))
+	assert.Equal(t, map[string]string{
+		"6c493ff740f9380390d5c9ddef4af18697ac9375": "file2\n",
+		"e2129701f1a4d54dc44f03c93bca0a2aec7c5449": "file1\n",
+	}, contents)
+}
+
+func TestGetPushedBlobs(t *testing.T) {
+	repoPath := filepath.Join(testReposDir, "repo1_bare")
+	commits := []*PushedCommit{
+		{ID: "8006ff9adbf0cb94da7dad9e537e53817f9fa5c0", ParentIDs: []string{"8d92fc957a4d7cfd98bc375f0b7bb189a0d6c9f2"}},
+		{ID: "8d92fc957a4d7cfd98bc375f0b7bb189a0d6c9f2", ParentIDs: []string{"95bb4d39648ee7e325106df01a621c530863a653"}},
+		{ID: "95bb4d39648ee7e325106df01a621c530863a653"},
+	}
+	blobs, err := GetPushedBlobs(t.Context(), repoPath, nil, commits)
+	require.NoError(t, err)
+	require.Len(t, blobs, 4)
+	// the oldest commit first, the root commit adds all its files
+	assert.Equal(t, &PushedBlob{CommitID: "95bb4d39648ee7e325106df01a621c530863a653", Path: "file1.txt", BlobID: "e2129701f1a4d54dc44f03c93bca0a2aec7c5449", Size: 6}, blobs[0])
+	assert.Equal(t, &PushedBlob{CommitID: "8d92fc957a4d7cfd98bc375f0b7bb189a0d6c9f2", Path: "file2.txt", BlobID: "6c493ff740f9380390d5c9ddef4af18697ac9375", Size: 6}, blobs[1])
+	assert.Equal(t, "foo/bar/link_to_hello", blobs[2].Path)
+	assert.Equal(t, "216bf54c2f2e2916b830ebe09e8c58a6ed52d86b", blobs[2].BlobID)
+	last := blobs[3]
+	assert.Equal(t, &PushedBlob{CommitID: "8006ff9adbf0cb94da7dad9e537e53817f9fa5c0", Path: "foo/nar/hello", BlobID: "b14df6442ea5a1b382985a6549b85d435376c351", Size: 3}, last)
+
+	// the blobs are read once, the larger ones are skipped
+	large := &PushedBlob{CommitID: last.CommitID, Path: "large", BlobID: "e2129701f1a4d54dc44f03c93bca0a2aec7c5449", Size: MaxScannedBlobSize + 1}
+	duplicate := &PushedBlob{CommitID: last.CommitID, Path: "copy", BlobID: last.BlobID, Size: last.Size}
+	var read []string
+	require.NoError(t, ReadPushedBlobs(t.Context(), repoPath, nil, []*PushedBlob{last, large, duplicate}, func(blob *PushedBlob, content []byte) error {
+		read = append(read, blob.Path)
+		assert.Len(t, content, int(blob.Size))
+		return nil
+	}))
+	assert.Equal(t, []string{"foo/nar/hello"}, read)
+}
+
+func TestGetMergedCommits(t *testing.T) {
+	repoPath := filepath.Join(testReposDir, "repo1_bare")
+	// the commit of the notes is only reachable from refs/notes/commits
+	commits, err := GetPushedCommits(t.Context(), repoPath, nil, "ca6b5ddf303169a72d2a2971acde4f6eea194e5c")
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+
+	commits, err = GetMergedCommits(t.Context(), repoPath, nil, "ca6b5ddf303169a72d2a2971acde4f6eea194e5c")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "ca6b5ddf303169a72d2a2971acde4f6eea194e5c", commits[0].ID)
+
+	commits, err = GetMergedCommits(t.Context(), repoPath, nil, "2839944139e0de9737a044f78b0e4b40d989a9e3")
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+}
+
+func TestWalkAllCommits(t *testing.T) {
+	var batches [][]*PushedCommit
+	require.NoError(t, WalkAllCommits(t.Context(), filepath.Join(testReposDir, "repo1_bare"), 4, func(commits []*PushedCommit) error {
+		batches = append(batches, commits)
+		return nil
+	}))
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 4)
+	assert.Len(t, batches[1], 4)
+	assert.Len(t, batches[2], 3)
+	// the oldest batch first, the most recent commit first in a batch
+	first := batches[0][len(batches[0])-1]
+	assert.Equal(t, "95bb4d39648ee7e325106df01a621c530863a653", first.ID)
+	assert.Empty(t, first.ParentIDs)
+
+	// the walk stops at the first error
+	calls := 0
+	err := WalkAllCommits(t.Context(), filepath.Join(testReposDir, "repo1_bare"), 4, func(commits []*PushedCommit) error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 1, calls)
+}
+
+func TestReadCommitBatches(t *testing.T) {
+	var batches [][]*PushedCommit
+	collect := func(commits []*PushedCommit) error {
+		batches = append(batches, commits)
+		return nil
+	}
+	require.NoError(t, readCommitBatches(bufio.NewReader(strings.NewReader("")), 2, collect))
+	assert.Empty(t, batches)
+
+	// the message of the last commit is empty
+	require.NoError(t, readCommitBatches(bufio.NewReader(strings.NewReader("aaa\x00\x00a@example.com\x00Initial\n\x00bbb\x00aaa\x00b@example.com\x00Second\n\x00ccc\x00bbb\x00c@example.com\x00")), 2, collect))
+	require.Len(t, batches, 2)
+	assert.Equal(t, []string{"bbb", "aaa"}, []string{batches[0][0].ID, batches[0][1].ID})
+	assert.Equal(t, &PushedCommit{ID: "ccc", ParentIDs: []string{"bbb"}, AuthorEmail: "c@example.com"}, batches[1][0])
+
+	require.Error(t, readCommitBatches(bufio.NewReader(strings.NewReader("aaa\x00bbb")), 2, collect))
+}
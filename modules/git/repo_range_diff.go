@@ -0,0 +1,95 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RangeDiffStatus is how a commit of the old range matches a commit of the new range
+type RangeDiffStatus string
+
+const (
+	RangeDiffUnchanged RangeDiffStatus = "=" // the commits have the same patch
+	RangeDiffChanged   RangeDiffStatus = "!" // the commits match but their patches differ
+	RangeDiffDropped   RangeDiffStatus = "<" // the commit is only in the old range
+	RangeDiffAdded     RangeDiffStatus = ">" // the commit is only in the new range
+)
+
+// RangeDiffCommit is a pair of matching commits of two ranges, or a commit only in one of them
+type RangeDiffCommit struct {
+	Status RangeDiffStatus
+	// OldIndex and NewIndex are the positions of the commits in their range starting at 1, 0 if absent
+	OldIndex    int
+	NewIndex    int
+	OldCommitID string
+	NewCommitID string
+	Subject     string
+	// Interdiff is the diff between the patches of the changed commits, each line being prefixed
+	// by the marker of the interdiff followed by the marker of the patches
+	Interdiff []string
+}
+
+var rangeDiffHeaderRe = regexp.MustCompile(`^\s*(\d+|-):\s+([0-9a-f]+|-+) ([=!<>])\s+(\d+|-):\s+([0-9a-f]+|-+) (.*)$`)
+
+// GetRangeDiff compares the commits of oldBase..oldHead with the commits of newBase..newHead with git range-diff,
+// matching the commits of both ranges even if they were rebased
+func (repo *Repository) GetRangeDiff(oldBase, oldHead, newBase, newHead string) ([]*RangeDiffCommit, error) {
+	objectFormat, err := repo.GetObjectFormat()
+	if err != nil {
+		return nil, err
+	}
+	// show the full commit IDs
+	stdout, _, err := NewCommand(repo.Ctx).AddOptionValues("-c", "core.abbrev="+strconv.Itoa(objectFormat.FullLength())).
+		AddArguments("range-diff", "--no-color").
+		AddDynamicArguments(oldBase+".."+oldHead, newBase+".."+newHead).
+		RunStdBytes(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		return nil, fmt.Errorf("range-diff: %w", err)
+	}
+	return ParseRangeDiff(bytes.NewReader(stdout))
+}
+
+// ParseRangeDiff parses the output of git range-diff
+func ParseRangeDiff(r io.Reader) ([]*RangeDiffCommit, error) {
+	commits := make([]*RangeDiffCommit, 0, 10)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "    ") {
+			if len(commits) == 0 {
+				return nil, fmt.Errorf("unexpected range-diff line: %q", line)
+			}
+			last := commits[len(commits)-1]
+			last.Interdiff = append(last.Interdiff, line[4:])
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		m := rangeDiffHeaderRe.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("unexpected range-diff line: %q", line)
+		}
+		c := &RangeDiffCommit{Status: RangeDiffStatus(m[3]), Subject: m[6]}
+		if m[1] != "-" {
+			c.OldIndex, _ = strconv.Atoi(m[1])
+			c.OldCommitID = m[2]
+		}
+		if m[4] != "-" {
+			c.NewIndex, _ = strconv.Atoi(m[4])
+			c.NewCommitID = m[5]
+		}
+		commits = append(commits, c)
+	}
+	return commits, scanner.Err()
+}
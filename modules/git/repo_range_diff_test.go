@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleRangeDiff = `1:  83b70262fb9e1b250fb197f40ed7547b40a4ea80 = 1:  2684919f1a3db0139a4577ff3ccf09f00aa0fc5c add 4
+2:  4f5cfdbd3fa4780424abd79940c304d877addec4 ! 2:  f1af2bfc77cc282437071f16139d1a16526b1f76 change ten
+    @@ big
+      9
+     -10
+    -+ten
+    ++TEN
+      11
+3:  7d35a25e60ef2fb9b2cf84a96577d35049645952 < -:  ---------------------------------------- add h
+-:  ---------------------------------------- > 3:  ee661bfe9ffb3e11394c63554ab7b252aa1a9859 add h and k
+`
+
+func TestParseRangeDiff(t *testing.T) {
+	commits, err := ParseRangeDiff(strings.NewReader(exampleRangeDiff))
+	require.NoError(t, err)
+	assert.Equal(t, []*RangeDiffCommit{
+		{
+			Status:      RangeDiffUnchanged,
+			OldIndex:    1,
+			NewIndex:    1,
+			OldCommitID: "83b70262fb9e1b250fb197f40ed7547b40a4ea80",
+			NewCommitID: "2684919f1a3db0139a4577ff3ccf09f00aa0fc5c",
+			Subject:     "add 4",
+		},
+		{
+			Status:      RangeDiffChanged,
+			OldIndex:    2,
+			NewIndex:    2,
+			OldCommitID: "4f5cfdbd3fa4780424abd79940c304d877addec4",
+			NewCommitID: "f1af2bfc77cc282437071f16139d1a16526b1f76",
+			Subject:     "change ten",
+			Interdiff:   []string{"@@ big", "  9", " -10", "-+ten", "++TEN", "  11"},
+		},
+		{
+			Status:      RangeDiffDropped,
+			OldIndex:    3,
+			OldCommitID: "7d35a25e60ef2fb9b2cf84a96577d35049645952",
+			Subject:     "add h",
+		},
+		{
+			Status:      RangeDiffAdded,
+			NewIndex:    3,
+			NewCommitID: "ee661bfe9ffb3e11394c63554ab7b252aa1a9859",
+			Subject:     "add h and k",
+		},
+	}, commits)
+
+	_, err = ParseRangeDiff(strings.NewReader("not a range-diff\n"))
+	require.Error(t, err)
+}
@@ -23,7 +23,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 4
+	issueIndexerLatestVersion = 5
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -74,6 +74,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("comments", textFieldMapping)
 
 	docMapping.AddFieldMappingsAt("is_pull", boolFieldMapping)
+	docMapping.AddFieldMappingsAt("is_confidential", boolFieldMapping)
 	docMapping.AddFieldMappingsAt("is_closed", boolFieldMapping)
 	docMapping.AddFieldMappingsAt("label_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("no_label", boolFieldMapping)
@@ -190,6 +191,17 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
 	}
 
+	if options.Confidential != nil {
+		confidentialQueries := []query.Query{inner_bleve.BoolFieldQuery(false, "is_confidential")}
+		for _, repoID := range options.Confidential.RepoIDs {
+			confidentialQueries = append(confidentialQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		if options.Confidential.PosterID > 0 {
+			confidentialQueries = append(confidentialQueries, inner_bleve.NumericEqualityQuery(options.Confidential.PosterID, "poster_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(confidentialQueries...))
+	}
+
 	if options.IsPull.Has() {
 		queries = append(queries, inner_bleve.BoolFieldQuery(options.IsPull.Value(), "is_pull"))
 	}
@@ -206,13 +218,21 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 				includeQueries = append(includeQueries, inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
 			}
 			queries = append(queries, bleve.NewConjunctionQuery(includeQueries...))
-		} else if len(options.IncludedAnyLabelIDs) > 0 {
+		}
+		if len(options.IncludedAnyLabelIDs) > 0 {
 			var includeQueries []query.Query
 			for _, labelID := range options.IncludedAnyLabelIDs {
 				includeQueries = append(includeQueries, inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
 			}
 			queries = append(queries, bleve.NewDisjunctionQuery(includeQueries...))
 		}
+		for _, group := range options.IncludedAnyLabelIDGroups {
+			var includeQueries []query.Query
+			for _, labelID := range group {
+				includeQueries = append(includeQueries, inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
+			}
+			queries = append(queries, bleve.NewDisjunctionQuery(includeQueries...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			var excludeQueries []query.Query
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -93,7 +93,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 			opts.LabelIDs = append(opts.LabelIDs, -id)
 		}
 
-		if len(options.IncludedLabelIDs) == 0 && len(options.IncludedAnyLabelIDs) > 0 {
+		if len(options.IncludedAnyLabelIDs) > 0 {
 			labels, err := issue_model.GetLabelsByIDs(ctx, options.IncludedAnyLabelIDs, "name")
 			if err != nil {
 				return nil, fmt.Errorf("GetLabelsByIDs: %v", err)
@@ -106,6 +106,14 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 				}
 			}
 		}
+		opts.AnyLabelIDGroups = options.IncludedAnyLabelIDGroups
+	}
+
+	if options.Confidential != nil {
+		opts.Confidential = &issue_model.ConfidentialFilter{
+			PosterID: options.Confidential.PosterID,
+			RepoIDs:  options.Confidential.RepoIDs,
+		}
 	}
 
 	return opts, nil
@@ -75,6 +75,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		searchOpt.UpdatedBeforeUnix = optional.Some(opts.UpdatedBeforeUnix)
 	}
 
+	searchOpt.Confidential = ToConfidentialFilter(opts.Confidential)
+
 	searchOpt.Paginator = opts.Paginator
 
 	switch opts.SortType {
@@ -105,3 +107,14 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 
 	return searchOpt
 }
+
+// ToConfidentialFilter converts the filter of the confidential issues of a database search
+func ToConfidentialFilter(filter *issues_model.ConfidentialFilter) *ConfidentialFilter {
+	if filter == nil {
+		return nil
+	}
+	return &ConfidentialFilter{
+		PosterID: filter.PosterID,
+		RepoIDs:  filter.RepoIDs,
+	}
+}
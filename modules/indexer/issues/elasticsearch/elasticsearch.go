@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 1
+	issueIndexerLatestVersion = 2
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -61,6 +61,7 @@ const (
 			"comments": { "type" : "text", "index": true },
 
 			"is_pull": { "type": "boolean", "index": true },
+			"is_confidential": { "type": "boolean", "index": true },
 			"is_closed": { "type": "boolean", "index": true },
 			"label_ids": { "type": "long", "index": true },
 			"no_label": { "type": "boolean", "index": true },
@@ -184,6 +185,18 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(q)
 	}
 
+	if options.Confidential != nil {
+		q := elastic.NewBoolQuery()
+		q.Should(elastic.NewTermQuery("is_confidential", false))
+		if len(options.Confidential.RepoIDs) > 0 {
+			q.Should(elastic.NewTermsQuery("repo_id", toAnySlice(options.Confidential.RepoIDs)...))
+		}
+		if options.Confidential.PosterID > 0 {
+			q.Should(elastic.NewTermQuery("poster_id", options.Confidential.PosterID))
+		}
+		query.Must(q)
+	}
+
 	if options.IsPull.Has() {
 		query.Must(elastic.NewTermQuery("is_pull", options.IsPull.Value()))
 	}
@@ -200,9 +213,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 				q.Must(elastic.NewTermQuery("label_ids", labelID))
 			}
 			query.Must(q)
-		} else if len(options.IncludedAnyLabelIDs) > 0 {
+		}
+		if len(options.IncludedAnyLabelIDs) > 0 {
 			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(options.IncludedAnyLabelIDs)...))
 		}
+		for _, group := range options.IncludedAnyLabelIDGroups {
+			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(group)...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := elastic.NewBoolQuery()
 			for _, labelID := range options.ExcludedLabelIDs {
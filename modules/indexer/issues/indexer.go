@@ -269,6 +269,9 @@ func IsAvailable(ctx context.Context) bool {
 // SearchOptions indicates the options for searching issues
 type SearchOptions = internal.SearchOptions
 
+// ConfidentialFilter limits a search to the confidential issues a user can read
+type ConfidentialFilter = internal.ConfidentialFilter
+
 const (
 	SortByScore        = internal.SortByScore
 	SortByCreatedDesc  = internal.SortByCreatedDesc
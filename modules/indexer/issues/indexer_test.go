@@ -12,6 +12,7 @@ import (
 	"forgejo.org/modules/indexer/issues/internal"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 
 	_ "forgejo.org/models"
 	_ "forgejo.org/models/actions"
@@ -43,6 +44,7 @@ func TestDBSearchIssues(t *testing.T) {
 	t.Run("search issues with order", searchIssueWithOrder)
 	t.Run("search issues in project", searchIssueInProject)
 	t.Run("search issues with paginator", searchIssueWithPaginator)
+	t.Run("search issues with query", searchIssueWithQuery)
 }
 
 func searchIssueWithKeyword(t *testing.T) {
@@ -408,3 +410,60 @@ func searchIssueWithPaginator(t *testing.T) {
 		assert.Equal(t, test.expectedTotal, total)
 	}
 }
+
+func searchIssueWithQuery(t *testing.T) {
+	tests := []struct {
+		opts        SearchOptions
+		expectedIDs []int64
+	}{
+		{
+			SearchOptions{
+				Keyword: "label:label1",
+			},
+			[]int64{2, 1},
+		},
+		{
+			SearchOptions{
+				Keyword: "label:label1 label:orglabel4",
+			},
+			[]int64{2},
+		},
+		{
+			SearchOptions{
+				Keyword: "label:label1 -label:orglabel4",
+			},
+			[]int64{1},
+		},
+		{
+			SearchOptions{
+				Keyword: "label:no-such-label",
+			},
+			[]int64{},
+		},
+		{
+			SearchOptions{
+				Keyword: "repo:user2/repo1 label:label1",
+				RepoIDs: []int64{1},
+			},
+			[]int64{2, 1},
+		},
+		{
+			// repo: never widens the searched repositories
+			SearchOptions{
+				Keyword: "repo:user2/repo1 label:label1",
+				RepoIDs: []int64{2},
+			},
+			[]int64{},
+		},
+	}
+	for _, test := range tests {
+		require.NoError(t, ApplyQuery(t.Context(), &test.opts, nil))
+		issueIDs, _, err := SearchIssues(t.Context(), &test.opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expectedIDs, issueIDs)
+	}
+
+	err := ApplyQuery(t.Context(), &SearchOptions{Keyword: "is:nothing"}, nil)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
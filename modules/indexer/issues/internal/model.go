@@ -22,6 +22,7 @@ type IndexerData struct {
 
 	// Fields used for filtering
 	IsPull             bool               `json:"is_pull"`
+	IsConfidential     bool               `json:"is_confidential"`
 	IsClosed           bool               `json:"is_closed"`
 	LabelIDs           []int64            `json:"label_ids"`
 	NoLabel            bool               `json:"no_label"` // True if LabelIDs is empty
@@ -82,9 +83,13 @@ type SearchOptions struct {
 
 	IncludedLabelIDs    []int64 // labels the issues have
 	ExcludedLabelIDs    []int64 // labels the issues don't have
-	IncludedAnyLabelIDs []int64 // labels the issues have at least one. It will be ignored if IncludedLabelIDs is not empty. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
+	IncludedAnyLabelIDs []int64 // labels the issues have at least one. It's combined with IncludedLabelIDs if both are set. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
 	NoLabelOnly         bool    // if the issues have no label, if true, IncludedLabelIDs and ExcludedLabelIDs, IncludedAnyLabelIDs will be ignored
 
+	IncludedAnyLabelIDGroups [][]int64 // groups of labels the issues have at least one of each, combined with the other label filters. Ignored if NoLabelOnly is true.
+
+	Confidential *ConfidentialFilter // limits the confidential issues to the ones a user can read, nil for all of them
+
 	MilestoneIDs []int64 // milestones the issues have
 
 	ProjectID       optional.Option[int64] // project the issues belong to
@@ -147,3 +152,9 @@ const (
 	//                    but what if the issue belongs to multiple projects?
 	//                    Since it's unsupported to search issues with keyword in project page, we don't need to support it.
 )
+
+// ConfidentialFilter limits a search to the confidential issues a user can read
+type ConfidentialFilter struct {
+	PosterID int64   // the user, who can read the confidential issues they posted, 0 for an anonymous user
+	RepoIDs  []int64 // the repositories whose confidential issues can all be read by the user
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/optional"
+)
+
+// QueryKey is the key of a qualifier in a search query, e.g. "label" in "label:bug".
+type QueryKey string
+
+const (
+	QueryKeyIs              QueryKey = "is"
+	QueryKeyNo              QueryKey = "no"
+	QueryKeyLabel           QueryKey = "label"
+	QueryKeyAuthor          QueryKey = "author"
+	QueryKeyAssignee        QueryKey = "assignee"
+	QueryKeyMentions        QueryKey = "mentions"
+	QueryKeyReviewRequested QueryKey = "review-requested"
+	QueryKeyReviewedBy      QueryKey = "reviewed-by"
+	QueryKeyMilestone       QueryKey = "milestone"
+	QueryKeyUpdated         QueryKey = "updated"
+	QueryKeyRepo            QueryKey = "repo"
+	QueryKeySort            QueryKey = "sort"
+)
+
+// QueryKeys lists all supported qualifier keys, in the order they are suggested to users.
+var QueryKeys = []QueryKey{
+	QueryKeyIs,
+	QueryKeyLabel,
+	QueryKeyAuthor,
+	QueryKeyAssignee,
+	QueryKeyMentions,
+	QueryKeyReviewRequested,
+	QueryKeyReviewedBy,
+	QueryKeyMilestone,
+	QueryKeyUpdated,
+	QueryKeyRepo,
+	QueryKeyNo,
+	QueryKeySort,
+}
+
+// negatableQueryKeys are the keys which accept a leading "-" to exclude matches.
+var negatableQueryKeys = map[QueryKey]bool{
+	QueryKeyLabel: true,
+}
+
+// QueryMe is the qualifier value which refers to the user doing the search, e.g. "assignee:@me".
+const QueryMe = "@me"
+
+// QueryFilter is a single "key:value" qualifier of a search query.
+type QueryFilter struct {
+	Key    QueryKey
+	Value  string
+	Negate bool
+}
+
+// Query is a search query split into its qualifiers and the remaining free text.
+type Query struct {
+	// Keyword is the free text part of the query, it keeps the syntax understood by Tokens.
+	Keyword string
+	Filters []QueryFilter
+}
+
+// ParseQuery parses a search query such as `is:open label:bug -label:wontfix author:alice fix crash`.
+// Terms which are not known qualifiers are kept verbatim in the keyword,
+// so "foo:bar" is still searched as text.
+func ParseQuery(q string) *Query {
+	query := &Query{}
+	var keywords []string
+	for _, term := range splitQueryTerms(q) {
+		if filter, ok := parseQueryFilter(term); ok {
+			query.Filters = append(query.Filters, filter)
+			continue
+		}
+		keywords = append(keywords, term)
+	}
+	query.Keyword = strings.Join(keywords, " ")
+	return query
+}
+
+// splitQueryTerms splits the query at white space which is not inside double quotes.
+// Escaped characters are kept as is, they are handled later by the tokenizer.
+func splitQueryTerms(q string) []string {
+	var (
+		terms   []string
+		sb      strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range q {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case (r == ' ' || r == '\t') && !quoted:
+			if sb.Len() > 0 {
+				terms = append(terms, sb.String())
+				sb.Reset()
+			}
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() > 0 {
+		terms = append(terms, sb.String())
+	}
+	return terms
+}
+
+func parseQueryFilter(term string) (QueryFilter, bool) {
+	var filter QueryFilter
+	if strings.HasPrefix(term, "-") {
+		filter.Negate = true
+		term = term[1:]
+	}
+	key, value, ok := strings.Cut(term, ":")
+	if !ok {
+		return filter, false
+	}
+	filter.Key = QueryKey(strings.ToLower(key))
+	if !slices.Contains(QueryKeys, filter.Key) || (filter.Negate && !negatableQueryKeys[filter.Key]) {
+		return filter, false
+	}
+	filter.Value = unquoteQueryValue(value)
+	if filter.Value == "" {
+		return filter, false
+	}
+	return filter, true
+}
+
+func unquoteQueryValue(value string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+			continue
+		case r == '"':
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// ParseQueryDateRange parses the value of a date qualifier like "updated:".
+// Supported forms are "2006-01-02", ">2006-01-02", ">=2006-01-02", "<2006-01-02", "<=2006-01-02"
+// and "2006-01-02..2006-01-31" where either side of the range may be "*".
+// The returned bounds are inclusive unix timestamps, as expected by SearchOptions.
+func ParseQueryDateRange(value string, loc *time.Location) (after, before optional.Option[int64], err error) {
+	parseDay := func(s string) (time.Time, error) {
+		t, err := time.ParseInLocation(time.DateOnly, s, loc)
+		if err != nil {
+			return t, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+		}
+		return t, nil
+	}
+	startOf := func(t time.Time) optional.Option[int64] {
+		return optional.Some(t.Unix())
+	}
+	endOf := func(t time.Time) optional.Option[int64] {
+		return optional.Some(t.AddDate(0, 0, 1).Unix() - 1)
+	}
+
+	switch {
+	case strings.HasPrefix(value, ">="):
+		t, err := parseDay(value[2:])
+		if err != nil {
+			return after, before, err
+		}
+		return startOf(t), before, nil
+	case strings.HasPrefix(value, ">"):
+		t, err := parseDay(value[1:])
+		if err != nil {
+			return after, before, err
+		}
+		return startOf(t.AddDate(0, 0, 1)), before, nil
+	case strings.HasPrefix(value, "<="):
+		t, err := parseDay(value[2:])
+		if err != nil {
+			return after, before, err
+		}
+		return after, endOf(t), nil
+	case strings.HasPrefix(value, "<"):
+		t, err := parseDay(value[1:])
+		if err != nil {
+			return after, before, err
+		}
+		return after, optional.Some(t.Unix() - 1), nil
+	case strings.Contains(value, ".."):
+		from, to, _ := strings.Cut(value, "..")
+		if from != "*" {
+			t, err := parseDay(from)
+			if err != nil {
+				return after, before, err
+			}
+			after = startOf(t)
+		}
+		if to != "*" {
+			t, err := parseDay(to)
+			if err != nil {
+				return after, before, err
+			}
+			before = endOf(t)
+		}
+		return after, before, nil
+	default:
+		t, err := parseDay(value)
+		if err != nil {
+			return after, before, err
+		}
+		return startOf(t), endOf(t), nil
+	}
+}
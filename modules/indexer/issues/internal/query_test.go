@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		Query   string
+		Keyword string
+		Filters []QueryFilter
+	}{
+		{
+			Query:   "fix crash",
+			Keyword: "fix crash",
+		},
+		{
+			Query:   `is:open label:bug -label:wontfix author:alice assignee:@me milestone:"v2" updated:>2026-01-01 repo:org/x`,
+			Keyword: "",
+			Filters: []QueryFilter{
+				{Key: QueryKeyIs, Value: "open"},
+				{Key: QueryKeyLabel, Value: "bug"},
+				{Key: QueryKeyLabel, Value: "wontfix", Negate: true},
+				{Key: QueryKeyAuthor, Value: "alice"},
+				{Key: QueryKeyAssignee, Value: QueryMe},
+				{Key: QueryKeyMilestone, Value: "v2"},
+				{Key: QueryKeyUpdated, Value: ">2026-01-01"},
+				{Key: QueryKeyRepo, Value: "org/x"},
+			},
+		},
+		{
+			Query:   `crash  milestone:"Release 2.0"   +"exact phrase" -noise`,
+			Keyword: `crash +"exact phrase" -noise`,
+			Filters: []QueryFilter{
+				{Key: QueryKeyMilestone, Value: "Release 2.0"},
+			},
+		},
+		{
+			Query:   `IS:Closed label:"needs \"triage\""`,
+			Keyword: "",
+			Filters: []QueryFilter{
+				{Key: QueryKeyIs, Value: "Closed"},
+				{Key: QueryKeyLabel, Value: `needs "triage"`},
+			},
+		},
+		{
+			// unknown keys, empty values and unsupported negations are searched as text
+			Query:   "foo:bar label: -author:alice http://example.com",
+			Keyword: "foo:bar label: -author:alice http://example.com",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Query, func(t *testing.T) {
+			q := ParseQuery(c.Query)
+			assert.Equal(t, c.Keyword, q.Keyword)
+			assert.Equal(t, c.Filters, q.Filters)
+		})
+	}
+}
+
+func TestParseQueryDateRange(t *testing.T) {
+	day := func(s string) int64 {
+		d, err := time.ParseInLocation(time.DateOnly, s, time.UTC)
+		require.NoError(t, err)
+		return d.Unix()
+	}
+
+	cases := []struct {
+		Value  string
+		After  optional.Option[int64]
+		Before optional.Option[int64]
+	}{
+		{Value: "2026-01-01", After: optional.Some(day("2026-01-01")), Before: optional.Some(day("2026-01-02") - 1)},
+		{Value: ">2026-01-01", After: optional.Some(day("2026-01-02"))},
+		{Value: ">=2026-01-01", After: optional.Some(day("2026-01-01"))},
+		{Value: "<2026-01-01", Before: optional.Some(day("2026-01-01") - 1)},
+		{Value: "<=2026-01-01", Before: optional.Some(day("2026-01-02") - 1)},
+		{Value: "2026-01-01..2026-01-31", After: optional.Some(day("2026-01-01")), Before: optional.Some(day("2026-02-01") - 1)},
+		{Value: "*..2026-01-31", Before: optional.Some(day("2026-02-01") - 1)},
+		{Value: "2026-01-01..*", After: optional.Some(day("2026-01-01"))},
+	}
+	for _, c := range cases {
+		t.Run(c.Value, func(t *testing.T) {
+			after, before, err := ParseQueryDateRange(c.Value, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, c.After, after)
+			assert.Equal(t, c.Before, before)
+		})
+	}
+
+	for _, value := range []string{"yesterday", ">2026-13-01", "2026-01-01..soon"} {
+		_, _, err := ParseQueryDateRange(value, time.UTC)
+		assert.Error(t, err, value)
+	}
+}
@@ -296,6 +296,60 @@ var cases = []*testIndexerCase{
 		ExpectedIDs:   []int64{1003, 1001, 1000},
 		ExpectedTotal: 3,
 	},
+	{
+		Name: "include labels and any labels",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1000, Title: "hello a", LabelIDs: []int64{2000, 2001, 2002}},
+			{ID: 1001, Title: "hello b", LabelIDs: []int64{2001}},
+			{ID: 1002, Title: "hello c", LabelIDs: []int64{2000, 2003}},
+			{ID: 1003, Title: "hello d", LabelIDs: []int64{2000, 2002}},
+			{ID: 1004, Title: "hello e", LabelIDs: []int64{2000}},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:             "hello",
+			IncludedLabelIDs:    []int64{2000},
+			IncludedAnyLabelIDs: []int64{2001, 2002},
+		},
+		ExpectedIDs:   []int64{1003, 1000},
+		ExpectedTotal: 2,
+	},
+	{
+		Name: "include groups of any labels",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1000, Title: "hello a", LabelIDs: []int64{2000, 2002}},
+			{ID: 1001, Title: "hello b", LabelIDs: []int64{2001}},
+			{ID: 1002, Title: "hello c", LabelIDs: []int64{2001, 2003}},
+			{ID: 1003, Title: "hello d", LabelIDs: []int64{2000, 2001}},
+			{ID: 1004, Title: "hello e", LabelIDs: []int64{2002, 2003}},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:                  "hello",
+			IncludedAnyLabelIDGroups: [][]int64{{2000, 2001}, {2002, 2003}},
+		},
+		ExpectedIDs:   []int64{1002, 1000},
+		ExpectedTotal: 2,
+	},
+	{
+		Name: "confidential",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1000, Title: "hello a", RepoID: 1, PosterID: 1},
+			{ID: 1001, Title: "hello b", RepoID: 1, PosterID: 1, IsConfidential: true},
+			{ID: 1002, Title: "hello c", RepoID: 2, PosterID: 2, IsConfidential: true},
+			{ID: 1003, Title: "hello d", RepoID: 3, PosterID: 1, IsConfidential: true},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:      "hello",
+			Confidential: &internal.ConfidentialFilter{PosterID: 2, RepoIDs: []int64{3}},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			ids := make([]int64, 0, len(result.Hits))
+			for _, hit := range result.Hits {
+				ids = append(ids, hit.ID)
+			}
+			assert.ElementsMatch(t, []int64{1000, 1002, 1003}, ids)
+			assert.EqualValues(t, 3, result.Total)
+		},
+	},
 	{
 		Name: "MilestoneIDs",
 		SearchOptions: &internal.SearchOptions{
@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 3
+	issueIndexerLatestVersion = 4
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -60,6 +60,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"repo_id",
 			"is_public",
 			"is_pull",
+			"is_confidential",
 			"is_closed",
 			"label_ids",
 			"no_label",
@@ -139,6 +140,18 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(q)
 	}
 
+	if options.Confidential != nil {
+		q := &inner_meilisearch.FilterOr{}
+		q.Or(inner_meilisearch.NewFilterEq("is_confidential", false))
+		if len(options.Confidential.RepoIDs) > 0 {
+			q.Or(inner_meilisearch.NewFilterIn("repo_id", options.Confidential.RepoIDs...))
+		}
+		if options.Confidential.PosterID > 0 {
+			q.Or(inner_meilisearch.NewFilterEq("poster_id", options.Confidential.PosterID))
+		}
+		query.And(q)
+	}
+
 	if options.IsPull.Has() {
 		query.And(inner_meilisearch.NewFilterEq("is_pull", options.IsPull.Value()))
 	}
@@ -155,9 +168,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 				q.And(inner_meilisearch.NewFilterEq("label_ids", labelID))
 			}
 			query.And(q)
-		} else if len(options.IncludedAnyLabelIDs) > 0 {
+		}
+		if len(options.IncludedAnyLabelIDs) > 0 {
 			query.And(inner_meilisearch.NewFilterIn("label_ids", options.IncludedAnyLabelIDs...))
 		}
+		for _, group := range options.IncludedAnyLabelIDGroups {
+			query.And(inner_meilisearch.NewFilterIn("label_ids", group...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := &inner_meilisearch.FilterAnd{}
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -0,0 +1,240 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/indexer/issues/internal"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// Query is a search query whose qualifiers, like `is:open label:bug author:@me`,
+// have been resolved against the database. It can be applied to several SearchOptions.
+type Query struct {
+	keyword string
+	filters []func(opts *SearchOptions) bool
+}
+
+// ParseQuery parses and resolves the qualifiers of a search query, see internal.ParseQuery.
+// Malformed qualifiers are reported as util.ErrInvalidArgument.
+// Qualifiers referring to something which doesn't exist, e.g. an unknown user,
+// are not an error, they just don't match anything.
+//
+// Label and milestone names are not scoped to repositories, the repositories of the
+// search options take care of it. Since the same label name may exist in several repositories,
+// a label qualifier matching more than one label matches issues having any of them.
+func ParseQuery(ctx context.Context, q string, doer *user_model.User) (*Query, error) {
+	parsed := internal.ParseQuery(q)
+	query := &Query{keyword: parsed.Keyword}
+	for _, filter := range parsed.Filters {
+		f, err := resolveQueryFilter(ctx, doer, filter)
+		if err != nil {
+			return nil, err
+		}
+		query.filters = append(query.filters, f)
+	}
+	return query, nil
+}
+
+// Apply narrows opts with the qualifiers of the query and sets its keyword to the remaining free text.
+// It never widens the set of searched repositories: a `repo:` qualifier only
+// selects among the repositories already allowed by opts.
+// If a qualifier can't match, opts is changed so that nothing matches, like for an empty list of repositories.
+func (q *Query) Apply(opts *SearchOptions) {
+	opts.Keyword = q.keyword
+	for _, f := range q.filters {
+		if !f(opts) {
+			opts.RepoIDs = []int64{0}
+			opts.AllPublic = false
+			return
+		}
+	}
+}
+
+// NoMatchQuery returns a query which doesn't match any issue, e.g. to show no result for an invalid query.
+func NoMatchQuery() *Query {
+	return &Query{filters: []func(opts *SearchOptions) bool{matchNothing}}
+}
+
+// ApplyQuery is a shortcut to parse the keyword of opts with ParseQuery and apply it to opts.
+func ApplyQuery(ctx context.Context, opts *SearchOptions, doer *user_model.User) error {
+	query, err := ParseQuery(ctx, opts.Keyword, doer)
+	if err != nil {
+		return err
+	}
+	query.Apply(opts)
+	return nil
+}
+
+func matchNothing(*SearchOptions) bool {
+	return false
+}
+
+func resolveQueryFilter(ctx context.Context, doer *user_model.User, filter internal.QueryFilter) (func(opts *SearchOptions) bool, error) {
+	switch filter.Key {
+	case internal.QueryKeyIs:
+		var isPull, isClosed optional.Option[bool]
+		switch strings.ToLower(filter.Value) {
+		case "open":
+			isClosed = optional.Some(false)
+		case "closed":
+			isClosed = optional.Some(true)
+		case "issue":
+			isPull = optional.Some(false)
+		case "pr", "pull":
+			isPull = optional.Some(true)
+		default:
+			return nil, util.NewInvalidArgumentErrorf("unknown value %q for %q, expected open, closed, issue or pr", filter.Value, filter.Key)
+		}
+		return func(opts *SearchOptions) bool {
+			if isPull.Has() {
+				opts.IsPull = isPull
+			}
+			if isClosed.Has() {
+				opts.IsClosed = isClosed
+			}
+			return true
+		}, nil
+	case internal.QueryKeyNo:
+		switch strings.ToLower(filter.Value) {
+		case "label":
+			return func(opts *SearchOptions) bool { opts.NoLabelOnly = true; return true }, nil
+		case "milestone":
+			return func(opts *SearchOptions) bool { opts.MilestoneIDs = []int64{0}; return true }, nil
+		case "assignee":
+			return func(opts *SearchOptions) bool { opts.AssigneeID = optional.Some[int64](0); return true }, nil
+		case "project":
+			return func(opts *SearchOptions) bool { opts.ProjectID = optional.Some[int64](0); return true }, nil
+		}
+		return nil, util.NewInvalidArgumentErrorf("unknown value %q for %q, expected label, milestone, assignee or project", filter.Value, filter.Key)
+	case internal.QueryKeyLabel:
+		ids, err := issues_model.GetLabelIDsByNames(ctx, []string{filter.Value})
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case filter.Negate:
+			return func(opts *SearchOptions) bool {
+				opts.ExcludedLabelIDs = append(slices.Clip(opts.ExcludedLabelIDs), ids...)
+				return true
+			}, nil
+		case len(ids) == 0:
+			return matchNothing, nil
+		case len(ids) == 1:
+			return func(opts *SearchOptions) bool {
+				opts.IncludedLabelIDs = append(slices.Clip(opts.IncludedLabelIDs), ids[0])
+				return true
+			}, nil
+		default:
+			return func(opts *SearchOptions) bool {
+				// a group for each term, the issues have to match all of them
+				opts.IncludedAnyLabelIDGroups = append(slices.Clip(opts.IncludedAnyLabelIDGroups), ids)
+				return true
+			}, nil
+		}
+	case internal.QueryKeyMilestone:
+		ids, err := issues_model.GetMilestoneIDsByNames(ctx, []string{filter.Value})
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return matchNothing, nil
+		}
+		return func(opts *SearchOptions) bool {
+			opts.MilestoneIDs = append(slices.Clip(opts.MilestoneIDs), ids...)
+			return true
+		}, nil
+	case internal.QueryKeyAuthor, internal.QueryKeyAssignee, internal.QueryKeyMentions,
+		internal.QueryKeyReviewRequested, internal.QueryKeyReviewedBy:
+		userID, err := queryUserID(ctx, doer, filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		if userID == 0 {
+			return matchNothing, nil
+		}
+		id := optional.Some(userID)
+		return func(opts *SearchOptions) bool {
+			switch filter.Key {
+			case internal.QueryKeyAuthor:
+				opts.PosterID = id
+			case internal.QueryKeyAssignee:
+				opts.AssigneeID = id
+			case internal.QueryKeyMentions:
+				opts.MentionID = id
+			case internal.QueryKeyReviewRequested:
+				opts.ReviewRequestedID = id
+			case internal.QueryKeyReviewedBy:
+				opts.ReviewedID = id
+			}
+			return true
+		}, nil
+	case internal.QueryKeyUpdated:
+		after, before, err := internal.ParseQueryDateRange(filter.Value, setting.DefaultUILocation)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("%q: %v", filter.Key, err)
+		}
+		return func(opts *SearchOptions) bool {
+			if after.Has() {
+				opts.UpdatedAfterUnix = after
+			}
+			if before.Has() {
+				opts.UpdatedBeforeUnix = before
+			}
+			return true
+		}, nil
+	case internal.QueryKeyRepo:
+		ownerName, repoName, ok := strings.Cut(filter.Value, "/")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid value %q for %q, expected owner/name", filter.Value, filter.Key)
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return matchNothing, nil
+			}
+			return nil, err
+		}
+		return func(opts *SearchOptions) bool {
+			if !slices.Contains(opts.RepoIDs, repo.ID) && (!opts.AllPublic || repo.IsPrivate) {
+				return false
+			}
+			opts.RepoIDs = []int64{repo.ID}
+			opts.AllPublic = false
+			return true
+		}, nil
+	case internal.QueryKeySort:
+		return func(opts *SearchOptions) bool {
+			opts.SortBy = ParseSortBy(filter.Value, opts.SortBy)
+			return true
+		}, nil
+	}
+	return nil, util.NewInvalidArgumentErrorf("unsupported qualifier %q", filter.Key)
+}
+
+// queryUserID returns the ID of the user named in a qualifier, or 0 if there is no such user.
+func queryUserID(ctx context.Context, doer *user_model.User, name string) (int64, error) {
+	if name == internal.QueryMe {
+		if doer == nil {
+			return 0, nil
+		}
+		return doer.ID, nil
+	}
+	u, err := user_model.GetUserByName(ctx, strings.TrimPrefix(name, "@"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return u.ID, nil
+}
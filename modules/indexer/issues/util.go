@@ -35,7 +35,8 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 
 	comments := make([]string, 0, len(issue.Comments))
 	for _, comment := range issue.Comments {
-		if comment.Content != "" {
+		// the internal comments can't be read by everyone who can search the issue
+		if comment.Content != "" && !comment.IsInternal {
 			// what ever the comment type is, index the content if it is not empty.
 			comments = append(comments, comment.Content)
 		}
@@ -100,6 +101,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		Content:            issue.Content,
 		Comments:           comments,
 		IsPull:             issue.IsPull,
+		IsConfidential:     issue.IsConfidential,
 		IsClosed:           issue.IsClosed,
 		LabelIDs:           labels,
 		NoLabel:            len(labels) == 0,
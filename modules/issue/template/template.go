@@ -309,6 +309,28 @@ func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 	return builder.String()
 }
 
+// DefaultValues returns the values the form of the template is filled with when it is shown,
+// so it can be rendered to markdown without user input
+func DefaultValues(template *api.IssueTemplate) url.Values {
+	values := url.Values{}
+	for _, field := range template.Fields {
+		if field.ID == "" {
+			continue
+		}
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			if value, ok := field.Attributes["value"].(string); ok {
+				values.Set("form-field-"+field.ID, value)
+			}
+		case api.IssueFormFieldTypeDropdown:
+			if index, ok := field.Attributes["default"].(int); ok {
+				values.Set("form-field-"+field.ID, strconv.Itoa(index))
+			}
+		}
+	}
+	return values
+}
+
 type valuedField struct {
 	*api.IssueFormField
 	url.Values
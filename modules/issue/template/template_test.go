@@ -908,6 +908,51 @@ Option 1 of dropdown, Option 2 of dropdown
 	}
 }
 
+func TestDefaultValues(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Name
+about: About
+body:
+  - type: input
+    id: id1
+    attributes:
+      label: Label of input
+      value: Value of input
+  - type: textarea
+    id: id2
+    attributes:
+      label: Label of textarea
+  - type: dropdown
+    id: id3
+    attributes:
+      label: Label of dropdown
+      options:
+        - Option 1 of dropdown
+        - Option 2 of dropdown
+      default: 1
+`))
+	require.NoError(t, err)
+
+	values := DefaultValues(template)
+	assert.Equal(t, url.Values{
+		"form-field-id1": {"Value of input"},
+		"form-field-id3": {"1"},
+	}, values)
+	assert.Equal(t, `### Label of input
+
+Value of input
+
+### Label of textarea
+
+_No response_
+
+### Label of dropdown
+
+Option 2 of dropdown
+
+`, RenderToMarkdown(template, values))
+}
+
 func Test_minQuotes(t *testing.T) {
 	type args struct {
 		value string
@@ -0,0 +1,83 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// cvss3Weights are the weights of the values of the base metrics, https://www.first.org/cvss/v3.1/specification-document#7-4-Metric-Values
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"S":  {"U": 0, "C": 0},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// CVSS3BaseScore computes the base score of a CVSS v3 vector like CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H,
+// https://www.first.org/cvss/v3.1/specification-document#7-1-Base-Metrics-Equations
+func CVSS3BaseScore(vector string) (float64, error) {
+	metrics, ok := strings.CutPrefix(vector, "CVSS:3.")
+	if !ok {
+		return 0, fmt.Errorf("not a CVSS v3 vector: %q", vector)
+	}
+	values := make(map[string]string, len(cvss3Weights))
+	// the first part is the minor version
+	for _, part := range strings.Split(metrics, "/")[1:] {
+		metric, value, _ := strings.Cut(part, ":")
+		if weights, ok := cvss3Weights[metric]; ok {
+			if _, ok := weights[value]; !ok {
+				return 0, fmt.Errorf("invalid value of %s in CVSS vector %q", metric, vector)
+			}
+			values[metric] = value
+		}
+	}
+	if len(values) != len(cvss3Weights) {
+		return 0, fmt.Errorf("incomplete CVSS vector: %q", vector)
+	}
+	weight := func(metric string) float64 {
+		return cvss3Weights[metric][values[metric]]
+	}
+
+	changed := values["S"] == "C"
+	privileges := weight("PR")
+	if changed {
+		switch values["PR"] {
+		case "L":
+			privileges = 0.68
+		case "H":
+			privileges = 0.5
+		}
+	}
+
+	iss := 1 - (1-weight("C"))*(1-weight("I"))*(1-weight("A"))
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+	exploitability := 8.22 * weight("AV") * weight("AC") * privileges * weight("UI")
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number with one decimal equal or higher, avoiding the errors of floating point numbers,
+// https://www.first.org/cvss/v3.1/specification-document#Appendix-A---Floating-Point-Rounding
+func roundUp(x float64) float64 {
+	i := int64(math.Round(x * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
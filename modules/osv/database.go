@@ -0,0 +1,94 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"forgejo.org/modules/json"
+)
+
+// maxEntrySize is the size of the largest vulnerability read, the largest ones are a few hundred KiB
+const maxEntrySize = 16 * 1024 * 1024
+
+// Decode reads a vulnerability in the JSON format
+func Decode(r io.Reader) (*Vulnerability, error) {
+	v := &Vulnerability{}
+	if err := json.NewDecoder(io.LimitReader(r, maxEntrySize)).Decode(v); err != nil {
+		return nil, err
+	}
+	if v.ID == "" {
+		return nil, errors.New("vulnerability without ID")
+	}
+	return v, nil
+}
+
+// ReadDatabase calls fn with each vulnerability of a database exported to a directory: the JSON files
+// of the vulnerabilities, or archives of them like the all.zip files of https://osv.dev/ for each ecosystem
+func ReadDatabase(dir string, fn func(*Vulnerability) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			return readFile(path, fn)
+		case ".zip":
+			return readArchive(path, fn)
+		}
+		return nil
+	})
+}
+
+func readFile(path string, fn func(*Vulnerability) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	v, err := Decode(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return fn(v)
+}
+
+func readArchive(path string, fn func(*Vulnerability) error) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(filepath.Ext(file.Name), ".json") {
+			continue
+		}
+		v, err := decodeArchiveFile(file)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", path, file.Name, err)
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeArchiveFile(file *zip.File) (*Vulnerability, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
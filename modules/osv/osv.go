@@ -0,0 +1,231 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package osv reads vulnerabilities in the Open Source Vulnerability format, https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Vulnerability is an entry of an OSV database
+type Vulnerability struct {
+	ID        string    `json:"id"`
+	Modified  time.Time `json:"modified"`
+	Published time.Time `json:"published"`
+	// Withdrawn is set when the vulnerability has been retracted
+	Withdrawn        *time.Time     `json:"withdrawn,omitempty"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Summary          string         `json:"summary,omitempty"`
+	Details          string         `json:"details,omitempty"`
+	Severity         []Severity     `json:"severity,omitempty"`
+	Affected         []*Affected    `json:"affected,omitempty"`
+	References       []Reference    `json:"references,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+// Severity is a score of a vulnerability, like a CVSS vector
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Reference is a link to more information about a vulnerability
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Package is a package of an ecosystem
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Affected is a package affected by a vulnerability and its affected versions
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []*Range `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Range is a range of affected versions, given as events introducing or fixing the vulnerability
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a version introducing or fixing a vulnerability, only one of its fields is set
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Types of ranges, GIT ranges of commits are not supported
+const (
+	RangeSemver    = "SEMVER"
+	RangeEcosystem = "ECOSYSTEM"
+)
+
+// IsWithdrawn returns whether the vulnerability has been retracted
+func (v *Vulnerability) IsWithdrawn() bool {
+	return v.Withdrawn != nil && !v.Withdrawn.IsZero()
+}
+
+// Level is the severity level of a vulnerability
+type Level int
+
+const (
+	LevelUnknown Level = iota
+	LevelLow
+	LevelModerate
+	LevelHigh
+	LevelCritical
+)
+
+// Levels are the severity levels, from the lowest to the highest
+var Levels = []Level{LevelUnknown, LevelLow, LevelModerate, LevelHigh, LevelCritical}
+
+// Name returns the name of the level, used by the translations
+func (l Level) Name() string {
+	switch l {
+	case LevelLow:
+		return "low"
+	case LevelModerate:
+		return "moderate"
+	case LevelHigh:
+		return "high"
+	case LevelCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// levelFromScore returns the level of a CVSS score, https://www.first.org/cvss/v3.1/specification-document#Qualitative-Severity-Rating-Scale
+func levelFromScore(score float64) Level {
+	switch {
+	case score >= 9:
+		return LevelCritical
+	case score >= 7:
+		return LevelHigh
+	case score >= 4:
+		return LevelModerate
+	case score > 0:
+		return LevelLow
+	}
+	return LevelUnknown
+}
+
+// Level returns the severity level of the vulnerability: the one given by the database, or computed
+// from its CVSS v3 vector
+func (v *Vulnerability) Level() Level {
+	// the GitHub advisories and several other databases give a level
+	if s, ok := v.DatabaseSpecific["severity"].(string); ok {
+		switch strings.ToUpper(s) {
+		case "LOW":
+			return LevelLow
+		case "MODERATE", "MEDIUM":
+			return LevelModerate
+		case "HIGH":
+			return LevelHigh
+		case "CRITICAL":
+			return LevelCritical
+		}
+	}
+	for _, s := range v.Severity {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if score, err := CVSS3BaseScore(s.Score); err == nil {
+			return levelFromScore(score)
+		}
+	}
+	return LevelUnknown
+}
+
+// parseVersion parses a version of any ecosystem, only the versions looking like semantic versions are supported
+func parseVersion(v string) *version.Version {
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return nil
+	}
+	return parsed
+}
+
+// IsAffected returns whether a version of the package is affected
+func (a *Affected) IsAffected(v string) bool {
+	if slices.Contains(a.Versions, v) {
+		return true
+	}
+	parsed := parseVersion(v)
+	if parsed == nil {
+		return false
+	}
+	for _, r := range a.Ranges {
+		if (r.Type == RangeSemver || r.Type == RangeEcosystem) && r.contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether a version is in the range: it is after a version introducing the vulnerability
+// and no later version up to it fixed the vulnerability, the events do not need to be sorted
+func (r *Range) contains(v *version.Version) bool {
+	for _, introducing := range r.Events {
+		if introducing.Introduced == "" {
+			continue
+		}
+		var introduced *version.Version
+		if introducing.Introduced != "0" {
+			if introduced = parseVersion(introducing.Introduced); introduced == nil || v.LessThan(introduced) {
+				continue
+			}
+		}
+
+		affected := true
+		for _, e := range r.Events {
+			if e.Fixed != "" || e.Limit != "" {
+				fixed := parseVersion(e.Fixed + e.Limit)
+				if fixed != nil && (introduced == nil || fixed.GreaterThan(introduced)) && v.GreaterThanOrEqual(fixed) {
+					affected = false
+					break
+				}
+			}
+			if e.LastAffected != "" {
+				last := parseVersion(e.LastAffected)
+				if last != nil && (introduced == nil || last.GreaterThanOrEqual(introduced)) && v.GreaterThan(last) {
+					affected = false
+					break
+				}
+			}
+		}
+		if affected {
+			return true
+		}
+	}
+	return false
+}
+
+// FixedVersions returns the versions fixing the vulnerability
+func (a *Affected) FixedVersions() []string {
+	var fixed []string
+	for _, r := range a.Ranges {
+		if r.Type != RangeSemver && r.Type != RangeEcosystem {
+			continue
+		}
+		for _, e := range r.Events {
+			if e.Fixed != "" && !slices.Contains(fixed, e.Fixed) {
+				fixed = append(fixed, e.Fixed)
+			}
+		}
+	}
+	return fixed
+}
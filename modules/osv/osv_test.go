@@ -0,0 +1,123 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lodashAdvisory = `{
+	"schema_version": "1.6.0",
+	"id": "GHSA-35jh-r3h4-6jhm",
+	"modified": "2024-03-01T00:00:00Z",
+	"published": "2021-05-06T00:00:00Z",
+	"aliases": ["CVE-2021-23337"],
+	"summary": "Command Injection in lodash",
+	"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H"}],
+	"affected": [{
+		"package": {"ecosystem": "npm", "name": "lodash"},
+		"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+	}],
+	"database_specific": {"severity": "HIGH"}
+}`
+
+func TestDecode(t *testing.T) {
+	v, err := Decode(strings.NewReader(lodashAdvisory))
+	require.NoError(t, err)
+	assert.Equal(t, "GHSA-35jh-r3h4-6jhm", v.ID)
+	assert.Equal(t, []string{"CVE-2021-23337"}, v.Aliases)
+	assert.False(t, v.IsWithdrawn())
+	assert.Equal(t, LevelHigh, v.Level())
+	require.Len(t, v.Affected, 1)
+	assert.Equal(t, "lodash", v.Affected[0].Package.Name)
+	assert.True(t, v.Affected[0].IsAffected("4.17.20"))
+	assert.False(t, v.Affected[0].IsAffected("4.17.21"))
+	assert.Equal(t, []string{"4.17.21"}, v.Affected[0].FixedVersions())
+
+	_, err = Decode(strings.NewReader(`{"summary": "no ID"}`))
+	require.Error(t, err)
+}
+
+func TestIsAffected(t *testing.T) {
+	a := &Affected{
+		Ranges: []*Range{
+			{Type: RangeSemver, Events: []Event{{Introduced: "2.0.0"}, {Fixed: "2.3.0"}, {Introduced: "1.0.0"}, {Fixed: "1.5.2"}}},
+			{Type: RangeEcosystem, Events: []Event{{Introduced: "3.0.0"}, {LastAffected: "3.1.0"}}},
+			{Type: "GIT", Events: []Event{{Introduced: "0"}}},
+		},
+		Versions: []string{"0.9.0-custom"},
+	}
+	for v, affected := range map[string]bool{
+		"0.9.0":         false,
+		"0.9.0-custom":  true,
+		"1.0.0":         true,
+		"1.5.1":         true,
+		"1.5.2":         false,
+		"1.9.0":         false,
+		"v2.1.0":        true,
+		"2.3.0":         false,
+		"3.1.0":         true,
+		"3.1.1":         false,
+		"not a version": false,
+	} {
+		assert.Equal(t, affected, a.IsAffected(v), v)
+	}
+	assert.Equal(t, []string{"2.3.0", "1.5.2"}, a.FixedVersions())
+}
+
+func TestLevel(t *testing.T) {
+	for vector, score := range map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N": 6.1,
+		"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N": 1.8,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H": 9.9,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N": 0,
+	} {
+		s, err := CVSS3BaseScore(vector)
+		require.NoError(t, err, vector)
+		assert.InDelta(t, score, s, 0.001, vector)
+	}
+	_, err := CVSS3BaseScore("CVSS:3.1/AV:N/AC:L")
+	require.Error(t, err)
+	_, err = CVSS3BaseScore("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N")
+	require.Error(t, err)
+
+	v := &Vulnerability{Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N"}}}
+	assert.Equal(t, LevelModerate, v.Level())
+	v.DatabaseSpecific = map[string]any{"severity": "CRITICAL"}
+	assert.Equal(t, LevelCritical, v.Level())
+	assert.Equal(t, LevelUnknown, (&Vulnerability{}).Level())
+}
+
+func TestReadDatabase(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "GHSA-35jh-r3h4-6jhm.json"), []byte(lodashAdvisory), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0o644))
+
+	f, err := os.Create(filepath.Join(dir, "all.zip"))
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, id := range []string{"GO-2022-0001", "GO-2022-0002"} {
+		entry, err := w.Create(id + ".json")
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(`{"id": "` + id + `", "modified": "2024-01-01T00:00:00Z"}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	var ids []string
+	require.NoError(t, ReadDatabase(dir, func(v *Vulnerability) error {
+		ids = append(ids, v.ID)
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{"GHSA-35jh-r3h4-6jhm", "GO-2022-0001", "GO-2022-0002"}, ids)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package secretscan finds credentials, such as access tokens and private keys, in the content of files
+package secretscan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"forgejo.org/modules/util"
+)
+
+// Pattern is a kind of secret found in the content of files
+type Pattern struct {
+	// ID identifies the kind of secret, it is stored with the alerts
+	ID   string
+	Name string
+	// Regexp matches the secret, or its first group is the secret if it has one
+	Regexp *regexp.Regexp
+}
+
+// IDs of the builtin patterns
+const (
+	PatternAWSAccessKeyID     = "aws_access_key_id"
+	PatternPrivateKey         = "private_key"
+	PatternForgejoAccessToken = "forgejo_access_token"
+	PatternGitHubToken        = "github_token"
+	PatternGitLabToken        = "gitlab_token"
+	PatternSlackToken         = "slack_token"
+	// PatternCustom is the ID of the patterns configured by the owner of a repository
+	PatternCustom = "custom"
+)
+
+// BuiltinPatterns are the secrets searched in every repository where secret scanning is enabled
+var BuiltinPatterns = []*Pattern{
+	{ID: PatternAWSAccessKeyID, Name: "AWS access key ID", Regexp: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{ID: PatternPrivateKey, Name: "Private key", Regexp: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`)},
+	// access tokens are 40 hexadecimal digits like the IDs of commits, only those near a keyword
+	// are considered and they still have to exist on the instance to be reported
+	{ID: PatternForgejoAccessToken, Name: "Forgejo access token", Regexp: regexp.MustCompile(`(?i:token|forgejo|gitea|authorization|password|secret)[^\n]{0,32}?\b([0-9a-f]{40})\b`)},
+	{ID: PatternGitHubToken, Name: "GitHub token", Regexp: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255})\b`)},
+	{ID: PatternGitLabToken, Name: "GitLab personal access token", Regexp: regexp.MustCompile(`\b(glpat-[A-Za-z0-9_\-]{20,})`)},
+	{ID: PatternSlackToken, Name: "Slack token", Regexp: regexp.MustCompile(`\b(xox[abprs]-[0-9A-Za-z\-]{10,})`)},
+}
+
+// ParseCustomPatterns compiles regular expressions, one per line, returning an invalid argument
+// error for the first invalid one
+func ParseCustomPatterns(s string) ([]*Pattern, error) {
+	var patterns []*Pattern
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		re, err := regexp.Compile(line)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid secret pattern %q: %v", line, err)
+		}
+		patterns = append(patterns, &Pattern{ID: PatternCustom, Name: line, Regexp: re})
+	}
+	return patterns, nil
+}
+
+// Finding is a secret found in the content of a file
+type Finding struct {
+	Pattern *Pattern
+	Secret  string
+	// Line is the line of the first occurrence of the secret, starting at 1
+	Line int
+}
+
+// Hash returns the hash identifying the secret without storing it
+func (f *Finding) Hash() string {
+	sum := sha256.Sum256([]byte(f.Secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Masked returns the secret with all but its first and last characters hidden
+func (f *Finding) Masked() string {
+	return Mask(f.Secret)
+}
+
+// Mask hides all but the first and the last four characters of a secret
+func Mask(secret string) string {
+	if len(secret) <= 12 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", min(len(secret)-8, 16)) + secret[len(secret)-4:]
+}
+
+// binarySniffLen is the length of the beginning of a file searched for a NUL byte to detect binary files
+const binarySniffLen = 8000
+
+// Scan returns the secrets the content of a file contains, each one once per pattern
+func Scan(patterns []*Pattern, content []byte) []*Finding {
+	if bytes.IndexByte(content[:min(len(content), binarySniffLen)], 0) >= 0 {
+		return nil
+	}
+	var findings []*Finding
+	for _, p := range patterns {
+		seen := make(map[string]bool)
+		for _, match := range p.Regexp.FindAllSubmatchIndex(content, -1) {
+			start, end := match[0], match[1]
+			if len(match) >= 4 && match[2] >= 0 {
+				start, end = match[2], match[3]
+			}
+			secret := string(content[start:end])
+			if secret == "" || seen[secret] {
+				continue
+			}
+			seen[secret] = true
+			findings = append(findings, &Finding{
+				Pattern: p,
+				Secret:  secret,
+				Line:    bytes.Count(content[:start], []byte{'\n'}) + 1,
+			})
+		}
+	}
+	return findings
+}
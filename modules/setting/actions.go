@@ -24,11 +24,14 @@ var (
 		AbandonedJobTimeout   time.Duration     `ini:"ABANDONED_JOB_TIMEOUT"`
 		SkipWorkflowStrings   []string          `ìni:"SKIP_WORKFLOW_STRINGS"`
 		LimitDispatchInputs   int64             `ini:"LIMIT_DISPATCH_INPUTS"`
+		EnableIDToken         bool              `ini:"ENABLE_ID_TOKEN"`
+		IDTokenExpiration     time.Duration     `ini:"ID_TOKEN_EXPIRATION"`
 	}{
 		Enabled:             true,
 		DefaultActionsURL:   defaultActionsURLForgejo,
 		SkipWorkflowStrings: []string{"[skip ci]", "[ci skip]", "[no ci]", "[skip actions]", "[actions skip]"},
 		LimitDispatchInputs: 10,
+		EnableIDToken:       true,
 	}
 )
 
@@ -97,6 +100,7 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
+	Actions.IDTokenExpiration = sec.Key("ID_TOKEN_EXPIRATION").MustDuration(10 * time.Minute)
 
 	if !Actions.LogCompression.IsValid() {
 		return fmt.Errorf("invalid [actions] LOG_COMPRESSION: %q", Actions.LogCompression)
@@ -0,0 +1,19 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import "time"
+
+// Audit settings
+var Audit = struct {
+	Enabled   bool          `ini:"ENABLED"`
+	Retention time.Duration `ini:"RETENTION"`
+}{
+	Enabled:   true,
+	Retention: 365 * 24 * time.Hour,
+}
+
+func loadAuditFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "audit", &Audit)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// DependencyGraph represents the settings of the dependency graphs of the repositories and of the
+// vulnerability database their dependencies are checked against
+var DependencyGraph = struct {
+	Enabled bool
+	// OSVPath is the directory the OSV vulnerability database is imported from
+	OSVPath string `ini:"OSV_PATH"`
+	// MaxFileSize is the size of the largest manifests parsed
+	MaxFileSize int64 `ini:"-"`
+}{
+	Enabled:     false,
+	OSVPath:     "osv",
+	MaxFileSize: 5 * 1024 * 1024,
+}
+
+// DependencyUpdates represents the settings of the bot opening pull requests updating the outdated dependencies
+var DependencyUpdates = struct {
+	Enabled bool
+	// BotUser is the name of the bot user created to open the pull requests
+	BotUser string
+	// The upstream registries the versions are looked up in along with the package registries of the owners,
+	// none if empty
+	NpmRegistry       string
+	PackagistRegistry string
+	PyPIRegistry      string `ini:"PYPI_REGISTRY"`
+	MavenRegistry     string
+	GoProxy           string
+}{
+	Enabled: false,
+	BotUser: "dependency-updates",
+}
+
+func loadDependencyGraphFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("dependency_graph")
+	if err := sec.MapTo(&DependencyGraph); err != nil {
+		return fmt.Errorf("failed to map DependencyGraph settings: %v", err)
+	}
+	if !filepath.IsAbs(DependencyGraph.OSVPath) {
+		DependencyGraph.OSVPath = filepath.Join(AppDataPath, DependencyGraph.OSVPath)
+	}
+	if value := sec.Key("MAX_FILE_SIZE").String(); value != "" {
+		size, err := humanize.ParseBytes(value)
+		if err != nil || size > math.MaxInt64 {
+			return fmt.Errorf("invalid [dependency_graph] MAX_FILE_SIZE %q", value)
+		}
+		DependencyGraph.MaxFileSize = int64(size)
+	}
+
+	if err := rootCfg.Section("dependency_updates").MapTo(&DependencyUpdates); err != nil {
+		return fmt.Errorf("failed to map DependencyUpdates settings: %v", err)
+	}
+	for _, registry := range []*string{
+		&DependencyUpdates.NpmRegistry, &DependencyUpdates.PackagistRegistry, &DependencyUpdates.PyPIRegistry,
+		&DependencyUpdates.MavenRegistry, &DependencyUpdates.GoProxy,
+	} {
+		*registry = strings.TrimSuffix(*registry, "/")
+	}
+	return nil
+}
@@ -128,6 +128,12 @@ func loadLogModeByName(rootCfg ConfigProvider, loggerName, modeName string) (wri
 		defaultFlags = "none"
 		defaultFilaName = "access.log"
 	}
+	if loggerName == "audit" {
+		// like the "access" logger, the "audit" logger outputs each event as is to its own writer
+		writerName += ".audit"
+		defaultFlags = "none"
+		defaultFilaName = "audit.log"
+	}
 
 	writerMode.Level = log.LevelFromString(ConfigInheritedKeyString(sec, "LEVEL", Log.Level.String()))
 	writerMode.StacktraceLevel = log.LevelFromString(ConfigInheritedKeyString(sec, "STACKTRACE_LEVEL", Log.StacktraceLogLevel.String()))
@@ -212,6 +218,7 @@ func initManagedLoggers(manager *log.LoggerManager, cfg ConfigProvider) {
 	initLoggerByName(manager, cfg, "access")
 	initLoggerByName(manager, cfg, "router")
 	initLoggerByName(manager, cfg, "xorm")
+	initLoggerByName(manager, cfg, "audit")
 }
 
 func initLoggerByName(manager *log.LoggerManager, rootCfg ConfigProvider, loggerName string) {
@@ -265,6 +272,10 @@ func IsAccessLogEnabled() bool {
 	return log.IsLoggerEnabled("access")
 }
 
+func IsAuditLogEnabled() bool {
+	return log.IsLoggerEnabled("audit")
+}
+
 func IsRouteLogEnabled() bool {
 	return log.IsLoggerEnabled("router")
 }
@@ -96,6 +96,8 @@ var OAuth2 = struct {
 	AccessTokenExpirationTime   int64
 	RefreshTokenExpirationTime  int64
 	InvalidateRefreshTokens     bool
+	DeviceCodeExpirationTime    int64
+	DeviceCodePollingInterval   int64
 	JWTSigningAlgorithm         string `ini:"JWT_SIGNING_ALGORITHM"`
 	JWTSigningPrivateKeyFile    string `ini:"JWT_SIGNING_PRIVATE_KEY_FILE"`
 	MaxTokenLength              int
@@ -106,6 +108,8 @@ var OAuth2 = struct {
 	AccessTokenExpirationTime:   3600,
 	RefreshTokenExpirationTime:  730,
 	InvalidateRefreshTokens:     true,
+	DeviceCodeExpirationTime:    900,
+	DeviceCodePollingInterval:   5,
 	JWTSigningAlgorithm:         "RS256",
 	JWTSigningPrivateKeyFile:    "jwt/private.pem",
 	MaxTokenLength:              math.MaxInt16,
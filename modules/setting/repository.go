@@ -57,6 +57,7 @@ var (
 		DisableStars                            bool
 		DisableForks                            bool
 		DefaultBranch                           string
+		DefaultRefFormat                        string
 		AllowAdoptionOfUnadoptedRepositories    bool
 		AllowDeleteOfUnadoptedRepositories      bool
 		DisableDownloadSourceArchives           bool
@@ -180,6 +181,7 @@ var (
 		DisableStars:                            false,
 		DisableForks:                            false,
 		DefaultBranch:                           "main",
+		DefaultRefFormat:                        "files",
 		AllowForkWithoutMaximumLimit:            true,
 
 		// Repository editor settings
@@ -327,6 +329,10 @@ func loadRepositoryFrom(rootCfg ConfigProvider) {
 		log.Fatal("Failed to map Repository.PullRequest settings: %v", err)
 	}
 
+	if Repository.DefaultRefFormat != "files" && Repository.DefaultRefFormat != "reftable" {
+		log.Fatal("Invalid [repository].DEFAULT_REF_FORMAT %q, it must be files or reftable", Repository.DefaultRefFormat)
+	}
+
 	if !rootCfg.Section("packages").Key("ENABLED").MustBool(Packages.Enabled) {
 		Repository.DisabledRepoUnits = append(Repository.DisabledRepoUnits, "repo.packages")
 	}
@@ -381,6 +387,9 @@ func loadRepositoryFrom(rootCfg ConfigProvider) {
 	if err := loadRepoArchiveFrom(rootCfg); err != nil {
 		log.Fatal("loadRepoArchiveFrom: %v", err)
 	}
+	if err := loadRepoBundleFrom(rootCfg); err != nil {
+		log.Fatal("loadRepoBundleFrom: %v", err)
+	}
 	Repository.EnableFlags = sec.Key("ENABLE_FLAGS").MustBool()
 
 	if Repository.Signing.Format == "ssh" && Repository.Signing.SigningKey != "none" && Repository.Signing.SigningKey != "" {
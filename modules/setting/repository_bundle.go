@@ -0,0 +1,45 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dustin/go-humanize"
+)
+
+// RepoBundle represents the settings of the bundles of whole repositories, which are
+// regenerated on a schedule and advertised to cloning clients with bundle-uri
+var RepoBundle = struct {
+	Enabled bool
+	// MinRepoSize is the git size from which the bundle of a repository is generated
+	MinRepoSize int64 `ini:"-"`
+	Storage     *Storage
+}{
+	Enabled:     false,
+	MinRepoSize: 100 * 1024 * 1024,
+}
+
+func loadRepoBundleFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("repo-bundle")
+	if sec == nil {
+		RepoBundle.Storage, err = getStorage(rootCfg, "repo-bundle", "", nil)
+		return err
+	}
+
+	if err := sec.MapTo(&RepoBundle); err != nil {
+		return fmt.Errorf("mapto repobundle failed: %v", err)
+	}
+	if value := sec.Key("MIN_REPO_SIZE").String(); value != "" {
+		size, err := humanize.ParseBytes(value)
+		if err != nil || size > math.MaxInt64 {
+			return fmt.Errorf("invalid [repo-bundle] MIN_REPO_SIZE %q", value)
+		}
+		RepoBundle.MinRepoSize = int64(size)
+	}
+
+	RepoBundle.Storage, err = getStorage(rootCfg, "repo-bundle", "", sec)
+	return err
+}
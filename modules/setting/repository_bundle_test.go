@@ -0,0 +1,34 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRepoBundle(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[repo-bundle]
+ENABLED = true
+MIN_REPO_SIZE = 1 GiB
+STORAGE_TYPE = minio
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadRepoBundleFrom(cfg))
+
+	assert.True(t, RepoBundle.Enabled)
+	assert.EqualValues(t, 1024*1024*1024, RepoBundle.MinRepoSize)
+	assert.EqualValues(t, "minio", RepoBundle.Storage.Type)
+	assert.Equal(t, "repo-bundle/", RepoBundle.Storage.MinioConfig.BasePath)
+
+	cfg, err = NewConfigProviderFromData(`
+[repo-bundle]
+MIN_REPO_SIZE = big
+`)
+	require.NoError(t, err)
+	require.Error(t, loadRepoBundleFrom(cfg))
+}
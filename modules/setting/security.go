@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"forgejo.org/modules/auth/password/hash"
 	"forgejo.org/modules/generate"
@@ -35,7 +36,11 @@ var (
 	PasswordHashAlgo                   string
 	PasswordCheckPwn                   bool
 	SuccessfulTokensCacheSize          int
+	AccessTokenMaxLifetime             time.Duration
+	AccessTokenExpiryWarning           time.Duration
 	DisableQueryAuthToken              bool
+	EnforceTwoFactorAuth               bool
+	OrgTwoFactorReminder               time.Duration
 	CSRFCookieName                     = "_csrf"
 	CSRFCookieHTTPOnly                 = true
 )
@@ -141,6 +146,10 @@ func loadSecurityFrom(rootCfg ConfigProvider) {
 	CSRFCookieHTTPOnly = sec.Key("CSRF_COOKIE_HTTP_ONLY").MustBool(true)
 	PasswordCheckPwn = sec.Key("PASSWORD_CHECK_PWN").MustBool(false)
 	SuccessfulTokensCacheSize = sec.Key("SUCCESSFUL_TOKENS_CACHE_SIZE").MustInt(20)
+	AccessTokenMaxLifetime = sec.Key("ACCESS_TOKEN_MAX_LIFETIME").MustDuration(0)
+	AccessTokenExpiryWarning = sec.Key("ACCESS_TOKEN_EXPIRY_WARNING").MustDuration(7 * 24 * time.Hour)
+	EnforceTwoFactorAuth = sec.Key("ENFORCE_TWO_FACTOR_AUTH").MustBool(false)
+	OrgTwoFactorReminder = sec.Key("ORG_TWO_FACTOR_REMINDER").MustDuration(3 * 24 * time.Hour)
 
 	InternalToken = loadSecret(sec, "INTERNAL_TOKEN_URI", "INTERNAL_TOKEN")
 	if InstallLock && InternalToken == "" {
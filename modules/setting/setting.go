@@ -151,6 +151,10 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadMirrorFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadQuotaFrom(cfg)
+	loadAuditFrom(cfg)
+	if err := loadDependencyGraphFrom(cfg); err != nil {
+		return err
+	}
 	loadOtherFrom(cfg)
 	return nil
 }
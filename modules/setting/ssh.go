@@ -50,6 +50,10 @@ var SSH = struct {
 	TrustedUserCAKeysParsed               []gossh.PublicKey  `ini:"-"`
 	PerWriteTimeout                       time.Duration      `ini:"SSH_PER_WRITE_TIMEOUT"`
 	PerWritePerKbTimeout                  time.Duration      `ini:"SSH_PER_WRITE_PER_KB_TIMEOUT"`
+	UserCAEnabled                         bool               `ini:"SSH_USER_CA_ENABLED"`
+	UserCAKey                             string             `ini:"SSH_USER_CA_KEY"`
+	UserCertificateTTL                    time.Duration      `ini:"-"`
+	UserCertificateMaxTTL                 time.Duration      `ini:"-"`
 }{
 	Disabled:                      false,
 	StartBuiltinServer:            false,
@@ -65,6 +69,7 @@ var SSH = struct {
 	AuthorizedKeysCommandTemplate: "{{.AppPath}} --config={{.CustomConf}} serv key-{{.Key.ID}}",
 	PerWriteTimeout:               PerWriteTimeout,
 	PerWritePerKbTimeout:          PerWritePerKbTimeout,
+	UserCAKey:                     "ssh/forgejo-user-ca",
 }
 
 func parseAuthorizedPrincipalsAllow(values []string) ([]string, bool) {
@@ -133,6 +138,9 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 			SSH.ServerHostKeys[i] = filepath.Join(AppDataPath, key)
 		}
 	}
+	if !filepath.IsAbs(SSH.UserCAKey) {
+		SSH.UserCAKey = filepath.Join(AppDataPath, SSH.UserCAKey)
+	}
 
 	SSH.KeygenPath = sec.Key("SSH_KEYGEN_PATH").String()
 	SSH.Port = sec.Key("SSH_PORT").MustInt(22)
@@ -154,7 +162,17 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 
 		SSH.TrustedUserCAKeysParsed = append(SSH.TrustedUserCAKeysParsed, pubKey)
 	}
-	if len(SSH.TrustedUserCAKeys) > 0 {
+	// When disable SSH, the certificate authority of Forgejo is disabled as well.
+	if SSH.Disabled {
+		SSH.UserCAEnabled = false
+	}
+	SSH.UserCertificateTTL = sec.Key("SSH_USER_CERTIFICATE_TTL").MustDuration(16 * time.Hour)
+	SSH.UserCertificateMaxTTL = sec.Key("SSH_USER_CERTIFICATE_MAX_TTL").MustDuration(24 * time.Hour)
+	if SSH.UserCertificateTTL > SSH.UserCertificateMaxTTL {
+		SSH.UserCertificateTTL = SSH.UserCertificateMaxTTL
+	}
+
+	if len(SSH.TrustedUserCAKeys) > 0 || SSH.UserCAEnabled {
 		// Set the default as email,username otherwise we can leave it empty
 		sec.Key("SSH_AUTHORIZED_PRINCIPALS_ALLOW").MustString("username,email")
 	} else {
@@ -0,0 +1,102 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ssh
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var userCASigner gossh.Signer
+
+// ErrUserCADisabled is returned when a user certificate is requested while the certificate authority is disabled
+var ErrUserCADisabled = errors.New("the SSH certificate authority is disabled")
+
+// initUserCA loads the private key of the certificate authority, generating it if missing,
+// and trusts its public key to authenticate the users
+func initUserCA() error {
+	keyPath := setting.SSH.UserCAKey
+	exist, err := util.IsExist(keyPath)
+	if err != nil {
+		return fmt.Errorf("check if %s exists: %w", keyPath, err)
+	}
+	if !exist {
+		if err := os.MkdirAll(filepath.Dir(keyPath), os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create directory %q for the ssh certificate authority: %w", filepath.Dir(keyPath), err)
+		}
+		public, private, err := util.GenerateSSHKeypair()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(keyPath, private, 0o600); err != nil {
+			return err
+		}
+		if err := os.WriteFile(keyPath+".pub", public, 0o644); err != nil {
+			return err
+		}
+		log.Info("SSH certificate authority key generated in %s", keyPath)
+	}
+
+	private, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+	signer, err := gossh.ParsePrivateKey(private)
+	if err != nil {
+		return fmt.Errorf("failed to parse the ssh certificate authority key %s: %w", keyPath, err)
+	}
+	userCASigner = signer
+
+	setting.SSH.TrustedUserCAKeys = append(setting.SSH.TrustedUserCAKeys, strings.TrimSpace(string(gossh.MarshalAuthorizedKey(signer.PublicKey()))))
+	setting.SSH.TrustedUserCAKeysParsed = append(setting.SSH.TrustedUserCAKeysParsed, signer.PublicKey())
+	return nil
+}
+
+// UserCAPublicKey returns the public key of the certificate authority in the authorized keys format
+func UserCAPublicKey() string {
+	if userCASigner == nil {
+		return ""
+	}
+	return strings.TrimSpace(string(gossh.MarshalAuthorizedKey(userCASigner.PublicKey())))
+}
+
+// SignUserCertificate signs a user certificate for the public key, valid for the principals during the ttl
+func SignUserCertificate(pub gossh.PublicKey, keyID string, principals []string, ttl time.Duration) (*gossh.Certificate, error) {
+	if userCASigner == nil {
+		return nil, ErrUserCADisabled
+	}
+
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cert := &gossh.Certificate{
+		Key:             pub,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		CertType:        gossh.UserCert,
+		KeyId:           keyID,
+		ValidPrincipals: principals,
+		// allow a little clock skew between the server and the clients
+		ValidAfter:  uint64(now.Add(-time.Minute).Unix()),
+		ValidBefore: uint64(now.Add(ttl).Unix()),
+	}
+	if err := cert.SignCert(rand.Reader, userCASigner); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestUserCA(t *testing.T) {
+	defer test.MockVariableValue(&setting.SSH.UserCAKey, filepath.Join(t.TempDir(), "ssh", "user-ca"))()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeys, nil)()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeysParsed, nil)()
+	defer test.MockVariableValue(&userCASigner, nil)()
+
+	_, err := SignUserCertificate(nil, "", nil, time.Hour)
+	require.ErrorIs(t, err, ErrUserCADisabled)
+
+	require.NoError(t, initUserCA())
+	assert.FileExists(t, setting.SSH.UserCAKey)
+	assert.FileExists(t, setting.SSH.UserCAKey+".pub")
+	assert.Equal(t, []string{UserCAPublicKey()}, setting.SSH.TrustedUserCAKeys)
+
+	// the key is loaded again rather than generated
+	caPublicKey := UserCAPublicKey()
+	setting.SSH.TrustedUserCAKeys = nil
+	require.NoError(t, initUserCA())
+	assert.Equal(t, caPublicKey, UserCAPublicKey())
+
+	public, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	pub, err := gossh.NewPublicKey(public)
+	require.NoError(t, err)
+
+	cert, err := SignUserCertificate(pub, "forgejo:user2", []string{"user2"}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(gossh.UserCert), cert.CertType)
+	assert.Equal(t, []string{"user2"}, cert.ValidPrincipals)
+
+	checker := &gossh.CertChecker{
+		IsUserAuthority: func(auth gossh.PublicKey) bool {
+			return string(auth.Marshal()) == string(setting.SSH.TrustedUserCAKeysParsed[0].Marshal())
+		},
+	}
+	require.NoError(t, checker.CheckCert("user2", cert))
+	require.Error(t, checker.CheckCert("user5", cert))
+
+	checker.Clock = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	require.Error(t, checker.CheckCert("user2", cert), "the certificate expired")
+}
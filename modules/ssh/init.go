@@ -21,6 +21,12 @@ func Init() error {
 		return nil
 	}
 
+	if setting.SSH.UserCAEnabled {
+		if err := initUserCA(); err != nil {
+			return fmt.Errorf("failed to initialize the ssh certificate authority: %w", err)
+		}
+	}
+
 	if setting.SSH.StartBuiltinServer {
 		Listen(setting.SSH.ListenHost, setting.SSH.ListenPort, setting.SSH.ServerCiphers, setting.SSH.ServerKeyExchanges, setting.SSH.ServerMACs)
 		log.Info("SSH server started on %s. Cipher list (%v), key exchange algorithms (%v), MACs (%v)",
@@ -83,6 +83,7 @@ func sessionHandler(session ssh.Session) {
 		"SSH_ORIGINAL_COMMAND="+command,
 		"SKIP_MINWINSVC=1",
 		"GIT_PROTOCOL="+gitProtocol,
+		"SSH_CONNECTION="+sshConnection(session),
 	)
 
 	stdout, err := cmd.StdoutPipe()
@@ -386,3 +387,17 @@ func GenKeyPair(keyPath string) error {
 	_, err = p.Write(public)
 	return err
 }
+
+// sshConnection formats the addresses of the session like OpenSSH does for SSH_CONNECTION,
+// the serv command reports the client address to the internal API from it
+func sshConnection(session ssh.Session) string {
+	clientIP, clientPort, err := net.SplitHostPort(session.RemoteAddr().String())
+	if err != nil {
+		return ""
+	}
+	serverIP, serverPort, err := net.SplitHostPort(session.LocalAddr().String())
+	if err != nil {
+		return ""
+	}
+	return strings.Join([]string{clientIP, clientPort, serverIP, serverPort}, " ")
+}
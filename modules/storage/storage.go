@@ -103,6 +103,9 @@ var (
 	// RepoArchives represents repository archives storage
 	RepoArchives ObjectStorage = UninitializedStorage
 
+	// RepoBundles represents the storage of the bundles of whole repositories
+	RepoBundles ObjectStorage = UninitializedStorage
+
 	// Packages represents packages storage
 	Packages ObjectStorage = UninitializedStorage
 
@@ -120,6 +123,7 @@ func Init() error {
 		initRepoAvatars,
 		initLFS,
 		initRepoArchives,
+		initRepoBundles,
 		initPackages,
 		initActions,
 	} {
@@ -181,6 +185,16 @@ func initRepoArchives() (err error) {
 	return err
 }
 
+func initRepoBundles() (err error) {
+	if !setting.RepoBundle.Enabled {
+		RepoBundles = DiscardStorage("RepoBundle isn't enabled")
+		return nil
+	}
+	log.Info("Initialising Repository Bundle storage with type: %s", setting.RepoBundle.Storage.Type)
+	RepoBundles, err = NewStorage(setting.RepoBundle.Storage.Type, setting.RepoBundle.Storage)
+	return err
+}
+
 func initPackages() (err error) {
 	if !setting.Packages.Enabled {
 		Packages = DiscardStorage("Packages isn't enabled")
@@ -119,6 +119,7 @@ var (
 	_ Payloader = &RepositoryPayload{}
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
+	_ Payloader = &SecretScanningAlertPayload{}
 )
 
 // _________                        __
@@ -484,3 +485,46 @@ type PackagePayload struct {
 func (p *PackagePayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
+
+// HookSecretScanningAlertAction an action that happens to a secret scanning alert
+type HookSecretScanningAlertAction string
+
+const (
+	// HookSecretScanningAlertCreated created
+	HookSecretScanningAlertCreated HookSecretScanningAlertAction = "created"
+	// HookSecretScanningAlertResolved resolved
+	HookSecretScanningAlertResolved HookSecretScanningAlertAction = "resolved"
+	// HookSecretScanningAlertReopened reopened
+	HookSecretScanningAlertReopened HookSecretScanningAlertAction = "reopened"
+)
+
+// SecretScanningAlert represents a secret found in a repository
+type SecretScanningAlert struct {
+	ID           int64  `json:"id"`
+	PatternID    string `json:"pattern_id"`
+	PatternName  string `json:"pattern_name"`
+	MaskedSecret string `json:"masked_secret"`
+	CommitID     string `json:"commit_id"`
+	Path         string `json:"path"`
+	Line         int    `json:"line"`
+	// enum: open,resolved
+	State        string `json:"state"`
+	Resolution   string `json:"resolution"`
+	BypassReason string `json:"bypass_reason"`
+	HTMLURL      string `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// SecretScanningAlertPayload represents a payload information of secret scanning alert event
+type SecretScanningAlertPayload struct {
+	Action     HookSecretScanningAlertAction `json:"action"`
+	Alert      *SecretScanningAlert          `json:"alert"`
+	Repository *Repository                   `json:"repository"`
+	Sender     *User                         `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *SecretScanningAlertPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
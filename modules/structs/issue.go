@@ -66,7 +66,9 @@ type Issue struct {
 	// enum: ["open", "closed"]
 	State    StateType `json:"state"`
 	IsLocked bool      `json:"is_locked"`
-	Comments int       `json:"comments"`
+	// Whether the issue can only be read by its poster and by the users who can write the issues
+	IsConfidential bool `json:"is_confidential"`
+	Comments       int  `json:"comments"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -115,6 +117,8 @@ type EditIssueOption struct {
 	RemoveDeadline *bool      `json:"unset_due_date"`
 	// swagger:strfmt date-time
 	Updated *time.Time `json:"updated_at"`
+	// Whether the issue can only be read by its poster and by the users who can write the issues
+	IsConfidential *bool `json:"is_confidential"`
 }
 
 // EditDeadlineOption options for creating a deadline
@@ -124,6 +128,16 @@ type EditDeadlineOption struct {
 	Deadline *time.Time `json:"due_date"`
 }
 
+// TransferIssueOption options for transferring an issue to another repository
+type TransferIssueOption struct {
+	// owner of the repository the issue is transferred to
+	// required: true
+	NewOwner string `json:"new_owner" binding:"Required"`
+	// name of the repository the issue is transferred to
+	// required: true
+	NewRepo string `json:"new_repo" binding:"Required"`
+}
+
 // IssueDeadline represents an issue deadline
 // swagger:model
 type IssueDeadline struct {
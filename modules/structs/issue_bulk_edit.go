@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// BulkEditIssuesOption options to edit all the issues and pull requests matching a search query
+type BulkEditIssuesOption struct {
+	// search query selecting the issues, it supports the same qualifiers as the issue search
+	// required: true
+	Query string `json:"query" binding:"Required"`
+	// only edit issues in the repositories of this owner
+	Owner string `json:"owner"`
+	// names of the labels to add
+	AddLabels []string `json:"add_labels"`
+	// names of the labels to remove
+	RemoveLabels []string `json:"remove_labels"`
+	// usernames of the users to assign
+	AddAssignees []string `json:"add_assignees"`
+	// usernames of the users to unassign
+	RemoveAssignees []string `json:"remove_assignees"`
+	// title of the milestone to set, an empty string removes the milestone
+	Milestone *string `json:"milestone"`
+	// title of the project to set, an empty string removes the project
+	Project *string `json:"project"`
+	// enum: open,closed
+	State *StateType `json:"state"`
+	// full name of the repository to move the issues to, after the other changes
+	MoveTo string `json:"move_to"`
+}
+
+// BulkEditIssuesTask represents the progress and the report of a bulk edit of issues
+type BulkEditIssuesTask struct {
+	ID int64 `json:"id"`
+	// enum: queued,running,stopped,failed,finished
+	Status string `json:"status"`
+	// reason why the whole task failed
+	Message string `json:"message,omitempty"`
+	Query   string `json:"query"`
+	// number of issues matching the query
+	Total int `json:"total"`
+	// number of issues which have been processed so far
+	Processed int `json:"processed"`
+	// number of issues which could not be edited
+	Failed  int                    `json:"failed"`
+	Results []*BulkEditIssueResult `json:"results"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Started *time.Time `json:"started_at"`
+	// swagger:strfmt date-time
+	Finished *time.Time `json:"finished_at"`
+}
+
+// BulkEditIssueResult is the outcome of a bulk edit for a single issue
+type BulkEditIssueResult struct {
+	IssueID int64  `json:"issue_id"`
+	Repo    string `json:"repository"`
+	Index   int64  `json:"number"`
+	// empty if the issue was edited successfully
+	Error string `json:"error,omitempty"`
+}
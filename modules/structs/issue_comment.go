@@ -18,6 +18,8 @@ type Comment struct {
 	OriginalAuthorID int64         `json:"original_author_id"`
 	Body             string        `json:"body"`
 	Attachments      []*Attachment `json:"assets"`
+	// Whether the comment can only be read by the users who can write the issues, it is never mailed
+	IsInternal bool `json:"is_internal"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -30,6 +32,8 @@ type CreateIssueCommentOption struct {
 	Body string `json:"body" binding:"Required"`
 	// swagger:strfmt date-time
 	Updated *time.Time `json:"updated_at"`
+	// Whether the comment can only be read by the users who can write the issues, it is never mailed
+	IsInternal bool `json:"is_internal"`
 }
 
 // EditIssueCommentOption options for editing a comment
@@ -50,6 +54,8 @@ type TimelineComment struct {
 	IssueURL string `json:"issue_url"`
 	Poster   *User  `json:"user"`
 	Body     string `json:"body"`
+	// Whether the comment can only be read by the users who can write the issues
+	IsInternal bool `json:"is_internal"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
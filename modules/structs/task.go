@@ -6,13 +6,18 @@ package structs
 // TaskType defines task type
 type TaskType int
 
-const TaskTypeMigrateRepo TaskType = iota // migrate repository from external or local disk
+const (
+	TaskTypeMigrateRepo    TaskType = iota // migrate repository from external or local disk
+	TaskTypeBulkEditIssues                 // edit the issues matching a search query
+)
 
 // Name returns the task type name
 func (taskType TaskType) Name() string {
 	switch taskType {
 	case TaskTypeMigrateRepo:
 		return "Migrate Repository"
+	case TaskTypeBulkEditIssues:
+		return "Bulk Edit Issues"
 	default:
 		return ""
 	}
@@ -29,3 +34,21 @@ const (
 	TaskStatusFailed                     // 3 task is failed
 	TaskStatusFinished                   // 4 task is finished
 )
+
+// Name returns the task status name
+func (status TaskStatus) Name() string {
+	switch status {
+	case TaskStatusQueued:
+		return "queued"
+	case TaskStatusRunning:
+		return "running"
+	case TaskStatusStopped:
+		return "stopped"
+	case TaskStatusFailed:
+		return "failed"
+	case TaskStatusFinished:
+		return "finished"
+	default:
+		return ""
+	}
+}
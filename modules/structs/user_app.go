@@ -16,6 +16,20 @@ type AccessToken struct {
 	Token          string   `json:"sha1"`
 	TokenLastEight string   `json:"token_last_eight"`
 	Scopes         []string `json:"scopes"`
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Resources the token is restricted to, it can access everything its scopes allow when empty
+	Resources []*AccessTokenResource `json:"resources"`
+}
+
+// AccessTokenResource represents a repository or an organization an access token is restricted to
+type AccessTokenResource struct {
+	// Repository full name, as owner/name
+	Repository string `json:"repository,omitempty"`
+	// Organization name, the token can access all its repositories
+	Organization string `json:"organization,omitempty"`
+	// enum: read,write
+	Permission string `json:"permission"`
 }
 
 // AccessTokenList represents a list of API access token.
@@ -29,13 +43,20 @@ type CreateAccessTokenOption struct {
 	Name string `json:"name" binding:"Required"`
 	// example: ["all", "read:activitypub","read:issue", "write:misc", "read:notification", "read:organization", "read:package", "read:repository", "read:user"]
 	Scopes []string `json:"scopes"`
+	// Expiration date of the token, mandatory when the administrator limits the lifetime of tokens
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Restrict the token to a set of repositories or to one organization
+	Resources []*AccessTokenResource `json:"resources"`
 }
 
 // CreateOAuth2ApplicationOptions holds options to create an oauth2 application
 type CreateOAuth2ApplicationOptions struct {
-	Name               string   `json:"name" binding:"Required"`
-	ConfidentialClient bool     `json:"confidential_client"`
-	RedirectURIs       []string `json:"redirect_uris" binding:"Required"`
+	Name               string `json:"name" binding:"Required"`
+	ConfidentialClient bool   `json:"confidential_client"`
+	// Allow the application to be authorized with the device authorization grant of RFC 8628
+	AllowDeviceGrant bool     `json:"allow_device_grant"`
+	RedirectURIs     []string `json:"redirect_uris" binding:"Required"`
 }
 
 // OAuth2Application represents an OAuth2 application.
@@ -46,6 +67,7 @@ type OAuth2Application struct {
 	ClientID           string    `json:"client_id"`
 	ClientSecret       string    `json:"client_secret"`
 	ConfidentialClient bool      `json:"confidential_client"`
+	AllowDeviceGrant   bool      `json:"allow_device_grant"`
 	RedirectURIs       []string  `json:"redirect_uris"`
 	Created            time.Time `json:"created"`
 }
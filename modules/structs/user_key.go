@@ -20,3 +20,26 @@ type PublicKey struct {
 	ReadOnly bool      `json:"read_only,omitempty"`
 	KeyType  string    `json:"key_type,omitempty"`
 }
+
+// CreateSSHCertificateOption options when requesting an SSH user certificate
+type CreateSSHCertificateOption struct {
+	// The SSH public key to certify, in the authorized keys format
+	//
+	// required: true
+	Key string `json:"key" binding:"Required"`
+	// Validity of the certificate in seconds, the default validity of the instance if zero
+	ValiditySeconds int64 `json:"validity_seconds"`
+}
+
+// SSHCertificate is a short-lived SSH user certificate signed by the certificate authority of the instance
+type SSHCertificate struct {
+	// The certificate in the authorized keys format, to save next to the private key as <key>-cert.pub
+	Certificate string   `json:"certificate"`
+	KeyID       string   `json:"key_id"`
+	Serial      uint64   `json:"serial"`
+	Principals  []string `json:"principals"`
+	// swagger:strfmt date-time
+	ValidAfter time.Time `json:"valid_after"`
+	// swagger:strfmt date-time
+	ValidBefore time.Time `json:"valid_before"`
+}
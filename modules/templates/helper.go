@@ -149,6 +149,12 @@ func NewFuncMap() template.FuncMap {
 		"DisableWebhooks": func() bool {
 			return setting.DisableWebhooks
 		},
+		"EnableAudit": func() bool {
+			return setting.Audit.Enabled
+		},
+		"EnableDependencyGraph": func() bool {
+			return setting.DependencyGraph.Enabled
+		},
 		"DisableImportLocal": func() bool {
 			return !setting.ImportLocalPaths
 		},
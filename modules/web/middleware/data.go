@@ -30,6 +30,9 @@ func (ds ContextData) MergeFrom(other ContextData) ContextData {
 
 const ContextDataKeySignedUser = "SignedUser"
 
+// ContextDataKeyRemoteAddr is the address of the client of the request, once the reverse proxy headers are applied
+const ContextDataKeyRemoteAddr = "RemoteAddr"
+
 type contextDataKeyType struct{}
 
 var contextDataKey contextDataKeyType
@@ -0,0 +1,123 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/actions"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/web"
+	actions_service "forgejo.org/services/actions"
+	"forgejo.org/services/auth/source/oauth2"
+	"forgejo.org/services/context"
+)
+
+// idTokenResponse is the response of an ID token request, the same as the one of GitHub Actions
+// so that the existing tooling relying on ACTIONS_ID_TOKEN_REQUEST_URL works unmodified
+type idTokenResponse struct {
+	Value string `json:"value"`
+}
+
+// openIDConfiguration is the OpenID Connect discovery document of the ID tokens issued to jobs
+type openIDConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                  []string `json:"scopes_supported"`
+}
+
+func idTokenRoutes(m *web.Route) {
+	m.Get("/.well-known/openid-configuration", idTokenOpenIDConfiguration)
+	m.Get("/.well-known/jwks", idTokenKeys)
+	m.Get("/idtoken", idTokenRequest)
+}
+
+func idTokenOpenIDConfiguration(resp http.ResponseWriter, req *http.Request) {
+	ctx, cleanUp := context.NewBaseContext(resp, req)
+	defer cleanUp()
+
+	if !actions_service.IDTokenEnabled() {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
+	issuer := actions_service.IDTokenIssuer()
+	ctx.JSON(http.StatusOK, &openIDConfiguration{
+		Issuer:                 issuer,
+		JWKSURI:                issuer + "/.well-known/jwks",
+		SubjectTypesSupported:  []string{"public"},
+		ResponseTypesSupported: []string{"id_token"},
+		ClaimsSupported: []string{
+			"sub", "aud", "exp", "iat", "iss", "jti", "nbf",
+			"ref", "ref_type", "sha", "repository", "repository_id", "repository_owner", "repository_owner_id",
+			"workflow", "job", "run_id", "run_number", "run_attempt", "event_name", "base_ref", "head_ref",
+			"actor", "actor_id", "environment",
+		},
+		IDTokenSigningAlgValuesSupported: []string{oauth2.DefaultSigningKey.SigningMethod().Alg()},
+		ScopesSupported:                  []string{"openid"},
+	})
+}
+
+func idTokenKeys(resp http.ResponseWriter, req *http.Request) {
+	ctx, cleanUp := context.NewBaseContext(resp, req)
+	defer cleanUp()
+
+	if !actions_service.IDTokenEnabled() {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
+	jwk, err := oauth2.DefaultSigningKey.ToJWK()
+	if err != nil {
+		log.Error("Error converting signing key to JWK: %v", err)
+		ctx.Error(http.StatusInternalServerError)
+		return
+	}
+	jwk["use"] = "sig"
+	ctx.JSON(http.StatusOK, map[string][]map[string]string{"keys": {jwk}})
+}
+
+// idTokenRequest issues an ID token to the job authenticated by its ID token request token,
+// for the audience given by the `audience` query parameter
+func idTokenRequest(resp http.ResponseWriter, req *http.Request) {
+	ctx, cleanUp := context.NewBaseContext(resp, req)
+	defer cleanUp()
+
+	if !actions_service.IDTokenEnabled() {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
+
+	authType, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(authType, "Bearer") {
+		ctx.Error(http.StatusUnauthorized, "Bad authorization header")
+		return
+	}
+	taskID, err := actions_service.IDTokenRequestTokenToTaskID(token)
+	if err != nil {
+		ctx.Error(http.StatusUnauthorized, "Invalid token")
+		return
+	}
+	task, err := actions.GetTaskByID(ctx, taskID)
+	if err != nil {
+		log.Error("Error getting task by ID: %v", err)
+		ctx.Error(http.StatusInternalServerError, "Error getting task by ID")
+		return
+	}
+	if task.Status != actions.StatusRunning {
+		ctx.Error(http.StatusUnauthorized, "Task is not running")
+		return
+	}
+
+	idToken, err := actions_service.CreateIDToken(ctx, task, ctx.FormTrim("audience"))
+	if err != nil {
+		log.Error("Error creating ID token for task %d: %v", task.ID, err)
+		ctx.Error(http.StatusForbidden, "Cannot issue an ID token to this job")
+		return
+	}
+	ctx.JSON(http.StatusOK, &idTokenResponse{Value: idToken})
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+func writeGroup(ctx *context.APIContext, status int, group *auth_model.ScimGroup) {
+	resource, err := scim_service.ToGroup(ctx, group, listOptions(ctx).ExcludeMembers)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", resource.Meta.Location)
+	writeJSON(ctx, status, resource)
+}
+
+// ListGroups lists the groups provisioned by the identity provider
+func ListGroups(ctx *context.APIContext) {
+	resp, err := scim_service.ListGroups(ctx, getSource(ctx), listOptions(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// CreateGroup provisions a new group
+func CreateGroup(ctx *context.APIContext) {
+	resource := &scim_service.Group{}
+	if !decodeBody(ctx, resource) {
+		return
+	}
+	group, err := scim_service.CreateGroup(ctx, getSource(ctx), resource)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeGroup(ctx, http.StatusCreated, group)
+}
+
+// GetGroup returns a provisioned group
+func GetGroup(ctx *context.APIContext) {
+	group := groupByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	writeGroup(ctx, http.StatusOK, group)
+}
+
+// ReplaceGroup replaces the name and the members of a provisioned group
+func ReplaceGroup(ctx *context.APIContext) {
+	group := groupByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	resource := &scim_service.Group{}
+	if !decodeBody(ctx, resource) {
+		return
+	}
+	if err := scim_service.ReplaceGroup(ctx, getSource(ctx), group, resource); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeGroup(ctx, http.StatusOK, group)
+}
+
+// PatchGroup modifies the name or the members of a provisioned group
+func PatchGroup(ctx *context.APIContext) {
+	group := groupByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	patch := &scim_service.PatchRequest{}
+	if !decodeBody(ctx, patch) {
+		return
+	}
+	if err := scim_service.PatchGroup(ctx, getSource(ctx), group, patch); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeGroup(ctx, http.StatusOK, group)
+}
+
+// DeleteGroup deletes a provisioned group
+func DeleteGroup(ctx *context.APIContext) {
+	group := groupByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := scim_service.DeleteGroup(ctx, getSource(ctx), group); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scim serves the SCIM 2.0 provisioning API used by identity providers
+// to create, update and remove the users and groups of an authentication source.
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models"
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/validation"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+const sourceDataKey = "ScimSource"
+
+// Routes returns the routes of the SCIM API, mounted at /scim/v2
+func Routes() *web.Route {
+	m := web.NewRoute()
+
+	m.Use(context.APIContexter())
+	m.Use(authenticate)
+
+	m.Get("/ServiceProviderConfig", ServiceProviderConfig)
+	m.Get("/ResourceTypes", ResourceTypes)
+	m.Group("/Users", func() {
+		m.Get("", ListUsers)
+		m.Post("", CreateUser)
+		m.Combo("/{id}").Get(GetUser).Put(ReplaceUser).Patch(PatchUser).Delete(DeleteUser)
+	})
+	m.Group("/Groups", func() {
+		m.Get("", ListGroups)
+		m.Post("", CreateGroup)
+		m.Combo("/{id}").Get(GetGroup).Put(ReplaceGroup).Patch(PatchGroup).Delete(DeleteGroup)
+	})
+	m.NotFound(func(w http.ResponseWriter, req *http.Request) {
+		writeResponse(w, http.StatusNotFound, newError(http.StatusNotFound, "", "not found"))
+	})
+	return m
+}
+
+// authenticate loads the authentication source of the bearer token of the request
+func authenticate(ctx *context.APIContext) {
+	scheme, token, _ := strings.Cut(ctx.Req.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		writeError(ctx, http.StatusUnauthorized, "", "a bearer token is required")
+		return
+	}
+
+	t, err := auth_model.GetScimToken(ctx, strings.TrimSpace(token))
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetScimToken: %v", err)
+		}
+		writeError(ctx, http.StatusUnauthorized, "", "invalid token")
+		return
+	}
+	source, err := auth_model.GetSourceByID(ctx, t.SourceID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if !source.IsActive {
+		writeError(ctx, http.StatusForbidden, "", "the authentication source is not active")
+		return
+	}
+	if err := auth_model.UpdateScimTokenLastUsed(ctx, t); err != nil {
+		log.Error("UpdateScimTokenLastUsed: %v", err)
+	}
+	ctx.Data[sourceDataKey] = source
+}
+
+func getSource(ctx *context.APIContext) *auth_model.Source {
+	return ctx.Data[sourceDataKey].(*auth_model.Source)
+}
+
+func newError(status int, scimType, detail string) *scim_service.Error {
+	return &scim_service.Error{
+		Schemas:  []string{scim_service.ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+func writeResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", scim_service.ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Encode SCIM response: %v", err)
+	}
+}
+
+func writeJSON(ctx *context.APIContext, status int, v any) {
+	writeResponse(ctx.Resp, status, v)
+}
+
+func writeError(ctx *context.APIContext, status int, scimType, detail string) {
+	writeJSON(ctx, status, newError(status, scimType, detail))
+}
+
+// handleError maps an error of the SCIM service to an error response
+func handleError(ctx *context.APIContext, err error) {
+	switch {
+	case scim_service.IsErrInvalidFilter(err):
+		writeError(ctx, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, util.ErrInvalidArgument), validation.IsErrEmailCharIsNotSupported(err):
+		writeError(ctx, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, util.ErrAlreadyExist):
+		writeError(ctx, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, util.ErrNotExist):
+		writeError(ctx, http.StatusNotFound, "", err.Error())
+	case models.IsErrUserOwnRepos(err), models.IsErrUserHasOrgs(err),
+		models.IsErrUserOwnPackages(err), models.IsErrDeleteLastAdminUser(err):
+		writeError(ctx, http.StatusConflict, "", err.Error())
+	default:
+		log.Error("SCIM request %s %s failed: %v", ctx.Req.Method, ctx.Req.URL.Path, err)
+		writeError(ctx, http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+// decodeBody decodes the JSON body of the request, writing an error response on failure
+func decodeBody(ctx *context.APIContext, v any) bool {
+	if err := json.NewDecoder(ctx.Req.Body).Decode(v); err != nil {
+		writeError(ctx, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return false
+	}
+	return true
+}
+
+func listOptions(ctx *context.APIContext) scim_service.ListOptions {
+	opts := scim_service.ListOptions{
+		Filter:     ctx.FormString("filter"),
+		StartIndex: ctx.FormInt("startIndex"),
+		Count:      ctx.FormInt("count"),
+	}
+	for _, attribute := range strings.Split(ctx.FormString("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attribute), "members") {
+			opts.ExcludeMembers = true
+		}
+	}
+	return opts
+}
+
+// ServiceProviderConfig describes the features of the SCIM API supported by this server
+func ServiceProviderConfig(ctx *context.APIContext) {
+	supported := func(b bool) map[string]any { return map[string]any{"supported": b} }
+	writeJSON(ctx, http.StatusOK, map[string]any{
+		"schemas":          []string{scim_service.ServiceProviderConfigSchema},
+		"documentationUri": "https://www.rfc-editor.org/rfc/rfc7644",
+		"patch":            supported(true),
+		"bulk":             map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]any{"supported": true, "maxResults": scim_service.MaxResults},
+		"changePassword":   supported(false),
+		"sort":             supported(false),
+		"etag":             supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Token generated on the authentication source page of the site administration",
+			"primary":     true,
+		}},
+		"meta": map[string]any{
+			"resourceType": "ServiceProviderConfig",
+			"location":     scim_service.BaseURL() + "/ServiceProviderConfig",
+		},
+	})
+}
+
+// ResourceTypes lists the resource types served by the SCIM API
+func ResourceTypes(ctx *context.APIContext) {
+	resourceType := func(name, endpoint, schema string) map[string]any {
+		return map[string]any{
+			"schemas":  []string{scim_service.ResourceTypeSchema},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+			"meta": map[string]any{
+				"resourceType": "ResourceType",
+				"location":     scim_service.BaseURL() + "/ResourceTypes/" + name,
+			},
+		}
+	}
+	writeJSON(ctx, http.StatusOK, &scim_service.ListResponse{
+		Schemas:      []string{scim_service.ListResponseSchema},
+		TotalResults: 2,
+		StartIndex:   1,
+		ItemsPerPage: 2,
+		Resources: []any{
+			resourceType("User", "/Users", scim_service.UserSchema),
+			resourceType("Group", "/Groups", scim_service.GroupSchema),
+		},
+	})
+}
+
+// userByID loads the user of the path, writing an error response on failure
+func userByID(ctx *context.APIContext) *user_model.User {
+	u, err := scim_service.GetUser(ctx, getSource(ctx), ctx.Params(":id"))
+	if err != nil {
+		handleError(ctx, err)
+		return nil
+	}
+	return u
+}
+
+// groupByID loads the group of the path, writing an error response on failure
+func groupByID(ctx *context.APIContext) *auth_model.ScimGroup {
+	group, err := scim_service.GetGroup(ctx, getSource(ctx), ctx.Params(":id"))
+	if err != nil {
+		handleError(ctx, err)
+		return nil
+	}
+	return group
+}
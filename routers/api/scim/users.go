@@ -0,0 +1,102 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	user_model "forgejo.org/models/user"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+func writeUser(ctx *context.APIContext, status int, u *user_model.User) {
+	resource, err := scim_service.ToUser(ctx, getSource(ctx), u)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", resource.Meta.Location)
+	writeJSON(ctx, status, resource)
+}
+
+// ListUsers lists the users provisioned by the identity provider
+func ListUsers(ctx *context.APIContext) {
+	resp, err := scim_service.ListUsers(ctx, getSource(ctx), listOptions(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// CreateUser provisions a new user
+func CreateUser(ctx *context.APIContext) {
+	resource := &scim_service.User{}
+	if !decodeBody(ctx, resource) {
+		return
+	}
+	u, err := scim_service.CreateUser(ctx, getSource(ctx), resource)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeUser(ctx, http.StatusCreated, u)
+}
+
+// GetUser returns a provisioned user
+func GetUser(ctx *context.APIContext) {
+	u := userByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	writeUser(ctx, http.StatusOK, u)
+}
+
+// ReplaceUser replaces the attributes of a provisioned user
+func ReplaceUser(ctx *context.APIContext) {
+	u := userByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	resource := &scim_service.User{}
+	if !decodeBody(ctx, resource) {
+		return
+	}
+	if err := scim_service.ReplaceUser(ctx, getSource(ctx), u, resource); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeUser(ctx, http.StatusOK, u)
+}
+
+// PatchUser modifies the attributes of a provisioned user, it is how most identity providers deactivate users
+func PatchUser(ctx *context.APIContext) {
+	u := userByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	patch := &scim_service.PatchRequest{}
+	if !decodeBody(ctx, patch) {
+		return
+	}
+	if err := scim_service.PatchUser(ctx, getSource(ctx), u, patch); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	writeUser(ctx, http.StatusOK, u)
+}
+
+// DeleteUser deletes a provisioned user
+func DeleteUser(ctx *context.APIContext) {
+	u := userByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := scim_service.DeleteUser(ctx, getSource(ctx), u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
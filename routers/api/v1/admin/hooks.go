@@ -7,12 +7,14 @@ import (
 	"errors"
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/webhook"
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	webhook_service "forgejo.org/services/webhook"
 )
@@ -164,7 +166,11 @@ func DeleteHook(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 
 	hookID := ctx.ParamsInt64(":id")
-	if err := webhook.DeleteDefaultSystemWebhook(ctx, hookID); err != nil {
+	w, err := webhook.GetSystemOrDefaultWebhook(ctx, hookID)
+	if err == nil {
+		err = webhook.DeleteDefaultSystemWebhook(ctx, w.ID)
+	}
+	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			ctx.NotFound()
 		} else {
@@ -172,5 +178,6 @@ func DeleteHook(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookSnapshot(w))
 	ctx.Status(http.StatusNoContent)
 }
@@ -168,6 +168,9 @@ func repoAssignment() func(ctx *context.APIContext) {
 		}
 		ctx.Repo.Owner = owner
 		ctx.ContextUser = owner
+		if !ctx.CheckOrgAccess(owner) {
+			return
+		}
 
 		// Get repository.
 		repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, repoName)
@@ -223,6 +226,7 @@ func repoAssignment() func(ctx *context.APIContext) {
 				ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
 				return
 			}
+			context.LimitRepoPermissionToToken(ctx.Data, repo, &ctx.Repo.Permission)
 		}
 
 		if !ctx.Repo.HasAccess() {
@@ -254,7 +258,8 @@ func commentAssignment(idParam string) func(ctx *context.APIContext) {
 			return
 		}
 
-		if !ctx.Repo.CanReadIssuesOrPulls(comment.Issue.IsPull) {
+		if !ctx.Repo.CanReadIssuesOrPulls(comment.Issue.IsPull) ||
+			!comment.Issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) || !comment.CanBeReadBy(comment.Issue, ctx.Repo.Permission) {
 			ctx.NotFound()
 			return
 		}
@@ -265,6 +270,23 @@ func commentAssignment(idParam string) func(ctx *context.APIContext) {
 	}
 }
 
+// reqIssueReader hides the confidential issues from the users who can't read them,
+// the handlers respond to the issues which don't exist
+func reqIssueReader() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+		if err != nil {
+			if !issues_model.IsErrIssueNotExist(err) {
+				ctx.InternalServerError(err)
+			}
+			return
+		}
+		if !issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
+			ctx.NotFound()
+		}
+	}
+}
+
 func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
 		if ctx.Package.AccessMode < accessMode && !ctx.IsUserSiteAdmin() {
@@ -364,6 +386,13 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 			return
 		}
 
+		if resources, ok := ctx.Data["ApiTokenResources"].(auth_model.AccessTokenResources); ok {
+			if !tokenResourcesAllowRoute(ctx, resources, requiredScopeLevel, requiredScopeCategories) {
+				ctx.Error(http.StatusForbidden, "tokenRequiresScope", "token is restricted to other repositories or organizations")
+				return
+			}
+		}
+
 		ctx.Data["requiredScopeCategories"] = requiredScopeCategories
 
 		// check if scope only applies to public resources
@@ -378,6 +407,45 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 	}
 }
 
+// tokenResourcesAllowRoute checks that a token restricted to resources targets one of them. The routes of
+// the scope categories holding repository or organization data, or granting access to them like the admin and
+// user ones, must be those of a repository, whose permission is limited by repoAssignment, or of an owner the
+// token has enough access to.
+func tokenResourcesAllowRoute(ctx *context.APIContext, resources auth_model.AccessTokenResources, level auth_model.AccessTokenScopeLevel, categories []auth_model.AccessTokenScopeCategory) bool {
+	restricted := false
+	for _, category := range []auth_model.AccessTokenScopeCategory{
+		auth_model.AccessTokenScopeCategoryAdmin,
+		auth_model.AccessTokenScopeCategoryUser,
+		auth_model.AccessTokenScopeCategoryRepository,
+		auth_model.AccessTokenScopeCategoryIssue,
+		auth_model.AccessTokenScopeCategoryNotification,
+		auth_model.AccessTokenScopeCategoryOrganization,
+		auth_model.AccessTokenScopeCategoryPackage,
+	} {
+		restricted = restricted || auth_model.ContainsCategory(categories, category)
+	}
+	if !restricted || ctx.Params("reponame") != "" {
+		return true
+	}
+
+	ownerName := ctx.Params("org")
+	if ownerName == "" {
+		ownerName = ctx.Params("username")
+	}
+	if ownerName == "" {
+		return false
+	}
+	owner, err := user_model.GetUserByName(ctx, ownerName)
+	if err != nil {
+		return false
+	}
+	requiredMode := perm.AccessModeRead
+	if level == auth_model.Write {
+		requiredMode = perm.AccessModeWrite
+	}
+	return resources.OwnerAccessMode(owner.ID) >= requiredMode
+}
+
 // Contexter middleware already checks token for user sign in process.
 func reqToken() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -652,6 +720,9 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				return
 			}
 			ctx.ContextUser = ctx.Org.Organization.AsUser()
+			if !ctx.CheckOrgAccess(ctx.ContextUser) {
+				return
+			}
 		}
 
 		if assignTeam {
@@ -664,6 +735,16 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				}
 				return
 			}
+			if !assignOrg {
+				org, err := user_model.GetUserByID(ctx, ctx.Org.Team.OrgID)
+				if err != nil {
+					ctx.Error(http.StatusInternalServerError, "GetUserByID", err)
+					return
+				}
+				if !ctx.CheckOrgAccess(org) {
+					return
+				}
+			}
 		}
 	}
 }
@@ -998,6 +1079,7 @@ func Routes() *web.Route {
 				m.Combo("/{id}").Get(user.GetPublicKey).
 					Delete(user.DeletePublicKey)
 			})
+			m.Post("/ssh_certificate", bind(api.CreateSSHCertificateOption{}), user.CreateSSHCertificate)
 
 			// (admin:application scope)
 			m.Group("/applications", func() {
@@ -1379,6 +1461,10 @@ func Routes() *web.Route {
 		// Issue (requires issue scope)
 		m.Group("/repos", func() {
 			m.Get("/issues/search", repo.SearchIssues)
+			m.Group("/issues/bulk_edit", func() {
+				m.Post("", bind(api.BulkEditIssuesOption{}), repo.BulkEditIssues)
+				m.Get("/{id}", repo.GetBulkEditIssuesTask)
+			}, reqToken())
 
 			m.Group("/{username}/{reponame}", func() {
 				m.Group("/issues", func() {
@@ -1433,6 +1519,7 @@ func Routes() *web.Route {
 							m.Delete("/{id}", repo.DeleteTime)
 						}, reqToken())
 						m.Combo("/deadline").Post(reqToken(), bind(api.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+						m.Post("/transfer", reqToken(), mustNotBeArchived, bind(api.TransferIssueOption{}), repo.TransferIssue)
 						m.Group("/stopwatch", func() {
 							m.Post("/start", repo.StartIssueStopwatch)
 							m.Post("/stop", repo.StopIssueStopwatch)
@@ -1471,7 +1558,7 @@ func Routes() *web.Route {
 								Delete(reqToken(), reqAdmin(), repo.UnpinIssue)
 							m.Patch("/{position}", reqToken(), reqAdmin(), repo.MoveIssuePin)
 						})
-					})
+					}, reqIssueReader())
 				}, mustEnableIssuesOrPulls)
 				m.Group("/labels", func() {
 					m.Combo("").Get(repo.ListLabels).
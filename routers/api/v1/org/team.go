@@ -10,6 +10,7 @@ import (
 
 	"forgejo.org/models"
 	activities_model "forgejo.org/models/activities"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -20,6 +21,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/user"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	org_service "forgejo.org/services/org"
@@ -251,6 +253,7 @@ func CreateTeam(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamCreate, audit_service.TeamTarget(team), nil, audit_service.TeamPermissions(ctx, team))
 
 	apiTeam, err := convert.ToTeam(ctx, team, true)
 	if err != nil {
@@ -291,6 +294,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.InternalServerError(err)
 		return
 	}
+	before := audit_service.TeamPermissions(ctx, team)
 
 	if form.CanCreateOrgRepo != nil {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
@@ -338,6 +342,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "EditTeam", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamUpdate, audit_service.TeamTarget(team), before, audit_service.TeamPermissions(ctx, team))
 
 	apiTeam, err := convert.ToTeam(ctx, team)
 	if err != nil {
@@ -365,10 +370,12 @@ func DeleteTeam(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
+	before := audit_service.TeamPermissions(ctx, ctx.Org.Team)
 	if err := models.DeleteTeam(ctx, ctx.Org.Team); err != nil {
 		ctx.Error(http.StatusInternalServerError, "DeleteTeam", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), before, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -497,10 +504,11 @@ func AddTeamMember(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
-	if err := models.AddTeamMember(ctx, ctx.Org.Team, u.ID); err != nil {
+	if err := org_service.AddTeamMember(ctx, ctx.Org.Team, u); err != nil {
 		ctx.Error(http.StatusInternalServerError, "AddMember", err)
 		return
 	}
+	audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberAdd, ctx.Org.Team, u.ID)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -538,6 +546,7 @@ func RemoveTeamMember(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "RemoveTeamMember", err)
 		return
 	}
+	audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberRemove, ctx.Org.Team, u.ID)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -21,6 +21,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	pull_service "forgejo.org/services/pull"
@@ -704,6 +705,7 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.RecordBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, nil, protectBranch)
 
 	if isBranchExist {
 		if err = pull_service.CheckPRsForBaseBranch(ctx, ctx.Repo.Repository, ruleName); err != nil {
@@ -803,6 +805,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.NotFound()
 		return
 	}
+	before := audit_service.BranchProtectionSnapshot(protectBranch)
 
 	if form.EnablePush != nil {
 		if !*form.EnablePush {
@@ -979,6 +982,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.RecordBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, before, protectBranch)
 
 	isPlainRule := !git_model.IsRuleNameSpecial(bpName)
 	var isBranchExist bool
@@ -1080,6 +1084,7 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteProtectedBranch", err)
 		return
 	}
+	audit_service.RecordBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, audit_service.BranchProtectionSnapshot(bp), nil)
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -17,6 +18,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	repo_service "forgejo.org/services/repository"
@@ -193,12 +195,16 @@ func AddCollaborator(ctx *context.APIContext) {
 		return
 	}
 
+	mode := perm.AccessModeWrite
 	if form.Permission != nil {
 		if err := repo_model.ChangeCollaborationAccessMode(ctx, ctx.Repo.Repository, collaborator.ID, perm.ParseAccessMode(*form.Permission)); err != nil {
 			ctx.Error(http.StatusInternalServerError, "ChangeCollaborationAccessMode", err)
 			return
 		}
+		mode = perm.ParseAccessMode(*form.Permission)
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionCollaboratorAdd, audit_service.CollaboratorTarget(ctx.Repo.Repository, collaborator),
+		nil, map[string]string{"mode": mode.String()})
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -244,7 +250,7 @@ func DeleteCollaborator(ctx *context.APIContext) {
 		return
 	}
 
-	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, collaborator.ID); err != nil {
+	if err := repo_service.DeleteCollaboration(ctx, ctx.Doer, ctx.Repo.Repository, collaborator.ID); err != nil {
 		ctx.Error(http.StatusInternalServerError, "DeleteCollaboration", err)
 		return
 	}
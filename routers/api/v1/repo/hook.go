@@ -7,6 +7,7 @@ package repo
 import (
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -17,6 +18,7 @@ import (
 	"forgejo.org/modules/web"
 	webhook_module "forgejo.org/modules/webhook"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	webhook_service "forgejo.org/services/webhook"
@@ -296,7 +298,11 @@ func DeleteHook(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
-	if err := webhook.DeleteWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id")); err != nil {
+	w, err := webhook.GetWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err == nil {
+		err = webhook.DeleteWebhookByID(ctx, w.ID)
+	}
+	if err != nil {
 		if webhook.IsErrWebhookNotExist(err) {
 			ctx.NotFound()
 		} else {
@@ -304,5 +310,6 @@ func DeleteHook(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookSnapshot(w))
 	ctx.Status(http.StatusNoContent)
 }
@@ -24,6 +24,7 @@ import (
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -55,7 +56,7 @@ func SearchIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: Search string
+	//   description: Search string. Supports qualifiers such as `is:open`, `label:bug`, `-label:wontfix`, `author:alice`, `assignee:@me`, `milestone:"v2"`, `updated:>2026-01-01` and `repo:owner/name`
 	//   type: string
 	// - name: priority_repo_id
 	//   in: query
@@ -196,8 +197,13 @@ func SearchIssues(ctx *context.APIContext) {
 			}
 			opts.TeamID = team.ID
 		}
+		if opts.ExcludeOwnerIDs, err = ctx.IPAllowlistDeniedOrgIDs(); err != nil {
+			ctx.Error(http.StatusInternalServerError, "IPAllowlistDeniedOrgIDs", err)
+			return
+		}
 
-		if opts.AllPublic {
+		// the indexer cannot leave out the public repositories of the excluded owners
+		if opts.AllPublic && len(opts.ExcludeOwnerIDs) == 0 {
 			allPublic = true
 			opts.AllPublic = false // set it false to avoid returning too many repos, we could filter by indexer
 		}
@@ -279,6 +285,13 @@ func SearchIssues(ctx *context.APIContext) {
 		SortBy:              issue_indexer.SortByCreatedDesc,
 	}
 
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, nil)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ConfidentialFilter", err)
+		return
+	}
+	searchOpt.Confidential = issue_indexer.ToConfidentialFilter(confidential)
+
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
 	}
@@ -309,6 +322,15 @@ func SearchIssues(ctx *context.APIContext) {
 	//        it's indeed an regression, but I think it is worth to support filtering by indexer first.
 	_ = ctx.FormInt64("priority_repo_id")
 
+	if err := issue_indexer.ApplyQuery(ctx, searchOpt, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ApplyQuery", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ApplyQuery", err)
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
@@ -354,7 +376,7 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: Search string. Supports qualifiers such as `is:open`, `label:bug`, `-label:wontfix`, `author:alice`, `assignee:@me`, `milestone:"v2"`, `updated:>2026-01-01` and `repo:owner/name`
 	//   type: string
 	// - name: type
 	//   in: query
@@ -408,6 +430,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -518,6 +542,12 @@ func ListIssues(ctx *context.APIContext) {
 		IsClosed:  isClosed,
 		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, []int64{ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ConfidentialFilter", err)
+		return
+	}
+	searchOpt.Confidential = issue_indexer.ToConfidentialFilter(confidential)
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
 	}
@@ -552,6 +582,15 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	if err := issue_indexer.ApplyQuery(ctx, searchOpt, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ApplyQuery", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ApplyQuery", err)
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
@@ -621,7 +660,7 @@ func GetIssue(ctx *context.APIContext) {
 	issue, err := issues_model.GetIssueWithAttrsByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound()
+			redirectTransferredIssue(ctx)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
 		}
@@ -907,6 +946,16 @@ func EditIssue(ctx *context.APIContext) {
 			return
 		}
 	}
+	if canWrite && form.IsConfidential != nil {
+		if err := issue_service.SetConfidential(ctx, issue, *form.IsConfidential); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "SetConfidential", err)
+				return
+			}
+			ctx.Error(http.StatusInternalServerError, "SetConfidential", err)
+			return
+		}
+	}
 	if form.State != nil {
 		if issue.IsPull {
 			if err := issue.LoadPullRequest(ctx); err != nil {
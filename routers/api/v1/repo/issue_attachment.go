@@ -407,5 +407,15 @@ func attachmentBelongsToRepoOrIssue(ctx *context.APIContext, attachment *repo_mo
 		ctx.NotFound("no such attachment in issue")
 		return false
 	}
+	canRead, err := issues_model.AttachmentCanBeReadBy(ctx, attachment, ctx.Doer, ctx.Repo.Permission)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "AttachmentCanBeReadBy", err)
+		return false
+	}
+	if !canRead {
+		log.Debug("Requested attachment[%d] is not readable by the doer.", attachment.ID)
+		ctx.NotFound("no such attachment in issue")
+		return false
+	}
 	return true
 }
@@ -0,0 +1,157 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	admin_model "forgejo.org/models/admin"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+	task_service "forgejo.org/services/task"
+)
+
+// BulkEditIssues edit all the issues matching a search query
+func BulkEditIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/issues/bulk_edit issue issueBulkEdit
+	// ---
+	// summary: Edit all the issues and pull requests matching a search query
+	// description: The issues are edited by a background job, its progress and report can be
+	//   retrieved with the returned id. Only issues in repositories the authenticated user has
+	//   access to are considered, issues the user may not edit are reported as failures.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/BulkEditIssuesOption"
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/BulkEditIssuesTask"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.BulkEditIssuesOption)
+
+	var owner *user_model.User
+	if form.Owner != "" {
+		var err error
+		owner, err = user_model.GetUserByName(ctx, form.Owner)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.NotFound()
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return
+		}
+	}
+
+	opts := issue_service.BulkEditOptions{
+		AddLabels:       form.AddLabels,
+		RemoveLabels:    form.RemoveLabels,
+		AddAssignees:    form.AddAssignees,
+		RemoveAssignees: form.RemoveAssignees,
+		Milestone:       form.Milestone,
+		Project:         form.Project,
+		MoveTo:          form.MoveTo,
+	}
+	if form.State != nil {
+		switch *form.State {
+		case api.StateOpen:
+			opts.IsClosed = optional.Some(false)
+		case api.StateClosed:
+			opts.IsClosed = optional.Some(true)
+		default:
+			ctx.Error(http.StatusUnprocessableEntity, "State", "state must be open or closed")
+			return
+		}
+	}
+
+	task, err := task_service.BulkEditIssues(ctx, ctx.Doer, owner, form.Query, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "BulkEditIssues", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "BulkEditIssues", err)
+		return
+	}
+	getBulkEditIssuesTask(ctx, task.ID, http.StatusAccepted)
+}
+
+// GetBulkEditIssuesTask get the progress and the report of a bulk edit of issues
+func GetBulkEditIssuesTask(ctx *context.APIContext) {
+	// swagger:operation GET /repos/issues/bulk_edit/{id} issue issueGetBulkEdit
+	// ---
+	// summary: Get the progress and the report of a bulk edit of issues
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the bulk edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BulkEditIssuesTask"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	getBulkEditIssuesTask(ctx, ctx.ParamsInt64(":id"), http.StatusOK)
+}
+
+func getBulkEditIssuesTask(ctx *context.APIContext, id int64, status int) {
+	task, payload, report, err := task_service.GetBulkEditIssuesTask(ctx, id, ctx.Doer.ID)
+	if err != nil {
+		if admin_model.IsErrTaskDoesNotExist(err) {
+			ctx.NotFound()
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetBulkEditIssuesTask", err)
+		return
+	}
+
+	apiTask := &api.BulkEditIssuesTask{
+		ID:        task.ID,
+		Status:    task.Status.Name(),
+		Message:   report.Error,
+		Query:     payload.Query,
+		Total:     report.Total,
+		Processed: report.Processed,
+		Failed:    report.Failed,
+		Results:   make([]*api.BulkEditIssueResult, 0, len(report.Results)),
+		Created:   task.Created.AsTime(),
+	}
+	if task.StartTime > 0 {
+		started := task.StartTime.AsTime()
+		apiTask.Started = &started
+	}
+	if task.EndTime > 0 {
+		finished := task.EndTime.AsTime()
+		apiTask.Finished = &finished
+	}
+	for _, result := range report.Results {
+		apiTask.Results = append(apiTask.Results, &api.BulkEditIssueResult{
+			IssueID: result.IssueID,
+			Repo:    result.RepoName,
+			Index:   result.Index,
+			Error:   result.Error,
+		})
+	}
+	ctx.JSON(status, apiTask)
+}
@@ -94,6 +94,9 @@ func ListIssueComments(ctx *context.APIContext) {
 		Before:  before,
 		Type:    issues_model.CommentTypeComment,
 	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		opts.IsInternal = optional.Some(false)
+	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
 	if err != nil {
@@ -202,6 +205,9 @@ func ListIssueCommentsAndTimeline(ctx *context.APIContext) {
 		Before:      before,
 		Type:        issues_model.CommentTypeUndefined,
 	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		opts.IsInternal = optional.Some(false)
+	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
 	if err != nil {
@@ -216,7 +222,7 @@ func ListIssueCommentsAndTimeline(ctx *context.APIContext) {
 
 	var apiComments []*api.TimelineComment
 	for _, comment := range comments {
-		if comment.Type != issues_model.CommentTypeCode && isXRefCommentAccessible(ctx, ctx.Doer, comment, issue.RepoID) {
+		if comment.Type != issues_model.CommentTypeCode && isXRefCommentAccessible(ctx, ctx.Doer, comment, issue.RepoID, ctx.Repo.Permission) {
 			comment.Issue = issue
 			apiComments = append(apiComments, convert.ToTimelineComment(ctx, issue.Repo, comment, ctx.Doer))
 		}
@@ -226,20 +232,31 @@ func ListIssueCommentsAndTimeline(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, &apiComments)
 }
 
-func isXRefCommentAccessible(ctx stdCtx.Context, user *user_model.User, c *issues_model.Comment, issueRepoID int64) bool {
+func isXRefCommentAccessible(ctx stdCtx.Context, user *user_model.User, c *issues_model.Comment, issueRepoID int64, issueRepoPerm access_model.Permission) bool {
 	// Remove comments that the user has no permissions to see
-	if issues_model.CommentTypeIsRef(c.Type) && c.RefRepoID != issueRepoID && c.RefRepoID != 0 {
-		var err error
-		// Set RefRepo for description in template
-		c.RefRepo, err = repo_model.GetRepositoryByID(ctx, c.RefRepoID)
-		if err != nil {
-			return false
+	if issues_model.CommentTypeIsRef(c.Type) && c.RefRepoID != 0 {
+		perm := issueRepoPerm
+		if c.RefRepoID != issueRepoID {
+			var err error
+			// Set RefRepo for description in template
+			c.RefRepo, err = repo_model.GetRepositoryByID(ctx, c.RefRepoID)
+			if err != nil {
+				return false
+			}
+			perm, err = access_model.GetUserRepoPermission(ctx, c.RefRepo, user)
+			if err != nil {
+				return false
+			}
+			if !perm.CanReadIssuesOrPulls(c.RefIsPull) {
+				return false
+			}
 		}
-		perm, err := access_model.GetUserRepoPermission(ctx, c.RefRepo, user)
+		// the referencing issue may be confidential
+		refIssue, err := issues_model.GetIssueByID(ctx, c.RefIssueID)
 		if err != nil {
-			return false
+			return issues_model.IsErrIssueNotExist(err)
 		}
-		if !perm.CanReadIssuesOrPulls(c.RefIsPull) {
+		if !refIssue.CanBeReadBy(user, perm) {
 			return false
 		}
 	}
@@ -320,6 +337,17 @@ func ListRepoIssueComments(ctx *context.APIContext) {
 		Before:      before,
 		IsPull:      isPull,
 	}
+	// internal comments and confidential issues are only read by the issue writers,
+	// the writers of the pull requests can't read them
+	if !ctx.Repo.CanWrite(unit.TypeIssues) {
+		opts.IsInternal = optional.Some(false)
+		opts.Confidential = &issues_model.ConfidentialFilter{}
+		if ctx.Doer != nil {
+			opts.Confidential.PosterID = ctx.Doer.ID
+		}
+	} else if !ctx.Repo.CanWrite(unit.TypePullRequests) {
+		opts.IsInternal = optional.Some(false)
+	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
 	if err != nil {
@@ -422,13 +450,23 @@ func CreateIssueComment(ctx *context.APIContext) {
 		return
 	}
 
+	if form.IsInternal && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "CreateIssueComment", "only the users who can write the issues can add an internal comment")
+		return
+	}
+
 	err = issue_service.SetIssueUpdateDate(ctx, issue, form.Updated, ctx.Doer)
 	if err != nil {
 		ctx.Error(http.StatusForbidden, "SetIssueUpdateDate", err)
 		return
 	}
 
-	comment, err := issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Body, nil)
+	var comment *issues_model.Comment
+	if form.IsInternal {
+		comment, err = issue_service.CreateInternalIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Body, nil)
+	} else {
+		comment, err = issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Body, nil)
+	}
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "CreateIssueComment", err)
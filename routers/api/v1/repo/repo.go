@@ -31,6 +31,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	actions_service "forgejo.org/services/actions"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/issue"
@@ -148,6 +149,15 @@ func Search(ctx *context.APIContext) {
 		IncludeDescription: ctx.FormBool("includeDesc"),
 	}
 
+	var err error
+	if opts.ExcludeOwnerIDs, err = ctx.IPAllowlistDeniedOrgIDs(); err != nil {
+		ctx.JSON(http.StatusInternalServerError, api.SearchError{
+			OK:    false,
+			Error: err.Error(),
+		})
+		return
+	}
+
 	if ctx.FormString("template") != "" {
 		opts.Template = optional.Some(ctx.FormBool("template"))
 	}
@@ -604,6 +614,13 @@ func GetByID(ctx *context.APIContext) {
 		ctx.NotFound()
 		return
 	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadOwner", err)
+		return
+	}
+	if !ctx.CheckOrgAccess(repo.Owner) {
+		return
+	}
 	ctx.JSON(http.StatusOK, convert.ToRepo(ctx, repo, permission))
 }
 
@@ -772,6 +789,9 @@ func updateBasicProperties(ctx *context.APIContext, opts api.EditRepoOption) err
 		ctx.Error(http.StatusInternalServerError, "UpdateRepository", err)
 		return err
 	}
+	if visibilityChanged {
+		audit_service.RecordRepoVisibility(ctx, ctx.Doer, repo)
+	}
 
 	log.Trace("Repository basic settings updated: %s/%s", owner.Name, repo.Name)
 	return nil
@@ -125,3 +125,10 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// BulkEditIssuesTask
+// swagger:response BulkEditIssuesTask
+type swaggerBulkEditIssuesTask struct {
+	// in:body
+	Body api.BulkEditIssuesTask `json:"body"`
+}
@@ -43,6 +43,10 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditIssueOption api.EditIssueOption
 	// in:body
+	BulkEditIssuesOption api.BulkEditIssuesOption
+	// in:body
+	TransferIssueOption api.TransferIssueOption
+	// in:body
 	EditDeadlineOption api.EditDeadlineOption
 
 	// in:body
@@ -61,6 +65,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateKeyOption api.CreateKeyOption
 
+	// in:body
+	CreateSSHCertificateOption api.CreateSSHCertificateOption
+
 	// in:body
 	RenameUserOption api.RenameUserOption
 
@@ -14,8 +14,11 @@ import (
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 )
@@ -57,13 +60,22 @@ func ListAccessTokens(ctx *context.APIContext) {
 		return
 	}
 
+	tokenIDs := make([]int64, len(tokens))
+	for i := range tokens {
+		tokenIDs[i] = tokens[i].ID
+	}
+	resources, err := auth_model.GetAccessTokenResourcesByTokenIDs(ctx, tokenIDs)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
 	apiTokens := make([]*api.AccessToken, len(tokens))
 	for i := range tokens {
-		apiTokens[i] = &api.AccessToken{
-			ID:             tokens[i].ID,
-			Name:           tokens[i].Name,
-			TokenLastEight: tokens[i].TokenLastEight,
-			Scopes:         tokens[i].Scope.StringSlice(),
+		apiTokens[i], err = toAPIAccessToken(ctx, tokens[i], resources[tokens[i].ID])
+		if err != nil {
+			ctx.InternalServerError(err)
+			return
 		}
 	}
 
@@ -126,19 +138,82 @@ func CreateAccessToken(ctx *context.APIContext) {
 		ctx.Error(http.StatusBadRequest, "AccessTokenScope", "access token must have a scope")
 		return
 	}
-	t.Scope = scope
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.Error(http.StatusInternalServerError, "NewAccessToken", err)
+	opts := auth_service.CreateAccessTokenOptions{
+		Name:      form.Name,
+		Scope:     scope,
+		Resources: make([]auth_service.AccessTokenResourceOption, 0, len(form.Resources)),
+	}
+	if form.ExpiresAt != nil {
+		opts.ExpiresUnix = timeutil.TimeStamp(form.ExpiresAt.Unix())
+	}
+	for _, r := range form.Resources {
+		if (r.Repository == "") == (r.Organization == "") {
+			ctx.Error(http.StatusBadRequest, "AccessTokenResource", "access token resource must be either a repository or an organization")
+			return
+		}
+		if r.Permission != "read" && r.Permission != "write" {
+			ctx.Error(http.StatusBadRequest, "AccessTokenResource", "access token resource permission must be read or write")
+			return
+		}
+		opts.Resources = append(opts.Resources, auth_service.AccessTokenResourceOption{
+			Repository:   r.Repository,
+			Organization: r.Organization,
+			Write:        r.Permission == "write",
+		})
+	}
+
+	t, err = auth_service.CreateAccessToken(ctx, ctx.ContextUser, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusBadRequest, "CreateAccessToken", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateAccessToken", err)
+		}
 		return
 	}
-	ctx.JSON(http.StatusCreated, &api.AccessToken{
-		Name:           t.Name,
-		Token:          t.Token,
+	resources, err := auth_model.GetAccessTokenResources(ctx, t.ID)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	apiToken, err := toAPIAccessToken(ctx, t, resources)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	apiToken.Token = t.Token
+	ctx.JSON(http.StatusCreated, apiToken)
+}
+
+func toAPIAccessToken(ctx *context.APIContext, t *auth_model.AccessToken, resources auth_model.AccessTokenResources) (*api.AccessToken, error) {
+	apiToken := &api.AccessToken{
 		ID:             t.ID,
+		Name:           t.Name,
 		TokenLastEight: t.TokenLastEight,
 		Scopes:         t.Scope.StringSlice(),
-	})
+		Resources:      []*api.AccessTokenResource{},
+	}
+	if t.ExpiresUnix != 0 {
+		expiresAt := t.ExpiresUnix.AsTime()
+		apiToken.ExpiresAt = &expiresAt
+	}
+	opts, err := auth_service.AccessTokenResourceOptions(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		permission := "read"
+		if opt.Write {
+			permission = "write"
+		}
+		apiToken.Resources = append(apiToken.Resources, &api.AccessTokenResource{
+			Repository:   opt.Repository,
+			Organization: opt.Organization,
+			Permission:   permission,
+		})
+	}
+	return apiToken, nil
 }
 
 // DeleteAccessToken delete access tokens
@@ -198,7 +273,7 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		return
 	}
 
-	if err := auth_model.DeleteAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID); err != nil {
+	if err := auth_service.DeleteAccessToken(ctx, ctx.ContextUser, tokenID); err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			ctx.NotFound()
 		} else {
@@ -240,6 +315,7 @@ func CreateOauth2Application(ctx *context.APIContext) {
 		UserID:             ctx.Doer.ID,
 		RedirectURIs:       data.RedirectURIs,
 		ConfidentialClient: data.ConfidentialClient,
+		AllowDeviceGrant:   data.AllowDeviceGrant,
 	})
 	if err != nil {
 		ctx.Error(http.StatusBadRequest, "", "error creating oauth2 application")
@@ -415,6 +491,7 @@ func UpdateOauth2Application(ctx *context.APIContext) {
 		ID:                 appID,
 		RedirectURIs:       data.RedirectURIs,
 		ConfidentialClient: data.ConfidentialClient,
+		AllowDeviceGrant:   data.AllowDeviceGrant,
 	})
 	if err != nil {
 		if auth_model.IsErrOauthClientIDInvalid(err) || auth_model.IsErrOAuthApplicationNotFound(err) {
@@ -5,15 +5,19 @@ package user
 
 import (
 	std_ctx "context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	asymkey_model "forgejo.org/models/asymkey"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/ssh"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/repo"
 	"forgejo.org/routers/api/v1/utils"
@@ -316,3 +320,50 @@ func DeletePublicKey(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// CreateSSHCertificate signs a short-lived SSH certificate for a public key of the authenticated user
+func CreateSSHCertificate(ctx *context.APIContext) {
+	// swagger:operation POST /user/ssh_certificate user userCreateSSHCertificate
+	// ---
+	// summary: Sign a short-lived SSH certificate for a public key of the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSSHCertificateOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SSHCertificate"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSSHCertificateOption)
+	if !setting.SSH.UserCAEnabled {
+		ctx.NotFound("SSH certificate authority", ssh.ErrUserCADisabled)
+		return
+	}
+
+	cert, err := asymkey_service.SignSSHUserCertificate(ctx, ctx.Doer, form.Key, time.Duration(form.ValiditySeconds)*time.Second)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.Error(http.StatusForbidden, "", err)
+		default:
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToSSHCertificate(cert))
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/models/webhook"
@@ -17,6 +18,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	webhook_module "forgejo.org/modules/webhook"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	webhook_service "forgejo.org/services/webhook"
 )
@@ -205,6 +207,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 				Wiki:                     util.SliceContainsString(form.Events, string(webhook_module.HookEventWiki), true),
 				Repository:               util.SliceContainsString(form.Events, string(webhook_module.HookEventRepository), true),
 				Release:                  util.SliceContainsString(form.Events, string(webhook_module.HookEventRelease), true),
+				SecretScanningAlert:      util.SliceContainsString(form.Events, string(webhook_module.HookEventSecretScanningAlert), true),
 			},
 			BranchFilter: form.BranchFilter,
 		},
@@ -249,6 +252,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		ctx.Error(http.StatusInternalServerError, "CreateWebhook", err)
 		return nil, false
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookCreate, w, nil)
 	return w, true
 }
 
@@ -320,6 +324,7 @@ func EditRepoHook(ctx *context.APIContext, form *api.EditHookOption, hookID int6
 // editHook edit the webhook `w` according to `form`. If an error occurs, write
 // to `ctx` accordingly and return the error. Return whether successful
 func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webhook) bool {
+	before := audit_service.WebhookSnapshot(w)
 	if form.Config != nil {
 		if url, ok := form.Config["url"]; ok {
 			w.URL = url
@@ -364,6 +369,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.Repository = util.SliceContainsString(form.Events, string(webhook_module.HookEventRepository), true)
 	w.Wiki = util.SliceContainsString(form.Events, string(webhook_module.HookEventWiki), true)
 	w.Release = util.SliceContainsString(form.Events, string(webhook_module.HookEventRelease), true)
+	w.SecretScanningAlert = util.SliceContainsString(form.Events, string(webhook_module.HookEventSecretScanningAlert), true)
 	w.BranchFilter = form.BranchFilter
 
 	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
@@ -402,12 +408,17 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 		ctx.Error(http.StatusInternalServerError, "UpdateWebhook", err)
 		return false
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookUpdate, w, before)
 	return true
 }
 
 // DeleteOwnerHook deletes the hook owned by the owner.
 func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int64) {
-	if err := webhook.DeleteWebhookByOwnerID(ctx, owner.ID, hookID); err != nil {
+	w, err := webhook.GetWebhookByOwnerID(ctx, owner.ID, hookID)
+	if err == nil {
+		err = webhook.DeleteWebhookByID(ctx, w.ID)
+	}
+	if err != nil {
 		if webhook.IsErrWebhookNotExist(err) {
 			ctx.NotFound()
 		} else {
@@ -415,5 +426,6 @@ func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int
 		}
 		return
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookSnapshot(w))
 	ctx.Status(http.StatusNoContent)
 }
@@ -16,6 +16,7 @@ type AuthResult struct {
 }
 
 func AuthShared(ctx *context.Base, sessionStore auth_service.SessionStore, authMethod auth_service.Method) (ar AuthResult, err error) {
+	ctx.Data[middleware.ContextDataKeyRemoteAddr] = ctx.Req.RemoteAddr
 	ar.Doer, err = authMethod.Verify(ctx.Req, ctx.Resp, ctx, sessionStore)
 	if err != nil {
 		return ar, err
@@ -29,6 +29,7 @@ import (
 	actions_router "forgejo.org/routers/api/actions"
 	forgejo "forgejo.org/routers/api/forgejo/v1"
 	packages_router "forgejo.org/routers/api/packages"
+	scim_router "forgejo.org/routers/api/scim"
 	apiv1 "forgejo.org/routers/api/v1"
 	"forgejo.org/routers/common"
 	"forgejo.org/routers/private"
@@ -38,6 +39,7 @@ import (
 	"forgejo.org/services/auth/source/oauth2"
 	"forgejo.org/services/automerge"
 	"forgejo.org/services/cron"
+	depgraph_service "forgejo.org/services/depgraph"
 	feed_service "forgejo.org/services/feed"
 	indexer_service "forgejo.org/services/indexer"
 	"forgejo.org/services/mailer"
@@ -49,6 +51,7 @@ import (
 	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
 	"forgejo.org/services/repository/archiver"
+	secretscan_service "forgejo.org/services/secretscan"
 	"forgejo.org/services/task"
 	"forgejo.org/services/uinotification"
 	"forgejo.org/services/webhook"
@@ -153,6 +156,8 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(secretscan_service.Init)
+	mustInit(depgraph_service.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
 	eventsource.GetManager().Init()
@@ -181,6 +186,7 @@ func NormalRoutes() *web.Route {
 	r.Mount("/api/v1", apiv1.Routes())
 	r.Mount("/api/forgejo/v1", forgejo.Routes())
 	r.Mount("/api/internal", private.Routes())
+	r.Mount("/scim/v2", scim_router.Routes())
 
 	r.Post("/-/fetch-redirect", common.FetchRedirectDelegate)
 
@@ -25,6 +25,8 @@ import (
 	"forgejo.org/modules/web"
 	gitea_context "forgejo.org/services/context"
 	pull_service "forgejo.org/services/pull"
+	pushrule_service "forgejo.org/services/pushrule"
+	secretscan_service "forgejo.org/services/secretscan"
 )
 
 type preReceiveContext struct {
@@ -52,6 +54,9 @@ type preReceiveContext struct {
 	isOverQuota bool
 
 	branchName string
+
+	secretScanner       *secretscan_service.PushScanner
+	loadedSecretScanner bool
 }
 
 // CanWriteCode returns true if pusher can write code
@@ -190,6 +195,14 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 	}
 	log.Trace("Git push options validation succeeded")
 
+	if ctx.Repo.Repository.IsBeingConverted() {
+		// the pushed objects would be lost when the converted repository replaces this one
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "Repository is being converted, you could retry after it finished",
+		})
+		return
+	}
+
 	if err := ourCtx.checkQuota(); err != nil {
 		return
 	}
@@ -217,11 +230,97 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		if ctx.Written() {
 			return
 		}
+		if refFullName.IsBranch() || refFullName.IsTag() {
+			preReceivePushRules(ourCtx, newCommitID)
+			if ctx.Written() {
+				return
+			}
+			preReceiveSecretScanning(ourCtx, newCommitID)
+			if ctx.Written() {
+				return
+			}
+		}
+	}
+
+	if ourCtx.secretScanner != nil {
+		bypassReason := secretscan_service.BypassReason(opts.GetGitPushOptions())
+		if msg := ourCtx.secretScanner.Blocked(bypassReason); msg != "" {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: msg,
+			})
+			return
+		}
+		if !ourCtx.loadPusherAndPermission() {
+			return
+		}
+		if err := ourCtx.secretScanner.Record(ctx, ourCtx.user, bypassReason); err != nil {
+			// the push is accepted, the alerts are not worth losing it
+			log.Error("Unable to record the secrets pushed to %-v: %v", ctx.Repo.Repository, err)
+		}
 	}
 
 	ctx.PlainText(http.StatusOK, "ok")
 }
 
+// preReceiveSecretScanning searches the secrets in the commits pushed to a reference, the push is rejected
+// or the alerts are recorded once all the references are checked
+func preReceiveSecretScanning(ctx *preReceiveContext, newCommitID string) {
+	if ctx.opts.IsWiki || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return
+	}
+	if !ctx.loadedSecretScanner {
+		scanner, err := secretscan_service.NewPushScanner(ctx, ctx.Repo.Repository, ctx.env)
+		if err != nil {
+			log.Error("Unable to load the secret scanning settings of %-v: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to load the secret scanning settings: %v", err),
+			})
+			return
+		}
+		ctx.secretScanner = scanner
+		ctx.loadedSecretScanner = true
+	}
+	if ctx.secretScanner == nil {
+		return
+	}
+	scan := ctx.secretScanner.Scan
+	// the commits of pull requests from forks are only in the pull request references until they are merged
+	if ctx.opts.PullRequestID > 0 {
+		scan = ctx.secretScanner.ScanMerge
+	}
+	if err := scan(ctx, newCommitID); err != nil {
+		log.Error("Unable to scan the push to %-v for secrets: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to scan the push for secrets: %v", err),
+		})
+	}
+}
+
+// preReceivePushRules rejects the commits which do not comply with the push rules of the repository and of its owner
+func preReceivePushRules(ctx *preReceiveContext, newCommitID string) {
+	if ctx.opts.IsWiki || newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return
+	}
+	check := pushrule_service.Check
+	// the commits of pull requests from forks are only in the pull request references until they are merged
+	if ctx.opts.PullRequestID > 0 {
+		check = pushrule_service.CheckMerge
+	}
+	violations, err := check(ctx, ctx.Repo.Repository, ctx.env, newCommitID)
+	if err != nil {
+		log.Error("Unable to check the push rules of %-v: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check the push rules: %v", err),
+		})
+		return
+	}
+	if len(violations) > 0 {
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: pushrule_service.FormatViolations(violations),
+		})
+	}
+}
+
 func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	branchName := refFullName.BranchName()
 	ctx.branchName = branchName
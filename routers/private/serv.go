@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	asymkey_model "forgejo.org/models/asymkey"
+	org_model "forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
@@ -174,6 +175,13 @@ func ServCommand(ctx *context.PrivateContext) {
 			return
 		}
 
+		if mode > perm.AccessModeRead && repo.IsBeingConverted() {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Repository %s/%s is being converted, you could retry after it finished", results.OwnerName, results.RepoName),
+			})
+			return
+		}
+
 		// We can shortcut at this point if the repo is a mirror
 		if mode > perm.AccessModeRead && repo.IsMirror {
 			ctx.JSON(http.StatusForbidden, private.Response{
@@ -273,6 +281,42 @@ func ServCommand(ctx *context.PrivateContext) {
 		}
 	}
 
+	// Deploy keys and site administrators are not restricted by the IP allowlist of an organization
+	if key.Type != asymkey_model.KeyTypeDeploy && !user.IsAdmin {
+		allowed, err := org_model.IsIPAllowed(ctx, owner, ctx.RemoteAddr())
+		if err != nil {
+			log.Error("Unable to check the IP allowlist of %s: %v", owner.Name, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check the IP allowlist of %s: %v", owner.Name, err),
+			})
+			return
+		}
+		if !allowed {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Your IP address %s is not allowed to access the resources of the organization %s.", ctx.RemoteAddr(), owner.Name),
+			})
+			return
+		}
+	}
+
+	// Deploy keys do not belong to a member of the organization
+	if key.Type != asymkey_model.KeyTypeDeploy {
+		compliant, err := org_model.IsTwoFactorCompliant(ctx, owner, user)
+		if err != nil {
+			log.Error("Unable to check the two-factor authentication policy of %s: %v", owner.Name, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check the two-factor authentication policy of %s: %v", owner.Name, err),
+			})
+			return
+		}
+		if !compliant {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("The organization %s requires its members to enable two-factor authentication, enroll at %suser/settings/security to access its resources.", owner.Name, setting.AppURL),
+			})
+			return
+		}
+	}
+
 	// Don't allow pushing if the repo is archived
 	if repoExist && mode > perm.AccessModeRead && repo.IsArchived {
 		ctx.JSON(http.StatusUnauthorized, private.Response{
@@ -21,6 +21,7 @@ import (
 	"forgejo.org/services/auth/source/ldap"
 	"forgejo.org/services/auth/source/oauth2"
 	pam_service "forgejo.org/services/auth/source/pam"
+	"forgejo.org/services/auth/source/saml"
 	"forgejo.org/services/auth/source/smtp"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -61,6 +62,7 @@ var (
 			{auth.DLDAP.String(), auth.DLDAP},
 			{auth.SMTP.String(), auth.SMTP},
 			{auth.OAuth2.String(), auth.OAuth2},
+			{auth.SAML.String(), auth.SAML},
 		}
 		if pam.Supported {
 			items = append(items, dropdownItem{auth.Names[auth.PAM], auth.PAM})
@@ -91,6 +93,7 @@ func NewAuthSource(ctx *context.Context) {
 	ctx.Data["SMTPAuths"] = smtp.Authenticators
 	oauth2providers := oauth2.GetSupportedOAuth2Providers()
 	ctx.Data["OAuth2Providers"] = oauth2providers
+	ctx.Data["SAMLNameIDFormats"] = saml.NameIDFormats
 
 	// only the first as default
 	ctx.Data["oauth2_provider"] = oauth2providers[0].Name()
@@ -193,6 +196,26 @@ func parseOAuth2Config(form forms.AuthenticationForm) *oauth2.Source {
 	}
 }
 
+func parseSAMLConfig(form forms.AuthenticationForm) *saml.Source {
+	return &saml.Source{
+		IdentityProviderMetadata:    strings.TrimSpace(form.SAMLIdentityProviderMetadata),
+		IdentityProviderMetadataURL: strings.TrimSpace(form.SAMLIdentityProviderMetadataURL),
+		NameIDFormat:                form.SAMLNameIDFormat,
+		SignRequests:                form.SAMLSignRequests,
+		IconURL:                     form.SAMLIconURL,
+		AttributeUsername:           form.SAMLAttributeUsername,
+		AttributeEmail:              form.SAMLAttributeEmail,
+		AttributeFullName:           form.SAMLAttributeFullName,
+		AttributeSSHPublicKey:       form.SAMLAttributeSSHPublicKey,
+		GroupAttribute:              form.SAMLGroupAttribute,
+		AdminGroup:                  form.SAMLAdminGroup,
+		RestrictedGroup:             form.SAMLRestrictedGroup,
+		GroupTeamMap:                form.SAMLGroupTeamMap,
+		GroupTeamMapRemoval:         form.SAMLGroupTeamMapRemoval,
+		SkipLocalTwoFA:              form.SkipLocalTwoFA,
+	}
+}
+
 // NewAuthSourcePost response for adding an auth source
 func NewAuthSourcePost(ctx *context.Context) {
 	form := *web.GetForm(ctx).(*forms.AuthenticationForm)
@@ -206,6 +229,7 @@ func NewAuthSourcePost(ctx *context.Context) {
 	ctx.Data["SMTPAuths"] = smtp.Authenticators
 	oauth2providers := oauth2.GetSupportedOAuth2Providers()
 	ctx.Data["OAuth2Providers"] = oauth2providers
+	ctx.Data["SAMLNameIDFormats"] = saml.NameIDFormats
 
 	hasTLS := false
 	var config convert.Conversion
@@ -233,6 +257,13 @@ func NewAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		samlConfig := parseSAMLConfig(form)
+		if err := samlConfig.Initialize(ctx, nil); err != nil {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.saml_invalid_config", err.Error()), tplAuthNew, form)
+			return
+		}
+		config = samlConfig
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
@@ -279,6 +310,7 @@ func EditAuthSource(ctx *context.Context) {
 	ctx.Data["SMTPAuths"] = smtp.Authenticators
 	oauth2providers := oauth2.GetSupportedOAuth2Providers()
 	ctx.Data["OAuth2Providers"] = oauth2providers
+	ctx.Data["SAMLNameIDFormats"] = saml.NameIDFormats
 
 	source, err := auth.GetSourceByID(ctx, ctx.ParamsInt64(":authid"))
 	if err != nil {
@@ -287,6 +319,10 @@ func EditAuthSource(ctx *context.Context) {
 	}
 	ctx.Data["Source"] = source
 	ctx.Data["HasTLS"] = source.HasTLS()
+	loadScimTokens(ctx, source)
+	if ctx.Written() {
+		return
+	}
 
 	if source.IsOAuth2() {
 		type Named interface {
@@ -313,6 +349,7 @@ func EditAuthSourcePost(ctx *context.Context) {
 	ctx.Data["SMTPAuths"] = smtp.Authenticators
 	oauth2providers := oauth2.GetSupportedOAuth2Providers()
 	ctx.Data["OAuth2Providers"] = oauth2providers
+	ctx.Data["SAMLNameIDFormats"] = saml.NameIDFormats
 
 	source, err := auth.GetSourceByID(ctx, ctx.ParamsInt64(":authid"))
 	if err != nil {
@@ -321,6 +358,10 @@ func EditAuthSourcePost(ctx *context.Context) {
 	}
 	ctx.Data["Source"] = source
 	ctx.Data["HasTLS"] = source.HasTLS()
+	loadScimTokens(ctx, source)
+	if ctx.Written() {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplAuthEdit)
@@ -349,6 +390,14 @@ func EditAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		samlConfig := parseSAMLConfig(form)
+		previous, _ := source.Cfg.(*saml.Source)
+		if err := samlConfig.Initialize(ctx, previous); err != nil {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.saml_invalid_config", err.Error()), tplAuthEdit, form)
+			return
+		}
+		config = samlConfig
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
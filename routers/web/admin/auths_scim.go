@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/url"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+// supportsScim reports whether users of the source can be provisioned through SCIM,
+// which is only the case of the sources signing users in with an external identity provider
+func supportsScim(source *auth.Source) bool {
+	return source.IsOAuth2() || source.IsSAML()
+}
+
+// loadScimTokens makes the SCIM endpoint and tokens of the source available to the edit page
+func loadScimTokens(ctx *context.Context, source *auth.Source) {
+	if !supportsScim(source) {
+		return
+	}
+	tokens, err := auth.FindScimTokens(ctx, source.ID)
+	if err != nil {
+		ctx.ServerError("FindScimTokens", err)
+		return
+	}
+	ctx.Data["SupportsScim"] = true
+	ctx.Data["ScimURL"] = scim_service.BaseURL()
+	ctx.Data["ScimTokens"] = tokens
+}
+
+// NewScimTokenPost generates a token for the identity provider to provision the users of the source
+func NewScimTokenPost(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.ParamsInt64(":authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+	redirectTo := setting.AppSubURL + "/admin/auths/" + url.PathEscape(ctx.Params(":authid"))
+	if !supportsScim(source) {
+		ctx.NotFound("NewScimTokenPost", nil)
+		return
+	}
+
+	name := ctx.FormTrim("scim_token_name")
+	if name == "" {
+		ctx.Flash.Error(ctx.Tr("admin.auths.scim_token_name_required"))
+		ctx.Redirect(redirectTo)
+		return
+	}
+	t := &auth.ScimToken{SourceID: source.ID, Name: name}
+	if err := auth.NewScimToken(ctx, t); err != nil {
+		ctx.ServerError("NewScimToken", err)
+		return
+	}
+	log.Trace("SCIM token %d generated by admin(%s) for authentication source %d", t.ID, ctx.Doer.Name, source.ID)
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_generated"))
+	ctx.Flash.Info(t.Token)
+	ctx.Redirect(redirectTo)
+}
+
+// DeleteScimTokenPost revokes a SCIM token of the source
+func DeleteScimTokenPost(ctx *context.Context) {
+	if err := auth.DeleteScimToken(ctx, ctx.ParamsInt64(":authid"), ctx.FormInt64("id")); err != nil {
+		ctx.Flash.Error("DeleteScimToken: " + err.Error())
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_deleted"))
+	}
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/auths/" + url.PathEscape(ctx.Params(":authid")))
+}
@@ -6,9 +6,11 @@ package admin
 import (
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/webhook"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	webhook_service "forgejo.org/services/webhook"
 )
@@ -63,9 +65,14 @@ func DefaultOrSystemWebhooks(ctx *context.Context) {
 
 // DeleteDefaultOrSystemWebhook handler to delete an admin-defined system or default webhook
 func DeleteDefaultOrSystemWebhook(ctx *context.Context) {
-	if err := webhook.DeleteDefaultSystemWebhook(ctx, ctx.FormInt64("id")); err != nil {
+	w, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.FormInt64("id"))
+	if err == nil {
+		err = webhook.DeleteDefaultSystemWebhook(ctx, w.ID)
+	}
+	if err != nil {
 		ctx.Flash.Error("DeleteDefaultWebhook: " + err.Error())
 	} else {
+		audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookSnapshot(w))
 		ctx.Flash.Success(ctx.Tr("repo.settings.webhook_deletion_success"))
 	}
 
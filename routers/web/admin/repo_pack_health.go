@@ -0,0 +1,104 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	repo_service "forgejo.org/services/repository"
+)
+
+const tplRepoPackHealth base.TplName = "admin/repo/pack_health"
+
+func getAdminRepo(ctx *context.Context) *repo_model.Repository {
+	repo, err := repo_model.GetRepositoryByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.NotFound("GetRepositoryByID", err)
+		} else {
+			ctx.ServerError("GetRepositoryByID", err)
+		}
+		return nil
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		ctx.ServerError("LoadOwner", err)
+		return nil
+	}
+	return repo
+}
+
+// RepoPackHealth shows how the objects of a repository are stored and its partial clone policy
+func RepoPackHealth(ctx *context.Context) {
+	repo := getAdminRepo(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("admin.repos.pack_health")
+	ctx.Data["PageIsAdminRepositories"] = true
+	ctx.Data["Repo"] = repo
+
+	health, err := git.GetPackHealth(ctx, repo.RepoPath())
+	if err != nil {
+		ctx.ServerError("GetPackHealth", err)
+		return
+	}
+	policy, err := git.GetPartialClonePolicy(ctx, repo.RepoPath())
+	if err != nil {
+		ctx.ServerError("GetPartialClonePolicy", err)
+		return
+	}
+	ctx.Data["PackHealth"] = health
+	ctx.Data["PartialClonePolicy"] = string(policy)
+	ctx.Data["PartialCloneDisabled"] = setting.Git.DisablePartialClone
+	ctx.Data["SupportGitMaintenance"] = git.SupportGitMaintenance
+	ctx.HTML(http.StatusOK, tplRepoPackHealth)
+}
+
+// RepoPackHealthPost runs the maintenance of a repository or changes its partial clone policy
+func RepoPackHealthPost(ctx *context.Context) {
+	repo := getAdminRepo(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := fmt.Sprintf("%s/admin/repos/%d/pack-health", setting.AppSubURL, repo.ID)
+
+	switch ctx.FormString("action") {
+	case "maintenance":
+		if err := repo_service.GitMaintenanceRepo(ctx, repo, git.MaintenanceOptions{
+			LooseObjects:      true,
+			IncrementalRepack: true,
+			MultiPackIndex:    true,
+			CommitGraph:       true,
+			Timeout:           time.Duration(setting.Git.Timeout.GC) * time.Second,
+		}); err != nil {
+			ctx.Flash.Error(ctx.Tr("admin.repos.pack_health.maintenance_failed", err.Error()))
+		} else {
+			ctx.Flash.Success(ctx.Tr("admin.repos.pack_health.maintenance_success"))
+		}
+	case "partial_clone":
+		if err := repo_service.SetPartialClonePolicy(ctx, repo, git.PartialClonePolicy(ctx.FormString("policy"))); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(err.Error())
+				ctx.Redirect(link)
+				return
+			}
+			ctx.ServerError("SetPartialClonePolicy", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("admin.repos.pack_health.partial_clone_success"))
+	default:
+		ctx.NotFound("", nil)
+		return
+	}
+	ctx.Redirect(link)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	"forgejo.org/models/db"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+const tplTwoFactor base.TplName = "admin/two_factor"
+
+// twoFactorOrgCompliance lists the members of an organization requiring two-factor authentication who did not enroll
+type twoFactorOrgCompliance struct {
+	Organization *user_model.User
+	Policy       *org_model.TwoFactorPolicy
+	Members      []*user_model.User
+}
+
+// TwoFactorCompliance reports the users not complying with the two-factor authentication policies
+func TwoFactorCompliance(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.two_factor")
+	ctx.Data["PageIsAdminTwoFactor"] = true
+	ctx.Data["EnforceTwoFactorAuth"] = setting.EnforceTwoFactorAuth
+
+	_, count, err := user_model.SearchUsers(ctx, &user_model.SearchUserOptions{
+		Actor:              ctx.Doer,
+		Type:               user_model.UserTypeIndividual,
+		ListOptions:        db.ListOptions{PageSize: 1},
+		IsActive:           optional.Some(true),
+		IsTwoFactorEnabled: optional.Some(false),
+	})
+	if err != nil {
+		ctx.ServerError("SearchUsers", err)
+		return
+	}
+	ctx.Data["UsersWithoutTwoFactor"] = count
+
+	policies, err := org_model.FindTwoFactorPolicies(ctx)
+	if err != nil {
+		ctx.ServerError("FindTwoFactorPolicies", err)
+		return
+	}
+	orgs := make([]*twoFactorOrgCompliance, 0, len(policies))
+	for _, p := range policies {
+		org, err := user_model.GetUserByID(ctx, p.OrgID)
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		members, err := org_model.GetTwoFactorNonCompliantMembers(ctx, p.OrgID)
+		if err != nil {
+			ctx.ServerError("GetTwoFactorNonCompliantMembers", err)
+			return
+		}
+		orgs = append(orgs, &twoFactorOrgCompliance{Organization: org, Policy: p, Members: members})
+	}
+	ctx.Data["Organizations"] = orgs
+
+	ctx.HTML(http.StatusOK, tplTwoFactor)
+}
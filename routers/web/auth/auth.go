@@ -159,6 +159,10 @@ func SignIn(ctx *context.Context) {
 		ctx.ServerError("UserSignIn", err)
 		return
 	}
+	if err := loadSAMLSources(ctx); err != nil {
+		ctx.ServerError("UserSignIn", err)
+		return
+	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
@@ -184,6 +188,10 @@ func SignInPost(ctx *context.Context) {
 		ctx.ServerError("UserSignIn", err)
 		return
 	}
+	if err := loadSAMLSources(ctx); err != nil {
+		ctx.ServerError("UserSignIn", err)
+		return
+	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
@@ -235,8 +243,15 @@ func SignInPost(ctx *context.Context) {
 	// Now handle 2FA:
 
 	// First of all if the source can skip local two fa we're done
-	if skipper, ok := source.Cfg.(auth_service.LocalTwoFASkipper); ok && skipper.IsSkipLocalTwoFA() {
-		handleSignIn(ctx, u, form.Remember)
+	skipper, ok := source.Cfg.(auth_service.LocalTwoFASkipper)
+	handleSignInWithTwoFactor(ctx, u, ok && skipper.IsSkipLocalTwoFA(), form.Remember)
+}
+
+// handleSignInWithTwoFactor signs the user in, or redirects them to the second factor
+// authentication page if they are enrolled in 2FA and the local 2FA is not skipped.
+func handleSignInWithTwoFactor(ctx *context.Context, u *user_model.User, skipLocalTwoFA, remember bool) {
+	if skipLocalTwoFA {
+		handleSignIn(ctx, u, remember)
 		return
 	}
 
@@ -257,14 +272,14 @@ func SignInPost(ctx *context.Context) {
 
 	if !hasTOTPtwofa && !hasWebAuthnTwofa {
 		// No two factor auth configured we can sign in the user
-		handleSignIn(ctx, u, form.Remember)
+		handleSignIn(ctx, u, remember)
 		return
 	}
 
 	updates := map[string]any{
 		// User will need to use 2FA TOTP or WebAuthn, save data
 		"twofaUid":      u.ID,
-		"twofaRemember": form.Remember,
+		"twofaRemember": remember,
 	}
 	if hasTOTPtwofa {
 		// User will need to use WebAuthn, save data
@@ -401,6 +416,10 @@ func SignUp(ctx *context.Context) {
 		ctx.ServerError("UserSignUp", err)
 		return
 	}
+	if err := loadSAMLSources(ctx); err != nil {
+		ctx.ServerError("UserSignUp", err)
+		return
+	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 	context.SetCaptchaData(ctx)
@@ -430,6 +449,10 @@ func SignUpPost(ctx *context.Context) {
 		ctx.ServerError("UserSignUp", err)
 		return
 	}
+	if err := loadSAMLSources(ctx); err != nil {
+		ctx.ServerError("UserSignUp", err)
+		return
+	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 	context.SetCaptchaData(ctx)
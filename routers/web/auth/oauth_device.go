@@ -0,0 +1,295 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/auth/source/oauth2"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplDeviceCode  base.TplName = "user/auth/device_code"
+	tplDeviceGrant base.TplName = "user/auth/device_grant"
+
+	// deviceCodeGrantType is the grant type of the token requests of devices
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// DeviceAuthorizationResponse represents a successful device authorization response
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// DeviceAuthorizationOAuth issues a device code and a user code to a device, the user enters the user code
+// on the verification page while the device polls the token endpoint with the device code
+func DeviceAuthorizationOAuth(ctx *context.Context) {
+	form := *web.GetForm(ctx).(*forms.DeviceAuthorizationForm)
+	if form.ClientID == "" || form.ClientSecret == "" {
+		authHeader := ctx.Req.Header.Get("Authorization")
+		if authType, authData, ok := strings.Cut(authHeader, " "); ok && strings.EqualFold(authType, "Basic") {
+			clientID, clientSecret, err := base.BasicAuthDecode(authData)
+			if err != nil || (form.ClientID != "" && form.ClientID != clientID) {
+				handleAccessTokenError(ctx, AccessTokenError{
+					ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+					ErrorDescription: "cannot parse basic auth header",
+				})
+				return
+			}
+			form.ClientID = clientID
+			form.ClientSecret = clientSecret
+		}
+	}
+
+	app, err := auth.GetOAuth2ApplicationByClientID(ctx, form.ClientID)
+	if err != nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: fmt.Sprintf("cannot load client with client id: %q", form.ClientID),
+		})
+		return
+	}
+	if app.ConfidentialClient && !app.ValidateClientSecret([]byte(form.ClientSecret)) {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: "invalid client secret",
+		})
+		return
+	}
+	if !app.AllowDeviceGrant {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeUnauthorizedClient,
+			ErrorDescription: "the client is not allowed to use the device authorization grant",
+		})
+		return
+	}
+
+	d, deviceCode, err := auth.CreateOAuth2DeviceAuthorization(ctx, app, form.Scope)
+	if err != nil {
+		log.Error("CreateOAuth2DeviceAuthorization: %v", err)
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "cannot proceed your request",
+		})
+		return
+	}
+
+	verificationURI := setting.AppURL + "login/device"
+	ctx.JSON(http.StatusOK, &DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                d.FormattedUserCode(),
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(d.FormattedUserCode()),
+		ExpiresIn:               setting.OAuth2.DeviceCodeExpirationTime,
+		Interval:                d.Interval,
+	})
+}
+
+// DeviceCode shows the page where the user enters the code displayed by a device
+func DeviceCode(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("auth.device_code_title")
+	ctx.Data["UserCode"] = ctx.FormTrim("user_code")
+	ctx.HTML(http.StatusOK, tplDeviceCode)
+}
+
+// loadDeviceAuthorization finds the pending device authorization of the user code and its application
+func loadDeviceAuthorization(ctx *context.Context, userCode string) (*auth.OAuth2DeviceAuthorization, *auth.OAuth2Application) {
+	d, err := auth.GetOAuth2DeviceAuthorizationByUserCode(ctx, userCode)
+	if err == nil {
+		var app *auth.OAuth2Application
+		if app, err = auth.GetOAuth2ApplicationByID(ctx, d.ApplicationID); err == nil {
+			return d, app
+		}
+	}
+	if !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetOAuth2DeviceAuthorizationByUserCode", err)
+		return nil, nil
+	}
+	ctx.Data["Title"] = ctx.Tr("auth.device_code_title")
+	ctx.Data["UserCode"] = userCode
+	ctx.RenderWithErr(ctx.Tr("auth.device_code_invalid"), tplDeviceCode, nil)
+	return nil, nil
+}
+
+// DeviceCodePost asks the user to authorize the device of the entered code
+func DeviceCodePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceGrantForm)
+	d, app := loadDeviceAuthorization(ctx, form.UserCode)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("auth.authorize_title", app.Name)
+	ctx.Data["Application"] = app
+	ctx.Data["UserCode"] = d.FormattedUserCode()
+	ctx.Data["Scope"] = d.Scope
+	if app.UID != 0 {
+		creator, err := user_model.GetUserByID(ctx, app.UID)
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">@%s</a>`, html.EscapeString(creator.HomeLink()), html.EscapeString(creator.Name)))
+	} else {
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(setting.AppSubURL+"/"), html.EscapeString(setting.AppName)))
+	}
+	ctx.HTML(http.StatusOK, tplDeviceGrant)
+}
+
+// DeviceGrantPost approves or denies the authorization of the device
+func DeviceGrantPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceGrantForm)
+	d, app := loadDeviceAuthorization(ctx, form.UserCode)
+	if ctx.Written() {
+		return
+	}
+
+	if !form.Granted {
+		if err := d.Deny(ctx, ctx.Doer.ID); err != nil {
+			ctx.ServerError("Deny", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("auth.device_code_denied", app.Name))
+		ctx.Redirect(setting.AppSubURL + "/login/device")
+		return
+	}
+
+	grant, err := app.GetGrantByUserID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetGrantByUserID", err)
+		return
+	}
+	if grant == nil {
+		if grant, err = app.CreateGrant(ctx, ctx.Doer.ID, d.Scope); err != nil {
+			ctx.ServerError("CreateGrant", err)
+			return
+		}
+	} else if grant.Scope != d.Scope {
+		ctx.Flash.Error(ctx.Tr("auth.device_code_scope_mismatch", app.Name))
+		ctx.Redirect(setting.AppSubURL + "/login/device")
+		return
+	}
+	if err := d.Approve(ctx, grant); err != nil {
+		ctx.ServerError("Approve", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("auth.device_code_approved", app.Name))
+	ctx.Redirect(setting.AppSubURL + "/login/device")
+}
+
+func handleDeviceCode(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey oauth2.JWTSigningKey) {
+	app, err := auth.GetOAuth2ApplicationByClientID(ctx, form.ClientID)
+	if err != nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: fmt.Sprintf("cannot load client with client id: %q", form.ClientID),
+		})
+		return
+	}
+	if app.ConfidentialClient && !app.ValidateClientSecret([]byte(form.ClientSecret)) {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: "invalid client secret",
+		})
+		return
+	}
+	if !app.AllowDeviceGrant {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeUnauthorizedClient,
+			ErrorDescription: "the client is not allowed to use the device authorization grant",
+		})
+		return
+	}
+
+	d, err := auth.GetOAuth2DeviceAuthorizationByDeviceCode(ctx, form.DeviceCode)
+	if err != nil || d.ApplicationID != app.ID {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid device code",
+		})
+		return
+	}
+	if d.IsExpired() {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeExpiredToken,
+			ErrorDescription: "the device code has expired",
+		})
+		return
+	}
+
+	switch d.Status {
+	case auth.OAuth2DeviceAuthorizationPending:
+		slowDown, err := d.Poll(ctx)
+		if err != nil {
+			log.Error("Poll: %v", err)
+		}
+		if slowDown {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeSlowDown,
+				ErrorDescription: fmt.Sprintf("poll at most every %d seconds", d.Interval),
+			})
+			return
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAuthorizationPending,
+			ErrorDescription: "the user has not yet approved the device",
+		})
+		return
+	case auth.OAuth2DeviceAuthorizationDenied:
+		if _, err := d.Invalidate(ctx); err != nil {
+			log.Error("Invalidate: %v", err)
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAccessDenied,
+			ErrorDescription: "the user has denied the device",
+		})
+		return
+	}
+
+	// remove the device authorization from database to deny duplicate usage
+	if deleted, err := d.Invalidate(ctx); err != nil || !deleted {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid device code",
+		})
+		return
+	}
+	grant, err := auth.GetOAuth2GrantByID(ctx, d.GrantID)
+	if err != nil || grant == nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "grant does not exist",
+		})
+		return
+	}
+	resp, tokenErr := newAccessTokenResponse(ctx, grant, serverKey, clientKey)
+	if tokenErr != nil {
+		handleAccessTokenError(ctx, *tokenErr)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
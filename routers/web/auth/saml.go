@@ -0,0 +1,165 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/auth/source/saml"
+	"forgejo.org/services/context"
+)
+
+const (
+	tplSAMLPost base.TplName = "user/auth/saml_post"
+
+	samlRequestIDSessionKey = "samlRequestID"
+)
+
+// loadSAMLSources makes the active SAML sources available to the sign in and sign up pages
+func loadSAMLSources(ctx *context.Context) error {
+	sources, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+	if err != nil {
+		return err
+	}
+	ctx.Data["SAMLSources"] = sources
+	return nil
+}
+
+func getSAMLSource(ctx *context.Context) *saml.Source {
+	authSource, err := auth.GetActiveSAMLSourceByName(ctx, ctx.Params(":provider"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetActiveSAMLSourceByName", err)
+		} else {
+			ctx.ServerError("GetActiveSAMLSourceByName", err)
+		}
+		return nil
+	}
+	return authSource.Cfg.(*saml.Source)
+}
+
+// SignInSAML redirects the user to the identity provider of the SAML source
+func SignInSAML(ctx *context.Context) {
+	source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	redirectTo := ctx.FormString("redirect_to")
+	if len(redirectTo) > 0 {
+		middleware.SetRedirectToCookie(ctx.Resp, redirectTo)
+	}
+
+	redirect, requestID, err := source.AuthenticationRequestURL()
+	if err != nil {
+		ctx.ServerError("AuthenticationRequestURL", err)
+		return
+	}
+	if err := ctx.Session.Set(samlRequestIDSessionKey, requestID); err != nil {
+		ctx.ServerError("Session.Set", err)
+		return
+	}
+
+	// ctx.Redirect drops the session cookie when redirecting to another site,
+	// which would lose the request ID of a visitor without a session yet.
+	http.Redirect(ctx.Resp, ctx.Req, redirect.String(), http.StatusSeeOther)
+}
+
+// SAMLMetadata serves the service provider metadata to be imported by the identity provider
+func SAMLMetadata(ctx *context.Context) {
+	source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	metadata, err := source.Metadata()
+	if err != nil {
+		ctx.ServerError("Metadata", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/samlmetadata+xml")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(metadata)
+}
+
+// SAMLAssertionConsumer handles the SAML response posted by the identity provider
+func SAMLAssertionConsumer(ctx *context.Context) {
+	source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	// The response is posted from the site of the identity provider, so the SameSite session
+	// cookie holding the request ID is not sent along. Post it again from our own site.
+	if !ctx.FormBool("forwarded") {
+		ctx.KeepSessionCookie()
+		ctx.Data["Title"] = ctx.Tr("sign_in")
+		ctx.Data["ActionURL"] = source.ServiceProviderURL() + "/acs"
+		ctx.Data["SAMLResponse"] = ctx.FormString("SAMLResponse")
+		ctx.Data["RelayState"] = ctx.FormString("RelayState")
+		ctx.HTML(http.StatusOK, tplSAMLPost)
+		return
+	}
+
+	requestID, _ := ctx.Session.Get(samlRequestIDSessionKey).(string)
+	if requestID == "" {
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error"))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+	_ = ctx.Session.Delete(samlRequestIDSessionKey)
+
+	assertion, err := source.ParseResponse(ctx.Req, requestID)
+	if err != nil {
+		log.Warn("Failed SAML authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error"))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+	asserted, err := source.AssertedUser(assertion)
+	if err != nil {
+		log.Warn("Failed SAML authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error"))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+
+	u, err := source.SignIn(ctx, asserted)
+	if err != nil {
+		switch {
+		case user_model.IsErrUserNotExist(err):
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.registration_disabled"))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+		case user_model.IsErrUserAlreadyExist(err), user_model.IsErrEmailAlreadyUsed(err):
+			log.Warn("Failed SAML authentication attempt for %s from %s: %v", asserted.NameID, ctx.RemoteAddr(), err)
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.user_exists"))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+		default:
+			ctx.ServerError("SignIn", err)
+		}
+		return
+	}
+
+	if u.ProhibitLogin || !u.IsActive {
+		log.Info("Failed SAML authentication attempt for %s from %s: user is prohibited from signing in", u.Name, ctx.RemoteAddr())
+		ctx.Data["Title"] = ctx.Tr("auth.prohibit_login")
+		ctx.HTML(http.StatusOK, "user/auth/prohibit_login")
+		return
+	}
+
+	handleSignInWithTwoFactor(ctx, u, source.SkipLocalTwoFA, false)
+}
@@ -95,6 +95,12 @@ func RenderRepoSearch(ctx *context.Context, opts *RepoSearchOptions) {
 	private := ctx.FormOptionalBool("private")
 	ctx.Data["IsPrivate"] = private
 
+	excludeOwnerIDs, err := ctx.IPAllowlistDeniedOrgIDs()
+	if err != nil {
+		ctx.ServerError("IPAllowlistDeniedOrgIDs", err)
+		return
+	}
+
 	repos, count, err = repo_model.SearchRepository(ctx, &repo_model.SearchRepoOptions{
 		ListOptions: db.ListOptions{
 			Page:     page,
@@ -116,6 +122,7 @@ func RenderRepoSearch(ctx *context.Context, opts *RepoSearchOptions) {
 		Mirror:             mirror,
 		Template:           template,
 		IsPrivate:          private,
+		ExcludeOwnerIDs:    excludeOwnerIDs,
 	})
 	if err != nil {
 		ctx.ServerError("SearchRepository", err)
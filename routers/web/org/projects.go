@@ -23,6 +23,8 @@ import (
 	shared_user "forgejo.org/routers/web/shared/user"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	"slices"
 )
 
 const (
@@ -350,6 +352,16 @@ func ViewProject(ctx *context.Context) {
 		ctx.ServerError("LoadIssuesOfColumns", err)
 		return
 	}
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, nil)
+	if err != nil {
+		ctx.ServerError("ConfidentialFilter", err)
+		return
+	}
+	for columnID, issues := range issuesMap {
+		issuesMap[columnID] = slices.DeleteFunc(issues, func(issue *issues_model.Issue) bool {
+			return !confidential.Allows(issue)
+		})
+	}
 
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*attachment_model.Attachment)
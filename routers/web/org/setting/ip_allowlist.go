@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"html/template"
+	"net"
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	org_model "forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const tplIPAllowlist base.TplName = "org/settings/ip_allowlist"
+
+// IPAllowlist renders the IP allowlist of the organization
+func IPAllowlist(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.ip_allowlist")
+	ctx.Data["PageIsSettingsIPAllowlist"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	l, err := org_model.GetIPAllowlist(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetIPAllowlist", err)
+		return
+	}
+	ctx.Data["IPAllowlist"] = l
+	ctx.Data["RemoteIP"] = remoteIP(ctx)
+
+	ctx.HTML(http.StatusOK, tplIPAllowlist)
+}
+
+// IPAllowlistPost updates the IP allowlist of the organization
+func IPAllowlistPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.IPAllowlistForm)
+	ctx.Data["Title"] = ctx.Tr("org.settings.ip_allowlist")
+	ctx.Data["PageIsSettingsIPAllowlist"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	l, err := org_model.GetIPAllowlist(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetIPAllowlist", err)
+		return
+	}
+	before := map[string]any{"enabled": l.Enabled, "ranges": l.Ranges}
+
+	renderError := func(msg template.HTML) {
+		ctx.Data["IPAllowlist"] = &org_model.IPAllowlist{Enabled: form.Enabled, Ranges: form.Ranges}
+		ctx.Data["RemoteIP"] = remoteIP(ctx)
+		ctx.RenderWithErr(msg, tplIPAllowlist, form)
+	}
+
+	ranges, err := org_model.NormalizeIPAllowlistRanges(form.Ranges)
+	if err != nil {
+		renderError(ctx.Tr("org.settings.ip_allowlist.invalid", err.Error()))
+		return
+	}
+
+	l.Enabled = form.Enabled
+	l.Ranges = ranges
+	// the members enabling the allowlist must not lock themselves out, the site administrators are exempt
+	if !ctx.Doer.IsAdmin && !l.IsAllowed(ctx.Req.RemoteAddr) {
+		renderError(ctx.Tr("org.settings.ip_allowlist.lockout", remoteIP(ctx)))
+		return
+	}
+
+	if err := org_model.SaveIPAllowlist(ctx, l); err != nil {
+		ctx.ServerError("SaveIPAllowlist", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgIPAllowlist, audit_service.UserTarget(ctx.Org.Organization.AsUser()),
+		before, map[string]any{"enabled": l.Enabled, "ranges": l.Ranges})
+
+	ctx.Flash.Success(ctx.Tr("org.settings.ip_allowlist.update_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/ip_allowlist")
+}
+
+func remoteIP(ctx *context.Context) string {
+	if host, _, err := net.SplitHostPort(ctx.Req.RemoteAddr); err == nil {
+		return host
+	}
+	return ctx.Req.RemoteAddr
+}
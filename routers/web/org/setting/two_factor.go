@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	org_model "forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	org_service "forgejo.org/services/org"
+)
+
+const tplTwoFactorPolicy base.TplName = "org/settings/two_factor"
+
+func prepareTwoFactorPolicy(ctx *context.Context) *org_model.TwoFactorPolicy {
+	ctx.Data["Title"] = ctx.Tr("org.settings.two_factor")
+	ctx.Data["PageIsSettingsTwoFactor"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return nil
+	}
+
+	p, err := org_model.GetTwoFactorPolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetTwoFactorPolicy", err)
+		return nil
+	}
+	ctx.Data["TwoFactorPolicy"] = p
+
+	members, err := org_model.GetTwoFactorNonCompliantMembers(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetTwoFactorNonCompliantMembers", err)
+		return nil
+	}
+	ctx.Data["NonCompliantMembers"] = members
+	return p
+}
+
+// TwoFactorPolicy renders the two-factor authentication policy of the organization
+func TwoFactorPolicy(ctx *context.Context) {
+	if prepareTwoFactorPolicy(ctx) == nil {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplTwoFactorPolicy)
+}
+
+// TwoFactorPolicyPost updates the two-factor authentication policy of the organization
+func TwoFactorPolicyPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.TwoFactorPolicyForm)
+	if prepareTwoFactorPolicy(ctx) == nil {
+		return
+	}
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplTwoFactorPolicy)
+		return
+	}
+
+	if err := org_service.UpdateTwoFactorPolicy(ctx, ctx.Doer, ctx.Org.Organization, form.Required, form.GraceDays); err != nil {
+		if errors.Is(err, org_service.ErrDoerWithoutTwoFactor) {
+			ctx.RenderWithErr(ctx.Tr("org.settings.two_factor.doer_not_enrolled"), tplTwoFactorPolicy, form)
+			return
+		}
+		ctx.ServerError("UpdateTwoFactorPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.two_factor.update_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/two_factor")
+}
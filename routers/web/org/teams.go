@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	org_model "forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -25,6 +26,7 @@ import (
 	"forgejo.org/modules/validation"
 	"forgejo.org/modules/web"
 	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
@@ -78,10 +80,15 @@ func TeamsAction(ctx *context.Context) {
 			ctx.Error(http.StatusNotFound)
 			return
 		}
-		err = models.AddTeamMember(ctx, ctx.Org.Team, ctx.Doer.ID)
+		err = org_service.AddTeamMember(ctx, ctx.Org.Team, ctx.Doer)
+		if err == nil {
+			audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberAdd, ctx.Org.Team, ctx.Doer.ID)
+		}
 	case "leave":
 		err = models.RemoveTeamMember(ctx, ctx.Org.Team, ctx.Doer.ID)
-		if err != nil {
+		if err == nil {
+			audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberRemove, ctx.Org.Team, ctx.Doer.ID)
+		} else {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
 			} else {
@@ -108,7 +115,9 @@ func TeamsAction(ctx *context.Context) {
 		}
 
 		err = models.RemoveTeamMember(ctx, ctx.Org.Team, uid)
-		if err != nil {
+		if err == nil {
+			audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberRemove, ctx.Org.Team, uid)
+		} else {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
 			} else {
@@ -162,7 +171,10 @@ func TeamsAction(ctx *context.Context) {
 		if ctx.Org.Team.IsMember(ctx, u.ID) {
 			ctx.Flash.Error(ctx.Tr("org.teams.add_duplicate_users"))
 		} else {
-			err = models.AddTeamMember(ctx, ctx.Org.Team, u.ID)
+			err = org_service.AddTeamMember(ctx, ctx.Org.Team, u)
+			if err == nil {
+				audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberAdd, ctx.Org.Team, u.ID)
+			}
 		}
 
 		page = "team"
@@ -367,6 +379,7 @@ func NewTeamPost(ctx *context.Context) {
 		return
 	}
 	log.Trace("Team created: %s/%s", ctx.Org.Organization.Name, t.Name)
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamCreate, audit_service.TeamTarget(t), nil, audit_service.TeamPermissions(ctx, t))
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
@@ -480,6 +493,7 @@ func EditTeam(ctx *context.Context) {
 func EditTeamPost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.CreateTeamForm)
 	t := ctx.Org.Team
+	before := audit_service.TeamPermissions(ctx, t)
 	newAccessMode := perm.ParseAccessMode(form.Permission)
 	unitPerms := getUnitPerms(ctx.Req.Form, newAccessMode)
 	if newAccessMode < perm.AccessModeAdmin {
@@ -545,14 +559,17 @@ func EditTeamPost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamUpdate, audit_service.TeamTarget(t), before, audit_service.TeamPermissions(ctx, t))
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
 // DeleteTeam response for the delete team request
 func DeleteTeam(ctx *context.Context) {
+	before := audit_service.TeamPermissions(ctx, ctx.Org.Team)
 	if err := models.DeleteTeam(ctx, ctx.Org.Team); err != nil {
 		ctx.Flash.Error("DeleteTeam: " + err.Error())
 	} else {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), before, nil)
 		ctx.Flash.Success(ctx.Tr("org.teams.delete_team_success"))
 	}
 
@@ -592,10 +609,11 @@ func TeamInvitePost(ctx *context.Context) {
 		return
 	}
 
-	if err := models.AddTeamMember(ctx, team, ctx.Doer.ID); err != nil {
+	if err := org_service.AddTeamMember(ctx, team, ctx.Doer); err != nil {
 		ctx.ServerError("AddTeamMember", err)
 		return
 	}
+	audit_service.RecordTeamMember(ctx, ctx.Doer, audit_model.ActionTeamMemberAdd, team, ctx.Doer.ID)
 
 	if err := org_model.RemoveInviteByID(ctx, invite.ID, team.ID); err != nil {
 		log.Error("RemoveInviteByID: %v", err)
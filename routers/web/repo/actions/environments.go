@@ -0,0 +1,155 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	actions_service "forgejo.org/services/actions"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+const (
+	tplEnvironments          base.TplName = "repo/actions/environments"
+	tplEnvironmentDeployment base.TplName = "repo/actions/environment"
+)
+
+// Environments render the deployment environments of a repository with their latest deployment
+func Environments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.environments")
+	ctx.Data["PageIsActions"] = true
+
+	environments, err := db.Find[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindEnvironments", err)
+		return
+	}
+
+	latest := make(map[int64]*actions_model.ActionDeployment, len(environments))
+	for _, env := range environments {
+		deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+			ListOptions:   db.ListOptions{Page: 1, PageSize: 1},
+			RepoID:        env.RepoID,
+			EnvironmentID: env.ID,
+		})
+		if err != nil {
+			ctx.ServerError("FindDeployments", err)
+			return
+		}
+		if len(deployments) == 0 {
+			continue
+		}
+		if err := deployments[0].LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		latest[env.ID] = deployments[0]
+	}
+	ctx.Data["Environments"] = environments
+	ctx.Data["LatestDeployments"] = latest
+
+	ctx.HTML(http.StatusOK, tplEnvironments)
+}
+
+// EnvironmentDeployments render the deployment history of an environment
+func EnvironmentDeployments(ctx *context.Context) {
+	env, err := actions_model.GetEnvironmentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":environment_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetEnvironmentByID", func(err error) bool { return errors.Is(err, util.ErrNotExist) }, err)
+		return
+	}
+	ctx.Data["Title"] = env.Name
+	ctx.Data["PageIsActions"] = true
+	ctx.Data["Environment"] = env
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	opts := actions_model.FindDeploymentsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: convert.ToCorrectPageSize(ctx.FormInt("limit")),
+		},
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	}
+	deployments, total, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+
+	canReview := make(map[int64]bool, len(deployments))
+	for _, d := range deployments {
+		d.Environment = env
+		if err := d.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		canReview[d.ID] = d.Status == actions_model.DeploymentStatusWaiting && d.IsCurrent() &&
+			actions_service.CanReviewDeployment(env, d, ctx.Doer)
+	}
+	ctx.Data["Deployments"] = deployments
+	ctx.Data["CanReview"] = canReview
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.SetDefaultParams(ctx)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplEnvironmentDeployment)
+}
+
+// ApproveDeployment approves a deployment waiting for a reviewer, its job is dispatched once
+// the wait timer of the environment has elapsed
+func ApproveDeployment(ctx *context.Context) {
+	reviewDeployment(ctx, true)
+}
+
+// RejectDeployment rejects a deployment waiting for a reviewer, its job fails
+func RejectDeployment(ctx *context.Context) {
+	reviewDeployment(ctx, false)
+}
+
+func reviewDeployment(ctx *context.Context, approve bool) {
+	d, err := actions_model.GetDeploymentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":deployment_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetDeploymentByID", func(err error) bool { return errors.Is(err, util.ErrNotExist) }, err)
+		return
+	}
+	if err := d.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	redirect := fmt.Sprintf("%s/actions/environments/%d", ctx.Repo.RepoLink, d.EnvironmentID)
+	if !d.IsCurrent() {
+		ctx.Flash.Error(ctx.Tr("actions.environments.review_failed"))
+		ctx.Redirect(redirect)
+		return
+	}
+
+	if err := actions_service.ReviewDeployment(ctx, d, ctx.Doer, approve); err != nil {
+		if errors.Is(err, actions_service.ErrDeploymentReview) {
+			ctx.Flash.Error(ctx.Tr("actions.environments.review_failed"))
+			ctx.Redirect(redirect)
+			return
+		}
+		ctx.ServerError("ReviewDeployment", err)
+		return
+	}
+
+	if approve {
+		ctx.Flash.Success(ctx.Tr("actions.environments.approve_success", d.Job.Name))
+	} else {
+		ctx.Flash.Success(ctx.Tr("actions.environments.reject_success", d.Job.Name))
+	}
+	ctx.Redirect(redirect)
+}
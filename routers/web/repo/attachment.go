@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	issues_model "forgejo.org/models/issues"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/modules/httpcache"
@@ -120,6 +121,15 @@ func ServeAttachment(ctx *context.Context, uuid string) {
 			ctx.Error(http.StatusNotFound)
 			return
 		}
+		canRead, err := issues_model.AttachmentCanBeReadBy(ctx, attach, ctx.Doer, perm)
+		if err != nil {
+			ctx.ServerError("AttachmentCanBeReadBy", err)
+			return
+		}
+		if !canRead {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
 	}
 
 	if attach.ExternalURL != "" {
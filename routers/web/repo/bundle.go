@@ -0,0 +1,72 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	repo_service "forgejo.org/services/repository"
+)
+
+// prepareRepoBundle tells the repository home whether a bundle of the whole repository can be downloaded
+func prepareRepoBundle(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if !setting.RepoBundle.Enabled || repo.GitSize < setting.RepoBundle.MinRepoSize {
+		return
+	}
+	_, err := repo_service.GetRepoBundle(ctx, repo)
+	ctx.Data["HasRepoBundle"] = err == nil
+}
+
+// DownloadBundle serves the bundle of all the branches and tags of the repository, either the most
+// recent one or the one of the given name advertised to cloning clients with bundle-uri
+func DownloadBundle(ctx *context.Context) {
+	if !setting.RepoBundle.Enabled {
+		ctx.NotFound("DownloadBundle", nil)
+		return
+	}
+
+	var bundle *repo_service.RepoBundle
+	var err error
+	if name := ctx.Params("name"); name != "" {
+		bundle, err = repo_service.GetRepoBundleByName(ctx.Repo.Repository.ID, name)
+	} else {
+		bundle, err = repo_service.GetRepoBundle(ctx, ctx.Repo.Repository)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetRepoBundle", err)
+		} else {
+			ctx.ServerError("GetRepoBundle", err)
+		}
+		return
+	}
+
+	downloadName := ctx.Repo.Repository.Name + ".bundle"
+	rPath := bundle.RelativePath()
+	if setting.RepoBundle.Storage.MinioConfig.ServeDirect {
+		// If we have a signed url (S3, object storage), redirect to this directly.
+		u, err := storage.RepoBundles.URL(rPath, downloadName, nil)
+		if u != nil && err == nil {
+			ctx.Redirect(u.String())
+			return
+		}
+	}
+
+	fr, err := storage.RepoBundles.Open(rPath)
+	if err != nil {
+		ctx.ServerError("Open", err)
+		return
+	}
+	defer fr.Close()
+
+	ctx.ServeContent(fr, &context.ServeHeaderOptions{
+		Filename:     downloadName,
+		LastModified: bundle.CreatedUnix.AsLocalTime(),
+	})
+}
@@ -473,7 +473,7 @@ func DrawIssueSummaryCard(ctx *context.Context) {
 		return
 	}
 
-	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) || !issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
 		ctx.Error(http.StatusNotFound)
 		return
 	}
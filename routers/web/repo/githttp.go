@@ -141,6 +141,10 @@ func httpBase(ctx *context.Context) *serviceHandler {
 		askAuth = askAuth || (repo.Owner.Visibility != structs.VisibleTypePublic)
 	}
 
+	if !ctx.CheckOrgAccess(owner) {
+		return nil
+	}
+
 	// check access
 	if askAuth {
 		// rely on the results of Contexter
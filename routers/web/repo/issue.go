@@ -210,6 +210,12 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		mileIDs = []int64{milestoneID}
 	}
 
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, []int64{repo.ID})
+	if err != nil {
+		ctx.ServerError("ConfidentialFilter", err)
+		return
+	}
+
 	var issueStats *issues_model.IssueStats
 	statsOpts := &issues_model.IssuesOptions{
 		RepoIDs:           []int64{repo.ID},
@@ -223,6 +229,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		ReviewedID:        reviewedID,
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
+		Confidential:      confidential,
 	}
 	if keyword != "" {
 		allIssueIDs, err := issueIDsFromSearch(ctx, keyword, statsOpts)
@@ -310,6 +317,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 			IsPull:            isPullOption,
 			LabelIDs:          labelIDs,
 			SortType:          sortType,
+			Confidential:      confidential,
 		})
 		if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
@@ -503,7 +511,15 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 }
 
 func issueIDsFromSearch(ctx *context.Context, keyword string, opts *issues_model.IssuesOptions) ([]int64, error) {
-	ids, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts))
+	searchOpt := issue_indexer.ToSearchOptions(keyword, opts)
+	if err := issue_indexer.ApplyQuery(ctx, searchOpt, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("search.invalid_query", err.Error()), true)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ApplyQuery: %w", err)
+	}
+	ids, _, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		return nil, fmt.Errorf("SearchIssues: %w", err)
 	}
@@ -1407,6 +1423,34 @@ func prepareHiddenCommentType(ctx *context.Context) {
 	}
 }
 
+// redirectTransferredIssue redirects to the new location of an issue which has been
+// transferred to another repository, if the doer can see it there
+func redirectTransferredIssue(ctx *context.Context, notExistErr error) {
+	issue, err := issues_model.GetIssueByIndexOrRedirect(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("GetIssueByIndex", notExistErr)
+		} else {
+			ctx.ServerError("GetIssueByIndexOrRedirect", err)
+		}
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.ServerError("LoadRepo", err)
+		return
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound("GetIssueByIndex", notExistErr)
+		return
+	}
+	ctx.Redirect(issue.Link(), http.StatusMovedPermanently)
+}
+
 // ViewIssue render issue view page
 func ViewIssue(ctx *context.Context) {
 	if ctx.Params(":type") == "issues" {
@@ -1434,7 +1478,7 @@ func ViewIssue(ctx *context.Context) {
 	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
-			ctx.NotFound("GetIssueByIndex", err)
+			redirectTransferredIssue(ctx, err)
 		} else {
 			ctx.ServerError("GetIssueByIndex", err)
 		}
@@ -1443,6 +1487,10 @@ func ViewIssue(ctx *context.Context) {
 	if issue.Repo == nil {
 		issue.Repo = ctx.Repo.Repository
 	}
+	if !issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
+		ctx.NotFound("GetIssueByIndex", nil)
+		return
+	}
 
 	// Make sure type and URL matches.
 	if ctx.Params(":type") == "issues" && issue.IsPull {
@@ -1491,6 +1539,7 @@ func ViewIssue(ctx *context.Context) {
 		ctx.ServerError("filterXRefComments", err)
 		return
 	}
+	issue.Comments = issue_service.FilterReadableComments(issue, issue.Comments, ctx.Repo.Permission)
 
 	ctx.Data["Title"] = fmt.Sprintf("#%d - %s", issue.Index, emoji.ReplaceAliases(issue.Title))
 
@@ -2172,7 +2221,8 @@ func GetActionIssue(ctx *context.Context) *issues_model.Issue {
 
 func checkIssueRights(ctx *context.Context, issue *issues_model.Issue) {
 	if issue.IsPull && !ctx.Repo.CanRead(unit.TypePullRequests) ||
-		!issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) {
+		!issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) ||
+		!issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
 		ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
 	}
 }
@@ -2204,7 +2254,7 @@ func getActionIssues(ctx *context.Context) issues_model.IssueList {
 			ctx.NotFound("some issue's RepoID is incorrect", errors.New("some issue's RepoID is incorrect"))
 			return nil
 		}
-		if issue.IsPull && !prUnitEnabled || !issue.IsPull && !issueUnitEnabled {
+		if issue.IsPull && !prUnitEnabled || !issue.IsPull && !issueUnitEnabled || !issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
 			ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
 			return nil
 		}
@@ -2236,7 +2286,7 @@ func GetIssueInfo(ctx *context.Context) {
 		}
 	} else {
 		// Need to check if Issues are enabled and we can read Issues
-		if !ctx.Repo.CanRead(unit.TypeIssues) {
+		if !ctx.Repo.CanRead(unit.TypeIssues) || !issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) {
 			ctx.Error(http.StatusNotFound)
 			return
 		}
@@ -2702,8 +2752,14 @@ func SearchIssues(ctx *context.Context) {
 			}
 			opts.TeamID = team.ID
 		}
+		if opts.ExcludeOwnerIDs, err = ctx.IPAllowlistDeniedOrgIDs(); err != nil {
+			log.Error("IPAllowlistDeniedOrgIDs: %v", err)
+			ctx.Error(http.StatusInternalServerError)
+			return
+		}
 
-		if opts.AllPublic {
+		// the indexer cannot leave out the public repositories of the excluded owners
+		if opts.AllPublic && len(opts.ExcludeOwnerIDs) == 0 {
 			allPublic = true
 			opts.AllPublic = false // set it false to avoid returning too many repos, we could filter by indexer
 		}
@@ -2792,6 +2848,14 @@ func SearchIssues(ctx *context.Context) {
 		SortBy:              issue_indexer.SortByCreatedDesc,
 	}
 
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, nil)
+	if err != nil {
+		log.Error("ConfidentialFilter: %v", err)
+		ctx.Error(http.StatusInternalServerError)
+		return
+	}
+	searchOpt.Confidential = issue_indexer.ToConfidentialFilter(confidential)
+
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
 	}
@@ -2822,6 +2886,16 @@ func SearchIssues(ctx *context.Context) {
 	//        it's indeed an regression, but I think it is worth to support filtering by indexer first.
 	_ = ctx.FormInt64("priority_repo_id")
 
+	if err := issue_indexer.ApplyQuery(ctx, searchOpt, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		log.Error("ApplyQuery: %v", err)
+		ctx.Error(http.StatusInternalServerError)
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		log.Error("SearchIssues: %v", err)
@@ -2960,6 +3034,12 @@ func ListIssues(ctx *context.Context) {
 		ProjectID: projectID,
 		SortBy:    issue_indexer.SortByCreatedDesc,
 	}
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, []int64{ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, err.Error())
+		return
+	}
+	searchOpt.Confidential = issue_indexer.ToConfidentialFilter(confidential)
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
 	}
@@ -2994,6 +3074,15 @@ func ListIssues(ctx *context.Context) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	if err := issue_indexer.ApplyQuery(ctx, searchOpt, ctx.Doer); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "ApplyQuery", err.Error())
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ApplyQuery", err.Error())
+		return
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err.Error())
@@ -3253,7 +3342,12 @@ func NewComment(ctx *context.Context) {
 		return
 	}
 
-	comment, err := issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Content, attachments)
+	var err error
+	if form.Internal && ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		comment, err = issue_service.CreateInternalIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Content, attachments)
+	} else {
+		comment, err = issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Content, attachments)
+	}
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.JSONError(ctx.Tr("repo.comment.blocked_by_user"))
@@ -3279,7 +3373,8 @@ func UpdateCommentContent(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID ||
+		!comment.Issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) || !comment.CanBeReadBy(comment.Issue, ctx.Repo.Permission) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -3354,7 +3449,8 @@ func DeleteComment(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID ||
+		!comment.Issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) || !comment.CanBeReadBy(comment.Issue, ctx.Repo.Permission) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -3479,7 +3575,8 @@ func ChangeCommentReaction(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID ||
+		!comment.Issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) || !comment.CanBeReadBy(comment.Issue, ctx.Repo.Permission) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -3579,18 +3676,30 @@ func filterXRefComments(ctx *context.Context, issue *issues_model.Issue) error {
 	// Remove comments that the user has no permissions to see
 	for i := 0; i < len(issue.Comments); {
 		c := issue.Comments[i]
-		if issues_model.CommentTypeIsRef(c.Type) && c.RefRepoID != issue.RepoID && c.RefRepoID != 0 {
-			var err error
-			// Set RefRepo for description in template
-			c.RefRepo, err = repo_model.GetRepositoryByID(ctx, c.RefRepoID)
-			if err != nil {
-				return err
+		if issues_model.CommentTypeIsRef(c.Type) && c.RefRepoID != 0 {
+			perm := ctx.Repo.Permission
+			if c.RefRepoID != issue.RepoID {
+				var err error
+				// Set RefRepo for description in template
+				c.RefRepo, err = repo_model.GetRepositoryByID(ctx, c.RefRepoID)
+				if err != nil {
+					return err
+				}
+				perm, err = access_model.GetUserRepoPermission(ctx, c.RefRepo, ctx.Doer)
+				if err != nil {
+					return err
+				}
+				if !perm.CanReadIssuesOrPulls(c.RefIsPull) {
+					issue.Comments = append(issue.Comments[:i], issue.Comments[i+1:]...)
+					continue
+				}
 			}
-			perm, err := access_model.GetUserRepoPermission(ctx, c.RefRepo, ctx.Doer)
-			if err != nil {
+			// the referencing issue may be confidential
+			refIssue, err := issues_model.GetIssueByID(ctx, c.RefIssueID)
+			if err != nil && !issues_model.IsErrIssueNotExist(err) {
 				return err
 			}
-			if !perm.CanReadIssuesOrPulls(c.RefIsPull) {
+			if refIssue != nil && !refIssue.CanBeReadBy(ctx.Doer, perm) {
 				issue.Comments = append(issue.Comments[:i], issue.Comments[i+1:]...)
 				continue
 			}
@@ -3626,7 +3735,8 @@ func GetCommentAttachments(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID ||
+		!comment.Issue.CanBeReadBy(ctx.Doer, ctx.Repo.Permission) || !comment.CanBeReadBy(comment.Issue, ctx.Repo.Permission) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
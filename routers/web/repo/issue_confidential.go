@@ -0,0 +1,31 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// SetIssueConfidential makes an issue confidential or not
+func SetIssueConfidential(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.SetConfidential(ctx, issue, ctx.FormBool("confidential")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+			return
+		}
+		ctx.ServerError("SetConfidential", err)
+		return
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
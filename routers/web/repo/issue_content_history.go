@@ -46,6 +46,17 @@ func GetContentHistoryList(ctx *context.Context) {
 	}
 
 	commentID := ctx.FormInt64("comment_id")
+	if commentID != 0 {
+		comment, err := issues_model.GetCommentByID(ctx, commentID)
+		if err != nil {
+			ctx.NotFoundOrServerError("GetCommentByID", issues_model.IsErrCommentNotExist, err)
+			return
+		}
+		if comment.IssueID != issue.ID || !comment.CanBeReadBy(issue, ctx.Repo.Permission) {
+			ctx.NotFound("CompareIssueID", issues_model.ErrCommentNotExist{})
+			return
+		}
+	}
 	items, _ := issues_model.FetchIssueContentHistoryList(ctx, issue.ID, commentID)
 
 	// render history list to HTML for frontend dropdown items: (name, value)
@@ -139,6 +150,12 @@ func GetContentHistoryDetail(ctx *context.Context) {
 			log.Error("can not get comment for issue content history %v. err=%v", historyID, err)
 			return
 		}
+		if !comment.CanBeReadBy(issue, ctx.Repo.Permission) {
+			ctx.JSON(http.StatusNotFound, map[string]any{
+				"message": "Can not find the content history",
+			})
+			return
+		}
 	}
 
 	// get the previous history revision (if exists)
@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"strings"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+// TransferIssue moves an issue to another repository given as "owner/repository"
+func TransferIssue(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.TransferIssueForm)
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ownerName, repoName, _ := strings.Cut(strings.TrimSpace(form.Repo), "/")
+	newRepo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.repo_not_exist", form.Repo))
+			return
+		}
+		ctx.ServerError("GetRepositoryByOwnerAndName", err)
+		return
+	}
+
+	if err := issue_service.TransferIssue(ctx, ctx.Doer, issue, newRepo); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			// don't leak the existence of private repositories
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.repo_not_exist", form.Repo))
+			return
+		}
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("repo.issues.transfer.failed", err.Error()))
+			return
+		}
+		ctx.ServerError("TransferIssue", err)
+		return
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
@@ -25,6 +25,8 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	"slices"
 )
 
 const (
@@ -328,6 +330,16 @@ func ViewProject(ctx *context.Context) {
 		ctx.ServerError("LoadIssuesOfColumns", err)
 		return
 	}
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, []int64{project.RepoID})
+	if err != nil {
+		ctx.ServerError("ConfidentialFilter", err)
+		return
+	}
+	for columnID, issues := range issuesMap {
+		issuesMap[columnID] = slices.DeleteFunc(issues, func(issue *issues_model.Issue) bool {
+			return !confidential.Allows(issue)
+		})
+	}
 
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*attachment_model.Attachment)
@@ -765,6 +765,7 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 	if pull.IsFilesConflicted() {
 		ctx.Data["IsPullFilesConflicted"] = true
 		ctx.Data["ConflictedFiles"] = pull.ConflictedFiles
+		ctx.Data["CanResolveConflicts"] = git.SupportGitMergeTree
 	}
 
 	ctx.Data["NumCommits"] = len(compareInfo.Commits)
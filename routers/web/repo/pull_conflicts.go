@@ -0,0 +1,146 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+	files_service "forgejo.org/services/repository/files"
+)
+
+const tplPullConflicts base.TplName = "repo/pulls/conflicts"
+
+// preparePullConflicts loads the conflicts of the pull request if the doer may update its head branch
+func preparePullConflicts(ctx *context.Context) (*issues_model.Issue, *pull_service.PullConflicts) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return nil, nil
+	}
+	pr := issue.PullRequest
+	if issue.IsClosed || pr.HasMerged || pr.HeadRepo == nil || !git.SupportGitMergeTree {
+		ctx.NotFound("PullConflicts", nil)
+		return nil, nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		ctx.ServerError("LoadBaseRepo", err)
+		return nil, nil
+	}
+	allowed, _, err := pull_service.IsUserAllowedToUpdate(ctx, pr, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("IsUserAllowedToUpdate", err)
+		return nil, nil
+	}
+	if !allowed {
+		ctx.NotFound("PullConflicts", nil)
+		return nil, nil
+	}
+
+	conflicts, err := pull_service.GetPullConflicts(ctx, pr)
+	if err != nil {
+		ctx.ServerError("GetPullConflicts", err)
+		return nil, nil
+	}
+	if len(conflicts.Files) == 0 {
+		ctx.Flash.Info(ctx.Tr("repo.pulls.conflicts.none"))
+		ctx.Redirect(issue.Link())
+		return nil, nil
+	}
+	return issue, conflicts
+}
+
+// PullConflicts renders the conflicts between the head and the target branches of a pull request
+func PullConflicts(ctx *context.Context) {
+	issue, conflicts := preparePullConflicts(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	unsupported := false
+	for _, file := range conflicts.Files {
+		unsupported = unsupported || file.Unsupported
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.pulls.conflicts.title", issue.Index)
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["Conflicts"] = conflicts
+	ctx.Data["HasUnsupportedConflicts"] = unsupported
+	ctx.Data["HeadBranch"] = issue.PullRequest.HeadBranch
+	ctx.Data["BaseBranch"] = issue.PullRequest.BaseBranch
+	ctx.Data["CommitMessage"] = fmt.Sprintf("Merge branch '%s' into %s", issue.PullRequest.BaseBranch, issue.PullRequest.HeadBranch)
+	ctx.HTML(http.StatusOK, tplPullConflicts)
+}
+
+// PullConflictsPost commits the resolution of the conflicts as a merge of the target branch into the head branch
+func PullConflictsPost(ctx *context.Context) {
+	issue, conflicts := preparePullConflicts(ctx)
+	if ctx.Written() {
+		return
+	}
+	conflictsLink := issue.Link() + "/conflicts"
+
+	if ctx.FormString("head_commit_id") != conflicts.HeadCommitID || ctx.FormString("base_commit_id") != conflicts.BaseCommitID {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.outdated"))
+		ctx.Redirect(conflictsLink)
+		return
+	}
+
+	files := make(map[string]string, len(conflicts.Files))
+	for i, file := range conflicts.Files {
+		choices := make([]pull_service.ConflictChoice, file.NumConflicts())
+		custom := make([]string, file.NumConflicts())
+		for j := range choices {
+			choices[j] = pull_service.ConflictChoice(ctx.FormString(fmt.Sprintf("choice_%d_%d", i, j)))
+			custom[j] = ctx.Req.FormValue(fmt.Sprintf("custom_%d_%d", i, j))
+		}
+		content, err := file.Resolve(choices, custom)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.unresolved", err.Error()))
+				ctx.Redirect(conflictsLink)
+				return
+			}
+			ctx.ServerError("Resolve", err)
+			return
+		}
+		files[file.Path] = content
+	}
+
+	message := ctx.FormTrim("message")
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into %s", issue.PullRequest.BaseBranch, issue.PullRequest.HeadBranch)
+	}
+
+	if _, err := files_service.ResolvePullConflicts(ctx, ctx.Doer, issue.PullRequest, &files_service.ResolveConflictsOptions{
+		HeadCommitID: conflicts.HeadCommitID,
+		BaseCommitID: conflicts.BaseCommitID,
+		Files:        files,
+		Message:      message,
+	}); err != nil {
+		switch {
+		case models.IsErrCommitIDDoesNotMatch(err), git.IsErrPushOutOfDate(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.outdated"))
+		case models.IsErrUserCannotCommit(err), models.IsErrFilePathProtected(err), git.IsErrPushRejected(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.not_allowed"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.conflicts.unresolved", err.Error()))
+		default:
+			ctx.ServerError("ResolvePullConflicts", err)
+			return
+		}
+		ctx.Redirect(conflictsLink)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.conflicts.success"))
+	ctx.Redirect(issue.Link())
+}
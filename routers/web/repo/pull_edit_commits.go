@@ -0,0 +1,133 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	pull_service "forgejo.org/services/pull"
+)
+
+const tplPullEditCommits base.TplName = "repo/pulls/edit_commits"
+
+// preparePullEditCommits loads the pull request if the doer may rewrite its head branch
+func preparePullEditCommits(ctx *context.Context) *issues_model.Issue {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return nil
+	}
+	pr := issue.PullRequest
+	if issue.IsClosed || pr.HasMerged || pr.HeadRepo == nil || pr.Flow == issues_model.PullRequestFlowAGit {
+		ctx.NotFound("PullEditCommits", nil)
+		return nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		ctx.ServerError("LoadBaseRepo", err)
+		return nil
+	}
+	// rewriting the head branch needs to force-push it, like updating it by rebase
+	_, allowed, err := pull_service.IsUserAllowedToUpdate(ctx, pr, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("IsUserAllowedToUpdate", err)
+		return nil
+	}
+	if !allowed {
+		ctx.NotFound("PullEditCommits", nil)
+		return nil
+	}
+	return issue
+}
+
+// PullEditCommits renders the commits of the pull request to reorder, squash, reword or drop them
+func PullEditCommits(ctx *context.Context) {
+	issue := preparePullEditCommits(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	headCommitID, commits, err := pull_service.GetEditableCommits(ctx, issue.PullRequest)
+	if err != nil {
+		if git.IsErrBranchNotExist(err) {
+			ctx.NotFound("GetEditableCommits", err)
+			return
+		}
+		ctx.ServerError("GetEditableCommits", err)
+		return
+	}
+	hasMergeCommits := false
+	for _, commit := range commits {
+		hasMergeCommits = hasMergeCommits || commit.ParentCount() > 1
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.pulls.edit_commits.title", issue.Index)
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["HeadCommitID"] = headCommitID
+	ctx.Data["Commits"] = commits
+	ctx.Data["HasMergeCommits"] = hasMergeCommits
+	ctx.Data["HeadBranch"] = issue.PullRequest.HeadBranch
+	ctx.HTML(http.StatusOK, tplPullEditCommits)
+}
+
+// PullEditCommitsPost rewrites the commits of the pull request and force-pushes its head branch
+func PullEditCommitsPost(ctx *context.Context) {
+	issue := preparePullEditCommits(ctx)
+	if ctx.Written() {
+		return
+	}
+	editLink := issue.Link() + "/edit_commits"
+
+	if err := ctx.Req.ParseForm(); err != nil {
+		ctx.ServerError("ParseForm", err)
+		return
+	}
+	commitIDs := ctx.Req.Form["commit"]
+	steps := make([]*pull_service.EditCommitsStep, 0, len(commitIDs))
+	positions := make(map[*pull_service.EditCommitsStep]int, len(commitIDs))
+	for i, commitID := range commitIDs {
+		step := &pull_service.EditCommitsStep{
+			CommitID: commitID,
+			Action:   pull_service.EditCommitsAction(ctx.FormString("action_" + commitID)),
+			Message:  ctx.FormString("message_" + commitID),
+		}
+		position, err := strconv.Atoi(ctx.FormTrim("position_" + commitID))
+		if err != nil {
+			position = i + 1
+		}
+		positions[step] = position
+		steps = append(steps, step)
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return positions[steps[i]] < positions[steps[j]]
+	})
+
+	if _, err := pull_service.EditCommits(ctx, issue.PullRequest, ctx.Doer, ctx.FormString("head_commit_id"), steps); err != nil {
+		switch {
+		case models.IsErrSHADoesNotMatch(err), git.IsErrPushOutOfDate(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.edit_commits.outdated"))
+		case models.IsErrRebaseConflicts(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.edit_commits.conflict", err.(models.ErrRebaseConflicts).CommitSHA))
+		case git.IsErrPushRejected(err):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.edit_commits.rejected", err.(*git.ErrPushRejected).Message))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.pulls.edit_commits.invalid", err.Error()))
+		default:
+			ctx.ServerError("EditCommits", err)
+			return
+		}
+		ctx.Redirect(editLink)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.edit_commits.success"))
+	ctx.Redirect(issue.Link())
+}
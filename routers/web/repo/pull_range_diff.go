@@ -0,0 +1,123 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/services/context"
+	"forgejo.org/services/gitdiff"
+)
+
+const tplPullRangeDiff base.TplName = "repo/pulls/range_diff"
+
+// getLastReviewedCommitID returns the commit the doer last reviewed in the pull request, empty if none
+func getLastReviewedCommitID(ctx *context.Context, issue *issues_model.Issue) (string, error) {
+	reviews, err := issues_model.FindLatestReviews(ctx, issues_model.FindReviewOptions{
+		IssueID:    issue.ID,
+		ReviewerID: ctx.Doer.ID,
+		Types: []issues_model.ReviewType{
+			issues_model.ReviewTypeApprove,
+			issues_model.ReviewTypeComment,
+			issues_model.ReviewTypeReject,
+		},
+	})
+	if err != nil && !issues_model.IsErrReviewNotExist(err) {
+		return "", err
+	}
+	if len(reviews) == 0 {
+		return "", nil
+	}
+	return reviews[0].CommitID, nil
+}
+
+// PullRangeDiff compares two pushed revisions of the pull request commit by commit, which
+// is meaningful even when the head branch was rebased in between. Without a "from" revision,
+// the changes since the last review of the doer are shown. The revisions must be the current head,
+// or a head recorded in the timeline of the pull request.
+func PullRangeDiff(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pr := issue.PullRequest
+	gitRepo := ctx.Repo.GitRepo
+
+	ctx.Data["Title"] = ctx.Tr("repo.pulls.range_diff.title", issue.Index)
+	ctx.Data["PageIsPullList"] = true
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		ctx.ServerError("GetRefCommitID", err)
+		return
+	}
+	fromID := ctx.FormTrim("from")
+	toID := ctx.FormTrim("to")
+	if toID == "" {
+		toID = headCommitID
+	}
+	if fromID == "" {
+		ctx.Data["SinceLastReview"] = true
+		if ctx.Doer != nil {
+			lastReviewedCommitID, err := getLastReviewedCommitID(ctx, issue)
+			if err != nil {
+				ctx.ServerError("getLastReviewedCommitID", err)
+				return
+			}
+			fromID = lastReviewedCommitID
+		}
+		if fromID == "" {
+			ctx.HTML(http.StatusOK, tplPullRangeDiff)
+			return
+		}
+	}
+
+	// the revisions may come from the query string, only compare the revisions of this pull request:
+	// the range of commits to compare is otherwise unbounded
+	revisions, err := issues_model.GetPullRequestRevisions(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetPullRequestRevisions", err)
+		return
+	}
+	revisions.Add(headCommitID)
+	fromCommit, err := gitRepo.GetCommit(fromID)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound("GetCommit", err)
+			return
+		}
+		ctx.ServerError("GetCommit", err)
+		return
+	}
+	toCommit, err := gitRepo.GetCommit(toID)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound("GetCommit", err)
+			return
+		}
+		ctx.ServerError("GetCommit", err)
+		return
+	}
+
+	if !revisions.Contains(fromCommit.ID.String()) || !revisions.Contains(toCommit.ID.String()) {
+		ctx.NotFound("PullRangeDiff", nil)
+		return
+	}
+
+	baseRef := git.BranchPrefix + pr.BaseBranch
+	if pr.HasMerged {
+		baseRef = pr.MergeBase
+	}
+	rangeDiff, err := gitdiff.GetRangeDiff(gitRepo, baseRef, fromCommit.ID.String(), toCommit.ID.String())
+	if err != nil {
+		ctx.ServerError("GetRangeDiff", err)
+		return
+	}
+
+	ctx.Data["RangeDiff"] = rangeDiff
+	ctx.HTML(http.StatusOK, tplPullRangeDiff)
+}
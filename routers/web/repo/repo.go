@@ -613,6 +613,13 @@ func SearchRepo(ctx *context.Context) {
 		IncludeDescription: ctx.FormBool("includeDesc"),
 	}
 
+	var err error
+	if opts.ExcludeOwnerIDs, err = ctx.IPAllowlistDeniedOrgIDs(); err != nil {
+		log.Error("IPAllowlistDeniedOrgIDs: %v", err)
+		ctx.JSON(http.StatusInternalServerError, nil)
+		return
+	}
+
 	if ctx.FormString("template") != "" {
 		opts.Template = optional.Some(ctx.FormBool("template"))
 	}
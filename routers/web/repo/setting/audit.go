@@ -0,0 +1,193 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+)
+
+const (
+	tplRepoAudit  base.TplName = "repo/settings/audit"
+	tplOrgAudit   base.TplName = "org/settings/audit"
+	tplAdminAudit base.TplName = "admin/audit"
+
+	auditExportPageSize = 100
+)
+
+type auditCtx struct {
+	OwnerID  int64
+	RepoID   int64
+	Template base.TplName
+	Link     string
+}
+
+func getAuditCtx(ctx *context.Context) (*auditCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &auditCtx{
+			RepoID:   ctx.Repo.Repository.ID,
+			Template: tplRepoAudit,
+			Link:     ctx.Repo.RepoLink + "/settings/audit",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &auditCtx{
+			OwnerID:  ctx.Org.Organization.ID,
+			Template: tplOrgAudit,
+			Link:     ctx.Org.OrgLink + "/settings/audit",
+		}, nil
+	}
+
+	if ctx.Data["PageIsAdmin"] == true {
+		return &auditCtx{
+			Template: tplAdminAudit,
+			Link:     setting.AppSubURL + "/admin/audit",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Audit context")
+}
+
+// auditFindOptions reads the filters of the audit log from the query, found is false when the
+// events can not match them, e.g. if the actor does not exist
+func auditFindOptions(ctx *context.Context, aCtx *auditCtx) (opts audit_model.FindEventsOptions, found bool, err error) {
+	opts = audit_model.FindEventsOptions{
+		OwnerID: aCtx.OwnerID,
+		RepoID:  aCtx.RepoID,
+		Action:  audit_model.Action(ctx.FormString("action")),
+	}
+
+	if actor := ctx.FormTrim("actor"); actor != "" {
+		u, err := user_model.GetUserByName(ctx, actor)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return opts, false, nil
+			}
+			return opts, false, err
+		}
+		opts.ActorID = u.ID
+	}
+
+	if since, err := time.ParseInLocation("2006-01-02", ctx.FormString("since"), setting.DefaultUILocation); err == nil {
+		opts.Since = timeutil.TimeStamp(since.Unix())
+	}
+	if until, err := time.ParseInLocation("2006-01-02", ctx.FormString("until"), setting.DefaultUILocation); err == nil {
+		// the events of the last day are included
+		opts.Until = timeutil.TimeStamp(until.AddDate(0, 0, 1).Unix())
+	}
+	return opts, true, nil
+}
+
+// AuditLog render the audit log of a repository, an organization or the instance
+func AuditLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	aCtx, err := getAuditCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAuditCtx", err)
+		return
+	}
+
+	opts, found, err := auditFindOptions(ctx, aCtx)
+	if err != nil {
+		ctx.ServerError("auditFindOptions", err)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.UI.Admin.NoticePagingNum}
+
+	var events []*audit_model.Event
+	var total int64
+	if found {
+		events, total, err = db.FindAndCount[audit_model.Event](ctx, opts)
+		if err != nil {
+			ctx.ServerError("FindAndCount", err)
+			return
+		}
+	}
+
+	ctx.Data["Events"] = events
+	ctx.Data["Actions"] = audit_model.AllActions
+	ctx.Data["AuditLink"] = aCtx.Link
+	ctx.Data["FilterAction"] = ctx.FormString("action")
+	ctx.Data["FilterActor"] = ctx.FormTrim("actor")
+	ctx.Data["FilterSince"] = ctx.FormString("since")
+	ctx.Data["FilterUntil"] = ctx.FormString("until")
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamString("action", ctx.FormString("action"))
+	pager.AddParamString("actor", ctx.FormTrim("actor"))
+	pager.AddParamString("since", ctx.FormString("since"))
+	pager.AddParamString("until", ctx.FormString("until"))
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, aCtx.Template)
+}
+
+// AuditLogExport exports the events of the audit log matching the filters as a JSON array
+func AuditLogExport(ctx *context.Context) {
+	aCtx, err := getAuditCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAuditCtx", err)
+		return
+	}
+
+	opts, found, err := auditFindOptions(ctx, aCtx)
+	if err != nil {
+		ctx.ServerError("auditFindOptions", err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.Header().Set("Content-Disposition", `attachment; filename="audit-log.json"`)
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	_, _ = ctx.Resp.Write([]byte("["))
+	count := 0
+	for page := 1; found; page++ {
+		opts.ListOptions = db.ListOptions{Page: page, PageSize: auditExportPageSize}
+		events, err := db.Find[audit_model.Event](ctx, opts)
+		if err != nil {
+			// the response has already been started, the export is left truncated
+			log.Error("Unable to export the audit log: %v", err)
+			return
+		}
+		for _, e := range events {
+			line, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			if count > 0 {
+				_, _ = ctx.Resp.Write([]byte(",\n"))
+			}
+			_, _ = ctx.Resp.Write(line)
+			count++
+		}
+		if len(events) < auditExportPageSize {
+			break
+		}
+	}
+	_, _ = ctx.Resp.Write([]byte("]\n"))
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -17,6 +18,7 @@ import (
 	"forgejo.org/modules/log"
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/mailer"
 	org_service "forgejo.org/services/org"
@@ -117,6 +119,9 @@ func CollaborationPost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionCollaboratorAdd, audit_service.CollaboratorTarget(ctx.Repo.Repository, u),
+		nil, map[string]string{"mode": perm.AccessModeWrite.String()})
+
 	if setting.Service.EnableNotifyMail {
 		mailer.SendCollaboratorMail(u, ctx.Doer, ctx.Repo.Repository)
 	}
@@ -127,18 +132,35 @@ func CollaborationPost(ctx *context.Context) {
 
 // ChangeCollaborationAccessMode response for changing access of a collaboration
 func ChangeCollaborationAccessMode(ctx *context.Context) {
+	uid := ctx.FormInt64("uid")
+	mode := perm.AccessMode(ctx.FormInt("mode"))
+	collaboration, err := repo_model.GetCollaboration(ctx, ctx.Repo.Repository.ID, uid)
+	if err != nil {
+		log.Error("GetCollaboration: %v", err)
+		return
+	}
 	if err := repo_model.ChangeCollaborationAccessMode(
 		ctx,
 		ctx.Repo.Repository,
-		ctx.FormInt64("uid"),
-		perm.AccessMode(ctx.FormInt("mode"))); err != nil {
+		uid,
+		mode); err != nil {
 		log.Error("ChangeCollaborationAccessMode: %v", err)
+		return
+	}
+	if collaboration != nil && collaboration.Mode != mode && mode > perm.AccessModeNone && mode <= perm.AccessModeOwner {
+		u, err := user_model.GetUserByID(ctx, uid)
+		if err != nil {
+			log.Error("GetUserByID: %v", err)
+			return
+		}
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionCollaboratorAccess, audit_service.CollaboratorTarget(ctx.Repo.Repository, u),
+			map[string]string{"mode": collaboration.Mode.String()}, map[string]string{"mode": mode.String()})
 	}
 }
 
 // DeleteCollaboration delete a collaboration for a repository
 func DeleteCollaboration(ctx *context.Context) {
-	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
+	if err := repo_service.DeleteCollaboration(ctx, ctx.Doer, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteCollaboration: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.settings.remove_collaborator_success"))
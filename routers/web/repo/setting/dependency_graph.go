@@ -0,0 +1,261 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	depgraph_model "forgejo.org/models/depgraph"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/context"
+	depgraph_service "forgejo.org/services/depgraph"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplRepoDependencyGraph base.TplName = "repo/settings/dependency_graph"
+	tplOrgDependencyGraph  base.TplName = "org/settings/dependency_graph"
+)
+
+type dependencyGraphCtx struct {
+	OwnerID  int64
+	RepoID   int64
+	Template base.TplName
+	Link     string
+}
+
+func getDependencyGraphCtx(ctx *context.Context) (*dependencyGraphCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &dependencyGraphCtx{
+			RepoID:   ctx.Repo.Repository.ID,
+			Template: tplRepoDependencyGraph,
+			Link:     ctx.Repo.RepoLink + "/settings/dependency_graph",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &dependencyGraphCtx{
+			OwnerID:  ctx.Org.Organization.ID,
+			Template: tplOrgDependencyGraph,
+			Link:     ctx.Org.OrgLink + "/settings/dependency_graph",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set DependencyGraph context")
+}
+
+// prepareDependencies sets the state of the dependency graph of the repository and a page of its dependencies
+func prepareDependencies(ctx *context.Context, dCtx *dependencyGraphCtx) {
+	graph, err := depgraph_model.GetGraph(ctx, dCtx.RepoID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetGraph", err)
+		return
+	}
+	ctx.Data["Graph"] = graph
+
+	keyword := strings.TrimSpace(ctx.FormString("q"))
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts := depgraph_model.FindDependenciesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		RepoID:      dCtx.RepoID,
+		Keyword:     keyword,
+	}
+	deps, total, err := depgraph_model.FindDependencies(ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindDependencies", err)
+		return
+	}
+	ctx.Data["Dependencies"] = deps
+	ctx.Data["Keyword"] = keyword
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamString("tab", "dependencies")
+	pager.AddParamString("q", keyword)
+	ctx.Data["Page"] = pager
+}
+
+// prepareDependencyAlerts sets a page of the dependency alerts of the repository or of the organization
+func prepareDependencyAlerts(ctx *context.Context, dCtx *dependencyGraphCtx) {
+	opts := depgraph_model.FindAlertsOptions{
+		OwnerID: dCtx.OwnerID,
+		RepoID:  dCtx.RepoID,
+	}
+	state := ctx.FormString("state")
+	switch state {
+	case "fixed":
+		opts.State, opts.IsFiltered = depgraph_model.AlertStateFixed, true
+	case "dismissed":
+		opts.State, opts.IsFiltered = depgraph_model.AlertStateDismissed, true
+	case "all":
+	default:
+		state = "open"
+		opts.State, opts.IsFiltered = depgraph_model.AlertStateOpen, true
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum}
+
+	alerts, total, err := depgraph_model.FindAlerts(ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAlerts", err)
+		return
+	}
+	if err := alerts.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Alerts"] = alerts
+	ctx.Data["FilterState"] = state
+	ctx.Data["DismissReasons"] = depgraph_model.DismissReasons
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamString("state", state)
+	ctx.Data["Page"] = pager
+}
+
+// DependencyGraph renders the dependency alerts of a repository or of an organization, and the dependencies of
+// a repository
+func DependencyGraph(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.dependency_graph")
+	ctx.Data["PageIsSettingsDependencyGraph"] = true
+
+	dCtx, err := getDependencyGraphCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getDependencyGraphCtx", err)
+		return
+	}
+	ctx.Data["DependencyGraphLink"] = dCtx.Link
+
+	count, err := depgraph_model.CountVulnerabilities(ctx)
+	if err != nil {
+		ctx.ServerError("CountVulnerabilities", err)
+		return
+	}
+	ctx.Data["VulnerabilityCount"] = count
+
+	if dCtx.RepoID > 0 && ctx.FormString("tab") == "dependencies" {
+		ctx.Data["Tab"] = "dependencies"
+		prepareDependencies(ctx, dCtx)
+	} else {
+		ctx.Data["Tab"] = "alerts"
+		prepareDependencyAlerts(ctx, dCtx)
+	}
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, dCtx.Template)
+}
+
+// DependencyGraphUpdate parses the manifests of the repository again in the background
+func DependencyGraphUpdate(ctx *context.Context) {
+	if err := depgraph_service.AddRepoToQueue(ctx.Repo.Repository); err != nil {
+		ctx.ServerError("AddRepoToQueue", err)
+		return
+	}
+	ctx.Flash.Info(ctx.Tr("repo.settings.dependency_graph.update_started"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/dependency_graph?tab=dependencies")
+}
+
+// dependencyAlertTarget returns the target of the audit events about an alert
+func dependencyAlertTarget(alert *depgraph_model.Alert) audit_service.Target {
+	return audit_service.Target{
+		OwnerID: alert.Repo.OwnerID,
+		RepoID:  alert.RepoID,
+		Type:    "dependency_alert",
+		ID:      alert.ID,
+		Name:    alert.VulnerabilityID + " " + alert.PackageName,
+	}
+}
+
+func getDependencyAlert(ctx *context.Context) *depgraph_model.Alert {
+	alert, err := depgraph_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetAlertByID", err)
+		} else {
+			ctx.ServerError("GetAlertByID", err)
+		}
+		return nil
+	}
+	alert.Repo = ctx.Repo.Repository
+	return alert
+}
+
+// DependencyAlertDismiss dismisses a dependency alert of the repository
+func DependencyAlertDismiss(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DependencyAlertDismissForm)
+	link := ctx.Repo.RepoLink + "/settings/dependency_graph"
+
+	alert := getDependencyAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+	if !alert.IsOpen() {
+		ctx.Redirect(link)
+		return
+	}
+
+	if err := depgraph_model.DismissAlert(ctx, alert, ctx.Doer.ID, form.Reason, strings.TrimSpace(form.Comment)); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("DismissAlert", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionDependencyAlertDismiss, dependencyAlertTarget(alert), nil,
+		map[string]any{"reason": alert.DismissReason, "comment": alert.Comment})
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.dependency_graph.alert_dismissed"))
+	ctx.Redirect(link)
+}
+
+// DependencyAlertReopen opens again a dismissed dependency alert of the repository
+func DependencyAlertReopen(ctx *context.Context) {
+	link := ctx.Repo.RepoLink + "/settings/dependency_graph"
+
+	alert := getDependencyAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+	if alert.State != depgraph_model.AlertStateDismissed {
+		ctx.Redirect(link)
+		return
+	}
+
+	before := map[string]any{"reason": alert.DismissReason, "comment": alert.Comment}
+	if err := depgraph_model.ReopenAlert(ctx, alert); err != nil {
+		ctx.ServerError("ReopenAlert", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionDependencyAlertReopen, dependencyAlertTarget(alert), before, nil)
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.dependency_graph.alert_reopened"))
+	ctx.Redirect(link)
+}
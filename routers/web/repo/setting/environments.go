@@ -0,0 +1,309 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	secret_model "forgejo.org/models/secret"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	actions_service "forgejo.org/services/actions"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	secret_service "forgejo.org/services/secrets"
+)
+
+const (
+	tplEnvironments    base.TplName = "repo/settings/environments"
+	tplEnvironmentEdit base.TplName = "repo/settings/environment_edit"
+)
+
+// Environments render the deployment environments of a repository
+func Environments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.environments")
+	ctx.Data["PageIsSharedSettingsEnvironments"] = true
+
+	environments, err := db.Find[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = environments
+
+	ctx.HTML(http.StatusOK, tplEnvironments)
+}
+
+// EnvironmentsPost response for creating a deployment environment
+func EnvironmentsPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.EditEnvironmentForm)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/actions/environments")
+		return
+	}
+
+	env, err := actions_service.CreateEnvironment(ctx, ctx.Repo.Repository, environmentOptions(form))
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.environments.invalid", err.Error()))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings/actions/environments")
+			return
+		}
+		ctx.ServerError("CreateEnvironment", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.environments.add_success", env.Name))
+	ctx.Redirect(environmentLink(ctx, env))
+}
+
+// EnvironmentEdit render the protection rules, the secrets and the variables of a deployment environment
+func EnvironmentEdit(ctx *context.Context) {
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	reviewers, err := user_model.GetUsersByIDs(ctx, env.ReviewerIDs)
+	if err != nil {
+		ctx.ServerError("GetUsersByIDs", err)
+		return
+	}
+	names := make([]string, 0, len(reviewers))
+	for _, u := range reviewers {
+		names = append(names, u.Name)
+	}
+	ctx.Data["Reviewers"] = strings.Join(names, ", ")
+
+	ctx.HTML(http.StatusOK, tplEnvironmentEdit)
+}
+
+// EnvironmentEditPost response for updating the protection rules of a deployment environment
+func EnvironmentEditPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.EditEnvironmentForm)
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	redirect := environmentLink(ctx, env)
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirect)
+		return
+	}
+
+	if err := actions_service.UpdateEnvironment(ctx, ctx.Repo.Repository, env, environmentOptions(form)); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.environments.invalid", err.Error()))
+			ctx.Redirect(redirect)
+			return
+		}
+		ctx.ServerError("UpdateEnvironment", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.environments.update_success", env.Name))
+	ctx.Redirect(redirect)
+}
+
+// EnvironmentDelete response for deleting a deployment environment
+func EnvironmentDelete(ctx *context.Context) {
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		ctx.ServerError("DeleteEnvironment", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.environments.deletion_success", env.Name))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/actions/environments")
+}
+
+// EnvironmentSecrets render the secrets of a deployment environment
+func EnvironmentSecrets(ctx *context.Context) {
+	ctx.Data["PageType"] = "secrets"
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	secrets, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindSecrets", err)
+		return
+	}
+	ctx.Data["Secrets"] = secrets
+
+	ctx.HTML(http.StatusOK, tplRepoSecrets)
+}
+
+// EnvironmentSecretsPost response for creating or updating a secret of a deployment environment
+func EnvironmentSecretsPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AddSecretForm)
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+
+	s, _, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, env.RepoID, env.ID, form.Name, util.ReserveLineBreakForTextarea(form.Data))
+	if err != nil {
+		log.Error("CreateOrUpdateEnvironmentSecret failed: %v", err)
+		ctx.JSONError(ctx.Tr("secrets.creation.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("secrets.creation.success", s.Name))
+	ctx.JSONRedirect(environmentLink(ctx, env) + "/secrets")
+}
+
+// EnvironmentSecretsDelete response for deleting a secret of a deployment environment
+func EnvironmentSecretsDelete(ctx *context.Context) {
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	id := ctx.FormInt64("id")
+	if err := secret_service.DeleteEnvironmentSecretByID(ctx, env.RepoID, env.ID, id); err != nil {
+		log.Error("DeleteEnvironmentSecretByID(%d) failed: %v", id, err)
+		ctx.JSONError(ctx.Tr("secrets.deletion.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("secrets.deletion.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env) + "/secrets")
+}
+
+// EnvironmentVariables render the variables of a deployment environment
+func EnvironmentVariables(ctx *context.Context) {
+	ctx.Data["PageType"] = "variables"
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	variables, err := db.Find[actions_model.ActionVariable](ctx, actions_model.FindVariablesOpts{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindVariables", err)
+		return
+	}
+	ctx.Data["Variables"] = variables
+
+	ctx.HTML(http.StatusOK, tplRepoVariables)
+}
+
+// EnvironmentVariableCreate response for creating a variable of a deployment environment
+func EnvironmentVariableCreate(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.EditVariableForm)
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+
+	v, err := actions_service.CreateEnvironmentVariable(ctx, env.RepoID, env.ID, form.Name, form.Data)
+	if err != nil {
+		log.Error("CreateEnvironmentVariable: %v", err)
+		ctx.JSONError(ctx.Tr("actions.variables.creation.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.variables.creation.success", v.Name))
+	ctx.JSONRedirect(environmentLink(ctx, env) + "/variables")
+}
+
+// EnvironmentVariableUpdate response for updating a variable of a deployment environment
+func EnvironmentVariableUpdate(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.EditVariableForm)
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+
+	id := ctx.ParamsInt64(":variable_id")
+	if ok, err := actions_service.UpdateEnvironmentVariable(ctx, id, env.RepoID, env.ID, form.Name, form.Data); err != nil || !ok {
+		if !ok {
+			ctx.JSONError(ctx.Tr("actions.variables.not_found"))
+		} else {
+			log.Error("UpdateEnvironmentVariable: %v", err)
+			ctx.JSONError(ctx.Tr("actions.variables.update.failed"))
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.variables.update.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env) + "/variables")
+}
+
+// EnvironmentVariableDelete response for deleting a variable of a deployment environment
+func EnvironmentVariableDelete(ctx *context.Context) {
+	env := prepareEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	id := ctx.ParamsInt64(":variable_id")
+	if ok, err := actions_model.DeleteEnvironmentVariable(ctx, id, 0, env.RepoID, env.ID); err != nil || !ok {
+		if !ok {
+			ctx.JSONError(ctx.Tr("actions.variables.not_found"))
+		} else {
+			log.Error("Delete variable [%d] failed: %v", id, err)
+			ctx.JSONError(ctx.Tr("actions.variables.deletion.failed"))
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.variables.deletion.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env) + "/variables")
+}
+
+func prepareEnvironment(ctx *context.Context) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":environment_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetEnvironmentByID", func(err error) bool { return errors.Is(err, util.ErrNotExist) }, err)
+		return nil
+	}
+	ctx.Data["Title"] = ctx.Tr("repo.settings.environments")
+	ctx.Data["PageIsSharedSettingsEnvironments"] = true
+	ctx.Data["Environment"] = env
+	ctx.Data["EnvironmentLink"] = environmentLink(ctx, env)
+	return env
+}
+
+func environmentLink(ctx *context.Context, env *actions_model.ActionEnvironment) string {
+	return fmt.Sprintf("%s/settings/actions/environments/%d", ctx.Repo.RepoLink, env.ID)
+}
+
+func environmentOptions(form *forms.EditEnvironmentForm) actions_service.EnvironmentOptions {
+	return actions_service.EnvironmentOptions{
+		Name:              form.Name,
+		Reviewers:         strings.Split(form.Reviewers, ","),
+		PreventSelfReview: form.PreventSelfReview,
+		WaitTimer:         form.WaitTimer,
+		RefPatterns:       form.RefPatterns,
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const tplIssueSchedules base.TplName = "repo/settings/issue_schedules"
+
+// IssueSchedules render the scheduled issue templates of a repository
+func IssueSchedules(ctx *context.Context) {
+	prepareIssueSchedules(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplIssueSchedules)
+}
+
+// IssueSchedulesPost response for scheduling an issue template
+func IssueSchedulesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.IssueScheduleForm)
+	prepareIssueSchedules(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.Data["HasError"] = true
+		ctx.HTML(http.StatusOK, tplIssueSchedules)
+		return
+	}
+
+	var labels []string
+	for _, name := range strings.Split(form.Labels, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			labels = append(labels, name)
+		}
+	}
+	s := &issues_model.IssueSchedule{
+		Name:         form.Name,
+		TemplateFile: form.TemplateFile,
+		Spec:         strings.TrimSpace(form.Spec),
+		Labels:       labels,
+		TeamID:       form.TeamID,
+	}
+	if err := issue_service.CreateIssueSchedule(ctx, ctx.Doer, ctx.Repo.Repository, s); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Data["HasError"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.issue_schedules.invalid", err.Error()), tplIssueSchedules, form)
+			return
+		}
+		ctx.ServerError("CreateIssueSchedule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_schedules.add_success", s.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+// IssueScheduleToggle pauses or resumes a scheduled issue template
+func IssueScheduleToggle(ctx *context.Context) {
+	s, err := issues_model.GetIssueScheduleByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueScheduleByID", issues_model.IsErrIssueScheduleNotExist, err)
+		return
+	}
+	if err := issue_service.SetIssueScheduleActive(ctx, s, !s.IsActive); err != nil {
+		ctx.ServerError("SetIssueScheduleActive", err)
+		return
+	}
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+// DeleteIssueSchedule response for deleting a scheduled issue template
+func DeleteIssueSchedule(ctx *context.Context) {
+	if err := issues_model.DeleteIssueSchedule(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
+		ctx.Flash.Error("DeleteIssueSchedule: " + err.Error())
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.settings.issue_schedules.deletion_success"))
+	}
+
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+func prepareIssueSchedules(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.issue_schedules")
+	ctx.Data["PageIsSettingsIssueSchedules"] = true
+
+	schedules, err := db.Find[issues_model.IssueSchedule](ctx, issues_model.FindIssueSchedulesOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindIssueSchedules", err)
+		return
+	}
+	ctx.Data["IssueSchedules"] = schedules
+
+	issueIDs := make([]int64, 0, len(schedules))
+	for _, s := range schedules {
+		if s.LastIssueID > 0 {
+			issueIDs = append(issueIDs, s.LastIssueID)
+		}
+	}
+	lastIssues, err := issues_model.GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetIssuesByIDs", err)
+		return
+	}
+	if _, err := lastIssues.LoadRepositories(ctx); err != nil {
+		ctx.ServerError("LoadRepositories", err)
+		return
+	}
+	// the issues may have been transferred, so they are linked through their current repository
+	lastIssuesByID := make(map[int64]*issues_model.Issue, len(lastIssues))
+	for _, issue := range lastIssues {
+		lastIssuesByID[issue.ID] = issue
+	}
+	ctx.Data["LastIssues"] = lastIssuesByID
+
+	templates, _ := issue_service.GetTemplatesFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
+	ctx.Data["IssueTemplates"] = templates
+
+	if ctx.Repo.Owner.IsOrganization() {
+		teams, err := organization.GetRepoTeams(ctx, ctx.Repo.Repository)
+		if err != nil {
+			ctx.ServerError("GetRepoTeams", err)
+			return
+		}
+		ctx.Data["Teams"] = teams
+		teamNames := make(map[int64]string, len(teams))
+		for _, team := range teams {
+			teamNames[team.ID] = team.Name
+		}
+		ctx.Data["TeamNames"] = teamNames
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/repo"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	pull_service "forgejo.org/services/pull"
@@ -153,6 +154,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		}
 	}
 
+	before := audit_service.BranchProtectionSnapshot(protectBranch)
+
 	var whitelistUsers, whitelistTeams, mergeWhitelistUsers, mergeWhitelistTeams, approvalsWhitelistUsers, approvalsWhitelistTeams []int64
 	protectBranch.RuleName = f.RuleName
 	if f.RequiredApprovals < 0 {
@@ -251,6 +254,7 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ctx.ServerError("UpdateProtectBranch", err)
 		return
 	}
+	audit_service.RecordBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, before, protectBranch)
 
 	// FIXME: since we only need to recheck files protected rules, we could improve this
 	matchedBranches, err := git_model.FindAllMatchedBranches(ctx, ctx.Repo.Repository.ID, protectBranch.RuleName)
@@ -296,6 +300,7 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
 		return
 	}
+	audit_service.RecordBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, audit_service.BranchProtectionSnapshot(rule), nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_protected_branch_success", rule.RuleName))
 	ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
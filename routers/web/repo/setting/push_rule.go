@@ -0,0 +1,150 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	audit_model "forgejo.org/models/audit"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	tplRepoPushRule base.TplName = "repo/settings/push_rule"
+	tplOrgPushRule  base.TplName = "org/settings/push_rule"
+)
+
+type pushRuleCtx struct {
+	OwnerID  int64
+	RepoID   int64
+	Target   audit_service.Target
+	Template base.TplName
+	Link     string
+}
+
+func getPushRuleCtx(ctx *context.Context) (*pushRuleCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &pushRuleCtx{
+			RepoID:   ctx.Repo.Repository.ID,
+			Target:   audit_service.RepoTarget(ctx.Repo.Repository),
+			Template: tplRepoPushRule,
+			Link:     ctx.Repo.RepoLink + "/settings/push_rules",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &pushRuleCtx{
+			OwnerID:  ctx.Org.Organization.ID,
+			Target:   audit_service.UserTarget(ctx.Org.Organization.AsUser()),
+			Template: tplOrgPushRule,
+			Link:     ctx.Org.OrgLink + "/settings/push_rules",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set PushRule context")
+}
+
+// pushRuleSnapshot returns the settings of a push rule as recorded in the audit log
+func pushRuleSnapshot(r *git_model.PushRule) map[string]any {
+	return map[string]any{
+		"author_email_pattern":   r.AuthorEmailPattern,
+		"commit_message_pattern": r.CommitMessagePattern,
+		"require_sign_off":       r.RequireSignOff,
+		"max_file_size":          r.MaxFileSize,
+		"forbidden_paths":        r.ForbiddenPaths,
+		"secret_patterns":        r.SecretPatterns,
+	}
+}
+
+// PushRule renders the push rules of a repository or of an organization
+func PushRule(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.push_rules")
+	ctx.Data["PageIsSettingsPushRules"] = true
+
+	pCtx, err := getPushRuleCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getPushRuleCtx", err)
+		return
+	}
+
+	rule, err := git_model.GetPushRule(ctx, pCtx.OwnerID, pCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetPushRule", err)
+		return
+	}
+	ctx.Data["PushRule"] = rule
+	if rule.MaxFileSize > 0 {
+		ctx.Data["MaxFileSize"] = humanize.IBytes(uint64(rule.MaxFileSize))
+	}
+	ctx.Data["PushRuleLink"] = pCtx.Link
+
+	ctx.HTML(http.StatusOK, pCtx.Template)
+}
+
+// PushRulePost updates the push rules of a repository or of an organization
+func PushRulePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.PushRuleForm)
+	ctx.Data["Title"] = ctx.Tr("repo.settings.push_rules")
+	ctx.Data["PageIsSettingsPushRules"] = true
+
+	pCtx, err := getPushRuleCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getPushRuleCtx", err)
+		return
+	}
+
+	rule, err := git_model.GetPushRule(ctx, pCtx.OwnerID, pCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetPushRule", err)
+		return
+	}
+	before := pushRuleSnapshot(rule)
+
+	rule.AuthorEmailPattern = strings.TrimSpace(form.AuthorEmailPattern)
+	rule.CommitMessagePattern = strings.TrimSpace(form.CommitMessagePattern)
+	rule.RequireSignOff = form.RequireSignOff
+	rule.ForbiddenPaths = strings.TrimSpace(form.ForbiddenPaths)
+	rule.SecretPatterns = strings.TrimSpace(form.SecretPatterns)
+	ctx.Data["PushRule"] = rule
+	ctx.Data["MaxFileSize"] = form.MaxFileSize
+	ctx.Data["PushRuleLink"] = pCtx.Link
+
+	rule.MaxFileSize = 0
+	if maxFileSize := strings.TrimSpace(form.MaxFileSize); maxFileSize != "" {
+		size, err := humanize.ParseBytes(maxFileSize)
+		if err != nil {
+			ctx.Data["Err_MaxFileSize"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.push_rules.invalid_max_file_size", maxFileSize), pCtx.Template, form)
+			return
+		}
+		rule.MaxFileSize = int64(size)
+	}
+
+	if err := git_model.SavePushRule(ctx, rule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.settings.push_rules.invalid", err.Error()), pCtx.Template, form)
+			return
+		}
+		ctx.ServerError("SavePushRule", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionPushRuleUpdate, pCtx.Target, before, pushRuleSnapshot(rule))
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.push_rules.update_success"))
+	ctx.Redirect(pCtx.Link)
+}
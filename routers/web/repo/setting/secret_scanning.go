@@ -0,0 +1,282 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	secretscan_model "forgejo.org/models/secretscan"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	notify_service "forgejo.org/services/notify"
+	secretscan_service "forgejo.org/services/secretscan"
+)
+
+const (
+	tplRepoSecretScanning base.TplName = "repo/settings/secret_scanning"
+	tplOrgSecretScanning  base.TplName = "org/settings/secret_scanning"
+)
+
+type secretScanningCtx struct {
+	OwnerID  int64
+	RepoID   int64
+	Target   audit_service.Target
+	Template base.TplName
+	Link     string
+}
+
+func getSecretScanningCtx(ctx *context.Context) (*secretScanningCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &secretScanningCtx{
+			RepoID:   ctx.Repo.Repository.ID,
+			Target:   audit_service.RepoTarget(ctx.Repo.Repository),
+			Template: tplRepoSecretScanning,
+			Link:     ctx.Repo.RepoLink + "/settings/secret_scanning",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &secretScanningCtx{
+			OwnerID:  ctx.Org.Organization.ID,
+			Target:   audit_service.UserTarget(ctx.Org.Organization.AsUser()),
+			Template: tplOrgSecretScanning,
+			Link:     ctx.Org.OrgLink + "/settings/secret_scanning",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set SecretScanning context")
+}
+
+// secretScanningSnapshot returns the secret scanning settings as recorded in the audit log
+func secretScanningSnapshot(s *secretscan_model.Setting) map[string]any {
+	return map[string]any{
+		"mode":            s.Mode.Name(),
+		"custom_patterns": s.CustomPatterns,
+	}
+}
+
+// prepareSecretScanning sets the data shared by the secret scanning page and its form: the effective
+// configuration of the repository and a page of alerts
+func prepareSecretScanning(ctx *context.Context, sCtx *secretScanningCtx) {
+	ctx.Data["SecretScanningLink"] = sCtx.Link
+	ctx.Data["Modes"] = secretscan_model.Modes
+	ctx.Data["Resolutions"] = secretscan_model.Resolutions
+
+	if sCtx.RepoID > 0 {
+		cfg, err := secretscan_model.GetRepoConfig(ctx, ctx.Repo.Repository)
+		if err != nil {
+			ctx.ServerError("GetRepoConfig", err)
+			return
+		}
+		ctx.Data["EffectiveMode"] = cfg.Mode
+	}
+
+	opts := secretscan_model.FindAlertsOptions{
+		OwnerID: sCtx.OwnerID,
+		RepoID:  sCtx.RepoID,
+	}
+	state := ctx.FormString("state")
+	switch state {
+	case "resolved":
+		opts.State, opts.IsFiltered = secretscan_model.AlertStateResolved, true
+	case "all":
+	default:
+		state = "open"
+		opts.State, opts.IsFiltered = secretscan_model.AlertStateOpen, true
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum}
+
+	alerts, total, err := secretscan_model.FindAlerts(ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAlerts", err)
+		return
+	}
+	if err := alerts.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Alerts"] = alerts
+	ctx.Data["FilterState"] = state
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamString("state", state)
+	ctx.Data["Page"] = pager
+}
+
+// SecretScanning renders the secret scanning settings and alerts of a repository or of an organization
+func SecretScanning(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.secret_scanning")
+	ctx.Data["PageIsSettingsSecretScanning"] = true
+
+	sCtx, err := getSecretScanningCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getSecretScanningCtx", err)
+		return
+	}
+
+	s, err := secretscan_model.GetSetting(ctx, sCtx.OwnerID, sCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetSetting", err)
+		return
+	}
+	ctx.Data["SecretScanning"] = s
+
+	prepareSecretScanning(ctx, sCtx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, sCtx.Template)
+}
+
+// SecretScanningPost updates the secret scanning settings of a repository or of an organization
+func SecretScanningPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SecretScanningForm)
+	ctx.Data["Title"] = ctx.Tr("repo.settings.secret_scanning")
+	ctx.Data["PageIsSettingsSecretScanning"] = true
+
+	sCtx, err := getSecretScanningCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getSecretScanningCtx", err)
+		return
+	}
+
+	s, err := secretscan_model.GetSetting(ctx, sCtx.OwnerID, sCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetSetting", err)
+		return
+	}
+	before := secretScanningSnapshot(s)
+
+	mode := secretscan_model.Mode(form.Mode)
+	if !mode.IsValid() {
+		ctx.Error(http.StatusBadRequest, "invalid mode")
+		return
+	}
+	s.Mode = mode
+	s.CustomPatterns = strings.TrimSpace(form.CustomPatterns)
+	ctx.Data["SecretScanning"] = s
+
+	if err := secretscan_model.SaveSetting(ctx, s); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			prepareSecretScanning(ctx, sCtx)
+			if ctx.Written() {
+				return
+			}
+			ctx.Data["Err_CustomPatterns"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.secret_scanning.invalid", err.Error()), sCtx.Template, form)
+			return
+		}
+		ctx.ServerError("SaveSetting", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionSecretScanningUpdate, sCtx.Target, before, secretScanningSnapshot(s))
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.update_success"))
+	ctx.Redirect(sCtx.Link)
+}
+
+// SecretScanningScanHistory scans the whole history of the repository for secrets in the background
+func SecretScanningScanHistory(ctx *context.Context) {
+	if err := secretscan_service.AddRepoToHistoryScanQueue(ctx.Repo.Repository, ctx.Doer); err != nil {
+		ctx.ServerError("AddRepoToHistoryScanQueue", err)
+		return
+	}
+	ctx.Flash.Info(ctx.Tr("repo.settings.secret_scanning.scan_history_started"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/secret_scanning")
+}
+
+// secretScanningAlertTarget returns the target of the audit events about an alert
+func secretScanningAlertTarget(alert *secretscan_model.Alert) audit_service.Target {
+	return audit_service.Target{
+		OwnerID: alert.Repo.OwnerID,
+		RepoID:  alert.RepoID,
+		Type:    "secret_scanning_alert",
+		ID:      alert.ID,
+		Name:    alert.PatternName + " " + alert.MaskedSecret,
+	}
+}
+
+func getSecretScanningAlert(ctx *context.Context) *secretscan_model.Alert {
+	alert, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetAlertByID", err)
+		} else {
+			ctx.ServerError("GetAlertByID", err)
+		}
+		return nil
+	}
+	alert.Repo = ctx.Repo.Repository
+	return alert
+}
+
+// SecretScanningAlertResolve resolves a secret scanning alert of the repository
+func SecretScanningAlertResolve(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SecretScanningResolveForm)
+	link := ctx.Repo.RepoLink + "/settings/secret_scanning"
+
+	alert := getSecretScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	if err := secretscan_model.ResolveAlert(ctx, alert, ctx.Doer.ID, form.Resolution, strings.TrimSpace(form.Comment)); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("ResolveAlert", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionSecretScanningAlertResolve, secretScanningAlertTarget(alert), nil,
+		map[string]any{"resolution": alert.Resolution, "comment": alert.Comment})
+	notify_service.SecretScanningAlertStateChange(ctx, ctx.Doer, alert)
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.alert_resolved"))
+	ctx.Redirect(link)
+}
+
+// SecretScanningAlertReopen opens again a resolved secret scanning alert of the repository
+func SecretScanningAlertReopen(ctx *context.Context) {
+	alert := getSecretScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	before := map[string]any{"resolution": alert.Resolution, "comment": alert.Comment}
+	if err := secretscan_model.ReopenAlert(ctx, alert); err != nil {
+		ctx.ServerError("ReopenAlert", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionSecretScanningAlertReopen, secretScanningAlertTarget(alert), before, nil)
+	notify_service.SecretScanningAlertStateChange(ctx, ctx.Doer, alert)
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.alert_reopened"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/secret_scanning")
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+const tplServiceDesk base.TplName = "repo/settings/service_desk"
+
+// ServiceDesk render the service desk settings of a repository
+func ServiceDesk(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.service_desk")
+	ctx.Data["PageIsSettingsServiceDesk"] = true
+	ctx.Data["IncomingEmailEnabled"] = setting.IncomingEmail.Enabled
+
+	desk, err := issues_model.GetServiceDeskByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil && !issues_model.IsErrServiceDeskNotExist(err) {
+		ctx.ServerError("GetServiceDeskByRepoID", err)
+		return
+	}
+	ctx.Data["ServiceDesk"] = desk
+
+	ctx.HTML(http.StatusOK, tplServiceDesk)
+}
+
+// ServiceDeskPost response for enabling, disabling or changing the address of the service desk
+func ServiceDeskPost(ctx *context.Context) {
+	link := ctx.Repo.RepoLink + "/settings/service_desk"
+
+	switch ctx.FormString("action") {
+	case "enable", "disable":
+		isEnabled := ctx.FormString("action") == "enable"
+		if _, err := issue_service.SetServiceDeskEnabled(ctx, ctx.Repo.Repository, isEnabled); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(ctx.Tr("repo.settings.service_desk.incoming_email_disabled"))
+				ctx.Redirect(link)
+				return
+			}
+			ctx.ServerError("SetServiceDeskEnabled", err)
+			return
+		}
+		if isEnabled {
+			ctx.Flash.Success(ctx.Tr("repo.settings.service_desk.enable_success"))
+		} else {
+			ctx.Flash.Success(ctx.Tr("repo.settings.service_desk.disable_success"))
+		}
+	case "regenerate":
+		desk, err := issues_model.GetServiceDeskByRepoID(ctx, ctx.Repo.Repository.ID)
+		if err != nil {
+			ctx.NotFoundOrServerError("GetServiceDeskByRepoID", issues_model.IsErrServiceDeskNotExist, err)
+			return
+		}
+		if err := issues_model.RegenerateServiceDeskKey(ctx, desk); err != nil {
+			ctx.ServerError("RegenerateServiceDeskKey", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("repo.settings.service_desk.regenerate_success"))
+	default:
+		ctx.NotFound("", nil)
+		return
+	}
+
+	ctx.Redirect(link)
+}
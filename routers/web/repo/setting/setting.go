@@ -33,6 +33,7 @@ import (
 	"forgejo.org/modules/web"
 	actions_service "forgejo.org/services/actions"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/federation"
 	"forgejo.org/services/forms"
@@ -92,6 +93,15 @@ func SettingsCtxData(ctx *context.Context) {
 	}
 	ctx.Data["PushMirrors"] = pushMirrors
 	ctx.Data["CanUseSSHMirroring"] = git.HasSSHExecutable
+
+	refFormat, err := git.GetRefFormat(ctx, ctx.Repo.Repository.RepoPath())
+	if err != nil {
+		ctx.ServerError("GetRefFormat", err)
+		return
+	}
+	ctx.Data["RefFormat"] = string(refFormat)
+	ctx.Data["SupportedObjectFormats"] = git.SupportedObjectFormats
+	ctx.Data["SupportReftable"] = git.SupportReftable
 }
 
 // Units show a repositorys unit settings page
@@ -386,6 +396,9 @@ func SettingsPost(ctx *context.Context) {
 			ctx.ServerError("UpdateRepository", err)
 			return
 		}
+		if visibilityChanged {
+			audit_service.RecordRepoVisibility(ctx, ctx.Doer, repo)
+		}
 		log.Trace("Repository basic settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
 
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
@@ -849,6 +862,33 @@ func SettingsPost(ctx *context.Context) {
 		ctx.Flash.Success(ctx.Tr("repo.settings.convert_fork_succeed"))
 		ctx.Redirect(repo.Link())
 
+	case "convert_format":
+		if !ctx.Repo.IsOwner() {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+		if repo.FullName() != form.RepoName {
+			ctx.RenderWithErr(ctx.Tr("form.enterred_invalid_repo_name"), tplSettingsOptions, nil)
+			return
+		}
+
+		if err := repo_service.AddRepoToConvertQueue(ctx, repo, &repo_service.ConvertOptions{
+			ObjectFormatName: ctx.FormString("object_format_name"),
+			RefFormat:        git.RefFormat(ctx.FormString("ref_format")),
+		}); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(err.Error())
+				ctx.Redirect(repo.Link() + "/settings")
+				return
+			}
+			ctx.ServerError("AddRepoToConvertQueue", err)
+			return
+		}
+
+		log.Trace("Repository queued for conversion: %s", repo.FullName())
+		ctx.Flash.Success(ctx.Tr("repo.settings.convert_format_started"))
+		ctx.Redirect(repo.Link() + "/settings")
+
 	case "transfer":
 		if !ctx.Repo.IsOwner() {
 			ctx.Error(http.StatusNotFound)
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -23,6 +24,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web/middleware"
 	webhook_module "forgejo.org/modules/webhook"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
@@ -175,6 +177,7 @@ func ParseHookEvent(form forms.WebhookCoreForm) *webhook_module.HookEvent {
 			Wiki:                     form.Wiki,
 			Repository:               form.Repository,
 			Package:                  form.Package,
+			SecretScanningAlert:      form.SecretScanningAlert,
 		},
 		BranchFilter: form.BranchFilter,
 	}
@@ -264,6 +267,7 @@ func WebhookCreate(ctx *context.Context) {
 		ctx.ServerError("CreateWebhook", err)
 		return
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookCreate, w, nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.add_hook_success"))
 	ctx.Redirect(orCtx.Link)
@@ -279,6 +283,7 @@ func WebhookUpdate(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Webhook"] = w
+	before := audit_service.WebhookSnapshot(w)
 
 	handler := webhook_service.GetWebhookHandler(w.Type)
 	if handler == nil {
@@ -329,6 +334,7 @@ func WebhookUpdate(ctx *context.Context) {
 		ctx.ServerError("UpdateWebhook", err)
 		return
 	}
+	audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookUpdate, w, before)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.update_hook_success"))
 	ctx.Redirect(fmt.Sprintf("%s/%d", orCtx.Link, w.ID))
@@ -475,9 +481,14 @@ func WebhookReplay(ctx *context.Context) {
 
 // WebhookDelete delete a webhook
 func WebhookDelete(ctx *context.Context) {
-	if err := webhook.DeleteWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
+	w, err := webhook.GetWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id"))
+	if err == nil {
+		err = webhook.DeleteWebhookByID(ctx, w.ID)
+	}
+	if err != nil {
 		ctx.Flash.Error("DeleteWebhookByRepoID: " + err.Error())
 	} else {
+		audit_service.RecordWebhook(ctx, ctx.Doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookSnapshot(w))
 		ctx.Flash.Success(ctx.Tr("repo.settings.webhook_deletion_success"))
 	}
 
@@ -6,6 +6,7 @@ package user
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -30,6 +31,7 @@ import (
 	"forgejo.org/modules/markup/markdown"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/routers/web/feed"
 	"forgejo.org/services/context"
 	issue_service "forgejo.org/services/issue"
@@ -464,6 +466,12 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		Team:       team,
 		User:       ctx.Doer,
 	}
+	confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, nil)
+	if err != nil {
+		ctx.ServerError("ConfidentialFilter", err)
+		return
+	}
+	opts.Confidential = confidential
 
 	// Search all repositories which
 	//
@@ -490,6 +498,10 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	if team != nil {
 		repoOpts.TeamID = team.ID
 	}
+	if repoOpts.ExcludeOwnerIDs, err = ctx.IPAllowlistDeniedOrgIDs(); err != nil {
+		ctx.ServerError("IPAllowlistDeniedOrgIDs", err)
+		return
+	}
 	accessibleRepos := container.Set[int64]{}
 	{
 		ids, _, err := repo_model.SearchRepositoryIDs(ctx, repoOpts)
@@ -509,7 +521,27 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		// it's not enough to show the repos that the doer owns or has been explicitly granted access to,
 		// because the doer may create issues or be mentioned in any public repo.
 		// So we need search issues in all public repos.
-		opts.AllPublic = true
+		if len(repoOpts.ExcludeOwnerIDs) == 0 {
+			opts.AllPublic = true
+		} else {
+			// the indexer cannot leave out the public repositories of the excluded owners
+			ids, _, err := repo_model.SearchRepositoryIDs(ctx, &repo_model.SearchRepoOptions{
+				Actor:           ctx.Doer,
+				Private:         true,
+				Collaborate:     optional.None[bool](),
+				UnitType:        unitType,
+				Archived:        optional.Some(false),
+				ExcludeOwnerIDs: repoOpts.ExcludeOwnerIDs,
+			})
+			if err != nil {
+				ctx.ServerError("SearchRepositoryIDs", err)
+				return
+			}
+			opts.RepoIDs = ids
+			if len(opts.RepoIDs) == 0 {
+				opts.RepoIDs = []int64{0}
+			}
+		}
 	}
 
 	switch filterMode {
@@ -590,11 +622,25 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// Get issues as defined by opts.
 	// ------------------------------
 
+	// Qualifiers in the keyword, like `label:bug`, narrow the search options further.
+	query, err := issue_indexer.ParseQuery(ctx, keyword, ctx.Doer)
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("ParseQuery", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("search.invalid_query", err.Error()), true)
+		query = issue_indexer.NoMatchQuery()
+	}
+	searchOpts := issue_indexer.ToSearchOptions(keyword, opts)
+	query.Apply(searchOpts)
+	isShowClosed = searchOpts.IsClosed.Value()
+
 	// Slice of Issues that will be displayed on the overview page
 	// USING FINAL STATE OF opts FOR A QUERY.
 	var issues issues_model.IssueList
 	{
-		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts))
+		issueIDs, _, err := issue_indexer.SearchIssues(ctx, searchOpts)
 		if err != nil {
 			ctx.ServerError("issueIDsFromSearch", err)
 			return
@@ -620,7 +666,7 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// -------------------------------
 	// Fill stats to post to ctx.Data.
 	// -------------------------------
-	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, issue_indexer.ToSearchOptions(keyword, opts))
+	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, query, issue_indexer.ToSearchOptions(keyword, opts))
 	if err != nil {
 		ctx.ServerError("getUserIssueStats", err)
 		return
@@ -676,6 +722,11 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	ctx.Data["IsShowClosed"] = isShowClosed
 	ctx.Data["SelectLabels"] = selectedLabels
 	ctx.Data["PageIsOrgIssues"] = org != nil
+	if org != nil {
+		ctx.Data["BulkEditLink"] = org.OrganisationLink() + "/issues/-/bulk_edit"
+	} else {
+		ctx.Data["BulkEditLink"] = setting.AppSubURL + "/issues/bulk_edit"
+	}
 
 	if isShowClosed {
 		ctx.Data["State"] = "closed"
@@ -814,7 +865,7 @@ func UsernameSubRoute(ctx *context.Context) {
 	}
 }
 
-func getUserIssueStats(ctx *context.Context, ctxUser *user_model.User, filterMode int, opts *issue_indexer.SearchOptions) (*issues_model.IssueStats, error) {
+func getUserIssueStats(ctx *context.Context, ctxUser *user_model.User, filterMode int, query *issue_indexer.Query, opts *issue_indexer.SearchOptions) (*issues_model.IssueStats, error) {
 	doerID := ctx.Doer.ID
 
 	opts = opts.Copy(func(o *issue_indexer.SearchOptions) {
@@ -835,6 +886,12 @@ func getUserIssueStats(ctx *context.Context, ctxUser *user_model.User, filterMod
 		ret = &issues_model.IssueStats{}
 	)
 
+	// The qualifiers of the query are applied last, so they take precedence over the filter mode.
+	countIssues := func(opts *issue_indexer.SearchOptions) (int64, error) {
+		query.Apply(opts)
+		return issue_indexer.CountIssues(ctx, opts)
+	}
+
 	{
 		openClosedOpts := opts.Copy()
 		switch filterMode {
@@ -853,39 +910,47 @@ func getUserIssueStats(ctx *context.Context, ctxUser *user_model.User, filterMod
 		case issues_model.FilterModeReviewed:
 			openClosedOpts.ReviewedID = optional.Some(doerID)
 		}
-		openClosedOpts.IsClosed = optional.Some(false)
-		ret.OpenCount, err = issue_indexer.CountIssues(ctx, openClosedOpts)
-		if err != nil {
-			return nil, err
+		// An `is:open` or `is:closed` qualifier leaves the other count empty.
+		openClosedOpts.IsClosed = optional.None[bool]()
+		query.Apply(openClosedOpts)
+		isClosed := openClosedOpts.IsClosed
+		if !isClosed.Has() || !isClosed.Value() {
+			openClosedOpts.IsClosed = optional.Some(false)
+			ret.OpenCount, err = issue_indexer.CountIssues(ctx, openClosedOpts)
+			if err != nil {
+				return nil, err
+			}
 		}
-		openClosedOpts.IsClosed = optional.Some(true)
-		ret.ClosedCount, err = issue_indexer.CountIssues(ctx, openClosedOpts)
-		if err != nil {
-			return nil, err
+		if !isClosed.Has() || isClosed.Value() {
+			openClosedOpts.IsClosed = optional.Some(true)
+			ret.ClosedCount, err = issue_indexer.CountIssues(ctx, openClosedOpts)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	ret.YourRepositoriesCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.AllPublic = false }))
+	ret.YourRepositoriesCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.AllPublic = false }))
 	if err != nil {
 		return nil, err
 	}
-	ret.AssignCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.AssigneeID = optional.Some(doerID) }))
+	ret.AssignCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.AssigneeID = optional.Some(doerID) }))
 	if err != nil {
 		return nil, err
 	}
-	ret.CreateCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.PosterID = optional.Some(doerID) }))
+	ret.CreateCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.PosterID = optional.Some(doerID) }))
 	if err != nil {
 		return nil, err
 	}
-	ret.MentionCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.MentionID = optional.Some(doerID) }))
+	ret.MentionCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.MentionID = optional.Some(doerID) }))
 	if err != nil {
 		return nil, err
 	}
-	ret.ReviewRequestedCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.ReviewRequestedID = optional.Some(doerID) }))
+	ret.ReviewRequestedCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.ReviewRequestedID = optional.Some(doerID) }))
 	if err != nil {
 		return nil, err
 	}
-	ret.ReviewedCount, err = issue_indexer.CountIssues(ctx, opts.Copy(func(o *issue_indexer.SearchOptions) { o.ReviewedID = optional.Some(doerID) }))
+	ret.ReviewedCount, err = countIssues(opts.Copy(func(o *issue_indexer.SearchOptions) { o.ReviewedID = optional.Some(doerID) }))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admin_model "forgejo.org/models/admin"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+	task_service "forgejo.org/services/task"
+)
+
+const (
+	tplIssuesBulkEdit       base.TplName = "user/dashboard/issues_bulk_edit"
+	tplIssuesBulkEditStatus base.TplName = "user/dashboard/issues_bulk_edit_status"
+)
+
+// BulkEditIssues renders the form to edit all the issues matching a search query
+func BulkEditIssues(ctx *context.Context) {
+	ctxUser := getDashboardContextUser(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("home.issues.bulk_edit.title")
+	ctx.Data["PageIsIssues"] = true
+
+	query := []string{}
+	if ctx.FormString("type") == "pulls" {
+		query = append(query, "is:pr")
+	} else {
+		query = append(query, "is:issue")
+	}
+	switch ctx.FormString("state") {
+	case "all":
+	case "closed":
+		query = append(query, "is:closed")
+	default:
+		query = append(query, "is:open")
+	}
+	if keyword := ctx.FormTrim("q"); keyword != "" {
+		query = append(query, keyword)
+	}
+	ctx.Data["query"] = strings.Join(query, " ")
+	ctx.Data["IsOrganization"] = ctxUser.IsOrganization()
+
+	ctx.HTML(http.StatusOK, tplIssuesBulkEdit)
+}
+
+// BulkEditIssuesPost starts a background job editing all the issues matching a search query
+func BulkEditIssuesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.BulkEditIssuesForm)
+	ctxUser := getDashboardContextUser(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("home.issues.bulk_edit.title")
+	ctx.Data["PageIsIssues"] = true
+	ctx.Data["IsOrganization"] = ctxUser.IsOrganization()
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssuesBulkEdit)
+		return
+	}
+
+	opts := issue_service.BulkEditOptions{
+		AddLabels:       splitBulkEditNames(form.AddLabels),
+		RemoveLabels:    splitBulkEditNames(form.RemoveLabels),
+		AddAssignees:    splitBulkEditNames(form.AddAssignees),
+		RemoveAssignees: splitBulkEditNames(form.RemoveAssignees),
+		Milestone:       bulkEditAction(form.MilestoneAction, form.Milestone),
+		Project:         bulkEditAction(form.ProjectAction, form.Project),
+		MoveTo:          strings.TrimSpace(form.MoveTo),
+	}
+	switch form.State {
+	case string(structs.StateOpen):
+		opts.IsClosed = optional.Some(false)
+	case string(structs.StateClosed):
+		opts.IsClosed = optional.Some(true)
+	}
+
+	var owner *user_model.User
+	if ctxUser.ID != ctx.Doer.ID {
+		owner = ctxUser
+	}
+	task, err := task_service.BulkEditIssues(ctx, ctx.Doer, owner, form.Query, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("home.issues.bulk_edit.invalid", err.Error()), tplIssuesBulkEdit, form)
+			return
+		}
+		ctx.ServerError("BulkEditIssues", err)
+		return
+	}
+	ctx.Redirect(fmt.Sprintf("%s/issues/bulk_edit/%d", setting.AppSubURL, task.ID))
+}
+
+// BulkEditIssuesStatus renders the progress and the report of a bulk edit
+func BulkEditIssuesStatus(ctx *context.Context) {
+	getDashboardContextUser(ctx)
+	if ctx.Written() {
+		return
+	}
+	task, payload, report, err := task_service.GetBulkEditIssuesTask(ctx, ctx.ParamsInt64(":task"), ctx.Doer.ID)
+	if err != nil {
+		if admin_model.IsErrTaskDoesNotExist(err) {
+			ctx.NotFound("GetBulkEditIssuesTask", err)
+			return
+		}
+		ctx.ServerError("GetBulkEditIssuesTask", err)
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("home.issues.bulk_edit.title")
+	ctx.Data["PageIsIssues"] = true
+	ctx.Data["Task"] = task
+	ctx.Data["Payload"] = payload
+	ctx.Data["Report"] = report
+	ctx.Data["IsRunning"] = task.Status == structs.TaskStatusQueued || task.Status == structs.TaskStatusRunning
+
+	ctx.HTML(http.StatusOK, tplIssuesBulkEditStatus)
+}
+
+func splitBulkEditNames(names string) []string {
+	var result []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func bulkEditAction(action, value string) *string {
+	switch action {
+	case "set":
+		value = strings.TrimSpace(value)
+		return &value
+	case "clear":
+		value = ""
+		return &value
+	}
+	return nil
+}
@@ -5,14 +5,20 @@
 package setting
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -52,25 +58,58 @@ func ApplicationsPost(ctx *context.Context) {
 	if !scope.HasPermissionScope() {
 		ctx.Flash.Error(ctx.Tr("settings.at_least_one_permission"), true)
 	}
-	t := &auth_model.AccessToken{
-		UID:   ctx.Doer.ID,
-		Name:  form.Name,
-		Scope: scope,
-	}
 
-	exist, err := auth_model.AccessTokenByNameExists(ctx, t)
+	exist, err := auth_model.AccessTokenByNameExists(ctx, &auth_model.AccessToken{UID: ctx.Doer.ID, Name: form.Name})
 	if err != nil {
 		ctx.ServerError("AccessTokenByNameExists", err)
 		return
 	}
 	if exist {
-		ctx.Flash.Error(ctx.Tr("settings.generate_token_name_duplicate", t.Name))
+		ctx.Flash.Error(ctx.Tr("settings.generate_token_name_duplicate", form.Name))
 		ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
 		return
 	}
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.ServerError("NewAccessToken", err)
+	opts := auth_service.CreateAccessTokenOptions{
+		Name:  form.Name,
+		Scope: scope,
+	}
+	if form.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation("2006-01-02", form.ExpiresAt, setting.DefaultUILocation)
+		if err != nil {
+			ctx.Flash.Error(ctx.Tr("settings.token_expires_invalid"))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+			return
+		}
+		opts.ExpiresUnix = timeutil.TimeStamp(expiresAt.Unix())
+	}
+	if err := auth_service.ValidateAccessTokenExpiry(opts.ExpiresUnix); err != nil {
+		if setting.AccessTokenMaxLifetime > 0 {
+			ctx.Flash.Error(ctx.Tr("settings.token_expires_max_lifetime", maxAccessTokenExpiry().Format("2006-01-02")))
+		} else {
+			ctx.Flash.Error(ctx.Tr("settings.token_expires_invalid"))
+		}
+		ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+		return
+	}
+	for _, repo := range splitLines(form.ReadRepositories) {
+		opts.Resources = append(opts.Resources, auth_service.AccessTokenResourceOption{Repository: repo})
+	}
+	for _, repo := range splitLines(form.WriteRepositories) {
+		opts.Resources = append(opts.Resources, auth_service.AccessTokenResourceOption{Repository: repo, Write: true})
+	}
+	if org := strings.TrimSpace(form.Organization); org != "" {
+		opts.Resources = append(opts.Resources, auth_service.AccessTokenResourceOption{Organization: org, Write: form.OrganizationPermission == "write"})
+	}
+
+	t, err := auth_service.CreateAccessToken(ctx, ctx.Doer, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("settings.token_resources_invalid", err.Error()))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+			return
+		}
+		ctx.ServerError("CreateAccessToken", err)
 		return
 	}
 
@@ -82,7 +121,7 @@ func ApplicationsPost(ctx *context.Context) {
 
 // DeleteApplication response for delete user access token
 func DeleteApplication(ctx *context.Context) {
-	if err := auth_model.DeleteAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	if err := auth_service.DeleteAccessToken(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
@@ -108,6 +147,22 @@ func RegenerateApplication(ctx *context.Context) {
 	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/applications")
 }
 
+// maxAccessTokenExpiry is the last day a new token may expire on, according to the maximum lifetime of tokens
+func maxAccessTokenExpiry() time.Time {
+	maxExpiry := time.Now().Add(setting.AccessTokenMaxLifetime).In(setting.DefaultUILocation)
+	return time.Date(maxExpiry.Year(), maxExpiry.Month(), maxExpiry.Day(), 0, 0, 0, 0, setting.DefaultUILocation)
+}
+
+func splitLines(s string) []string {
+	lines := make([]string, 0, 5)
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func loadApplicationsData(ctx *context.Context) {
 	ctx.Data["AccessTokenScopePublicOnly"] = auth_model.AccessTokenScopePublicOnly
 	tokens, err := db.Find[auth_model.AccessToken](ctx, auth_model.ListAccessTokensOptions{UserID: ctx.Doer.ID})
@@ -116,6 +171,29 @@ func loadApplicationsData(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Tokens"] = tokens
+
+	tokenIDs := make([]int64, len(tokens))
+	for i := range tokens {
+		tokenIDs[i] = tokens[i].ID
+	}
+	resources, err := auth_model.GetAccessTokenResourcesByTokenIDs(ctx, tokenIDs)
+	if err != nil {
+		ctx.ServerError("GetAccessTokenResourcesByTokenIDs", err)
+		return
+	}
+	tokenResources := make(map[int64][]auth_service.AccessTokenResourceOption, len(resources))
+	for tokenID, r := range resources {
+		if tokenResources[tokenID], err = auth_service.AccessTokenResourceOptions(ctx, r); err != nil {
+			ctx.ServerError("AccessTokenResourceOptions", err)
+			return
+		}
+	}
+	ctx.Data["TokenResources"] = tokenResources
+
+	ctx.Data["AccessTokenMinExpiry"] = time.Now().In(setting.DefaultUILocation).AddDate(0, 0, 1).Format("2006-01-02")
+	if setting.AccessTokenMaxLifetime > 0 {
+		ctx.Data["AccessTokenMaxExpiry"] = maxAccessTokenExpiry().Format("2006-01-02")
+	}
 	ctx.Data["EnableOAuth2"] = setting.OAuth2.Enabled
 	ctx.Data["IsAdmin"] = ctx.Doer.IsAdmin
 	if setting.OAuth2.Enabled {
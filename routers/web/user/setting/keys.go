@@ -5,17 +5,21 @@
 package setting
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	asymkey_model "forgejo.org/models/asymkey"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	asymkey_service "forgejo.org/services/asymkey"
 	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 )
 
@@ -286,6 +290,10 @@ func DeleteKey(ctx *context.Context) {
 }
 
 func loadKeysData(ctx *context.Context) {
+	ctx.Data["EnableSSHCertificates"] = setting.SSH.UserCAEnabled
+	ctx.Data["SSHCertificateTTL"] = setting.SSH.UserCertificateTTL.String()
+	ctx.Data["SSHCertificateMaxTTL"] = setting.SSH.UserCertificateMaxTTL.String()
+
 	keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
 		OwnerID:    ctx.Doer.ID,
 		NotKeytype: asymkey_model.KeyTypePrincipal,
@@ -336,3 +344,42 @@ func loadKeysData(ctx *context.Context) {
 	ctx.Data["VerifyingFingerprint"] = ctx.FormString("verify_ssh")
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
 }
+
+// SSHCertificatePost signs a short-lived SSH certificate for a public key of the user
+func SSHCertificatePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SSHCertificateForm)
+	ctx.Data["Title"] = ctx.Tr("settings")
+	ctx.Data["PageIsSettingsKeys"] = true
+	ctx.Data["DisableSSH"] = setting.SSH.Disabled
+	ctx.Data["BuiltinSSH"] = setting.SSH.StartBuiltinServer
+	ctx.Data["AllowPrincipals"] = setting.SSH.AuthorizedPrincipalsEnabled
+	ctx.Data["Link"] = setting.AppSubURL + "/user/settings/keys"
+
+	if !setting.SSH.UserCAEnabled {
+		ctx.NotFound("SSHCertificatePost", nil)
+		return
+	}
+
+	loadKeysData(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsKeys)
+		return
+	}
+
+	cert, err := asymkey_service.SignSSHUserCertificate(ctx, ctx.Doer, form.Content, time.Duration(form.ValidHours)*time.Hour)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrPermissionDenied) {
+			ctx.Data["Err_Content"] = true
+			ctx.RenderWithErr(ctx.Tr("settings.ssh_certificate_failed", err.Error()), tplSettingsKeys, form)
+			return
+		}
+		ctx.ServerError("SignSSHUserCertificate", err)
+		return
+	}
+
+	ctx.Data["SSHCertificate"] = convert.ToSSHCertificate(cert)
+	ctx.HTML(http.StatusOK, tplSettingsKeys)
+}
@@ -52,6 +52,7 @@ func (oa *OAuth2CommonHandlers) AddApp(ctx *context.Context) {
 		RedirectURIs:       util.SplitTrimSpace(form.RedirectURIs, "\n"),
 		UserID:             oa.OwnerID,
 		ConfidentialClient: form.ConfidentialClient,
+		AllowDeviceGrant:   form.AllowDeviceGrant,
 	})
 	if err != nil {
 		ctx.ServerError("CreateOAuth2Application", err)
@@ -120,6 +121,7 @@ func (oa *OAuth2CommonHandlers) EditSave(ctx *context.Context) {
 		RedirectURIs:       util.SplitTrimSpace(form.RedirectURIs, "\n"),
 		UserID:             oa.OwnerID,
 		ConfidentialClient: form.ConfidentialClient,
+		AllowDeviceGrant:   form.AllowDeviceGrant,
 	}); err != nil {
 		ctx.ServerError("UpdateOAuth2Application", err)
 		return
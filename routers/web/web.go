@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/perm"
 	quota_model "forgejo.org/models/quota"
 	"forgejo.org/models/unit"
@@ -169,6 +170,23 @@ func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.Cont
 				ctx.Redirect(setting.AppSubURL + "/")
 				return
 			}
+
+			if setting.EnforceTwoFactorAuth && !ctx.IsBasicAuth && !ctx.Doer.MustChangePassword && !isTwoFactorEnrollmentPath(ctx.Req.URL.Path) {
+				hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, ctx.Doer.ID)
+				if err != nil {
+					ctx.ServerError("HasTwoFactorByUID", err)
+					return
+				}
+				if !hasTwoFactor {
+					if strings.HasPrefix(ctx.Req.UserAgent(), "git") {
+						ctx.Error(http.StatusUnauthorized, ctx.Locale.TrString("auth.must_enroll_two_factor"))
+						return
+					}
+					ctx.Flash.Warning(ctx.Tr("auth.must_enroll_two_factor"))
+					ctx.Redirect(setting.AppSubURL + "/user/settings/security")
+					return
+				}
+			}
 		}
 
 		// Redirect to dashboard (or alternate location) if user tries to visit any non-login page.
@@ -218,6 +236,15 @@ func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.Cont
 	}
 }
 
+// isTwoFactorEnrollmentPath reports whether the page remains available to the users who must enroll
+// two-factor authentication before using the web interface
+func isTwoFactorEnrollmentPath(path string) bool {
+	return strings.HasPrefix(path, "/user/settings/security") ||
+		strings.HasPrefix(path, "/avatar/") ||
+		strings.HasPrefix(path, "/user/avatar/") ||
+		path == "/user/logout" || path == "/user/events"
+}
+
 func ctxDataSet(args ...any) func(ctx *context.Context) {
 	return func(ctx *context.Context) {
 		for i := 0; i < len(args); i += 2 {
@@ -345,6 +372,20 @@ func registerRoutes(m *web.Route) {
 		}
 	}
 
+	auditEnabled := func(ctx *context.Context) {
+		if !setting.Audit.Enabled {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+	}
+
+	dependencyGraphEnabled := func(ctx *context.Context) {
+		if !setting.DependencyGraph.Enabled {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+	}
+
 	lfsServerEnabled := func(ctx *context.Context) {
 		if !setting.LFS.StartServer {
 			ctx.Error(http.StatusNotFound)
@@ -436,6 +477,13 @@ func registerRoutes(m *web.Route) {
 		})
 	}
 
+	addSettingsAuditRoutes := func() {
+		m.Group("/audit", func() {
+			m.Get("", repo_setting.AuditLog)
+			m.Get("/export", repo_setting.AuditLogExport)
+		}, auditEnabled)
+	}
+
 	// FIXME: not all routes need go through same middleware.
 	// Especially some AJAX requests, we can reduce middleware number to improve performance.
 
@@ -473,6 +521,9 @@ func registerRoutes(m *web.Route) {
 	m.Group("/issues", func() {
 		m.Get("", user.Issues)
 		m.Get("/search", repo.SearchIssues)
+		m.Combo("/bulk_edit").Get(user.BulkEditIssues).
+			Post(web.Bind(forms.BulkEditIssuesForm{}), user.BulkEditIssuesPost)
+		m.Get("/bulk_edit/{task}", user.BulkEditIssuesStatus)
 	}, reqSignIn)
 
 	if setting.Moderation.Enabled {
@@ -535,8 +586,15 @@ func registerRoutes(m *web.Route) {
 		m.Methods("POST, OPTIONS", "/access_token", optionsCorsHandler(), web.Bind(forms.AccessTokenForm{}), ignSignInAndCsrf, auth.AccessTokenOAuth)
 		m.Methods("GET, OPTIONS", "/keys", optionsCorsHandler(), ignSignInAndCsrf, auth.OIDCKeys)
 		m.Methods("POST, OPTIONS", "/introspect", optionsCorsHandler(), web.Bind(forms.IntrospectTokenForm{}), ignSignInAndCsrf, auth.IntrospectOAuth)
+		m.Methods("POST, OPTIONS", "/device_authorization", optionsCorsHandler(), web.Bind(forms.DeviceAuthorizationForm{}), ignSignInAndCsrf, auth.DeviceAuthorizationOAuth)
 	}, oauth2Enabled)
 
+	m.Group("/login/device", func() {
+		m.Combo("").Get(auth.DeviceCode).
+			Post(web.Bind(forms.DeviceGrantForm{}), auth.DeviceCodePost)
+		m.Post("/grant", web.Bind(forms.DeviceGrantForm{}), auth.DeviceGrantPost)
+	}, reqSignIn, oauth2Enabled)
+
 	m.Group("/user/settings", func() {
 		m.Get("", user_setting.Profile)
 		m.Post("", web.Bind(forms.UpdateProfileForm{}), user_setting.ProfilePost)
@@ -599,6 +657,7 @@ func registerRoutes(m *web.Route) {
 		m.Combo("/keys").Get(user_setting.Keys).
 			Post(web.Bind(forms.AddKeyForm{}), user_setting.KeysPost)
 		m.Post("/keys/delete", user_setting.DeleteKey)
+		m.Post("/keys/certificate", web.Bind(forms.SSHCertificateForm{}), user_setting.SSHCertificatePost)
 		m.Group("/packages", func() {
 			m.Get("", user_setting.Packages)
 			m.Group("/rules", func() {
@@ -666,6 +725,11 @@ func registerRoutes(m *web.Route) {
 			m.Get("/{provider}", auth.SignInOAuth)
 			m.Get("/{provider}/callback", auth.SignInOAuthCallback)
 		})
+		m.Group("/saml/{provider}", func() {
+			m.Get("", auth.SignInSAML)
+			m.Get("/metadata", auth.SAMLMetadata)
+			m.Post("/acs", ignSignInAndCsrf, auth.SAMLAssertionConsumer)
+		})
 	})
 	// ***** END: User *****
 
@@ -729,6 +793,7 @@ func registerRoutes(m *web.Route) {
 			m.Get("", admin.Repos)
 			m.Combo("/unadopted").Get(admin.UnadoptedRepos).Post(admin.AdoptOrDeleteRepository)
 			m.Post("/delete", admin.DeleteRepo)
+			m.Combo("/{id}/pack-health").Get(admin.RepoPackHealth).Post(admin.RepoPackHealthPost)
 		})
 
 		m.Group("/packages", func() {
@@ -758,8 +823,12 @@ func registerRoutes(m *web.Route) {
 			m.Combo("/{authid}").Get(admin.EditAuthSource).
 				Post(web.Bind(forms.AuthenticationForm{}), admin.EditAuthSourcePost)
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
+			m.Post("/{authid}/scim_tokens", admin.NewScimTokenPost)
+			m.Post("/{authid}/scim_tokens/delete", admin.DeleteScimTokenPost)
 		})
 
+		m.Get("/two_factor", admin.TwoFactorCompliance)
+
 		m.Group("/notices", func() {
 			m.Get("", admin.Notices)
 			m.Post("/delete", admin.DeleteNotices)
@@ -781,6 +850,8 @@ func registerRoutes(m *web.Route) {
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
 		})
+
+		addSettingsAuditRoutes()
 	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
 	// ***** END: Admin *****
 
@@ -855,6 +926,8 @@ func registerRoutes(m *web.Route) {
 			m.Get("/dashboard", user.Dashboard)
 			m.Get("/dashboard/{team}", user.Dashboard)
 			m.Get("/issues", user.Issues)
+			m.Combo("/issues/-/bulk_edit").Get(user.BulkEditIssues).
+				Post(web.Bind(forms.BulkEditIssuesForm{}), user.BulkEditIssuesPost)
 			m.Get("/issues/{team}", user.Issues)
 			m.Get("/pulls", user.Pulls)
 			m.Get("/pulls/{team}", user.Pulls)
@@ -934,6 +1007,16 @@ func registerRoutes(m *web.Route) {
 					m.Post("/unblock", org_setting.BlockedUsersUnblock)
 				})
 				m.Get("/storage_overview", org_setting.StorageOverview)
+				addSettingsAuditRoutes()
+				m.Combo("/push_rules").Get(repo_setting.PushRule).
+					Post(web.Bind(forms.PushRuleForm{}), repo_setting.PushRulePost)
+				m.Combo("/secret_scanning").Get(repo_setting.SecretScanning).
+					Post(web.Bind(forms.SecretScanningForm{}), repo_setting.SecretScanningPost)
+				m.Get("/dependency_graph", dependencyGraphEnabled, repo_setting.DependencyGraph)
+				m.Combo("/ip_allowlist").Get(org_setting.IPAllowlist).
+					Post(web.Bind(forms.IPAllowlistForm{}), org_setting.IPAllowlistPost)
+				m.Combo("/two_factor").Get(org_setting.TwoFactorPolicy).
+					Post(web.Bind(forms.TwoFactorPolicyForm{}), org_setting.TwoFactorPolicyPost)
 
 				m.Group("/packages", func() {
 					m.Get("", org.Packages)
@@ -1094,6 +1177,17 @@ func registerRoutes(m *web.Route) {
 				m.Post("/delete", repo_setting.DeleteDeployKey)
 			})
 
+			m.Group("/issue_schedules", func() {
+				m.Combo("").Get(repo_setting.IssueSchedules).
+					Post(web.Bind(forms.IssueScheduleForm{}), repo_setting.IssueSchedulesPost)
+				m.Post("/{id}/toggle", repo_setting.IssueScheduleToggle)
+				m.Post("/delete", repo_setting.DeleteIssueSchedule)
+			}, repo.MustEnableIssues, context.RepoMustNotBeArchived())
+
+			m.Combo("/service_desk", repo.MustEnableIssues, context.RepoMustNotBeArchived()).
+				Get(repo_setting.ServiceDesk).
+				Post(repo_setting.ServiceDeskPost)
+
 			m.Group("/lfs", func() {
 				m.Get("/", repo_setting.LFSFiles)
 				m.Get("/show/{oid}", repo_setting.LFSFileGet)
@@ -1112,7 +1206,43 @@ func registerRoutes(m *web.Route) {
 				addSettingsRunnersRoutes()
 				addSettingsSecretsRoutes()
 				addSettingsVariablesRoutes()
+				m.Group("/environments", func() {
+					m.Combo("").Get(repo_setting.Environments).
+						Post(web.Bind(forms.EditEnvironmentForm{}), repo_setting.EnvironmentsPost)
+					m.Group("/{environment_id}", func() {
+						m.Combo("").Get(repo_setting.EnvironmentEdit).
+							Post(web.Bind(forms.EditEnvironmentForm{}), repo_setting.EnvironmentEditPost)
+						m.Post("/delete", repo_setting.EnvironmentDelete)
+						m.Group("/secrets", func() {
+							m.Get("", repo_setting.EnvironmentSecrets)
+							m.Post("", web.Bind(forms.AddSecretForm{}), repo_setting.EnvironmentSecretsPost)
+							m.Post("/delete", repo_setting.EnvironmentSecretsDelete)
+						})
+						m.Group("/variables", func() {
+							m.Get("", repo_setting.EnvironmentVariables)
+							m.Post("/new", web.Bind(forms.EditVariableForm{}), repo_setting.EnvironmentVariableCreate)
+							m.Post("/{variable_id}/edit", web.Bind(forms.EditVariableForm{}), repo_setting.EnvironmentVariableUpdate)
+							m.Post("/{variable_id}/delete", repo_setting.EnvironmentVariableDelete)
+						})
+					})
+				})
 			}, actions.MustEnableActions)
+			addSettingsAuditRoutes()
+			m.Combo("/push_rules").Get(repo_setting.PushRule).
+				Post(web.Bind(forms.PushRuleForm{}), repo_setting.PushRulePost)
+			m.Group("/secret_scanning", func() {
+				m.Combo("").Get(repo_setting.SecretScanning).
+					Post(web.Bind(forms.SecretScanningForm{}), repo_setting.SecretScanningPost)
+				m.Post("/scan", repo_setting.SecretScanningScanHistory)
+				m.Post("/alerts/{id}/resolve", web.Bind(forms.SecretScanningResolveForm{}), repo_setting.SecretScanningAlertResolve)
+				m.Post("/alerts/{id}/reopen", repo_setting.SecretScanningAlertReopen)
+			})
+			m.Group("/dependency_graph", func() {
+				m.Get("", repo_setting.DependencyGraph)
+				m.Post("/update", repo_setting.DependencyGraphUpdate)
+				m.Post("/alerts/{id}/dismiss", web.Bind(forms.DependencyAlertDismissForm{}), repo_setting.DependencyAlertDismiss)
+				m.Post("/alerts/{id}/reopen", repo_setting.DependencyAlertReopen)
+			}, dependencyGraphEnabled)
 			// the follow handler must be under "settings", otherwise this incomplete repo can't be accessed
 			m.Group("/migrate", func() {
 				m.Post("/retry", repo.MigrateRetryPost)
@@ -1197,6 +1327,8 @@ func registerRoutes(m *web.Route) {
 				m.Post("/reactions/{action}", web.Bind(forms.ReactionForm{}), repo.ChangeIssueReaction)
 				m.Post("/lock", reqRepoIssuesOrPullsWriter, web.Bind(forms.IssueLockForm{}), repo.LockIssue)
 				m.Post("/unlock", reqRepoIssuesOrPullsWriter, repo.UnlockIssue)
+				m.Post("/transfer", reqRepoIssuesOrPullsWriter, web.Bind(forms.TransferIssueForm{}), repo.TransferIssue)
+				m.Post("/confidential", reqRepoIssuesOrPullsWriter, repo.SetIssueConfidential)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
 			}, context.RepoMustNotBeArchived())
 			m.Group("/{index}", func() {
@@ -1423,6 +1555,15 @@ func registerRoutes(m *web.Route) {
 				})
 			})
 
+			m.Group("/environments", func() {
+				m.Get("", actions.Environments)
+				m.Get("/{environment_id}", actions.EnvironmentDeployments)
+			})
+			m.Group("/deployments/{deployment_id}", func() {
+				m.Post("/approve", actions.ApproveDeployment)
+				m.Post("/reject", actions.RejectDeployment)
+			}, reqSignIn)
+
 			m.Group("/workflows/{workflow_name}", func() {
 				m.Get("/badge.svg", badges.GetWorkflowBadge)
 				m.Get("/runs/latest", actions.ViewLatestWorkflowRun)
@@ -1475,6 +1616,11 @@ func registerRoutes(m *web.Route) {
 			m.Post("/*", repo.InitiateDownload)
 		}, repo.MustBeNotEmpty, dlSourceEnabled, reqRepoCodeReader)
 
+		m.Group("/bundle", func() {
+			m.Get("", repo.DownloadBundle)
+			m.Get("/{name}", repo.DownloadBundle)
+		}, repo.MustBeNotEmpty, dlSourceEnabled, reqRepoCodeReader)
+
 		m.Group("/branches", func() {
 			m.Get("/list", repo.GetBranchesList)
 			m.Get("", repo.Branches)
@@ -1515,6 +1661,11 @@ func registerRoutes(m *web.Route) {
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
+			m.Combo("/conflicts").Get(repo.PullConflicts).
+				Post(context.RepoMustNotBeArchived(), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.PullConflictsPost)
+			m.Combo("/edit_commits").Get(repo.PullEditCommits).
+				Post(context.RepoMustNotBeArchived(), context.EnforceQuotaWeb(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.PullEditCommitsPost)
+			m.Get("/range-diff", repo.PullRangeDiff)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), context.RepoRef(), repo.CleanUpPullRequest)
 			m.Group("/files", func() {
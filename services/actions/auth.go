@@ -16,6 +16,10 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// actionsResultsScope is the scope of the runtime tokens of the tasks, the tokens of other scopes signed with the
+// same secret are not accepted as runtime tokens
+const actionsResultsScope = "Actions.Results"
+
 type actionsClaims struct {
 	jwt.RegisteredClaims
 	Scp    string `json:"scp"`
@@ -55,7 +59,7 @@ func CreateAuthorizationToken(taskID, runID, jobID int64) (string, error) {
 			ExpiresAt: jwt.NewNumericDate(now.Add(24 * time.Hour)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
-		Scp:    fmt.Sprintf("Actions.Results:%d:%d", runID, jobID),
+		Scp:    fmt.Sprintf("%s:%d:%d", actionsResultsScope, runID, jobID),
 		Ac:     string(ac),
 		TaskID: taskID,
 		RunID:  runID,
@@ -99,7 +103,7 @@ func TokenToTaskID(token string) (int64, error) {
 	}
 
 	c, ok := parsedToken.Claims.(*actionsClaims)
-	if !parsedToken.Valid || !ok {
+	if !parsedToken.Valid || !ok || !strings.HasPrefix(c.Scp, actionsResultsScope+":") {
 		return 0, fmt.Errorf("invalid token claim")
 	}
 
@@ -0,0 +1,244 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	secret_model "forgejo.org/models/secret"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// maxEnvironmentWaitTimer is the maximum wait timer of an environment, 30 days as on GitHub
+const maxEnvironmentWaitTimer = 30 * 24 * 60
+
+// EnvironmentOptions are the protection rules of an environment
+type EnvironmentOptions struct {
+	Name              string
+	Reviewers         []string
+	PreventSelfReview bool
+	WaitTimer         int64
+	RefPatterns       string
+}
+
+// applyEnvironmentOptions validates the options and sets them on the environment, the reviewers must
+// be able to read the actions of the repository
+func applyEnvironmentOptions(ctx context.Context, repo *repo_model.Repository, env *actions_model.ActionEnvironment, opts EnvironmentOptions) error {
+	if opts.WaitTimer < 0 || opts.WaitTimer > maxEnvironmentWaitTimer {
+		return util.NewInvalidArgumentErrorf("the wait timer must be between 0 and %d minutes", maxEnvironmentWaitTimer)
+	}
+
+	reviewerIDs := make([]int64, 0, len(opts.Reviewers))
+	for _, name := range opts.Reviewers {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return util.NewNotExistErrorf("user %s does not exist", name)
+			}
+			return err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, u)
+		if err != nil {
+			return err
+		}
+		if !perm.CanRead(unit.TypeActions) {
+			return util.NewInvalidArgumentErrorf("user %s cannot access the actions of the repository", name)
+		}
+		if !slices.Contains(reviewerIDs, u.ID) {
+			reviewerIDs = append(reviewerIDs, u.ID)
+		}
+	}
+
+	env.ReviewerIDs = reviewerIDs
+	env.PreventSelfReview = opts.PreventSelfReview
+	env.WaitTimer = opts.WaitTimer
+	env.RefPatterns = strings.TrimSpace(opts.RefPatterns)
+	return nil
+}
+
+// CreateEnvironment creates an environment of the repository with its protection rules
+func CreateEnvironment(ctx context.Context, repo *repo_model.Repository, opts EnvironmentOptions) (*actions_model.ActionEnvironment, error) {
+	opts.Name = strings.TrimSpace(opts.Name)
+	if opts.Name == "" || len(opts.Name) > 255 {
+		return nil, util.NewInvalidArgumentErrorf("invalid environment name %q", opts.Name)
+	}
+	env := &actions_model.ActionEnvironment{
+		RepoID: repo.ID,
+		Name:   opts.Name,
+	}
+	if err := applyEnvironmentOptions(ctx, repo, env, opts); err != nil {
+		return nil, err
+	}
+	return env, actions_model.InsertEnvironment(ctx, env)
+}
+
+// UpdateEnvironment updates the protection rules of an environment, the name of an environment
+// is referenced by the workflows and cannot be changed
+func UpdateEnvironment(ctx context.Context, repo *repo_model.Repository, env *actions_model.ActionEnvironment, opts EnvironmentOptions) error {
+	if err := applyEnvironmentOptions(ctx, repo, env, opts); err != nil {
+		return err
+	}
+	return actions_model.UpdateEnvironment(ctx, env)
+}
+
+// DeleteEnvironment deletes an environment with its secrets, variables and deployment history
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := secret_model.DeleteSecretsOfEnvironment(ctx, env.RepoID, env.ID); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	})
+}
+
+// getOrCreateEnvironment returns the environment a job deploys to, it is created without
+// protection rules when it does not exist yet as on GitHub
+func getOrCreateEnvironment(ctx context.Context, repoID int64, name string) (*actions_model.ActionEnvironment, error) {
+	env, err := actions_model.GetEnvironmentByName(ctx, repoID, name)
+	if err == nil || !errors.Is(err, util.ErrNotExist) {
+		return env, err
+	}
+	env = &actions_model.ActionEnvironment{RepoID: repoID, Name: name}
+	if err := actions_model.InsertEnvironment(ctx, env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// checkJobEnvironment applies the protection rules of the environment of a job whose needs are
+// satisfied, it returns the status the job can take: waiting to be dispatched to the runners,
+// blocked until its deployment is approved and the wait timer has elapsed, or failure
+func checkJobEnvironment(ctx context.Context, run *actions_model.ActionRun, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	var deployment *actions_model.ActionDeployment
+	if job.DeploymentID != 0 {
+		d, err := actions_model.GetDeploymentByID(ctx, job.RepoID, job.DeploymentID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return actions_model.StatusBlocked, err
+		}
+		deployment = d
+	}
+
+	if deployment == nil {
+		env, err := getOrCreateEnvironment(ctx, job.RepoID, job.Environment)
+		if err != nil {
+			return actions_model.StatusBlocked, err
+		}
+		deployment = &actions_model.ActionDeployment{
+			RepoID:        job.RepoID,
+			EnvironmentID: env.ID,
+			RunID:         run.ID,
+			JobID:         job.ID,
+			Ref:           run.Ref,
+			CommitSHA:     job.CommitSHA,
+			TriggerUserID: run.TriggerUserID,
+		}
+		switch {
+		case !env.IsRefAllowed(run.Ref):
+			deployment.Status = actions_model.DeploymentStatusRefNotAllowed
+		case env.HasReviewers():
+			deployment.Status = actions_model.DeploymentStatusWaiting
+		default:
+			deployment.Status = actions_model.DeploymentStatusApproved
+			deployment.DispatchAfter = timeutil.TimeStampNow().Add(env.WaitTimer * 60)
+		}
+		if err := actions_model.InsertDeployment(ctx, deployment, job); err != nil {
+			return actions_model.StatusBlocked, err
+		}
+	}
+
+	switch deployment.Status {
+	case actions_model.DeploymentStatusRejected, actions_model.DeploymentStatusRefNotAllowed:
+		return actions_model.StatusFailure, nil
+	case actions_model.DeploymentStatusApproved:
+		if deployment.IsDispatchable() {
+			return actions_model.StatusWaiting, nil
+		}
+	}
+	return actions_model.StatusBlocked, nil
+}
+
+// ErrDeploymentReview is returned when a user cannot review a deployment
+var ErrDeploymentReview = errors.New("cannot review the deployment")
+
+// ReviewDeployment approves or rejects a deployment waiting for a reviewer of its environment,
+// the job of the deployment is dispatched or fails accordingly
+func ReviewDeployment(ctx context.Context, d *actions_model.ActionDeployment, doer *user_model.User, approve bool) error {
+	if d.Status != actions_model.DeploymentStatusWaiting {
+		return ErrDeploymentReview
+	}
+	env, err := actions_model.GetEnvironmentByID(ctx, d.RepoID, d.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	if !CanReviewDeployment(env, d, doer) {
+		return ErrDeploymentReview
+	}
+
+	status := actions_model.DeploymentStatusRejected
+	var dispatchAfter timeutil.TimeStamp
+	if approve {
+		status = actions_model.DeploymentStatusApproved
+		dispatchAfter = timeutil.TimeStampNow().Add(env.WaitTimer * 60)
+	}
+	ok, err := actions_model.ReviewDeployment(ctx, d, status, doer.ID, dispatchAfter)
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrDeploymentReview
+	}
+	return EmitJobsIfReady(d.RunID)
+}
+
+// CanReviewDeployment returns whether the user is a reviewer of the environment allowed to review the deployment
+func CanReviewDeployment(env *actions_model.ActionEnvironment, d *actions_model.ActionDeployment, doer *user_model.User) bool {
+	if doer == nil || !env.IsReviewer(doer.ID) {
+		return false
+	}
+	return !env.PreventSelfReview || d.TriggerUserID != doer.ID
+}
+
+// DispatchElapsedDeployments submits to the job emitter the runs having jobs whose deployment
+// has been approved and the wait timer of the environment has elapsed since
+func DispatchElapsedDeployments(ctx context.Context) error {
+	runIDs, err := actions_model.FindRunIDsOfElapsedDeployments(ctx)
+	if err != nil {
+		return err
+	}
+	for _, runID := range runIDs {
+		if err := EmitJobsIfReady(runID); err != nil {
+			log.Error("EmitJobsIfReady for run %d: %v", runID, err)
+		}
+	}
+	return nil
+}
+
+// emitEnvironmentJobs submits a new run to the job emitter when some of its jobs deploy to an environment,
+// these jobs are created blocked until the protection rules of their environment are checked
+func emitEnvironmentJobs(ctx context.Context, run *actions_model.ActionRun) error {
+	if run.NeedApproval {
+		return nil
+	}
+	has, err := db.Exist[actions_model.ActionRunJob](ctx, builder.Eq{"run_id": run.ID}.And(builder.Neq{"environment": ""}))
+	if err != nil || !has {
+		return err
+	}
+	return EmitJobsIfReady(run.ID)
+}
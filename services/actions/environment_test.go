@@ -0,0 +1,293 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	secret_model "forgejo.org/models/secret"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+	secret_service "forgejo.org/services/secrets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockJobEmitterQueue checks the jobs of the runs synchronously when they are emitted
+func mockJobEmitterQueue(t *testing.T) func() {
+	t.Helper()
+	q, err := queue.NewWorkerPoolQueueWithContext(t.Context(), "actions_ready_job", setting.QueueSettings{Type: "immediate"}, jobEmitterQueueHandler, true)
+	require.NoError(t, err)
+	return test.MockVariableValue(&jobEmitterQueue, q)
+}
+
+// insertEnvironmentRun creates a run of the repository for the ref with a single job deploying to the environment
+func insertEnvironmentRun(t *testing.T, repo *repo_model.Repository, ref, environment string) (*actions_model.ActionRun, *actions_model.ActionRunJob) {
+	t.Helper()
+	index, err := db.GetNextResourceIndex(db.DefaultContext, "action_run_index", repo.ID)
+	require.NoError(t, err)
+	run := &actions_model.ActionRun{
+		Title:         "deploy",
+		RepoID:        repo.ID,
+		OwnerID:       repo.OwnerID,
+		WorkflowID:    "deploy.yaml",
+		Index:         index,
+		TriggerUserID: 2,
+		Ref:           ref,
+		CommitSHA:     "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		Event:         "push",
+		TriggerEvent:  "push",
+		Status:        actions_model.StatusBlocked,
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, run))
+	job := &actions_model.ActionRunJob{
+		RunID:       run.ID,
+		RepoID:      repo.ID,
+		OwnerID:     repo.OwnerID,
+		CommitSHA:   run.CommitSHA,
+		Name:        "deploy",
+		JobID:       "deploy",
+		Environment: environment,
+		Status:      actions_model.StatusBlocked,
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, job))
+	return run, job
+}
+
+func loadJobDeployment(t *testing.T, job *actions_model.ActionRunJob) (*actions_model.ActionRunJob, *actions_model.ActionDeployment) {
+	t.Helper()
+	job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+	require.NotZero(t, job.DeploymentID)
+	deployment := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionDeployment{ID: job.DeploymentID})
+	return job, deployment
+}
+
+func TestEnvironmentJobBlocked(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockJobEmitterQueue(t)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	_, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production", Reviewers: []string{"user2"}, RefPatterns: "main"})
+	require.NoError(t, err)
+
+	t.Run("WaitingForReview", func(t *testing.T) {
+		run, job := insertEnvironmentRun(t, repo, "refs/heads/main", "production")
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+
+		job, deployment := loadJobDeployment(t, job)
+		assert.Equal(t, actions_model.StatusBlocked, job.Status)
+		assert.Equal(t, actions_model.DeploymentStatusWaiting, deployment.Status)
+
+		// checking the run again does not deploy the job twice
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+		unittest.AssertCount(t, &actions_model.ActionDeployment{JobID: job.ID}, 1)
+	})
+
+	t.Run("RefNotAllowed", func(t *testing.T) {
+		run, job := insertEnvironmentRun(t, repo, "refs/tags/main", "production")
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+
+		job, deployment := loadJobDeployment(t, job)
+		assert.Equal(t, actions_model.StatusFailure, job.Status)
+		assert.NotZero(t, job.Stopped)
+		assert.Equal(t, actions_model.DeploymentStatusRefNotAllowed, deployment.Status)
+	})
+
+	t.Run("CreatedWithoutRules", func(t *testing.T) {
+		run, job := insertEnvironmentRun(t, repo, "refs/heads/feature", "staging")
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+
+		unittest.AssertExistsAndLoadBean(t, &actions_model.ActionEnvironment{RepoID: repo.ID, Name: "staging"})
+		job, deployment := loadJobDeployment(t, job)
+		assert.Equal(t, actions_model.StatusWaiting, job.Status)
+		assert.Equal(t, actions_model.DeploymentStatusApproved, deployment.Status)
+	})
+}
+
+func TestEnvironmentReviewDeployment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockJobEmitterQueue(t)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	_, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production", Reviewers: []string{"user2"}})
+	require.NoError(t, err)
+
+	t.Run("Approve", func(t *testing.T) {
+		run, job := insertEnvironmentRun(t, repo, "refs/heads/main", "production")
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+		_, deployment := loadJobDeployment(t, job)
+
+		require.ErrorIs(t, ReviewDeployment(db.DefaultContext, deployment, reader, true), ErrDeploymentReview)
+		require.NoError(t, ReviewDeployment(db.DefaultContext, deployment, owner, true))
+
+		job, deployment = loadJobDeployment(t, job)
+		assert.Equal(t, actions_model.DeploymentStatusApproved, deployment.Status)
+		assert.Equal(t, owner.ID, deployment.ReviewerID)
+		assert.Equal(t, actions_model.StatusWaiting, job.Status)
+
+		// a deployment is only reviewed once
+		require.ErrorIs(t, ReviewDeployment(db.DefaultContext, deployment, owner, false), ErrDeploymentReview)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		run, job := insertEnvironmentRun(t, repo, "refs/heads/main", "production")
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+		_, deployment := loadJobDeployment(t, job)
+
+		require.NoError(t, ReviewDeployment(db.DefaultContext, deployment, owner, false))
+
+		job, deployment = loadJobDeployment(t, job)
+		assert.Equal(t, actions_model.DeploymentStatusRejected, deployment.Status)
+		assert.Equal(t, actions_model.StatusFailure, job.Status)
+	})
+
+	t.Run("PreventSelfReview", func(t *testing.T) {
+		env, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "self-review", Reviewers: []string{"user2"}, PreventSelfReview: true})
+		require.NoError(t, err)
+		run, job := insertEnvironmentRun(t, repo, "refs/heads/main", env.Name)
+		require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+		_, deployment := loadJobDeployment(t, job)
+
+		assert.False(t, CanReviewDeployment(env, deployment, owner))
+		require.ErrorIs(t, ReviewDeployment(db.DefaultContext, deployment, owner, true), ErrDeploymentReview)
+	})
+}
+
+func TestEnvironmentWaitTimer(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockJobEmitterQueue(t)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	_, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production", WaitTimer: 10})
+	require.NoError(t, err)
+	_, err = CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "invalid", WaitTimer: maxEnvironmentWaitTimer + 1})
+	require.Error(t, err)
+
+	run, job := insertEnvironmentRun(t, repo, "refs/heads/main", "production")
+	require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+
+	job, deployment := loadJobDeployment(t, job)
+	assert.Equal(t, actions_model.DeploymentStatusApproved, deployment.Status)
+	assert.Greater(t, deployment.DispatchAfter, timeutil.TimeStampNow().Add(9*60))
+	assert.Equal(t, actions_model.StatusBlocked, job.Status)
+
+	// nothing to dispatch until the wait timer has elapsed
+	require.NoError(t, DispatchElapsedDeployments(db.DefaultContext))
+	unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID, Status: actions_model.StatusBlocked})
+
+	deployment.DispatchAfter = timeutil.TimeStampNow().Add(-1)
+	_, err = db.GetEngine(db.DefaultContext).ID(deployment.ID).Cols("dispatch_after").Update(deployment)
+	require.NoError(t, err)
+	require.NoError(t, DispatchElapsedDeployments(db.DefaultContext))
+	unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID, Status: actions_model.StatusWaiting})
+}
+
+func TestEnvironmentSecretsAndVariables(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	env, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production"})
+	require.NoError(t, err)
+
+	_, _, err = secret_service.CreateOrUpdateSecret(db.DefaultContext, repo.OwnerID, 0, "TOKEN", "owner")
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateSecret(db.DefaultContext, 0, repo.ID, "TOKEN", "repo")
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateSecret(db.DefaultContext, 0, repo.ID, "REPO_ONLY", "repo")
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateEnvironmentSecret(db.DefaultContext, repo.ID, env.ID, "TOKEN", "environment")
+	require.NoError(t, err)
+
+	_, err = CreateVariable(db.DefaultContext, 0, repo.ID, "TARGET", "repo")
+	require.NoError(t, err)
+	_, err = CreateEnvironmentVariable(db.DefaultContext, repo.ID, env.ID, "TARGET", "environment")
+	require.NoError(t, err)
+
+	run, job := insertEnvironmentRun(t, repo, "refs/heads/main", env.Name)
+	require.NoError(t, run.LoadRepo(db.DefaultContext))
+	job.Run = run
+
+	secrets, err := secret_model.GetSecretsOfTask(db.DefaultContext, &actions_model.ActionTask{Job: job, Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "environment", secrets["TOKEN"])
+	assert.Equal(t, "repo", secrets["REPO_ONLY"])
+
+	variables, err := actions_model.GetVariablesOfJob(db.DefaultContext, job)
+	require.NoError(t, err)
+	assert.Equal(t, "environment", variables["TARGET"])
+
+	// the jobs of other environments only get the repository ones
+	job.Environment = "staging"
+	secrets, err = secret_model.GetSecretsOfTask(db.DefaultContext, &actions_model.ActionTask{Job: job, Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "repo", secrets["TOKEN"])
+	variables, err = actions_model.GetVariablesOfJob(db.DefaultContext, job)
+	require.NoError(t, err)
+	assert.Equal(t, "repo", variables["TARGET"])
+}
+
+func TestEnvironmentRerunJob(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockJobEmitterQueue(t)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	_, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production", Reviewers: []string{"user2"}})
+	require.NoError(t, err)
+
+	run, job := insertEnvironmentRun(t, repo, "refs/heads/main", "production")
+	require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+	_, deployment := loadJobDeployment(t, job)
+	require.NoError(t, ReviewDeployment(db.DefaultContext, deployment, owner, false))
+	job, _ = loadJobDeployment(t, job)
+	require.Equal(t, actions_model.StatusFailure, job.Status)
+
+	// the new attempt is deployed again and waits for a new review
+	require.NoError(t, RerunJob(db.DefaultContext, job, false))
+	job, rerunDeployment := loadJobDeployment(t, job)
+	assert.NotEqual(t, deployment.ID, rerunDeployment.ID)
+	assert.Equal(t, actions_model.DeploymentStatusWaiting, rerunDeployment.Status)
+	assert.Equal(t, actions_model.StatusBlocked, job.Status)
+	deployment.Job, rerunDeployment.Job = job, job
+	assert.False(t, deployment.IsCurrent())
+	assert.True(t, rerunDeployment.IsCurrent())
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockJobEmitterQueue(t)()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	env, err := CreateEnvironment(db.DefaultContext, repo, EnvironmentOptions{Name: "production", Reviewers: []string{"user2"}})
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateEnvironmentSecret(db.DefaultContext, repo.ID, env.ID, "TOKEN", "environment")
+	require.NoError(t, err)
+	_, err = CreateEnvironmentVariable(db.DefaultContext, repo.ID, env.ID, "TARGET", "environment")
+	require.NoError(t, err)
+	run, job := insertEnvironmentRun(t, repo, "refs/heads/main", env.Name)
+	require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+	_, deployment := loadJobDeployment(t, job)
+
+	require.NoError(t, DeleteEnvironment(db.DefaultContext, env))
+	unittest.AssertNotExistsBean(t, &actions_model.ActionEnvironment{ID: env.ID})
+	unittest.AssertNotExistsBean(t, &actions_model.ActionDeployment{ID: deployment.ID})
+	unittest.AssertNotExistsBean(t, &secret_model.Secret{RepoID: repo.ID, EnvironmentID: env.ID})
+	unittest.AssertNotExistsBean(t, &actions_model.ActionVariable{RepoID: repo.ID, EnvironmentID: env.ID})
+
+	// the blocked job is deployed again to an environment created without protection rules
+	require.NoError(t, checkJobsOfRun(db.DefaultContext, run.ID))
+	job, deployment = loadJobDeployment(t, job)
+	assert.Equal(t, actions_model.DeploymentStatusApproved, deployment.Status)
+	assert.Equal(t, actions_model.StatusWaiting, job.Status)
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/auth/source/oauth2"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// idTokenRequestScope is the scope of the tokens jobs use to request ID tokens, it differs from the scope
+// of the runtime tokens so that neither token can be used as the other
+const idTokenRequestScope = "Actions.IDToken"
+
+// IDTokenClaims are the claims of the OpenID Connect ID tokens issued to jobs, they follow the
+// claims of GitHub Actions so that third party services can be configured alike
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Ref               string `json:"ref"`
+	RefType           string `json:"ref_type"`
+	SHA               string `json:"sha"`
+	Repository        string `json:"repository"`
+	RepositoryID      string `json:"repository_id"`
+	RepositoryOwner   string `json:"repository_owner"`
+	RepositoryOwnerID string `json:"repository_owner_id"`
+	Workflow          string `json:"workflow"`
+	Job               string `json:"job"`
+	RunID             string `json:"run_id"`
+	RunNumber         string `json:"run_number"`
+	RunAttempt        string `json:"run_attempt"`
+	EventName         string `json:"event_name"`
+	BaseRef           string `json:"base_ref"`
+	HeadRef           string `json:"head_ref"`
+	Actor             string `json:"actor"`
+	ActorID           string `json:"actor_id"`
+	Environment       string `json:"environment,omitempty"`
+}
+
+// IDTokenIssuer returns the issuer of the ID tokens, the OpenID Connect discovery document
+// is served at <issuer>/.well-known/openid-configuration
+func IDTokenIssuer() string {
+	return setting.AppURL + "api/actions"
+}
+
+// IDTokenRequestURL returns the URL jobs request their ID tokens from
+func IDTokenRequestURL() string {
+	return IDTokenIssuer() + "/idtoken"
+}
+
+// IDTokenEnabled reports whether ID tokens can be issued, which requires an asymmetric signing key
+// so that third parties can verify the tokens with the published keys
+func IDTokenEnabled() bool {
+	return setting.Actions.EnableIDToken && oauth2.DefaultSigningKey != nil && !oauth2.DefaultSigningKey.IsSymmetric()
+}
+
+// canRequestIDToken reports whether the job of a single job workflow payload is allowed to request ID tokens,
+// with `permissions: id-token: write` or `permissions: write-all` on the job or, if the job declares no
+// permissions, on the workflow
+func canRequestIDToken(workflowPayload []byte) bool {
+	var workflow struct {
+		Permissions yaml.Node `yaml:"permissions"`
+		Jobs        map[string]struct {
+			Permissions yaml.Node `yaml:"permissions"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(workflowPayload, &workflow); err != nil {
+		return false
+	}
+	permissions := workflow.Permissions
+	for _, job := range workflow.Jobs {
+		if !job.Permissions.IsZero() {
+			permissions = job.Permissions
+		}
+	}
+	switch permissions.Kind {
+	case yaml.ScalarNode:
+		return permissions.Value == "write-all"
+	case yaml.MappingNode:
+		var scopes map[string]string
+		if err := permissions.Decode(&scopes); err == nil {
+			return scopes["id-token"] == "write"
+		}
+	}
+	return false
+}
+
+// CreateIDTokenRequestToken creates the token a task authenticates with to request ID tokens
+func CreateIDTokenRequestToken(taskID, runID, jobID int64) (string, error) {
+	now := time.Now()
+	claims := actionsClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(24 * time.Hour)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+		Scp:    fmt.Sprintf("%s:%d:%d", idTokenRequestScope, runID, jobID),
+		TaskID: taskID,
+		RunID:  runID,
+		JobID:  jobID,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(setting.GetGeneralTokenSigningSecret())
+}
+
+// IDTokenRequestTokenToTaskID returns the TaskID of a token created by CreateIDTokenRequestToken
+func IDTokenRequestTokenToTaskID(token string) (int64, error) {
+	parsedToken, err := jwt.ParseWithClaims(token, &actionsClaims{}, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return setting.GetGeneralTokenSigningSecret(), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	c, ok := parsedToken.Claims.(*actionsClaims)
+	if !parsedToken.Valid || !ok || !strings.HasPrefix(c.Scp, idTokenRequestScope+":") {
+		return 0, fmt.Errorf("invalid token claim")
+	}
+	return c.TaskID, nil
+}
+
+// idTokenSubject returns the subject of an ID token, which identifies the repository and
+// the environment, the pull request or the ref the job runs for
+func idTokenSubject(claims *IDTokenClaims) string {
+	switch {
+	case claims.Environment != "":
+		return fmt.Sprintf("repo:%s:environment:%s", claims.Repository, claims.Environment)
+	case strings.HasPrefix(claims.EventName, "pull_request"):
+		return fmt.Sprintf("repo:%s:pull_request", claims.Repository)
+	default:
+		return fmt.Sprintf("repo:%s:ref:%s", claims.Repository, claims.Ref)
+	}
+}
+
+// CreateIDToken issues a short-lived OpenID Connect ID token to the running task, for the audience
+// requested by the job or the URL of the repository owner by default
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	if !IDTokenEnabled() {
+		return "", fmt.Errorf("ID tokens are disabled or the JWT signing algorithm is symmetric")
+	}
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	job := task.Job
+	run := job.Run
+	if run.IsForkPullRequest {
+		return "", fmt.Errorf("ID tokens are not issued to pull requests from forks")
+	}
+
+	gitCtx := GenerateGiteaContext(run, job)
+	str := func(key string) string {
+		s, _ := gitCtx[key].(string)
+		return s
+	}
+	if audience == "" {
+		audience = setting.AppURL + run.Repo.OwnerName
+	}
+
+	now := time.Now()
+	claims := &IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    IDTokenIssuer(),
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(setting.Actions.IDTokenExpiration)),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        fmt.Sprintf("%d-%d", task.ID, now.UnixNano()),
+		},
+		Ref:               str("ref"),
+		RefType:           str("ref_type"),
+		SHA:               str("sha"),
+		Repository:        run.Repo.FullName(),
+		RepositoryID:      fmt.Sprint(run.Repo.ID),
+		RepositoryOwner:   run.Repo.OwnerName,
+		RepositoryOwnerID: fmt.Sprint(run.Repo.OwnerID),
+		Workflow:          run.WorkflowID,
+		Job:               job.JobID,
+		RunID:             fmt.Sprint(run.ID),
+		RunNumber:         fmt.Sprint(run.Index),
+		RunAttempt:        fmt.Sprint(job.Attempt),
+		EventName:         run.TriggerEvent,
+		BaseRef:           str("base_ref"),
+		HeadRef:           str("head_ref"),
+		Actor:             run.TriggerUser.Name,
+		ActorID:           fmt.Sprint(run.TriggerUser.ID),
+		Environment:       job.Environment,
+	}
+	claims.Subject = idTokenSubject(claims)
+	claims.IssuedAt = jwt.NewNumericDate(now)
+
+	signingKey := oauth2.DefaultSigningKey
+	token := jwt.NewWithClaims(signingKey.SigningMethod(), claims)
+	signingKey.PreProcessToken(token)
+	return token.SignedString(signingKey.SignKey())
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/services/auth/source/oauth2"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDTokenRequestToken(t *testing.T) {
+	token, err := CreateIDTokenRequestToken(47, 791, 192)
+	require.NoError(t, err)
+	taskID, err := IDTokenRequestTokenToTaskID(token)
+	require.NoError(t, err)
+	assert.EqualValues(t, 47, taskID)
+
+	// the runtime token must not be usable to request ID tokens
+	runtimeToken, err := CreateAuthorizationToken(47, 791, 192)
+	require.NoError(t, err)
+	_, err = IDTokenRequestTokenToTaskID(runtimeToken)
+	require.Error(t, err)
+
+	// and the ID token request token must not be usable as a runtime token
+	_, err = TokenToTaskID(token)
+	require.Error(t, err)
+}
+
+func TestCanRequestIDToken(t *testing.T) {
+	for name, c := range map[string]struct {
+		payload  string
+		expected bool
+	}{
+		"None":            {payload: "jobs:\n  job:\n    runs-on: docker\n", expected: false},
+		"Job":             {payload: "jobs:\n  job:\n    permissions:\n      id-token: write\n", expected: true},
+		"Job read":        {payload: "jobs:\n  job:\n    permissions:\n      id-token: read\n", expected: false},
+		"Workflow":        {payload: "permissions:\n  id-token: write\njobs:\n  job:\n    runs-on: docker\n", expected: true},
+		"Job overrides":   {payload: "permissions:\n  id-token: write\njobs:\n  job:\n    permissions:\n      contents: read\n", expected: false},
+		"Write all":       {payload: "permissions: write-all\njobs:\n  job:\n    runs-on: docker\n", expected: true},
+		"Read all":        {payload: "jobs:\n  job:\n    permissions: read-all\n", expected: false},
+		"Invalid payload": {payload: "jobs: [", expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, canRequestIDToken([]byte(c.payload)))
+		})
+	}
+}
+
+func TestCreateIDToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := oauth2.CreateJWTSigningKey("EdDSA", privateKey)
+	require.NoError(t, err)
+	defer test.MockVariableValue(&oauth2.DefaultSigningKey, signingKey)()
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	idToken, err := CreateIDToken(t.Context(), task, "sts.example.com")
+	require.NoError(t, err)
+
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(*jwt.Token) (any, error) {
+		return signingKey.VerifyKey(), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, IDTokenIssuer(), claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"sts.example.com"}, claims.Audience)
+	assert.Equal(t, "repo:user5/repo4:ref:refs/heads/master", claims.Subject)
+	assert.Equal(t, "user5/repo4", claims.Repository)
+	assert.Equal(t, "artifact.yaml", claims.Workflow)
+	assert.Equal(t, "job_2", claims.Job)
+	assert.Equal(t, "791", claims.RunID)
+	assert.Equal(t, "187", claims.RunNumber)
+
+	// symmetric keys cannot be verified by third parties
+	hmacKey, err := oauth2.CreateJWTSigningKey("HS256", setting.GetGeneralTokenSigningSecret())
+	require.NoError(t, err)
+	defer test.MockVariableValue(&oauth2.DefaultSigningKey, hmacKey)()
+	_, err = CreateIDToken(t.Context(), task, "")
+	require.Error(t, err)
+}
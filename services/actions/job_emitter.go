@@ -12,6 +12,7 @@ import (
 	"forgejo.org/models/db"
 	"forgejo.org/modules/graceful"
 	"forgejo.org/modules/queue"
+	"forgejo.org/modules/timeutil"
 
 	"github.com/nektos/act/pkg/jobparser"
 	"xorm.io/builder"
@@ -45,10 +46,19 @@ func jobEmitterQueueHandler(items ...*jobUpdate) []*jobUpdate {
 }
 
 func checkJobsOfRun(ctx context.Context, runID int64) error {
+	run, err := actions_model.GetRunByID(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if run.NeedApproval {
+		// the jobs are unblocked once the run is approved
+		return nil
+	}
 	jobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{RunID: runID})
 	if err != nil {
 		return err
 	}
+	var hasFailedDeployment bool
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		idToJobs := make(map[string][]*actions_model.ActionRunJob, len(jobs))
 		for _, job := range jobs {
@@ -58,8 +68,22 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 		updates := newJobStatusResolver(jobs).Resolve()
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
+				cols := []string{"status"}
+				if status == actions_model.StatusWaiting && job.Environment != "" {
+					if status, err = checkJobEnvironment(ctx, run, job); err != nil {
+						return err
+					}
+					if status == actions_model.StatusBlocked {
+						continue
+					}
+					if status == actions_model.StatusFailure {
+						hasFailedDeployment = true
+						job.Stopped = timeutil.TimeStampNow()
+						cols = append(cols, "stopped")
+					}
+				}
 				job.Status = status
-				if n, err := UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status"); err != nil {
+				if n, err := UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, cols...); err != nil {
 					return err
 				} else if n != 1 {
 					return fmt.Errorf("no affected for updating blocked job %v", job.ID)
@@ -71,6 +95,10 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 		return err
 	}
 	CreateCommitStatus(ctx, jobs...)
+	if hasFailedDeployment {
+		// the jobs needing the ones which failed to deploy can now be resolved
+		return EmitJobsIfReady(runID)
+	}
 	return nil
 }
 
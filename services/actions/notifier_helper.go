@@ -388,6 +388,9 @@ func handleWorkflows(
 			log.Error("InsertRun: %v", err)
 			continue
 		}
+		if err := emitEnvironmentJobs(ctx, run); err != nil {
+			log.Error("emitEnvironmentJobs: %v", err)
+		}
 
 		alljobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{RunID: run.ID})
 		if err != nil {
@@ -4,8 +4,13 @@
 package actions
 
 import (
+	"context"
+
 	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
 	"forgejo.org/modules/container"
+
+	"xorm.io/builder"
 )
 
 // GetAllRerunJobs get all jobs that need to be rerun when job should be rerun
@@ -36,3 +41,34 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 
 	return rerunJobs
 }
+
+// RerunJob resets a done job for a new attempt, blocked when it should wait for the jobs it needs
+func RerunJob(ctx context.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
+	status := job.Status
+	if !status.IsDone() {
+		return nil
+	}
+
+	job.TaskID = 0
+	job.Status = actions_model.StatusWaiting
+	// the new attempt of a job deploying to an environment is deployed again, once unblocked by the job emitter
+	if shouldBlock || job.Environment != "" {
+		job.Status = actions_model.StatusBlocked
+	}
+	job.DeploymentID = 0
+	job.Started = 0
+	job.Stopped = 0
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		_, err := UpdateRunJob(ctx, job, builder.Eq{"status": status}, "task_id", "status", "deployment_id", "started", "stopped")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatus(ctx, job)
+	if job.Environment != "" && !shouldBlock {
+		return EmitJobsIfReady(job.RunID)
+	}
+	return nil
+}
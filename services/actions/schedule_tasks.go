@@ -150,8 +150,7 @@ func CreateScheduleTask(ctx context.Context, cron *actions_model.ActionSchedule)
 		return err
 	}
 
-	// Return nil if no errors occurred
-	return nil
+	return emitEnvironmentJobs(ctx, run)
 }
 
 // CancelPreviousJobs cancels all previous jobs of the same repository, reference, workflow, and event.
@@ -43,9 +43,9 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
 
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -90,6 +90,15 @@ func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error)
 	gitCtx := GenerateGiteaContext(t.Job.Run, t.Job)
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
+	if IDTokenEnabled() && !t.IsForkPullRequest && canRequestIDToken(t.Job.WorkflowPayload) {
+		idTokenRequestToken, err := CreateIDTokenRequestToken(t.ID, t.Job.RunID, t.JobID)
+		if err != nil {
+			return nil, err
+		}
+		// exposed to the steps as ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN by the runner
+		gitCtx["forgejo_actions_id_token_request_url"] = IDTokenRequestURL()
+		gitCtx["forgejo_actions_id_token_request_token"] = idTokenRequestToken
+	}
 
 	return structpb.NewStruct(gitCtx)
 }
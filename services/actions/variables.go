@@ -49,6 +49,38 @@ func UpdateVariable(ctx context.Context, variableID, ownerID, repoID int64, name
 	})
 }
 
+// CreateEnvironmentVariable creates a variable of an environment of a repository
+func CreateEnvironmentVariable(ctx context.Context, repoID, environmentID int64, name, data string) (*actions_model.ActionVariable, error) {
+	if err := secret_service.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	if err := envNameCIRegexMatch(name); err != nil {
+		return nil, err
+	}
+
+	return actions_model.InsertEnvironmentVariable(ctx, repoID, environmentID, name, util.ReserveLineBreakForTextarea(data))
+}
+
+// UpdateEnvironmentVariable updates a variable of an environment of a repository
+func UpdateEnvironmentVariable(ctx context.Context, variableID, repoID, environmentID int64, name, data string) (bool, error) {
+	if err := secret_service.ValidateName(name); err != nil {
+		return false, err
+	}
+
+	if err := envNameCIRegexMatch(name); err != nil {
+		return false, err
+	}
+
+	return actions_model.UpdateVariable(ctx, &actions_model.ActionVariable{
+		ID:            variableID,
+		Name:          strings.ToUpper(name),
+		Data:          util.ReserveLineBreakForTextarea(data),
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+	})
+}
+
 func DeleteVariableByName(ctx context.Context, ownerID, repoID int64, name string) error {
 	if err := secret_service.ValidateName(name); err != nil {
 		return err
@@ -0,0 +1,73 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/ssh"
+	"forgejo.org/modules/util"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// SignSSHUserCertificate signs a short-lived certificate for the public key of the user, whose principal
+// is the username. A ttl of zero requests the default validity of the certificates.
+func SignSSHUserCertificate(ctx context.Context, u *user_model.User, publicKey string, ttl time.Duration) (*gossh.Certificate, error) {
+	if !setting.SSH.UserCAEnabled {
+		return nil, ssh.ErrUserCADisabled
+	}
+	if ttl == 0 {
+		ttl = setting.SSH.UserCertificateTTL
+	}
+	if ttl < 0 || ttl > setting.SSH.UserCertificateMaxTTL {
+		return nil, util.NewInvalidArgumentErrorf("the validity of the certificate must be at most %s", setting.SSH.UserCertificateMaxTTL)
+	}
+
+	content, err := asymkey_model.CheckPublicKeyString(publicKey)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid public key: %v", err)
+	}
+	pub, _, _, _, err := gossh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid public key: %v", err)
+	}
+	if _, ok := pub.(*gossh.Certificate); ok {
+		return nil, util.NewInvalidArgumentErrorf("a certificate can not be signed")
+	}
+
+	if err := ensureUsernamePrincipal(ctx, u); err != nil {
+		return nil, err
+	}
+
+	cert, err := ssh.SignUserCertificate(pub, fmt.Sprintf("forgejo:%s", u.Name), []string{u.Name}, ttl)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("SSH certificate %d signed for %s, valid until %s", cert.Serial, u.Name, time.Unix(int64(cert.ValidBefore), 0))
+	return cert, nil
+}
+
+// ensureUsernamePrincipal registers the username as a principal of the user, so the certificates signed
+// for it authenticate the user, unless it already belongs to someone else
+func ensureUsernamePrincipal(ctx context.Context, u *user_model.User) error {
+	key, err := asymkey_model.SearchPublicKeyByContentExact(ctx, u.Name)
+	if err == nil {
+		if key.OwnerID != u.ID || key.Type != asymkey_model.KeyTypePrincipal {
+			return util.NewPermissionDeniedErrorf("the principal %s is already used by another key", u.Name)
+		}
+		return nil
+	}
+	if !asymkey_model.IsErrKeyNotExist(err) {
+		return err
+	}
+	_, err = asymkey_model.AddPrincipalKey(ctx, u.ID, u.Name, 0)
+	return err
+}
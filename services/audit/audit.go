@@ -0,0 +1,236 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web/middleware"
+)
+
+// Target is the object an event is about, with the owner and the repository it belongs to
+// which scope the event to the audit log of the organization or of the repository
+type Target struct {
+	OwnerID int64
+	RepoID  int64
+	Type    string
+	ID      int64
+	Name    string
+}
+
+// RepoTarget returns the target of an event about a repository
+func RepoTarget(repo *repo_model.Repository) Target {
+	return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: "repository", ID: repo.ID, Name: repo.FullName()}
+}
+
+// CollaboratorTarget returns the target of an event about a collaborator of a repository
+func CollaboratorTarget(repo *repo_model.Repository, u *user_model.User) Target {
+	return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: "collaborator", ID: u.ID, Name: u.Name}
+}
+
+// UserTarget returns the target of an event about a user or an organization
+func UserTarget(u *user_model.User) Target {
+	t := Target{OwnerID: u.ID, Type: "user", ID: u.ID, Name: u.Name}
+	if u.IsOrganization() {
+		t.Type = "organization"
+	}
+	return t
+}
+
+// TeamTarget returns the target of an event about a team of an organization
+func TeamTarget(team *organization.Team) Target {
+	return Target{OwnerID: team.OrgID, Type: "team", ID: team.ID, Name: team.Name}
+}
+
+// Record records an event in the audit log and writes it to the audit logger, if enabled. The doer
+// defaults to the signed in user of the request ctx belongs to, whose address is recorded as well.
+// The before and after values are recorded as JSON. Failing to record an event is logged but does
+// not fail the operation the event is about.
+func Record(ctx context.Context, doer *user_model.User, action audit_model.Action, target Target, before, after any) {
+	if !setting.Audit.Enabled {
+		return
+	}
+
+	data := middleware.GetContextData(ctx)
+	if doer == nil {
+		doer, _ = data[middleware.ContextDataKeySignedUser].(*user_model.User)
+	}
+	remoteAddr, _ := data[middleware.ContextDataKeyRemoteAddr].(string)
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+
+	if target.RepoID > 0 && target.OwnerID == 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, target.RepoID)
+		if err != nil {
+			log.Error("Unable to record the audit event %s: GetRepositoryByID[%d]: %v", action, target.RepoID, err)
+			return
+		}
+		target.OwnerID = repo.OwnerID
+	}
+
+	e := &audit_model.Event{
+		Action:     action,
+		IPAddress:  remoteAddr,
+		OwnerID:    target.OwnerID,
+		RepoID:     target.RepoID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Before:     marshalValue(before),
+		After:      marshalValue(after),
+	}
+	if doer != nil {
+		e.ActorID = doer.ID
+		e.ActorName = doer.Name
+	}
+	if err := audit_model.InsertEvent(ctx, e); err != nil {
+		log.Error("Unable to record the audit event %s: %v", action, err)
+		return
+	}
+
+	if setting.IsAuditLogEnabled() {
+		if line, err := json.Marshal(e); err == nil {
+			log.GetLogger("audit").Info("%s", line)
+		}
+	}
+}
+
+// RecordRepoVisibility records the change of the visibility of a repository, once updated
+func RecordRepoVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	Record(ctx, doer, audit_model.ActionRepoVisibility, RepoTarget(repo),
+		map[string]bool{"private": !repo.IsPrivate}, map[string]bool{"private": repo.IsPrivate})
+}
+
+// TeamPermissions returns the permissions of a team as recorded before and after the events
+// about the team, its units are loaded if they are not already
+func TeamPermissions(ctx context.Context, team *organization.Team) map[string]any {
+	if err := team.LoadUnits(ctx); err != nil {
+		log.Error("LoadUnits[%d]: %v", team.ID, err)
+	}
+	return map[string]any{
+		"name":                      team.Name,
+		"access_mode":               team.AccessMode.String(),
+		"includes_all_repositories": team.IncludesAllRepositories,
+		"can_create_org_repo":       team.CanCreateOrgRepo,
+		"units":                     team.GetUnitsMap(),
+	}
+}
+
+// RecordTeamMember records the addition or the removal of a member of a team
+func RecordTeamMember(ctx context.Context, doer *user_model.User, action audit_model.Action, team *organization.Team, userID int64) {
+	u, err := user_model.GetPossibleUserByID(ctx, userID)
+	if err != nil {
+		log.Error("Unable to record the audit event %s: GetPossibleUserByID[%d]: %v", action, userID, err)
+		return
+	}
+	member := map[string]string{"member": u.Name}
+	if action == audit_model.ActionTeamMemberRemove {
+		Record(ctx, doer, action, TeamTarget(team), member, nil)
+	} else {
+		Record(ctx, doer, action, TeamTarget(team), nil, member)
+	}
+}
+
+// BranchProtectionSnapshot returns a copy of a branch protection rule to be recorded as the value
+// before it is updated or deleted, nil if the rule is not stored yet
+func BranchProtectionSnapshot(pb *git_model.ProtectedBranch) *git_model.ProtectedBranch {
+	if pb == nil || pb.ID == 0 {
+		return nil
+	}
+	c := *pb
+	c.Repo = nil
+	return &c
+}
+
+// RecordBranchProtection records the creation, the update or the deletion of a branch protection
+// rule, depending on which of the before and after values is nil
+func RecordBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, before, after *git_model.ProtectedBranch) {
+	target := Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: "branch_protection"}
+	var action audit_model.Action
+	var beforeValue, afterValue any
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		action = audit_model.ActionBranchProtectionCreate
+		afterValue = BranchProtectionSnapshot(after)
+		target.ID, target.Name = after.ID, after.RuleName
+	case after == nil:
+		action = audit_model.ActionBranchProtectionDelete
+		beforeValue = before
+		target.ID, target.Name = before.ID, before.RuleName
+	default:
+		action = audit_model.ActionBranchProtectionUpdate
+		beforeValue, afterValue = before, BranchProtectionSnapshot(after)
+		target.ID, target.Name = after.ID, after.RuleName
+	}
+	Record(ctx, doer, action, target, beforeValue, afterValue)
+}
+
+// WebhookSnapshot returns the value of a webhook recorded before and after the events about it,
+// without its secret nor its authorization header
+func WebhookSnapshot(w *webhook_model.Webhook) map[string]any {
+	v := map[string]any{
+		"type":         w.Type,
+		"url":          util.SanitizeCredentialURLs(w.URL),
+		"http_method":  w.HTTPMethod,
+		"content_type": w.ContentType.Name(),
+		"active":       w.IsActive,
+	}
+	if w.HookEvent != nil {
+		// copied as the events are updated in place
+		v["events"] = *w.HookEvent
+	}
+	return v
+}
+
+// RecordWebhook records an event about a webhook, its value after the event is recorded unless
+// it has been deleted
+func RecordWebhook(ctx context.Context, doer *user_model.User, action audit_model.Action, w *webhook_model.Webhook, before map[string]any) {
+	target := Target{OwnerID: w.OwnerID, RepoID: w.RepoID, Type: "webhook", ID: w.ID, Name: util.SanitizeCredentialURLs(w.URL)}
+	if action == audit_model.ActionWebhookDelete {
+		Record(ctx, doer, action, target, before, nil)
+		return
+	}
+	Record(ctx, doer, action, target, before, WebhookSnapshot(w))
+}
+
+func marshalValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		log.Error("Unable to marshal the audit value %v: %v", v, err)
+		return ""
+	}
+	return string(bs)
+}
+
+// DeleteExpiredEvents deletes the events of the audit log older than the retention setting
+func DeleteExpiredEvents(ctx context.Context) error {
+	if setting.Audit.Retention <= 0 {
+		return nil
+	}
+	n, err := audit_model.DeleteEventsOlderThan(ctx, timeutil.TimeStamp(time.Now().Add(-setting.Audit.Retention).Unix()))
+	if err != nil {
+		return err
+	}
+	log.Trace("Deleted %d expired audit events", n)
+	return nil
+}
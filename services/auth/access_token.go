@@ -0,0 +1,247 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/mailer"
+)
+
+// AccessTokenResourceOption selects a repository, as owner/name, or an organization a token is restricted to
+type AccessTokenResourceOption struct {
+	Repository   string
+	Organization string
+	Write        bool
+}
+
+// CreateAccessTokenOptions are the options to create an access token
+type CreateAccessTokenOptions struct {
+	Name        string
+	Scope       auth_model.AccessTokenScope
+	ExpiresUnix timeutil.TimeStamp
+	// Resources restrict the token to a set of repositories or to one organization, the token can access
+	// everything its scope allows when empty
+	Resources []AccessTokenResourceOption
+}
+
+// ValidateAccessTokenExpiry checks the expiration date of a new token against the maximum lifetime
+// configured by the administrator
+func ValidateAccessTokenExpiry(expires timeutil.TimeStamp) error {
+	now := time.Now()
+	if expires == 0 {
+		if setting.AccessTokenMaxLifetime > 0 {
+			return util.NewInvalidArgumentErrorf("access token must expire within %s", setting.AccessTokenMaxLifetime)
+		}
+		return nil
+	}
+	if expires.AsTime().Before(now) {
+		return util.NewInvalidArgumentErrorf("access token expiration date must be in the future")
+	}
+	if setting.AccessTokenMaxLifetime > 0 && expires.AsTime().After(now.Add(setting.AccessTokenMaxLifetime)) {
+		return util.NewInvalidArgumentErrorf("access token must expire within %s", setting.AccessTokenMaxLifetime)
+	}
+	return nil
+}
+
+// resolveAccessTokenResources finds the resources a token of the user is restricted to,
+// the user must have access to each of them
+func resolveAccessTokenResources(ctx context.Context, u *user_model.User, opts []AccessTokenResourceOption) (auth_model.AccessTokenResources, error) {
+	resources := make(auth_model.AccessTokenResources, 0, len(opts))
+	for _, opt := range opts {
+		mode := perm.AccessModeRead
+		if opt.Write {
+			mode = perm.AccessModeWrite
+		}
+
+		if opt.Organization != "" {
+			if len(opts) > 1 {
+				return nil, util.NewInvalidArgumentErrorf("access token can be restricted to a set of repositories or to one organization")
+			}
+			org, err := organization.GetOrgByName(ctx, opt.Organization)
+			if err != nil {
+				if organization.IsErrOrgNotExist(err) {
+					return nil, util.NewNotExistErrorf("organization %s does not exist", opt.Organization)
+				}
+				return nil, err
+			}
+			isMember, err := org.IsOrgMember(ctx, u.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !isMember {
+				return nil, util.NewNotExistErrorf("organization %s does not exist", opt.Organization)
+			}
+			resources = append(resources, &auth_model.AccessTokenResource{OwnerID: org.ID, Mode: mode})
+			continue
+		}
+
+		ownerName, repoName, ok := strings.Cut(opt.Repository, "/")
+		if !ok || ownerName == "" || repoName == "" {
+			return nil, util.NewInvalidArgumentErrorf("invalid repository %q, expected owner/name", opt.Repository)
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, util.NewNotExistErrorf("repository %s does not exist", opt.Repository)
+			}
+			return nil, err
+		}
+		permission, err := access_model.GetUserRepoPermission(ctx, repo, u)
+		if err != nil {
+			return nil, err
+		}
+		if !permission.HasAccess() {
+			return nil, util.NewNotExistErrorf("repository %s does not exist", opt.Repository)
+		}
+		resources = append(resources, &auth_model.AccessTokenResource{RepoID: repo.ID, Mode: mode})
+	}
+	return resources, nil
+}
+
+// CreateAccessToken creates an access token of the user after validating its expiration date and resources
+func CreateAccessToken(ctx context.Context, u *user_model.User, opts CreateAccessTokenOptions) (*auth_model.AccessToken, error) {
+	if err := ValidateAccessTokenExpiry(opts.ExpiresUnix); err != nil {
+		return nil, err
+	}
+	t := &auth_model.AccessToken{
+		UID:         u.ID,
+		Name:        opts.Name,
+		Scope:       opts.Scope,
+		ExpiresUnix: opts.ExpiresUnix,
+	}
+	if len(opts.Resources) == 0 {
+		if err := auth_model.NewAccessToken(ctx, t); err != nil {
+			return nil, err
+		}
+	} else {
+		resources, err := resolveAccessTokenResources(ctx, u, opts.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if err := auth_model.NewAccessTokenWithResources(ctx, t, resources); err != nil {
+			return nil, err
+		}
+	}
+	audit_service.Record(ctx, nil, audit_model.ActionTokenCreate, accessTokenTarget(u, t), nil, accessTokenValue(t, opts.Resources))
+	return t, nil
+}
+
+// DeleteAccessToken deletes an access token of the user
+func DeleteAccessToken(ctx context.Context, u *user_model.User, id int64) error {
+	t, exist, err := db.GetByID[auth_model.AccessToken](ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exist || t.UID != u.ID {
+		return auth_model.ErrAccessTokenNotExist{}
+	}
+	if err := auth_model.DeleteAccessTokenByID(ctx, id, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, nil, audit_model.ActionTokenDelete, accessTokenTarget(u, t), accessTokenValue(t, nil), nil)
+	return nil
+}
+
+func accessTokenTarget(u *user_model.User, t *auth_model.AccessToken) audit_service.Target {
+	return audit_service.Target{OwnerID: u.ID, Type: "access_token", ID: t.ID, Name: t.Name}
+}
+
+// accessTokenValue is the value of a token recorded in the audit log, without its secret
+func accessTokenValue(t *auth_model.AccessToken, resources []AccessTokenResourceOption) map[string]any {
+	v := map[string]any{"scope": string(t.Scope), "expires": t.ExpiresUnix}
+	if len(resources) > 0 {
+		v["resources"] = resources
+	}
+	return v
+}
+
+// AccessTokenResourceOptions describes the resources of a restricted token by name,
+// the resources which have been deleted since the token was created are skipped
+func AccessTokenResourceOptions(ctx context.Context, resources auth_model.AccessTokenResources) ([]AccessTokenResourceOption, error) {
+	opts := make([]AccessTokenResourceOption, 0, len(resources))
+	for _, r := range resources {
+		opt := AccessTokenResourceOption{Write: r.Mode >= perm.AccessModeWrite}
+		if r.RepoID != 0 {
+			repo, err := repo_model.GetRepositoryByID(ctx, r.RepoID)
+			if err != nil {
+				if repo_model.IsErrRepoNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			opt.Repository = repo.FullName()
+		} else {
+			owner, err := user_model.GetUserByID(ctx, r.OwnerID)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			opt.Organization = owner.Name
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+// NotifyExpiringAccessTokens warns the users by email about their access tokens expiring within
+// the configured warning period, each token is only notified once
+func NotifyExpiringAccessTokens(ctx context.Context) error {
+	if setting.AccessTokenExpiryWarning <= 0 {
+		return nil
+	}
+	tokens, err := auth_model.FindAccessTokensToWarnOfExpiry(ctx, timeutil.TimeStampNow().AddDuration(setting.AccessTokenExpiryWarning))
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[int64][]*auth_model.AccessToken)
+	userIDs := make([]int64, 0, len(tokens))
+	for _, t := range tokens {
+		if _, ok := byUser[t.UID]; !ok {
+			userIDs = append(userIDs, t.UID)
+		}
+		byUser[t.UID] = append(byUser[t.UID], t)
+	}
+
+	for _, userID := range userIDs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("while notifying expiring access tokens")
+		default:
+		}
+		u, err := user_model.GetUserByID(ctx, userID)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+		} else if err := mailer.SendAccessTokenExpiringMail(u, byUser[userID]); err != nil {
+			log.Error("SendAccessTokenExpiringMail for user %d: %v", userID, err)
+			continue
+		}
+		for _, t := range byUser[userID] {
+			if err := auth_model.SetAccessTokenExpiryNotified(ctx, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -5,11 +5,13 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
 
+	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/auth/webauthn"
 	"forgejo.org/modules/log"
@@ -61,6 +63,22 @@ func isArchivePath(req *http.Request) bool {
 	return archivePathRe.MatchString(req.URL.Path)
 }
 
+// storeAccessTokenScope makes the scope and, for a restricted token, the resources
+// of the access token available to the permission checks
+func storeAccessTokenScope(ctx context.Context, t *auth_model.AccessToken, store DataStore) error {
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiTokenScope"] = t.Scope
+	if !t.RestrictToResources {
+		return nil
+	}
+	resources, err := auth_model.GetAccessTokenResources(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	store.GetData()["ApiTokenResources"] = resources
+	return nil
+}
+
 // handleSignIn clears existing session variables and stores new ones for the specified user object
 func handleSignIn(resp http.ResponseWriter, req *http.Request, sess SessionStore, user *user_model.User) {
 	// We need to regenerate the session...
@@ -193,8 +193,10 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 	if err = auth_model.UpdateAccessToken(ctx, t); err != nil {
 		log.Error("UpdateAccessToken: %v", err)
 	}
-	store.GetData()["IsApiToken"] = true
-	store.GetData()["ApiTokenScope"] = t.Scope
+	if err := storeAccessTokenScope(ctx, t, store); err != nil {
+		log.Error("storeAccessTokenScope: %v", err)
+		return 0
+	}
 	return t.UID
 }
 
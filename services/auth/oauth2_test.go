@@ -31,6 +31,19 @@ func TestUserIDFromToken(t *testing.T) {
 		assert.Equal(t, true, ds["IsActionsToken"])
 		assert.Equal(t, ds["ActionsTaskID"], int64(RunningTaskID))
 	})
+
+	t.Run("Actions ID token request JWT", func(t *testing.T) {
+		const RunningTaskID = 47
+		token, err := actions.CreateIDTokenRequestToken(RunningTaskID, 1, 2)
+		require.NoError(t, err)
+
+		ds := make(middleware.ContextData)
+
+		o := OAuth2{}
+		uid := o.userIDFromToken(t.Context(), token, ds)
+		assert.Zero(t, uid)
+		assert.NotContains(t, ds, "IsActionsToken")
+	})
 }
 
 func TestCheckTaskIsRunning(t *testing.T) {
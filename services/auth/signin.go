@@ -18,6 +18,7 @@ import (
 	_ "forgejo.org/services/auth/source/db"   // register the sources (and below)
 	_ "forgejo.org/services/auth/source/ldap" // register the ldap source
 	_ "forgejo.org/services/auth/source/pam"  // register the pam source
+	_ "forgejo.org/services/auth/source/saml" // register the saml source
 )
 
 // UserSignIn validates user name and password.
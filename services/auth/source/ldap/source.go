@@ -111,6 +111,11 @@ func (source *Source) ProvidesSSHKeys() bool {
 	return len(strings.TrimSpace(source.AttributeSSHPublicKey)) > 0
 }
 
+// GroupTeamMapping returns the mapping of the groups of this source to organization teams
+func (source *Source) GroupTeamMapping() (string, bool) {
+	return source.GroupTeamMap, source.GroupTeamMapRemoval
+}
+
 // SetAuthSource sets the related AuthSource
 func (source *Source) SetAuthSource(authSource *auth.Source) {
 	source.authSource = authSource
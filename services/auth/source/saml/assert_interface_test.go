@@ -0,0 +1,23 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml_test
+
+import (
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/services/auth"
+	"forgejo.org/services/auth/source/saml"
+)
+
+// This test file exists to assert that our Source exposes the interfaces that we expect
+// It tightly binds the interfaces and implementation without breaking go import cycles
+
+type sourceInterface interface {
+	auth_model.Config
+	auth_model.SourceSettable
+	auth_model.GroupTeamMapper
+	auth_model.SSHKeyProvider
+	auth.PasswordAuthenticator
+}
+
+var _ (sourceInterface) = &saml.Source{}
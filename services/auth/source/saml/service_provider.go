@@ -0,0 +1,283 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+)
+
+// rsaSHA256SignatureMethod is the XML signature algorithm used to sign authentication requests
+const rsaSHA256SignatureMethod = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+
+// maxMetadataSize limits the size of identity provider metadata fetched from a URL
+const maxMetadataSize = 1 << 20
+
+// NameIDFormats lists the name identifier formats that can be requested from the identity provider
+var NameIDFormats = []string{
+	string(saml.PersistentNameIDFormat),
+	string(saml.EmailAddressNameIDFormat),
+	string(saml.TransientNameIDFormat),
+	string(saml.UnspecifiedNameIDFormat),
+}
+
+// GenerateServiceProviderKeyPair creates a self-signed certificate and its RSA private key, both PEM encoded.
+// The key signs authentication requests and decrypts encrypted assertions.
+func GenerateServiceProviderKeyPair() (certificate, privateKey string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: setting.Domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	privateKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return certificate, privateKey, nil
+}
+
+func (source *Source) keyPair() (crypto.Signer, *x509.Certificate, error) {
+	certBlock, _ := pem.Decode([]byte(source.ServiceProviderCertificate))
+	if certBlock == nil {
+		return nil, nil, errors.New("invalid service provider certificate")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid service provider certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode([]byte(source.ServiceProviderPrivateKey))
+	if keyBlock == nil {
+		return nil, nil, errors.New("invalid service provider private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes); err == nil {
+		return key, cert, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid service provider private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("service provider private key must be an RSA key")
+	}
+	return rsaKey, cert, nil
+}
+
+// ServiceProviderURL returns the base URL of the service provider endpoints of this source
+func (source *Source) ServiceProviderURL() string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(source.authSource.Name)
+}
+
+// ServiceProvider returns the SAML service provider of this source.
+// The identity provider metadata is only set if it has been configured.
+func (source *Source) ServiceProvider() (*saml.ServiceProvider, error) {
+	key, cert, err := source.keyPair()
+	if err != nil {
+		return nil, err
+	}
+	metadataURL, err := url.Parse(source.ServiceProviderURL() + "/metadata")
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(source.ServiceProviderURL() + "/acs")
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          metadataURL.String(),
+		Key:               key,
+		Certificate:       cert,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		AuthnNameIDFormat: saml.NameIDFormat(source.NameIDFormat),
+	}
+	if sp.AuthnNameIDFormat == "" {
+		sp.AuthnNameIDFormat = saml.PersistentNameIDFormat
+	}
+	if source.SignRequests {
+		sp.SignatureMethod = rsaSHA256SignatureMethod
+	}
+	if source.IdentityProviderMetadata != "" {
+		sp.IDPMetadata, err = samlsp.ParseMetadata([]byte(source.IdentityProviderMetadata))
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity provider metadata: %w", err)
+		}
+	}
+	return sp, nil
+}
+
+// Metadata returns the XML metadata of the service provider to be imported by the identity provider
+func (source *Source) Metadata() ([]byte, error) {
+	sp, err := source.ServiceProvider()
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), metadata...), nil
+}
+
+// Validate checks that the key pair and the identity provider metadata of this source are usable
+func (source *Source) Validate() error {
+	if _, _, err := source.keyPair(); err != nil {
+		return err
+	}
+	if source.IdentityProviderMetadata == "" {
+		return errors.New("identity provider metadata is required")
+	}
+	metadata, err := samlsp.ParseMetadata([]byte(source.IdentityProviderMetadata))
+	if err != nil {
+		return fmt.Errorf("invalid identity provider metadata: %w", err)
+	}
+	if len(metadata.IDPSSODescriptors) == 0 {
+		return errors.New("identity provider metadata has no IDPSSODescriptor")
+	}
+	return nil
+}
+
+// Initialize completes the configuration of a source being created or updated. It reuses the key pair
+// of the previous configuration or generates a new one, fetches the identity provider metadata
+// if a URL is set and validates the result.
+func (source *Source) Initialize(ctx context.Context, previous *Source) error {
+	if previous != nil && source.ServiceProviderCertificate == "" && source.ServiceProviderPrivateKey == "" {
+		source.ServiceProviderCertificate = previous.ServiceProviderCertificate
+		source.ServiceProviderPrivateKey = previous.ServiceProviderPrivateKey
+	}
+	if source.ServiceProviderCertificate == "" && source.ServiceProviderPrivateKey == "" {
+		certificate, privateKey, err := GenerateServiceProviderKeyPair()
+		if err != nil {
+			return err
+		}
+		source.ServiceProviderCertificate = certificate
+		source.ServiceProviderPrivateKey = privateKey
+	}
+	if previous != nil && source.IdentityProviderMetadata == "" && source.IdentityProviderMetadataURL == "" {
+		source.IdentityProviderMetadata = previous.IdentityProviderMetadata
+	}
+	if source.NameIDFormat == "" {
+		source.NameIDFormat = string(saml.PersistentNameIDFormat)
+	}
+	if err := source.FetchIdentityProviderMetadata(ctx); err != nil {
+		return err
+	}
+	return source.Validate()
+}
+
+// FetchIdentityProviderMetadata replaces the identity provider metadata by the one served at IdentityProviderMetadataURL
+func (source *Source) FetchIdentityProviderMetadata(ctx context.Context) error {
+	if source.IdentityProviderMetadataURL == "" {
+		return nil
+	}
+	u, err := url.Parse(source.IdentityProviderMetadataURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid identity provider metadata URL: %q", source.IdentityProviderMetadataURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: proxy.Proxy()},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch identity provider metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch identity provider metadata: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return fmt.Errorf("fetch identity provider metadata: %w", err)
+	}
+	if _, err := samlsp.ParseMetadata(data); err != nil {
+		return fmt.Errorf("invalid identity provider metadata: %w", err)
+	}
+	source.IdentityProviderMetadata = string(data)
+	return nil
+}
+
+// AuthenticationRequestURL returns the identity provider URL the user must be redirected to in order to sign in,
+// together with the ID of the authentication request which the response must refer to.
+func (source *Source) AuthenticationRequestURL() (*url.URL, string, error) {
+	sp, err := source.ServiceProvider()
+	if err != nil {
+		return nil, "", err
+	}
+	if sp.IDPMetadata == nil {
+		return nil, "", errors.New("identity provider metadata is not configured")
+	}
+	location := sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if location == "" {
+		return nil, "", errors.New("identity provider does not support the HTTP-Redirect binding")
+	}
+	req, err := sp.MakeAuthenticationRequest(location, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return nil, "", err
+	}
+	redirect, err := req.Redirect("", sp)
+	if err != nil {
+		return nil, "", err
+	}
+	return redirect, req.ID, nil
+}
+
+// ParseResponse verifies the signature of the SAML response posted to the assertion consumer service,
+// decrypts it if needed and returns the assertion it holds.
+func (source *Source) ParseResponse(req *http.Request, requestID string) (*saml.Assertion, error) {
+	sp, err := source.ServiceProvider()
+	if err != nil {
+		return nil, err
+	}
+	if sp.IDPMetadata == nil {
+		return nil, errors.New("identity provider metadata is not configured")
+	}
+	assertion, err := sp.ParseResponse(req, []string{requestID})
+	if err != nil {
+		var invalidResponse *saml.InvalidResponseError
+		if errors.As(err, &invalidResponse) && invalidResponse.PrivateErr != nil {
+			return nil, fmt.Errorf("invalid SAML response: %w", invalidResponse.PrivateErr)
+		}
+		return nil, err
+	}
+	return assertion, nil
+}
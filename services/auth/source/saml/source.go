@@ -0,0 +1,65 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"strings"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/json"
+)
+
+// Source holds configuration for the SAML 2.0 login source.
+type Source struct {
+	IdentityProviderMetadata    string
+	IdentityProviderMetadataURL string
+	ServiceProviderCertificate  string
+	ServiceProviderPrivateKey   string
+	SignRequests                bool
+	NameIDFormat                string
+	IconURL                     string
+
+	AttributeUsername     string
+	AttributeEmail        string
+	AttributeFullName     string
+	AttributeSSHPublicKey string
+	GroupAttribute        string
+	AdminGroup            string
+	RestrictedGroup       string
+	GroupTeamMap          string
+	GroupTeamMapRemoval   bool
+	SkipLocalTwoFA        bool `json:",omitempty"`
+
+	// reference to the authSource
+	authSource *auth.Source
+}
+
+// FromDB fills up a SAML Source from serialized format.
+func (source *Source) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &source)
+}
+
+// ToDB exports a SAML Source to a serialized format.
+func (source *Source) ToDB() ([]byte, error) {
+	return json.Marshal(source)
+}
+
+// ProvidesSSHKeys returns if this source provides SSH Keys
+func (source *Source) ProvidesSSHKeys() bool {
+	return len(strings.TrimSpace(source.AttributeSSHPublicKey)) > 0
+}
+
+// GroupTeamMapping returns the mapping of the groups of this source to organization teams
+func (source *Source) GroupTeamMapping() (string, bool) {
+	return source.GroupTeamMap, source.GroupTeamMapRemoval
+}
+
+// SetAuthSource sets the related AuthSource
+func (source *Source) SetAuthSource(authSource *auth.Source) {
+	source.authSource = authSource
+}
+
+func init() {
+	auth.RegisterTypeConfig(auth.SAML, &Source{})
+}
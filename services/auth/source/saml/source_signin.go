@@ -0,0 +1,179 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	user_model "forgejo.org/models/user"
+	auth_module "forgejo.org/modules/auth"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	source_service "forgejo.org/services/auth/source"
+	user_service "forgejo.org/services/user"
+
+	"github.com/crewjam/saml"
+)
+
+// AssertedUser holds the user information asserted by the identity provider
+type AssertedUser struct {
+	NameID       string
+	Username     string
+	Email        string
+	FullName     string
+	SSHPublicKey []string
+	Groups       container.Set[string]
+}
+
+func attributeValues(assertion *saml.Assertion, name string) []string {
+	if name == "" {
+		return nil
+	}
+	var values []string
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			if attribute.Name != name && attribute.FriendlyName != name {
+				continue
+			}
+			for _, value := range attribute.Values {
+				if v := strings.TrimSpace(value.Value); v != "" {
+					values = append(values, v)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func firstAttributeValue(assertion *saml.Assertion, name string) string {
+	if values := attributeValues(assertion, name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// AssertedUser maps the subject and the attributes of the assertion to a user according to the source configuration
+func (source *Source) AssertedUser(assertion *saml.Assertion) (*AssertedUser, error) {
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || strings.TrimSpace(assertion.Subject.NameID.Value) == "" {
+		return nil, errors.New("assertion has no subject name identifier")
+	}
+	asserted := &AssertedUser{
+		NameID:       strings.TrimSpace(assertion.Subject.NameID.Value),
+		Username:     firstAttributeValue(assertion, source.AttributeUsername),
+		Email:        firstAttributeValue(assertion, source.AttributeEmail),
+		FullName:     firstAttributeValue(assertion, source.AttributeFullName),
+		SSHPublicKey: attributeValues(assertion, source.AttributeSSHPublicKey),
+		Groups:       container.SetOf(attributeValues(assertion, source.GroupAttribute)...),
+	}
+
+	if asserted.Email == "" && strings.Contains(asserted.NameID, "@") {
+		asserted.Email = asserted.NameID
+	}
+	if asserted.Username == "" {
+		asserted.Username, _, _ = strings.Cut(asserted.NameID, "@")
+	}
+	username, err := user_model.NormalizeUserName(asserted.Username)
+	if err != nil {
+		return nil, err
+	}
+	asserted.Username = username
+	if asserted.Email == "" {
+		return nil, errors.New("assertion has no email address")
+	}
+	return asserted, nil
+}
+
+// SignIn returns the local user matching the asserted user, creating it if it does not exist yet.
+// The admin and restricted flags, the email address, the full name, the SSH keys and the team memberships
+// are synchronized on every sign in.
+func (source *Source) SignIn(ctx context.Context, asserted *AssertedUser) (*user_model.User, error) {
+	isAdmin := source.AdminGroup != "" && asserted.Groups.Contains(source.AdminGroup)
+	isRestricted := !isAdmin && source.RestrictedGroup != "" && asserted.Groups.Contains(source.RestrictedGroup)
+	isAttributeSSHPublicKeySet := source.ProvidesSSHKeys()
+
+	user := &user_model.User{
+		LoginType:   source.authSource.Type,
+		LoginSource: source.authSource.ID,
+		LoginName:   asserted.NameID,
+	}
+	has, err := user_model.GetUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if has {
+		if !user.ProhibitLogin {
+			opts := &user_service.UpdateOptions{}
+			if source.AdminGroup != "" && user.IsAdmin != isAdmin {
+				// Change existing admin flag only if AdminGroup option is set
+				opts.IsAdmin = optional.Some(isAdmin)
+			}
+			if !isAdmin && source.RestrictedGroup != "" && user.IsRestricted != isRestricted {
+				// Change existing restricted flag only if RestrictedGroup option is set
+				opts.IsRestricted = optional.Some(isRestricted)
+			}
+			if asserted.FullName != "" && user.FullName != asserted.FullName {
+				opts.FullName = optional.Some(asserted.FullName)
+			}
+			if opts.IsAdmin.Has() || opts.IsRestricted.Has() || opts.FullName.Has() {
+				if err := user_service.UpdateUser(ctx, user, opts); err != nil {
+					return nil, err
+				}
+			}
+			if err := user_service.ReplacePrimaryEmailAddress(ctx, user, asserted.Email); err != nil {
+				log.Error("SAML[%s]: unable to update the primary email address of user %s: %v", source.authSource.Name, user.Name, err)
+			}
+		}
+		if isAttributeSSHPublicKeySet && asymkey_model.SynchronizePublicKeys(ctx, user, source.authSource, asserted.SSHPublicKey) {
+			if err := asymkey_model.RewriteAllPublicKeys(ctx); err != nil {
+				return user, err
+			}
+		}
+	} else {
+		if setting.Service.DisableRegistration || setting.Service.AllowOnlyInternalRegistration {
+			return nil, user_model.ErrUserNotExist{Name: asserted.Username}
+		}
+
+		user = &user_model.User{
+			LowerName:   strings.ToLower(asserted.Username),
+			Name:        asserted.Username,
+			FullName:    asserted.FullName,
+			Email:       asserted.Email,
+			LoginType:   source.authSource.Type,
+			LoginSource: source.authSource.ID,
+			LoginName:   asserted.NameID,
+			IsAdmin:     isAdmin,
+		}
+		overwriteDefault := &user_model.CreateUserOverwriteOptions{
+			IsRestricted: optional.Some(isRestricted),
+			IsActive:     optional.Some(true),
+		}
+		if err := user_model.CreateUser(ctx, user, overwriteDefault); err != nil {
+			return nil, err
+		}
+
+		if isAttributeSSHPublicKeySet && asymkey_model.AddPublicKeysBySource(ctx, user, source.authSource, asserted.SSHPublicKey) {
+			if err := asymkey_model.RewriteAllPublicKeys(ctx); err != nil {
+				return user, err
+			}
+		}
+	}
+
+	if source.GroupAttribute != "" && (source.GroupTeamMap != "" || source.GroupTeamMapRemoval) {
+		groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap)
+		if err != nil {
+			return user, err
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, user, asserted.Groups, groupTeamMapping, source.GroupTeamMapRemoval); err != nil {
+			return user, err
+		}
+	}
+
+	return user, nil
+}
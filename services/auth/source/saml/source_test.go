@@ -0,0 +1,149 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIdentityProviderMetadata = `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/metadata">
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </IDPSSODescriptor>
+</EntityDescriptor>`
+
+func newTestSource(t *testing.T) *Source {
+	t.Helper()
+	certificate, privateKey, err := GenerateServiceProviderKeyPair()
+	require.NoError(t, err)
+
+	source := &Source{
+		IdentityProviderMetadata:   testIdentityProviderMetadata,
+		ServiceProviderCertificate: certificate,
+		ServiceProviderPrivateKey:  privateKey,
+		AttributeEmail:             "mail",
+		AttributeFullName:          "displayName",
+		AttributeSSHPublicKey:      "sshPublicKey",
+		GroupAttribute:             "groups",
+	}
+	source.SetAuthSource(&auth.Source{ID: 1, Type: auth.SAML, Name: "corp idp"})
+	return source
+}
+
+func TestServiceProvider(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://forgejo.example.com/")()
+	source := newTestSource(t)
+	require.NoError(t, source.Validate())
+
+	metadata, err := source.Metadata()
+	require.NoError(t, err)
+	var descriptor saml.EntityDescriptor
+	require.NoError(t, xml.Unmarshal(metadata, &descriptor))
+	assert.Equal(t, "https://forgejo.example.com/user/saml/corp%20idp/metadata", descriptor.EntityID)
+	require.Len(t, descriptor.SPSSODescriptors, 1)
+	assert.Equal(t, "https://forgejo.example.com/user/saml/corp%20idp/acs", descriptor.SPSSODescriptors[0].AssertionConsumerServices[0].Location)
+	assert.NotEmpty(t, descriptor.SPSSODescriptors[0].KeyDescriptors)
+
+	redirect, requestID, err := source.AuthenticationRequestURL()
+	require.NoError(t, err)
+	assert.NotEmpty(t, requestID)
+	assert.Equal(t, "idp.example.com", redirect.Host)
+	assert.NotEmpty(t, redirect.Query().Get("SAMLRequest"))
+	assert.Empty(t, redirect.Query().Get("Signature"))
+
+	source.SignRequests = true
+	redirect, _, err = source.AuthenticationRequestURL()
+	require.NoError(t, err)
+	assert.NotEmpty(t, redirect.Query().Get("Signature"))
+}
+
+func TestValidate(t *testing.T) {
+	source := newTestSource(t)
+	source.IdentityProviderMetadata = ""
+	require.Error(t, source.Validate())
+
+	source = newTestSource(t)
+	source.ServiceProviderPrivateKey = "invalid"
+	require.Error(t, source.Validate())
+}
+
+func TestAssertedUser(t *testing.T) {
+	source := newTestSource(t)
+	assertion := &saml.Assertion{
+		Subject: &saml.Subject{NameID: &saml.NameID{Value: "jdoe@example.com"}},
+		AttributeStatements: []saml.AttributeStatement{{
+			Attributes: []saml.Attribute{
+				{Name: "urn:oid:2.16.840.1.113730.3.1.241", FriendlyName: "displayName", Values: []saml.AttributeValue{{Value: "John Doe"}}},
+				{Name: "groups", Values: []saml.AttributeValue{{Value: "developers"}, {Value: "admins"}}},
+				{Name: "sshPublicKey", Values: []saml.AttributeValue{{Value: "ssh-ed25519 AAAA"}, {Value: " "}}},
+			},
+		}},
+	}
+
+	asserted, err := source.AssertedUser(assertion)
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@example.com", asserted.NameID)
+	assert.Equal(t, "jdoe", asserted.Username)
+	assert.Equal(t, "jdoe@example.com", asserted.Email)
+	assert.Equal(t, "John Doe", asserted.FullName)
+	assert.Equal(t, []string{"ssh-ed25519 AAAA"}, asserted.SSHPublicKey)
+	assert.True(t, asserted.Groups.Contains("developers"))
+	assert.True(t, asserted.Groups.Contains("admins"))
+
+	source.AttributeUsername = "uid"
+	assertion.AttributeStatements[0].Attributes = append(assertion.AttributeStatements[0].Attributes,
+		saml.Attribute{Name: "uid", Values: []saml.AttributeValue{{Value: "john.doe"}}},
+		saml.Attribute{Name: "mail", Values: []saml.AttributeValue{{Value: "john.doe@example.com"}}},
+	)
+	asserted, err = source.AssertedUser(assertion)
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe", asserted.Username)
+	assert.Equal(t, "john.doe@example.com", asserted.Email)
+
+	_, err = source.AssertedUser(&saml.Assertion{Subject: &saml.Subject{}})
+	require.Error(t, err)
+
+	_, err = source.AssertedUser(&saml.Assertion{Subject: &saml.Subject{NameID: &saml.NameID{Value: "opaque-id"}}})
+	require.Error(t, err)
+}
+
+func TestSignInSync(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	source := newTestSource(t)
+
+	asserted := &AssertedUser{
+		NameID:   "jdoe@example.com",
+		Username: "jdoe",
+		Email:    "jdoe@example.com",
+		FullName: "John Doe",
+	}
+	user, err := source.SignIn(db.DefaultContext, asserted)
+	require.NoError(t, err)
+	assert.Equal(t, "John Doe", user.FullName)
+
+	asserted.Email = "john.doe@example.com"
+	asserted.FullName = "John Q. Doe"
+	user, err = source.SignIn(db.DefaultContext, asserted)
+	require.NoError(t, err)
+	user = unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user.ID})
+	assert.Equal(t, "john.doe@example.com", user.Email)
+	assert.Equal(t, "John Q. Doe", user.FullName)
+
+	asserted.FullName = ""
+	_, err = source.SignIn(db.DefaultContext, asserted)
+	require.NoError(t, err)
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user.ID, FullName: "John Q. Doe"})
+}
@@ -27,6 +27,12 @@ func removeSessionCookieHeader(w http.ResponseWriter) {
 	}
 }
 
+// KeepSessionCookie removes the session cookie from the response, so that a request made
+// without the session cookie, like a cross-site POST, does not replace the session of the browser
+func (ctx *Context) KeepSessionCookie() {
+	removeSessionCookieHeader(ctx.Resp)
+}
+
 // SetSiteCookie convenience function to set most cookies consistently
 // CSRF and a few others are the exception here
 func (ctx *Context) SetSiteCookie(name, value string, maxAge int) {
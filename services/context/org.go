@@ -105,6 +105,9 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 	}
 
 	org := ctx.Org.Organization
+	if !ctx.CheckOrgAccess(org.AsUser()) {
+		return
+	}
 
 	// Handle Visibility
 	if org.Visibility != structs.VisibleTypePublic && !ctx.IsSigned {
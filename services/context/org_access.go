@@ -0,0 +1,128 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package context
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+)
+
+const tplStatus403 base.TplName = "status/403"
+
+// isIPAllowlistExempt reports whether the request is exempt from the IP allowlists of the organizations:
+// the Actions runners and the site administrators, who must be able to fix a misconfigured allowlist
+func isIPAllowlistExempt(b *Base, doer *user_model.User) bool {
+	return b.Data["IsActionsToken"] == true || (doer != nil && doer.IsAdmin)
+}
+
+// ipAllowlistDeniedMessage explains why the request is denied, the same message is used by the SSH server
+func ipAllowlistDeniedMessage(owner *user_model.User, remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+	return fmt.Sprintf("Your IP address %s is not allowed to access the resources of the organization %s.", remoteAddr, owner.Name)
+}
+
+// twoFactorDeniedMessage explains why the request is denied, the same message is used by the SSH server
+func twoFactorDeniedMessage(owner *user_model.User) string {
+	return fmt.Sprintf("The organization %s requires its members to enable two-factor authentication, enroll at %suser/settings/security to access its resources.", owner.Name, setting.AppURL)
+}
+
+// orgAccessDenied checks the policies of the owner, if it is an organization, and returns the reason
+// why the request is denied, or an empty string if it is allowed
+func orgAccessDenied(b *Base, doer, owner *user_model.User) (string, error) {
+	if !isIPAllowlistExempt(b, doer) {
+		allowed, err := org_model.IsIPAllowed(b, owner, b.Req.RemoteAddr)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			return ipAllowlistDeniedMessage(owner, b.Req.RemoteAddr), nil
+		}
+	}
+
+	// the Actions runners do not act as a member of the organization
+	if b.Data["IsActionsToken"] != true {
+		compliant, err := org_model.IsTwoFactorCompliant(b, owner, doer)
+		if err != nil {
+			return "", err
+		}
+		if !compliant {
+			return twoFactorDeniedMessage(owner), nil
+		}
+	}
+	return "", nil
+}
+
+// CheckOrgAccess checks the request against the IP allowlist and the two-factor authentication policy
+// of the owner, if it is an organization, and renders an error page if it is denied (web context)
+func (ctx *Context) CheckOrgAccess(owner *user_model.User) bool {
+	message, err := orgAccessDenied(ctx.Base, ctx.Doer, owner)
+	if err != nil {
+		ctx.ServerError("orgAccessDenied", err)
+		return false
+	}
+	if message == "" {
+		return true
+	}
+
+	showHTML := false
+	for _, part := range ctx.Req.Header["Accept"] {
+		if strings.Contains(part, "text/html") {
+			showHTML = true
+			break
+		}
+	}
+	if !showHTML {
+		ctx.plainTextInternal(3, http.StatusForbidden, []byte(message+"\n"))
+		return false
+	}
+
+	ctx.Data["Title"] = ctx.Locale.TrString("org.settings.access_denied_title")
+	ctx.Data["ErrorMsg"] = message
+	ctx.HTML(http.StatusForbidden, tplStatus403)
+	return false
+}
+
+// CheckOrgAccess checks the request against the IP allowlist and the two-factor authentication policy
+// of the owner, if it is an organization, and responds with an error if it is denied (API context)
+func (ctx *APIContext) CheckOrgAccess(owner *user_model.User) bool {
+	message, err := orgAccessDenied(ctx.Base, ctx.Doer, owner)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return false
+	}
+	if message != "" {
+		ctx.Error(http.StatusForbidden, "OrgAccess", message)
+		return false
+	}
+	return true
+}
+
+// ipAllowlistDeniedOrgIDs returns the organizations whose IP allowlist denies the request
+func ipAllowlistDeniedOrgIDs(b *Base, doer *user_model.User) ([]int64, error) {
+	if isIPAllowlistExempt(b, doer) {
+		return nil, nil
+	}
+	return org_model.FindIPAllowlistDeniedOrgIDs(b, b.Req.RemoteAddr)
+}
+
+// IPAllowlistDeniedOrgIDs returns the organizations whose resources must be left out of the lists
+// and the searches spanning several owners, as their IP allowlist denies the request (web context)
+func (ctx *Context) IPAllowlistDeniedOrgIDs() ([]int64, error) {
+	return ipAllowlistDeniedOrgIDs(ctx.Base, ctx.Doer)
+}
+
+// IPAllowlistDeniedOrgIDs returns the organizations whose resources must be left out of the lists
+// and the searches spanning several owners, as their IP allowlist denies the request (API context)
+func (ctx *APIContext) IPAllowlistDeniedOrgIDs() ([]int64, error) {
+	return ipAllowlistDeniedOrgIDs(ctx.Base, ctx.Doer)
+}
@@ -44,6 +44,9 @@ func PackageAssignment() func(ctx *Context) {
 				ctx.ServerError(title, err)
 			}
 		}
+		if !ctx.CheckOrgAccess(ctx.ContextUser) {
+			return
+		}
 		paCtx := &packageAssignmentCtx{Base: ctx.Base, Doer: ctx.Doer, ContextUser: ctx.ContextUser}
 		ctx.Package = packageAssignment(paCtx, errorFn)
 	}
@@ -52,6 +55,9 @@ func PackageAssignment() func(ctx *Context) {
 // PackageAssignmentAPI returns a middleware to handle Context.Package assignment
 func PackageAssignmentAPI() func(ctx *APIContext) {
 	return func(ctx *APIContext) {
+		if !ctx.CheckOrgAccess(ctx.ContextUser) {
+			return
+		}
 		paCtx := &packageAssignmentCtx{Base: ctx.Base, Doer: ctx.Doer, ContextUser: ctx.ContextUser}
 		ctx.Package = packageAssignment(paCtx, ctx.Error)
 	}
@@ -7,9 +7,12 @@ import (
 	"net/http"
 
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/web/middleware"
 )
 
 // RequireRepoAdmin returns a middleware for requiring repository admin permission
@@ -117,6 +120,17 @@ func CheckRepoScopedToken(ctx *Context, repo *repo_model.Repository, level auth_
 		return
 	}
 
+	if resources, ok := ctx.Data["ApiTokenResources"].(auth_model.AccessTokenResources); ok {
+		requiredMode := perm.AccessModeRead
+		if level == auth_model.Write {
+			requiredMode = perm.AccessModeWrite
+		}
+		if resources.RepoAccessMode(repo.ID, repo.OwnerID) < requiredMode {
+			ctx.Error(http.StatusForbidden)
+			return
+		}
+	}
+
 	scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
 	if ok { // it's a personal access token but not oauth2 token
 		var scopeMatched bool
@@ -147,3 +161,11 @@ func CheckRepoScopedToken(ctx *Context, repo *repo_model.Repository, level auth_
 		}
 	}
 }
+
+// LimitRepoPermissionToToken limits the permission on the repository to the access granted
+// by the resources of the restricted access token used to sign in, if any
+func LimitRepoPermissionToToken(data middleware.ContextData, repo *repo_model.Repository, permission *access_model.Permission) {
+	if resources, ok := data["ApiTokenResources"].(auth_model.AccessTokenResources); ok {
+		permission.LimitTo(resources.RepoAccessMode(repo.ID, repo.OwnerID))
+	}
+}
@@ -378,6 +378,7 @@ func repoAssignment(ctx *Context, repo *repo_model.Repository) {
 		ctx.ServerError("GetUserRepoPermission", err)
 		return
 	}
+	LimitRepoPermissionToToken(ctx.Data, repo, &ctx.Repo.Permission)
 
 	// Check access.
 	if !ctx.Repo.HasAccess() {
@@ -494,6 +495,9 @@ func RepoAssignment(ctx *Context) context.CancelFunc {
 	ctx.ContextUser = owner
 	ctx.Data["ContextUser"] = ctx.ContextUser
 	ctx.Data["Username"] = ctx.Repo.Owner.Name
+	if !ctx.CheckOrgAccess(owner) {
+		return nil
+	}
 
 	// redirect link to wiki
 	if strings.HasSuffix(repoName, ".wiki") {
@@ -29,6 +29,8 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	"forgejo.org/services/gitdiff"
+
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // ToEmail convert models.EmailAddress to api.Email
@@ -254,6 +256,18 @@ func ToPublicKey(apiLink string, key *asymkey_model.PublicKey) *api.PublicKey {
 	}
 }
 
+// ToSSHCertificate converts a signed SSH user certificate to api.SSHCertificate
+func ToSSHCertificate(cert *gossh.Certificate) *api.SSHCertificate {
+	return &api.SSHCertificate{
+		Certificate: strings.TrimSpace(string(gossh.MarshalAuthorizedKey(cert))),
+		KeyID:       cert.KeyId,
+		Serial:      cert.Serial,
+		Principals:  cert.ValidPrincipals,
+		ValidAfter:  time.Unix(int64(cert.ValidAfter), 0),
+		ValidBefore: time.Unix(int64(cert.ValidBefore), 0),
+	}
+}
+
 // ToGPGKey converts models.GPGKey to api.GPGKey
 func ToGPGKey(key *asymkey_model.GPGKey) *api.GPGKey {
 	subkeys := make([]*api.GPGKey, len(key.SubsKey))
@@ -456,6 +470,7 @@ func ToOAuth2Application(app *auth.OAuth2Application) *api.OAuth2Application {
 		ClientID:           app.ClientID,
 		ClientSecret:       app.ClientSecret,
 		ConfidentialClient: app.ConfidentialClient,
+		AllowDeviceGrant:   app.AllowDeviceGrant,
 		RedirectURIs:       app.RedirectURIs,
 		Created:            app.CreatedUnix.AsTime(),
 	}
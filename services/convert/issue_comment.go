@@ -24,6 +24,7 @@ func ToAPIComment(ctx context.Context, repo *repo_model.Repository, c *issues_mo
 		PRURL:       c.PRURL(ctx),
 		Body:        c.Content,
 		Attachments: ToAPIAttachments(repo, c.Attachments),
+		IsInternal:  c.IsInternal,
 		Created:     c.CreatedUnix.AsTime(),
 		Updated:     c.UpdatedUnix.AsTime(),
 	}
@@ -79,15 +80,16 @@ func ToTimelineComment(ctx context.Context, repo *repo_model.Repository, c *issu
 	}
 
 	comment := &api.TimelineComment{
-		ID:       c.ID,
-		Type:     c.Type.String(),
-		Poster:   ToUser(ctx, c.Poster, nil),
-		HTMLURL:  c.HTMLURL(ctx),
-		IssueURL: c.IssueURL(ctx),
-		PRURL:    c.PRURL(ctx),
-		Body:     c.Content,
-		Created:  c.CreatedUnix.AsTime(),
-		Updated:  c.UpdatedUnix.AsTime(),
+		ID:         c.ID,
+		Type:       c.Type.String(),
+		Poster:     ToUser(ctx, c.Poster, nil),
+		HTMLURL:    c.HTMLURL(ctx),
+		IssueURL:   c.IssueURL(ctx),
+		PRURL:      c.PRURL(ctx),
+		Body:       c.Content,
+		IsInternal: c.IsInternal,
+		Created:    c.CreatedUnix.AsTime(),
+		Updated:    c.UpdatedUnix.AsTime(),
 
 		OldProjectID: c.OldProjectID,
 		ProjectID:    c.ProjectID,
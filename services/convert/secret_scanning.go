@@ -0,0 +1,31 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	secretscan_model "forgejo.org/models/secretscan"
+	api "forgejo.org/modules/structs"
+)
+
+// ToSecretScanningAlert converts a secret scanning alert, whose repository is loaded, to api.SecretScanningAlert
+func ToSecretScanningAlert(a *secretscan_model.Alert) *api.SecretScanningAlert {
+	state := "open"
+	if !a.IsOpen() {
+		state = "resolved"
+	}
+	return &api.SecretScanningAlert{
+		ID:           a.ID,
+		PatternID:    a.PatternID,
+		PatternName:  a.PatternName,
+		MaskedSecret: a.MaskedSecret,
+		CommitID:     a.CommitID,
+		Path:         a.Path,
+		Line:         a.Line,
+		State:        state,
+		Resolution:   a.Resolution,
+		BypassReason: a.BypassReason,
+		HTMLURL:      a.HTMLURL(),
+		Created:      a.CreatedUnix.AsTime(),
+	}
+}
@@ -20,6 +20,7 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerDispatchDeployments()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +75,13 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerDispatchDeployments() {
+	RegisterTaskFatal("dispatch_deployments", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.DispatchElapsedDeployments(ctx)
+	})
+}
@@ -13,9 +13,12 @@ import (
 	"forgejo.org/models/webhook"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/auth"
+	issue_service "forgejo.org/services/issue"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
+	org_service "forgejo.org/services/org"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
@@ -156,6 +159,46 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerCreateScheduledIssues() {
+	RegisterTaskFatal("create_scheduled_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CreateScheduledIssues(ctx)
+	})
+}
+
+func registerNotifyExpiringAccessTokens() {
+	RegisterTaskFatal("notify_expiring_access_tokens", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return auth.NotifyExpiringAccessTokens(ctx)
+	})
+}
+
+func registerRemindOrgTwoFactorPolicies() {
+	RegisterTaskFatal("remind_org_two_factor_policies", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return org_service.RemindTwoFactorPolicies(ctx)
+	})
+}
+
+func registerCleanupAuditEvents() {
+	RegisterTaskFatal("cleanup_audit_events", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return audit_service.DeleteExpiredEvents(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -169,6 +212,12 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerCreateScheduledIssues()
+	registerNotifyExpiringAccessTokens()
+	registerRemindOrgTwoFactorPolicies()
+	if setting.Audit.Enabled {
+		registerCleanupAuditEvents()
+	}
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
@@ -15,6 +15,7 @@ import (
 	issue_indexer "forgejo.org/modules/indexer/issues"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/updatechecker"
+	depgraph_service "forgejo.org/services/depgraph"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
 	user_service "forgejo.org/services/user"
@@ -65,6 +66,77 @@ func registerGarbageCollectRepositories() {
 	})
 }
 
+func registerMaintainRepositories() {
+	type RepoMaintenanceConfig struct {
+		BaseConfig
+		Timeout           time.Duration
+		LooseObjects      bool
+		IncrementalRepack bool
+		MultiPackIndex    bool
+		CommitGraph       bool
+	}
+	RegisterTaskFatal("git_maintenance_repos", &RepoMaintenanceConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 24h",
+		},
+		Timeout:           time.Duration(setting.Git.Timeout.GC) * time.Second,
+		LooseObjects:      true,
+		IncrementalRepack: true,
+		MultiPackIndex:    true,
+		CommitGraph:       true,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		maintenanceConfig := config.(*RepoMaintenanceConfig)
+		return repo_service.GitMaintenanceRepos(ctx, git.MaintenanceOptions{
+			LooseObjects:      maintenanceConfig.LooseObjects,
+			IncrementalRepack: maintenanceConfig.IncrementalRepack,
+			MultiPackIndex:    maintenanceConfig.MultiPackIndex,
+			CommitGraph:       maintenanceConfig.CommitGraph,
+			Timeout:           maintenanceConfig.Timeout,
+		})
+	})
+}
+
+func registerUpdateRepositoryBundles() {
+	if !setting.RepoBundle.Enabled {
+		return
+	}
+	RegisterTaskFatal("update_repo_bundles", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 24h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return repo_service.UpdateRepoBundles(ctx)
+	})
+}
+
+func registerUpdateVulnerabilityDatabase() {
+	if !setting.DependencyGraph.Enabled {
+		return
+	}
+	RegisterTaskFatal("update_vulnerability_database", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 24h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return depgraph_service.ImportVulnerabilities(ctx)
+	})
+}
+
+func registerUpdateDependencies() {
+	if !setting.DependencyUpdates.Enabled {
+		return
+	}
+	RegisterTaskFatal("update_dependencies", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 24h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return depgraph_service.QueueDependencyUpdates(ctx)
+	})
+}
+
 func registerRewriteAllPublicKeys() {
 	RegisterTaskFatal("resync_all_sshkeys", &BaseConfig{
 		Enabled:    false,
@@ -229,6 +301,10 @@ func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
 	registerGarbageCollectRepositories()
+	registerMaintainRepositories()
+	registerUpdateRepositoryBundles()
+	registerUpdateVulnerabilityDatabase()
+	registerUpdateDependencies()
 	registerRewriteAllPublicKeys()
 	registerRewriteAllPrincipalKeys()
 	registerRepositoryUpdateHook()
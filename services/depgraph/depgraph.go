@@ -0,0 +1,253 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/db"
+	depgraph_model "forgejo.org/models/depgraph"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/setting"
+	notify_service "forgejo.org/services/notify"
+)
+
+// maxManifests is the number of manifests parsed in a repository, the others are ignored
+const maxManifests = 1000
+
+// isVendored returns whether a file is a copy of a dependency rather than a file of the project
+func isVendored(filePath string) bool {
+	for _, dir := range strings.Split(filePath, "/") {
+		if dir == "node_modules" || dir == "vendor" {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateGraph parses the manifests of the default branch of a repository, replaces its dependency graph and
+// checks its dependencies against the vulnerability database
+func UpdateGraph(ctx context.Context, repo *repo_model.Repository) error {
+	if repo.IsEmpty || repo.IsArchived {
+		return nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return fmt.Errorf("GetBranchCommit: %w", err)
+	}
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return fmt.Errorf("ListEntriesRecursiveWithSize: %w", err)
+	}
+
+	var paths []string
+	sizes := make(map[string]int64)
+	for _, entry := range entries {
+		if !entry.IsRegular() || !depgraph.IsManifest(entry.Name()) || isVendored(entry.Name()) {
+			continue
+		}
+		paths = append(paths, entry.Name())
+		sizes[entry.Name()] = entry.Size()
+	}
+	manifests := depgraph.SelectManifests(paths)
+	if len(manifests) > maxManifests {
+		manifests = manifests[:maxManifests]
+	}
+
+	var deps []*depgraph_model.Dependency
+	parsed := 0
+	for _, manifest := range manifests {
+		if sizes[manifest] > setting.DependencyGraph.MaxFileSize {
+			log.Debug("Dependency graph of %-v: %s is too large", repo, manifest)
+			continue
+		}
+		blob, err := commit.Tree.GetBlobByPath(manifest)
+		if err != nil {
+			return fmt.Errorf("GetBlobByPath(%s): %w", manifest, err)
+		}
+		content, err := blob.GetBlobContent(setting.DependencyGraph.MaxFileSize)
+		if err != nil {
+			return fmt.Errorf("GetBlobContent(%s): %w", manifest, err)
+		}
+		found, err := depgraph.Parse(manifest, []byte(content))
+		if err != nil {
+			// a broken manifest of the repository is not an error of the instance
+			log.Debug("Dependency graph of %-v: unable to parse %s: %v", repo, manifest, err)
+			continue
+		}
+		parsed++
+		for _, d := range found {
+			deps = append(deps, &depgraph_model.Dependency{
+				Manifest:    manifest,
+				Ecosystem:   d.Ecosystem,
+				Name:        depgraph.NormalizeName(d.Ecosystem, d.Name),
+				Version:     d.Version,
+				Requirement: d.Requirement,
+				Direct:      d.Direct,
+				Development: d.Development,
+			})
+		}
+	}
+
+	if err := depgraph_model.ReplaceGraph(ctx, repo.ID, commit.ID.String(), parsed, deps); err != nil {
+		return fmt.Errorf("ReplaceGraph: %w", err)
+	}
+	return CheckVulnerabilities(ctx, repo)
+}
+
+// CheckVulnerabilities matches the dependencies of a repository against the vulnerability database, updates
+// its alerts and notifies the new ones
+func CheckVulnerabilities(ctx context.Context, repo *repo_model.Repository) error {
+	deps, err := db.Find[depgraph_model.Dependency](ctx, depgraph_model.FindDependenciesOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repo.ID,
+		IsVersioned: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	byEcosystem := make(map[depgraph.Ecosystem]map[string][]*depgraph_model.Dependency)
+	for _, d := range deps {
+		if byEcosystem[d.Ecosystem] == nil {
+			byEcosystem[d.Ecosystem] = make(map[string][]*depgraph_model.Dependency)
+		}
+		byEcosystem[d.Ecosystem][d.Name] = append(byEcosystem[d.Ecosystem][d.Name], d)
+	}
+
+	var matches []*depgraph_model.Alert
+	for _, ecosystem := range depgraph_model.Ecosystems {
+		byName := byEcosystem[ecosystem]
+		if len(byName) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		vulns, err := depgraph_model.FindVulnerabilities(ctx, ecosystem, names)
+		if err != nil {
+			return fmt.Errorf("FindVulnerabilities: %w", err)
+		}
+		for _, v := range vulns {
+			matches = append(matches, matchVulnerability(v, ecosystem, byName)...)
+		}
+	}
+
+	opened, err := depgraph_model.UpdateAlerts(ctx, repo.ID, matches)
+	if err != nil {
+		return fmt.Errorf("UpdateAlerts: %w", err)
+	}
+	if len(opened) > 0 {
+		for _, a := range opened {
+			a.Repo = repo
+		}
+		notify_service.NewDependencyAlerts(ctx, repo, opened)
+	}
+	return nil
+}
+
+// matchVulnerability returns an alert for each package of an ecosystem the repository depends on in a version
+// affected by a vulnerability
+func matchVulnerability(v *osv.Vulnerability, ecosystem depgraph.Ecosystem, byName map[string][]*depgraph_model.Dependency) []*depgraph_model.Alert {
+	var alerts []*depgraph_model.Alert
+	byPackage := make(map[string]*depgraph_model.Alert)
+	for _, affected := range v.Affected {
+		if depgraph.Ecosystem(affected.Package.Ecosystem) != ecosystem {
+			continue
+		}
+		name := depgraph.NormalizeName(ecosystem, affected.Package.Name)
+		for _, d := range byName[name] {
+			if !affected.IsAffected(d.Version) {
+				continue
+			}
+			a, ok := byPackage[name]
+			if !ok {
+				a = &depgraph_model.Alert{
+					VulnerabilityID: v.ID,
+					Ecosystem:       ecosystem,
+					PackageName:     name,
+					Aliases:         v.Aliases,
+					Summary:         v.Summary,
+					Level:           v.Level(),
+				}
+				byPackage[name] = a
+				alerts = append(alerts, a)
+			}
+			if !slices.Contains(a.Versions, d.Version) {
+				a.Versions = append(a.Versions, d.Version)
+			}
+			if !slices.Contains(a.Manifests, d.Manifest) {
+				a.Manifests = append(a.Manifests, d.Manifest)
+			}
+			for _, fixed := range affected.FixedVersions() {
+				if !slices.Contains(a.FixedVersions, fixed) {
+					a.FixedVersions = append(a.FixedVersions, fixed)
+				}
+			}
+		}
+	}
+	return alerts
+}
+
+// ImportVulnerabilities imports the OSV database exported to the directory of the settings, and checks the
+// dependencies of all the repositories again if it changed
+func ImportVulnerabilities(ctx context.Context) error {
+	if _, err := os.Stat(setting.DependencyGraph.OSVPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("the OSV database directory %s does not exist", setting.DependencyGraph.OSVPath)
+		}
+		return err
+	}
+
+	changed := 0
+	if err := osv.ReadDatabase(setting.DependencyGraph.OSVPath, func(v *osv.Vulnerability) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		saved, err := depgraph_model.SaveVulnerability(ctx, v)
+		if err != nil {
+			return fmt.Errorf("SaveVulnerability(%s): %w", v.ID, err)
+		}
+		if saved {
+			changed++
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	log.Info("%d vulnerabilities of the OSV database have been imported", changed)
+	if changed == 0 {
+		return nil
+	}
+
+	repoIDs, err := depgraph_model.GetGraphRepoIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, repoID := range repoIDs {
+		if err := graphQueue.Push(&graphRequest{RepoID: repoID, CheckOnly: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	notify_service "forgejo.org/services/notify"
+)
+
+type depgraphNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &depgraphNotifier{}
+
+// NewNotifier creates a notifier updating the dependency graph, and rebasing the pull requests updating the
+// dependencies, when the default branch changes
+func NewNotifier() notify_service.Notifier {
+	return &depgraphNotifier{}
+}
+
+func (n *depgraphNotifier) update(repo *repo_model.Repository) {
+	if !setting.DependencyGraph.Enabled {
+		return
+	}
+	if err := AddRepoToQueue(repo); err != nil {
+		log.Error("Unable to add %-v to the dependency graph queue: %v", repo, err)
+	}
+}
+
+func (n *depgraphNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if opts.RefFullName.IsBranch() && !opts.IsDelRef() && opts.RefFullName.BranchName() == repo.DefaultBranch {
+		n.update(repo)
+		if setting.DependencyUpdates.Enabled {
+			if err := AddRepoToUpdateQueue(repo, true); err != nil {
+				log.Error("Unable to add %-v to the dependency updates queue: %v", repo, err)
+			}
+		}
+	}
+}
+
+func (n *depgraphNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	n.PushCommits(ctx, pusher, repo, opts, commits)
+}
+
+func (n *depgraphNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
+	if !repo.IsEmpty {
+		n.update(repo)
+	}
+}
+
+func (n *depgraphNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
+	if !repo.IsEmpty {
+		n.update(repo)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+	"errors"
+
+	"forgejo.org/models/perm"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	"forgejo.org/modules/setting"
+	notify_service "forgejo.org/services/notify"
+)
+
+// graphRequest is a repository whose dependency graph is to be updated
+type graphRequest struct {
+	RepoID int64
+	// CheckOnly matches the dependencies against the vulnerability database without parsing the manifests again
+	CheckOnly bool
+}
+
+// graphQueue represents a queue to update the dependency graphs of repositories in the background
+var graphQueue *queue.WorkerPoolQueue[*graphRequest]
+
+func handleGraphUpdate(items ...*graphRequest) []*graphRequest {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, req := range items {
+		repo, err := repo_model.GetRepositoryByID(ctx, req.RepoID)
+		if err != nil {
+			if !repo_model.IsErrRepoNotExist(err) {
+				log.Error("GetRepositoryByID [%d]: %v", req.RepoID, err)
+			}
+			continue
+		}
+		if req.CheckOnly {
+			err = CheckVulnerabilities(ctx, repo)
+		} else {
+			err = UpdateGraph(ctx, repo)
+		}
+		if err != nil {
+			log.Error("Unable to update the dependency graph of %-v: %v", repo, err)
+			if err := system_model.CreateRepositoryNotice("Unable to update the dependency graph of %s: %v", repo.FullName(), err); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// updateRequest is a repository whose dependencies are to be updated by pull requests
+type updateRequest struct {
+	RepoID int64
+	// RebaseOnly rebases the open pull requests on the default branch without looking for updates
+	RebaseOnly bool
+}
+
+// updateQueue represents a queue to update the dependencies of repositories in the background
+var updateQueue *queue.WorkerPoolQueue[*updateRequest]
+
+func handleDependencyUpdate(items ...*updateRequest) []*updateRequest {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, req := range items {
+		repo, err := repo_model.GetRepositoryByID(ctx, req.RepoID)
+		if err != nil {
+			if !repo_model.IsErrRepoNotExist(err) {
+				log.Error("GetRepositoryByID [%d]: %v", req.RepoID, err)
+			}
+			continue
+		}
+		if err := UpdateDependencies(ctx, repo, req.RebaseOnly); err != nil {
+			log.Error("Unable to update the dependencies of %-v: %v", repo, err)
+			if err := system_model.CreateRepositoryNotice("Unable to update the dependencies of %s: %v", repo.FullName(), err); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Init starts the queues updating the dependency graphs and the dependencies, and the notifier adding the pushed
+// repositories to them
+func Init() error {
+	if setting.DependencyGraph.Enabled {
+		graphQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "dependency_graph", handleGraphUpdate)
+		if graphQueue == nil {
+			return errors.New("unable to create dependency_graph queue")
+		}
+		go graceful.GetManager().RunWithCancel(graphQueue)
+	}
+
+	if setting.DependencyUpdates.Enabled {
+		// created now for the administrators to give it access to the repositories
+		if _, err := GetBotUser(graceful.GetManager().ShutdownContext()); err != nil {
+			return err
+		}
+		updateQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "dependency_updates", handleDependencyUpdate)
+		if updateQueue == nil {
+			return errors.New("unable to create dependency_updates queue")
+		}
+		go graceful.GetManager().RunWithCancel(updateQueue)
+	}
+
+	if setting.DependencyGraph.Enabled || setting.DependencyUpdates.Enabled {
+		notify_service.RegisterNotifier(NewNotifier())
+	}
+	return nil
+}
+
+// AddRepoToQueue updates the dependency graph of a repository in the background
+func AddRepoToQueue(repo *repo_model.Repository) error {
+	return graphQueue.Push(&graphRequest{RepoID: repo.ID})
+}
+
+// AddRepoToUpdateQueue updates the dependencies of a repository in the background, or only rebases its open pull
+// requests
+func AddRepoToUpdateQueue(repo *repo_model.Repository, rebaseOnly bool) error {
+	return updateQueue.Push(&updateRequest{RepoID: repo.ID, RebaseOnly: rebaseOnly})
+}
+
+// QueueDependencyUpdates adds the repositories the bot has write access to to the queue updating their
+// dependencies, those whose updates are not scheduled today only have their pull requests rebased
+func QueueDependencyUpdates(ctx context.Context) error {
+	bot, err := GetBotUser(ctx)
+	if err != nil {
+		return err
+	}
+	repoIDs, err := access_model.GetRepoIDsByAccessMode(ctx, bot.ID, perm.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := updateQueue.Push(&updateRequest{RepoID: repoID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
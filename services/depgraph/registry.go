@@ -0,0 +1,217 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+)
+
+// maxRegistryResponseSize is the size of the largest responses of the upstream registries, the metadata of the
+// npm packages with thousands of versions are tens of MiB
+const maxRegistryResponseSize = 64 * 1024 * 1024
+
+var registryClient = &http.Client{
+	Timeout: time.Minute,
+	Transport: &http.Transport{
+		Proxy: proxy.Proxy(),
+	},
+}
+
+// packageTypes are the types of the package registries of the ecosystems
+var packageTypes = map[depgraph.Ecosystem]packages_model.Type{
+	depgraph.EcosystemNpm:      packages_model.TypeNpm,
+	depgraph.EcosystemComposer: packages_model.TypeComposer,
+	depgraph.EcosystemPyPI:     packages_model.TypePyPI,
+	depgraph.EcosystemMaven:    packages_model.TypeMaven,
+	depgraph.EcosystemGo:       packages_model.TypeGo,
+}
+
+// packageVersions returns the versions of a package published in the package registry of an owner and in the
+// upstream registry of its ecosystem
+func packageVersions(ctx context.Context, ownerID int64, ecosystem depgraph.Ecosystem, name string) ([]string, error) {
+	packageType, ok := packageTypes[ecosystem]
+	if !ok {
+		return nil, nil
+	}
+	packageName := depgraph.NormalizeName(ecosystem, name)
+	if ecosystem == depgraph.EcosystemMaven {
+		// the Maven registry names the packages groupId-artifactId
+		packageName = strings.Replace(packageName, ":", "-", 1)
+	}
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ownerID, packageType, packageName)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, pv.Version)
+	}
+
+	upstream, err := upstreamVersions(ctx, ecosystem, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up the versions of %s in the upstream registry: %w", name, err)
+	}
+	return append(versions, upstream...), nil
+}
+
+// getUpstream returns the body of a response of an upstream registry, nil if the package does not exist
+func getUpstream(ctx context.Context, link string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Forgejo/"+setting.AppVer)
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d of %s", resp.StatusCode, link)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+}
+
+// upstreamVersions returns the versions of a package published in the upstream registry of its ecosystem, none
+// if it is not configured. The names come from the manifests of the repositories and are validated before they
+// are used in the links.
+func upstreamVersions(ctx context.Context, ecosystem depgraph.Ecosystem, name string) ([]string, error) {
+	if !depgraph.IsValidName(ecosystem, name) {
+		return nil, fmt.Errorf("invalid %s package name %q", ecosystem, name)
+	}
+	var versions []string
+	switch ecosystem {
+	case depgraph.EcosystemNpm:
+		if setting.DependencyUpdates.NpmRegistry == "" {
+			return nil, nil
+		}
+		// https://github.com/npm/registry/blob/main/docs/REGISTRY-API.md#getpackage
+		body, err := getUpstream(ctx, setting.DependencyUpdates.NpmRegistry+"/"+url.PathEscape(name))
+		if err != nil || body == nil {
+			return nil, err
+		}
+		var metadata struct {
+			Versions map[string]struct{} `json:"versions"`
+		}
+		if err := json.Unmarshal(body, &metadata); err != nil {
+			return nil, err
+		}
+		for v := range metadata.Versions {
+			versions = append(versions, v)
+		}
+
+	case depgraph.EcosystemComposer:
+		if setting.DependencyUpdates.PackagistRegistry == "" {
+			return nil, nil
+		}
+		// https://packagist.org/apidoc#get-package-metadata-v2
+		name = strings.ToLower(name)
+		body, err := getUpstream(ctx, setting.DependencyUpdates.PackagistRegistry+"/p2/"+escapePathSegments(name)+".json")
+		if err != nil || body == nil {
+			return nil, err
+		}
+		var metadata struct {
+			Packages map[string][]struct {
+				Version string `json:"version"`
+			} `json:"packages"`
+		}
+		if err := json.Unmarshal(body, &metadata); err != nil {
+			return nil, err
+		}
+		for _, p := range metadata.Packages[name] {
+			versions = append(versions, strings.TrimPrefix(p.Version, "v"))
+		}
+
+	case depgraph.EcosystemPyPI:
+		if setting.DependencyUpdates.PyPIRegistry == "" {
+			return nil, nil
+		}
+		// https://docs.pypi.org/api/json/#get-a-project
+		body, err := getUpstream(ctx, setting.DependencyUpdates.PyPIRegistry+"/pypi/"+url.PathEscape(depgraph.NormalizeName(ecosystem, name))+"/json")
+		if err != nil || body == nil {
+			return nil, err
+		}
+		var metadata struct {
+			Releases map[string][]struct{} `json:"releases"`
+		}
+		if err := json.Unmarshal(body, &metadata); err != nil {
+			return nil, err
+		}
+		for v := range metadata.Releases {
+			versions = append(versions, v)
+		}
+
+	case depgraph.EcosystemMaven:
+		if setting.DependencyUpdates.MavenRegistry == "" {
+			return nil, nil
+		}
+		groupID, artifactID, ok := strings.Cut(name, ":")
+		if !ok {
+			return nil, nil
+		}
+		body, err := getUpstream(ctx, setting.DependencyUpdates.MavenRegistry+"/"+escapePathSegments(strings.ReplaceAll(groupID, ".", "/"))+"/"+url.PathEscape(artifactID)+"/maven-metadata.xml")
+		if err != nil || body == nil {
+			return nil, err
+		}
+		var metadata struct {
+			Versions []string `xml:"versioning>versions>version"`
+		}
+		if err := xml.Unmarshal(body, &metadata); err != nil {
+			return nil, err
+		}
+		versions = metadata.Versions
+
+	case depgraph.EcosystemGo:
+		if setting.DependencyUpdates.GoProxy == "" {
+			return nil, nil
+		}
+		// https://go.dev/ref/mod#goproxy-protocol
+		body, err := getUpstream(ctx, setting.DependencyUpdates.GoProxy+"/"+escapePathSegments(escapeModulePath(name))+"/@v/list")
+		if err != nil || body == nil {
+			return nil, err
+		}
+		for _, v := range strings.Fields(string(body)) {
+			versions = append(versions, strings.TrimPrefix(v, "v"))
+		}
+	}
+	return versions, nil
+}
+
+// escapePathSegments escapes each segment of a path separated by slashes
+func escapePathSegments(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeModulePath escapes the upper case letters of a module path for the case insensitive file systems of the
+// Go module proxies, https://go.dev/ref/mod#goproxy-protocol
+func escapeModulePath(modulePath string) string {
+	var sb strings.Builder
+	for _, r := range modulePath {
+		if 'A' <= r && r <= 'Z' {
+			sb.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,476 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package depgraph
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/depgraph"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	issue_service "forgejo.org/services/issue"
+	pull_service "forgejo.org/services/pull"
+	repo_service "forgejo.org/services/repository"
+	files_service "forgejo.org/services/repository/files"
+)
+
+// updateBranchPrefix is the prefix of the branches of the pull requests of the bot
+const updateBranchPrefix = "dependency-updates/"
+
+// requirementUpdate is the update of a requirement of a manifest to a newer version
+type requirementUpdate struct {
+	Manifest       string
+	Ecosystem      depgraph.Ecosystem
+	Name           string
+	Requirement    string
+	NewRequirement string
+	Version        string
+	NewVersion     string
+	Type           depgraph.UpdateType
+}
+
+// pullUpdate is a pull request updating a dependency, in one or several manifests, or a group of dependencies
+type pullUpdate struct {
+	Branch  string
+	Group   string
+	Updates []*requirementUpdate
+}
+
+// Title returns the title of the pull request, and the message of its commit
+func (p *pullUpdate) Title() string {
+	if p.Group != "" {
+		return fmt.Sprintf("Update the %s group of dependencies", p.Group)
+	}
+	u := p.Updates[0]
+	return fmt.Sprintf("Update %s from %s to %s", u.Name, u.Version, u.NewVersion)
+}
+
+// Content returns the description of the pull request
+func (p *pullUpdate) Content() string {
+	var sb strings.Builder
+	sb.WriteString("| Package | Ecosystem | From | To | Manifest |\n|---|---|---|---|---|\n")
+	for _, u := range p.Updates {
+		fmt.Fprintf(&sb, "| `%s` | %s | `%s` | `%s` | `%s` |\n", u.Name, u.Ecosystem, u.Requirement, u.NewRequirement, u.Manifest)
+	}
+	sb.WriteString("\nOnly the manifests are updated: the lockfiles next to them, if any, are to be regenerated with the package manager in this branch.\n")
+	return sb.String()
+}
+
+var unsafeBranchChars = regexp.MustCompile(`[^A-Za-z0-9._/-]+|\.\.+|//+`)
+
+// branchName returns the name of a branch of the bot, safe whatever the names of the packages
+func branchName(parts ...string) string {
+	name := updateBranchPrefix + unsafeBranchChars.ReplaceAllString(strings.Join(parts, "/"), "-")
+	return strings.TrimSuffix(strings.TrimSuffix(name, ".lock"), ".")
+}
+
+// GetBotUser returns the bot user opening the pull requests updating the dependencies, created if needed
+func GetBotUser(ctx context.Context) (*user_model.User, error) {
+	u, err := user_model.GetUserByName(ctx, setting.DependencyUpdates.BotUser)
+	if err == nil {
+		if !u.IsBot() {
+			return nil, fmt.Errorf("the user %s opening the dependency updates is not a bot", u.Name)
+		}
+		return u, nil
+	} else if !user_model.IsErrUserNotExist(err) {
+		return nil, err
+	}
+
+	u = &user_model.User{
+		Name:          setting.DependencyUpdates.BotUser,
+		FullName:      "Dependency updates",
+		Email:         setting.DependencyUpdates.BotUser + "@" + setting.Service.NoReplyAddress,
+		Type:          user_model.UserTypeBot,
+		ProhibitLogin: true,
+	}
+	if err := user_model.AdminCreateUser(ctx, u, &user_model.CreateUserOverwriteOptions{
+		IsActive:         optional.Some(true),
+		KeepEmailPrivate: optional.Some(true),
+	}); err != nil {
+		return nil, fmt.Errorf("unable to create the bot user %s: %w", u.Name, err)
+	}
+	log.Info("The bot user %s opening the dependency updates has been created", u.Name)
+	return u, nil
+}
+
+// readUpdateConfig returns the configuration of the dependency updates of a commit, nil if it has none
+func readUpdateConfig(commit *git.Commit) (*depgraph.UpdateConfig, error) {
+	for _, configPath := range depgraph.UpdateConfigPaths {
+		content, err := commit.GetFileContent(configPath, 1024*1024)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		cfg, err := depgraph.ParseUpdateConfig([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", configPath, err)
+		}
+		return cfg, nil
+	}
+	return nil, nil
+}
+
+// planUpdates returns the pull requests updating the outdated requirements of the manifests of a commit
+func planUpdates(ctx context.Context, repo *repo_model.Repository, commit *git.Commit, cfg *depgraph.UpdateConfig) ([]*pullUpdate, error) {
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, fmt.Errorf("ListEntriesRecursiveWithSize: %w", err)
+	}
+
+	type packageKey struct {
+		Ecosystem depgraph.Ecosystem
+		Name      string
+	}
+	versions := make(map[packageKey][]string)
+	pulls := make(map[string]*pullUpdate)
+	for _, entry := range entries {
+		manifest := entry.Name()
+		if !entry.IsRegular() || !depgraph.IsEditable(manifest) || isVendored(manifest) || entry.Size() > setting.DependencyGraph.MaxFileSize {
+			continue
+		}
+		content, err := entry.Blob().GetBlobContent(setting.DependencyGraph.MaxFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("GetBlobContent(%s): %w", manifest, err)
+		}
+		deps, err := depgraph.Parse(manifest, []byte(content))
+		if err != nil {
+			log.Debug("Dependency updates of %-v: unable to parse %s: %v", repo, manifest, err)
+			continue
+		}
+
+		for _, d := range deps {
+			current := depgraph.RequirementVersion(d.Requirement)
+			if !d.Direct || current == "" || !cfg.Includes(manifest, d.Ecosystem) {
+				continue
+			}
+			key := packageKey{d.Ecosystem, depgraph.NormalizeName(d.Ecosystem, d.Name)}
+			available, ok := versions[key]
+			if !ok {
+				if available, err = packageVersions(ctx, repo.OwnerID, d.Ecosystem, d.Name); err != nil {
+					// an unreachable registry must not prevent the other updates
+					log.Warn("Dependency updates of %-v: %v", repo, err)
+				}
+				versions[key] = available
+			}
+			latest := cfg.LatestVersion(d.Name, current, available)
+			if latest == "" {
+				continue
+			}
+
+			u := &requirementUpdate{
+				Manifest:       manifest,
+				Ecosystem:      d.Ecosystem,
+				Name:           d.Name,
+				Requirement:    d.Requirement,
+				NewRequirement: depgraph.UpdateRequirement(d.Requirement, latest),
+				Version:        current,
+				NewVersion:     strings.TrimPrefix(latest, "v"),
+				Type:           depgraph.GetUpdateType(current, latest),
+			}
+			group := cfg.Group(d.Name, u.Type)
+			branch := branchName(strings.ToLower(string(d.Ecosystem)), d.Name+"-"+u.NewVersion)
+			if group != "" {
+				branch = "group/" + group
+			}
+			p, ok := pulls[branch]
+			if !ok {
+				p = &pullUpdate{Branch: branch, Group: group}
+				pulls[branch] = p
+			}
+			p.Updates = append(p.Updates, u)
+		}
+	}
+
+	planned := make([]*pullUpdate, 0, len(pulls))
+	for _, p := range pulls {
+		if p.Group != "" {
+			// a new branch for each set of versions of the group, the pull request of the previous ones is closed
+			h := sha256.New()
+			for _, u := range p.Updates {
+				fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\n", u.Manifest, u.Ecosystem, u.Name, u.NewVersion)
+			}
+			p.Branch = branchName("group", p.Group+"-"+hex.EncodeToString(h.Sum(nil))[:8])
+		}
+		planned = append(planned, p)
+	}
+	slices.SortFunc(planned, func(a, b *pullUpdate) int { return strings.Compare(a.Branch, b.Branch) })
+	return planned, nil
+}
+
+// openUpdatePulls returns the open pull requests of the bot in a repository
+func openUpdatePulls(ctx context.Context, repo *repo_model.Repository, bot *user_model.User) ([]*issues_model.PullRequest, error) {
+	prs, err := issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, repo.ID, repo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	var pulls []*issues_model.PullRequest
+	for _, pr := range prs {
+		if pr.HeadRepoID != repo.ID || !strings.HasPrefix(pr.HeadBranch, updateBranchPrefix) {
+			continue
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			return nil, err
+		}
+		if pr.Issue.PosterID == bot.ID {
+			pulls = append(pulls, pr)
+		}
+	}
+	return pulls, nil
+}
+
+// closeUpdatePull closes a pull request of the bot which is not needed anymore for the reason, and deletes its
+// branch. If someone else pushed commits to the branch, the pull request is left open and the reason is commented.
+func closeUpdatePull(ctx context.Context, gitRepo *git.Repository, repo *repo_model.Repository, bot *user_model.User, pr *issues_model.PullRequest, baseCommitID, reason string) error {
+	onlyBot, err := hasOnlyBotCommits(gitRepo, bot, pr, baseCommitID)
+	if err != nil {
+		return err
+	}
+	if !onlyBot {
+		return commentUpdatePull(ctx, repo, bot, pr, reason+" It has commits which are not from the bot and is left open.")
+	}
+	if err := issue_service.ChangeStatus(ctx, pr.Issue, bot, "", true); err != nil {
+		return fmt.Errorf("ChangeStatus: %w", err)
+	}
+	if err := repo_service.DeleteBranch(ctx, bot, repo, gitRepo, pr.HeadBranch); err != nil {
+		return fmt.Errorf("DeleteBranch: %w", err)
+	}
+	return nil
+}
+
+// hasOnlyBotCommits returns whether all the commits of the branch of a pull request of the bot are authored by the bot
+func hasOnlyBotCommits(gitRepo *git.Repository, bot *user_model.User, pr *issues_model.PullRequest, baseCommitID string) (bool, error) {
+	headCommitID, err := gitRepo.GetBranchCommitID(pr.HeadBranch)
+	if err != nil {
+		return false, fmt.Errorf("GetBranchCommitID(%s): %w", pr.HeadBranch, err)
+	}
+	commits, err := gitRepo.CommitsBetweenIDs(headCommitID, baseCommitID)
+	if err != nil {
+		return false, fmt.Errorf("CommitsBetweenIDs: %w", err)
+	}
+	for _, c := range commits {
+		if c.Author == nil || (!strings.EqualFold(c.Author.Email, bot.GetEmail()) && !strings.EqualFold(c.Author.Email, bot.Email)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// commentUpdatePull comments a pull request of the bot, unless the bot already commented the same
+func commentUpdatePull(ctx context.Context, repo *repo_model.Repository, bot *user_model.User, pr *issues_model.PullRequest, content string) error {
+	comments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{IssueID: pr.IssueID, Type: issues_model.CommentTypeComment})
+	if err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if c.PosterID == bot.ID && c.Content == content {
+			return nil
+		}
+	}
+	if _, err := issue_service.CreateIssueComment(ctx, bot, repo, pr.Issue, content, nil); err != nil {
+		return fmt.Errorf("CreateIssueComment: %w", err)
+	}
+	return nil
+}
+
+// rebaseUpdatePull rebases a pull request of the bot on the default branch if it is behind, and returns whether
+// it is up to date
+func rebaseUpdatePull(ctx context.Context, gitRepo *git.Repository, bot *user_model.User, pr *issues_model.PullRequest, baseCommitID string) bool {
+	headCommitID, err := gitRepo.GetBranchCommitID(pr.HeadBranch)
+	if err != nil {
+		log.Error("GetBranchCommitID(%s): %v", pr.HeadBranch, err)
+		return false
+	}
+	if mergeBase, _, err := gitRepo.GetMergeBase("", baseCommitID, headCommitID); err == nil && mergeBase == baseCommitID {
+		return true
+	}
+	if err := pull_service.Update(ctx, pr, bot, "", true); err != nil {
+		log.Debug("Unable to rebase %-v on the default branch: %v", pr, err)
+		return false
+	}
+	return true
+}
+
+// createUpdatePull commits the updates of the manifests to a new branch and opens its pull request
+func createUpdatePull(ctx context.Context, repo *repo_model.Repository, bot *user_model.User, commit *git.Commit, cfg *depgraph.UpdateConfig, p *pullUpdate) error {
+	byManifest := make(map[string][]*requirementUpdate)
+	var manifests []string
+	for _, u := range p.Updates {
+		if _, ok := byManifest[u.Manifest]; !ok {
+			manifests = append(manifests, u.Manifest)
+		}
+		byManifest[u.Manifest] = append(byManifest[u.Manifest], u)
+	}
+
+	files := make([]*files_service.ChangeRepoFile, 0, len(manifests))
+	for _, manifest := range manifests {
+		content, err := commit.GetFileContent(manifest, int(setting.DependencyGraph.MaxFileSize))
+		if err != nil {
+			return err
+		}
+		edited := []byte(content)
+		for _, u := range byManifest[manifest] {
+			if edited, err = depgraph.EditManifest(manifest, edited, u.Name, u.Requirement, u.NewRequirement); err != nil {
+				return fmt.Errorf("EditManifest(%s, %s): %w", manifest, u.Name, err)
+			}
+		}
+		files = append(files, &files_service.ChangeRepoFile{
+			Operation:     "update",
+			TreePath:      manifest,
+			ContentReader: bytes.NewReader(edited),
+		})
+	}
+
+	title := p.Title()
+	if _, err := files_service.ChangeRepoFiles(ctx, repo, bot, &files_service.ChangeRepoFilesOptions{
+		LastCommitID: commit.ID.String(),
+		OldBranch:    repo.DefaultBranch,
+		NewBranch:    p.Branch,
+		Message:      title,
+		Files:        files,
+	}); err != nil {
+		return fmt.Errorf("ChangeRepoFiles: %w", err)
+	}
+
+	var labelIDs []int64
+	if len(cfg.Labels) > 0 {
+		var err error
+		if labelIDs, err = issues_model.GetLabelIDsInRepoByNames(ctx, repo.ID, cfg.Labels); err != nil {
+			return err
+		}
+	}
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    title,
+		PosterID: bot.ID,
+		Poster:   bot,
+		IsPull:   true,
+		Content:  p.Content(),
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: p.Branch,
+		BaseBranch: repo.DefaultBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		MergeBase:  commit.ID.String(),
+		Type:       issues_model.PullRequestGitea,
+	}
+	if err := pull_service.NewPullRequest(ctx, repo, issue, labelIDs, nil, pr, nil); err != nil {
+		return fmt.Errorf("NewPullRequest: %w", err)
+	}
+	return nil
+}
+
+// UpdateDependencies opens the pull requests updating the outdated dependencies of a repository if they are
+// scheduled, closes those which are not needed anymore, and rebases the others on the default branch. Only the
+// open pull requests are rebased if rebaseOnly is set.
+func UpdateDependencies(ctx context.Context, repo *repo_model.Repository, rebaseOnly bool) error {
+	if repo.IsEmpty || repo.IsArchived || repo.IsMirror || !repo.UnitEnabled(ctx, unit.TypePullRequests) {
+		return nil
+	}
+	bot, err := GetBotUser(ctx)
+	if err != nil {
+		return err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, bot)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWrite(unit.TypeCode) {
+		return nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return fmt.Errorf("GetBranchCommit: %w", err)
+	}
+	cfg, err := readUpdateConfig(commit)
+	if err != nil || cfg == nil {
+		return err
+	}
+
+	open, err := openUpdatePulls(ctx, repo, bot)
+	if err != nil {
+		return err
+	}
+	const notRebased = "This pull request cannot be rebased on the default branch anymore."
+	if rebaseOnly || !cfg.IsDue(time.Now()) {
+		for _, pr := range open {
+			if !rebaseUpdatePull(ctx, gitRepo, bot, pr, commit.ID.String()) {
+				// it is opened again on top of the default branch by the next scheduled update
+				if err := closeUpdatePull(ctx, gitRepo, repo, bot, pr, commit.ID.String(), notRebased); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	planned, err := planUpdates(ctx, repo, commit, cfg)
+	if err != nil {
+		return err
+	}
+	isPlanned := make(map[string]bool, len(planned))
+	for _, p := range planned {
+		isPlanned[p.Branch] = true
+	}
+	isOpen := make(map[string]bool, len(open))
+	for _, pr := range open {
+		// the dependency has been updated in another way, a newer version is available or the pull request
+		// cannot be rebased anymore
+		reason := ""
+		if !isPlanned[pr.HeadBranch] {
+			reason = "This update is not needed anymore, the dependency was updated in another way or a newer version is available."
+		} else if !rebaseUpdatePull(ctx, gitRepo, bot, pr, commit.ID.String()) {
+			reason = notRebased
+		}
+		if reason != "" {
+			if err := closeUpdatePull(ctx, gitRepo, repo, bot, pr, commit.ID.String(), reason); err != nil {
+				return err
+			}
+			continue
+		}
+		isOpen[pr.HeadBranch] = true
+	}
+
+	for _, p := range planned {
+		if len(isOpen) >= cfg.Limit {
+			break
+		}
+		// the branches of the pull requests closed by the maintainers are kept to not propose the same update again
+		if isOpen[p.Branch] || gitRepo.IsBranchExist(p.Branch) {
+			continue
+		}
+		if err := createUpdatePull(ctx, repo, bot, commit, cfg, p); err != nil {
+			return fmt.Errorf("unable to open the pull request %s: %w", p.Branch, err)
+		}
+		isOpen[p.Branch] = true
+	}
+	return nil
+}
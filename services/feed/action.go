@@ -40,6 +40,10 @@ func NewNotifier() notify_service.Notifier {
 }
 
 func (a *actionNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	// the feeds are read by everyone who can read the repository
+	if issue.IsConfidential {
+		return
+	}
 	if err := issue.LoadPoster(ctx); err != nil {
 		log.Error("issue.LoadPoster: %v", err)
 		return
@@ -65,6 +69,9 @@ func (a *actionNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue
 
 // IssueChangeStatus notifies close or reopen issue to notifiers
 func (a *actionNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	if issue.IsConfidential {
+		return
+	}
 	// Compose comment action, could be plain comment, close or reopen issue/pull request.
 	// This object will be used to notify watchers in the end of function.
 	act := &activities_model.Action{
@@ -100,6 +107,9 @@ func (a *actionNotifier) IssueChangeStatus(ctx context.Context, doer *user_model
 func (a *actionNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
 	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
 ) {
+	if issue.IsConfidential || comment.IsInternal {
+		return
+	}
 	act := &activities_model.Action{
 		ActUserID: doer.ID,
 		ActUser:   doer,
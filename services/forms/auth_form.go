@@ -15,7 +15,7 @@ import (
 // AuthenticationForm form for authentication
 type AuthenticationForm struct {
 	ID                            int64
-	Type                          int    `binding:"Range(2,7)"`
+	Type                          int    `binding:"Range(2,9)"`
 	Name                          string `binding:"Required;MaxSize(30)"`
 	Host                          string
 	Port                          int
@@ -79,6 +79,21 @@ type AuthenticationForm struct {
 	SkipLocalTwoFA                bool
 	GroupTeamMap                  string `binding:"ValidGroupTeamMap"`
 	GroupTeamMapRemoval           bool
+
+	SAMLIdentityProviderMetadata    string
+	SAMLIdentityProviderMetadataURL string
+	SAMLNameIDFormat                string
+	SAMLSignRequests                bool
+	SAMLIconURL                     string
+	SAMLAttributeUsername           string
+	SAMLAttributeEmail              string
+	SAMLAttributeFullName           string
+	SAMLAttributeSSHPublicKey       string
+	SAMLGroupAttribute              string
+	SAMLAdminGroup                  string
+	SAMLRestrictedGroup             string
+	SAMLGroupTeamMap                string `binding:"ValidGroupTeamMap"`
+	SAMLGroupTeamMapRemoval         bool
 }
 
 // Validate validates fields
@@ -53,6 +53,30 @@ func (f *UpdateOrgSettingForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// IPAllowlistForm form for updating the IP allowlist of an organization
+type IPAllowlistForm struct {
+	Enabled bool
+	Ranges  string
+}
+
+// Validate validates the fields
+func (f *IPAllowlistForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// TwoFactorPolicyForm form for updating the two-factor authentication policy of an organization
+type TwoFactorPolicyForm struct {
+	Required  bool
+	GraceDays int `binding:"Range(0,90)" locale:"org.settings.two_factor.grace_days"`
+}
+
+// Validate validates the fields
+func (f *TwoFactorPolicyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // ___________
 // \__    ___/___ _____    _____
 //   |    |_/ __ \\__  \  /     \
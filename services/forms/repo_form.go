@@ -246,6 +246,58 @@ func (f *ProtectBranchForm) Validate(req *http.Request, errs binding.Errors) bin
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// PushRuleForm form for changing the push rules of a repository or an organization
+type PushRuleForm struct {
+	AuthorEmailPattern   string
+	CommitMessagePattern string
+	RequireSignOff       bool
+	MaxFileSize          string
+	ForbiddenPaths       string
+	SecretPatterns       string
+}
+
+// Validate validates the fields
+func (f *PushRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SecretScanningForm form for the secret scanning settings of a repository or an organization
+type SecretScanningForm struct {
+	Mode           int
+	CustomPatterns string
+}
+
+// Validate validates the fields
+func (f *SecretScanningForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// SecretScanningResolveForm form for resolving a secret scanning alert
+type SecretScanningResolveForm struct {
+	Resolution string `binding:"Required"`
+	Comment    string `binding:"MaxSize(255)"`
+}
+
+// Validate validates the fields
+func (f *SecretScanningResolveForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// DependencyAlertDismissForm form for dismissing a dependency alert
+type DependencyAlertDismissForm struct {
+	Reason  string `binding:"Required"`
+	Comment string `binding:"MaxSize(255)"`
+}
+
+// Validate validates the fields
+func (f *DependencyAlertDismissForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 //  __      __      ___.   .__                   __
 // /  \    /  \ ____\_ |__ |  |__   ____   ____ |  | __
 // \   \/\/   // __ \| __ \|  |  \ /  _ \ /  _ \|  |/ /
@@ -277,6 +329,7 @@ type WebhookCoreForm struct {
 	Wiki                     bool
 	Repository               bool
 	Package                  bool
+	SecretScanningAlert      bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
 	AuthorizationHeader      string
@@ -336,9 +389,10 @@ func (f *CreateIssueForm) Validate(req *http.Request, errs binding.Errors) bindi
 
 // CreateCommentForm form for creating comment
 type CreateCommentForm struct {
-	Content string
-	Status  string `binding:"OmitEmpty;In(reopen,close)"`
-	Files   []string
+	Content  string
+	Status   string `binding:"OmitEmpty;In(reopen,close)"`
+	Files    []string
+	Internal bool
 }
 
 // Validate validates the fields
@@ -385,6 +439,70 @@ func (i IssueLockForm) HasValidReason() bool {
 	return false
 }
 
+// BulkEditIssuesForm form for editing the issues matching a search query.
+// Labels and assignees are comma separated names, the milestone and project
+// actions are "set" or "clear", anything else keeps them unchanged.
+type BulkEditIssuesForm struct {
+	Query           string `binding:"Required"`
+	AddLabels       string
+	RemoveLabels    string
+	AddAssignees    string
+	RemoveAssignees string
+	MilestoneAction string
+	Milestone       string
+	ProjectAction   string
+	Project         string
+	State           string
+	MoveTo          string
+}
+
+// TransferIssueForm form for moving an issue to another repository
+type TransferIssueForm struct {
+	Repo string `binding:"Required"`
+}
+
+// Validate validates the fields
+func (f *TransferIssueForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// Validate validates the fields
+func (f *BulkEditIssuesForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// IssueScheduleForm form for scheduling the creation of issues from an issue template
+type IssueScheduleForm struct {
+	Name         string `binding:"Required;MaxSize(255)"`
+	TemplateFile string `binding:"Required"`
+	Spec         string `binding:"Required;MaxSize(255)"`
+	Labels       string
+	TeamID       int64
+}
+
+// Validate validates the fields
+func (f *IssueScheduleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// EditEnvironmentForm form for creating or editing a deployment environment
+type EditEnvironmentForm struct {
+	Name              string `binding:"MaxSize(255)"`
+	Reviewers         string
+	PreventSelfReview bool
+	WaitTimer         int64
+	RefPatterns       string
+}
+
+// Validate validates the fields
+func (f *EditEnvironmentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateProjectForm form for creating a project
 type CreateProjectForm struct {
 	Title        string `binding:"Required;MaxSize(100)"`
@@ -184,6 +184,7 @@ type AccessTokenForm struct {
 	RedirectURI  string `json:"redirect_uri"`
 	Code         string `json:"code"`
 	RefreshToken string `json:"refresh_token"`
+	DeviceCode   string `json:"device_code"`
 
 	// PKCE support
 	CodeVerifier string `json:"code_verifier"`
@@ -195,6 +196,31 @@ func (f *AccessTokenForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// DeviceAuthorizationForm for requesting the authorization of a device (RFC 8628)
+type DeviceAuthorizationForm struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+}
+
+// Validate validates the fields
+func (f *DeviceAuthorizationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// DeviceGrantForm for approving or denying the authorization of a device with its user code
+type DeviceGrantForm struct {
+	UserCode string `binding:"Required"`
+	Granted  bool
+}
+
+// Validate validates the fields
+func (f *DeviceGrantForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // IntrospectTokenForm for introspecting tokens
 type IntrospectTokenForm struct {
 	Token string `json:"token"`
@@ -346,6 +372,18 @@ func (f *AddKeyForm) Validate(req *http.Request, errs binding.Errors) binding.Er
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// SSHCertificateForm form for requesting an SSH certificate
+type SSHCertificateForm struct {
+	Content    string `binding:"Required"`
+	ValidHours int    `binding:"Range(0,8760)"`
+}
+
+// Validate validates the fields
+func (f *SSHCertificateForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // AddSecretForm for adding secrets
 type AddSecretForm struct {
 	Name string `binding:"Required;MaxSize(255)"`
@@ -370,8 +408,13 @@ func (f *EditVariableForm) Validate(req *http.Request, errs binding.Errors) bind
 
 // NewAccessTokenForm form for creating access token
 type NewAccessTokenForm struct {
-	Name  string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
-	Scope []string
+	Name                   string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
+	Scope                  []string
+	ExpiresAt              string
+	ReadRepositories       string
+	WriteRepositories      string
+	Organization           string
+	OrganizationPermission string
 }
 
 // Validate validates the fields
@@ -391,6 +434,7 @@ type EditOAuth2ApplicationForm struct {
 	Name               string `binding:"Required;MaxSize(255)" form:"application_name"`
 	RedirectURIs       string `binding:"Required;ValidUrlList" form:"redirect_uris"`
 	ConfidentialClient bool   `form:"confidential_client"`
+	AllowDeviceGrant   bool   `form:"allow_device_grant"`
 }
 
 // Validate validates the fields
@@ -0,0 +1,103 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"forgejo.org/modules/git"
+)
+
+// RangeDiff represents the comparison of two revisions of a branch, matching their commits
+// even if the branch was rebased in between
+type RangeDiff struct {
+	OldBase string
+	OldHead string
+	NewBase string
+	NewHead string
+	Commits []*RangeDiffCommit
+}
+
+// RangeDiffCommit represents a pair of matching commits, or a commit only in one of the revisions
+type RangeDiffCommit struct {
+	*git.RangeDiffCommit
+	Lines []*DiffLine
+}
+
+// NumAdded returns the number of commits only in the new revision
+func (d *RangeDiff) NumAdded() int {
+	return d.count(git.RangeDiffAdded)
+}
+
+// NumDropped returns the number of commits only in the old revision
+func (d *RangeDiff) NumDropped() int {
+	return d.count(git.RangeDiffDropped)
+}
+
+// NumChanged returns the number of matching commits whose changes differ
+func (d *RangeDiff) NumChanged() int {
+	return d.count(git.RangeDiffChanged)
+}
+
+func (d *RangeDiff) count(status git.RangeDiffStatus) int {
+	n := 0
+	for _, c := range d.Commits {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// GetRangeDiff compares the commits of oldHead with the commits of newHead, both ranges
+// starting at their merge base with baseRef
+func GetRangeDiff(gitRepo *git.Repository, baseRef, oldHead, newHead string) (*RangeDiff, error) {
+	oldBase, _, err := gitRepo.GetMergeBase("", baseRef, oldHead)
+	if err != nil {
+		return nil, err
+	}
+	newBase, _, err := gitRepo.GetMergeBase("", baseRef, newHead)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := gitRepo.GetRangeDiff(oldBase, oldHead, newBase, newHead)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeDiff := &RangeDiff{
+		OldBase: oldBase,
+		OldHead: oldHead,
+		NewBase: newBase,
+		NewHead: newHead,
+		Commits: make([]*RangeDiffCommit, 0, len(commits)),
+	}
+	for _, commit := range commits {
+		rangeDiff.Commits = append(rangeDiff.Commits, &RangeDiffCommit{
+			RangeDiffCommit: commit,
+			Lines:           parseInterdiffLines(commit.Interdiff),
+		})
+	}
+	return rangeDiff, nil
+}
+
+// parseInterdiffLines types the lines of an interdiff by their first marker,
+// the one telling how the patch of the commit changed
+func parseInterdiffLines(interdiff []string) []*DiffLine {
+	lines := make([]*DiffLine, 0, len(interdiff))
+	for _, content := range interdiff {
+		line := &DiffLine{Type: DiffLinePlain, Content: content}
+		if len(content) > 0 {
+			switch content[0] {
+			case '@':
+				line.Type = DiffLineSection
+			case '+':
+				line.Type = DiffLineAdd
+			case '-':
+				line.Type = DiffLineDel
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package gitdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterdiffLines(t *testing.T) {
+	lines := parseInterdiffLines([]string{"@@ big", "  9", " -10", "-+ten", "++TEN", ""})
+	types := make([]DiffLineType, 0, len(lines))
+	for _, line := range lines {
+		types = append(types, line.Type)
+	}
+	assert.Equal(t, []DiffLineType{DiffLineSection, DiffLinePlain, DiffLinePlain, DiffLineDel, DiffLineAdd, DiffLinePlain}, types)
+	assert.Equal(t, "-+ten", lines[3].Content)
+}
@@ -127,6 +127,10 @@ func (r *indexerNotifier) IssueChangeTitle(ctx context.Context, doer *user_model
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
 
+func (r *indexerNotifier) IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
 func (r *indexerNotifier) IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string) {
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
@@ -0,0 +1,312 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+)
+
+// MaxBulkEditIssues is the maximum number of issues a single bulk edit may change
+var MaxBulkEditIssues = 1000
+
+// BulkEditOptions are the changes a bulk edit makes to every issue.
+// Labels, milestones and projects are referred to by name since
+// the issues may belong to different repositories.
+type BulkEditOptions struct {
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+	// Milestone is the title of the milestone to set, an empty string removes the milestone
+	Milestone *string
+	// Project is the title of the project to set, an empty string removes the project
+	Project  *string
+	IsClosed optional.Option[bool]
+	// MoveTo is the full name of the repository the issues are transferred to, after the other changes
+	MoveTo string
+}
+
+// IsEmpty returns true if the options don't change anything
+func (opts *BulkEditOptions) IsEmpty() bool {
+	return len(opts.AddLabels) == 0 && len(opts.RemoveLabels) == 0 &&
+		len(opts.AddAssignees) == 0 && len(opts.RemoveAssignees) == 0 &&
+		opts.Milestone == nil && opts.Project == nil && !opts.IsClosed.Has() && opts.MoveTo == ""
+}
+
+// FindBulkEditIssueIDs returns the IDs of the issues matching the search query among the repositories
+// the doer has access to. If owner is not nil, only the repositories of owner are searched.
+// A query matching nothing or more than MaxBulkEditIssues issues is an invalid argument.
+func FindBulkEditIssueIDs(ctx context.Context, doer, owner *user_model.User, query string) ([]int64, error) {
+	repoOpts := &repo_model.SearchRepoOptions{
+		Actor:       doer,
+		OwnerID:     doer.ID,
+		Private:     true,
+		Collaborate: optional.None[bool](),
+	}
+	if owner != nil && owner.ID != doer.ID {
+		repoOpts.OwnerID = owner.ID
+		repoOpts.Collaborate = optional.Some(false)
+	}
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, repoOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(repoIDs) == 0 {
+		repoIDs = []int64{0}
+	}
+
+	searchOpts := &issue_indexer.SearchOptions{
+		Paginator: &db.ListOptions{PageSize: MaxBulkEditIssues + 1},
+		Keyword:   query,
+		RepoIDs:   repoIDs,
+		SortBy:    issue_indexer.SortByCreatedAsc,
+	}
+	if err := issue_indexer.ApplyQuery(ctx, searchOpts, doer); err != nil {
+		return nil, err
+	}
+	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("no issue matches the query %q", query)
+	}
+	if total > int64(MaxBulkEditIssues) {
+		return nil, util.NewInvalidArgumentErrorf("the query matches %d issues but at most %d can be edited at once", total, MaxBulkEditIssues)
+	}
+	return ids, nil
+}
+
+// BulkEdit applies the changes of a bulk edit to a single issue.
+// Names are resolved in the repository of the issue and, for labels and projects, in its owner.
+// Nothing is changed if one of them doesn't exist, if the doer may not edit the issue or if one of the changes fails.
+func BulkEdit(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, opts *BulkEditOptions) error {
+	for _, name := range opts.AddAssignees {
+		for _, removed := range opts.RemoveAssignees {
+			if strings.EqualFold(name, removed) {
+				return util.NewInvalidArgumentErrorf("user %q can't be both assigned and unassigned", name)
+			}
+		}
+	}
+
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if issue.Repo.IsArchived {
+		return util.NewPermissionDeniedErrorf("repository %s is archived", issue.Repo.FullName())
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWriteIssuesOrPulls(issue.IsPull) {
+		return util.NewPermissionDeniedErrorf("no permission to edit issues of %s", issue.Repo.FullName())
+	}
+
+	addLabels, err := bulkEditLabels(ctx, issue.Repo, opts.AddLabels)
+	if err != nil {
+		return err
+	}
+	removeLabels, err := bulkEditLabels(ctx, issue.Repo, opts.RemoveLabels)
+	if err != nil {
+		return err
+	}
+	addAssignees, err := bulkEditAssignees(ctx, issue, opts.AddAssignees, true)
+	if err != nil {
+		return err
+	}
+	removeAssignees, err := bulkEditAssignees(ctx, issue, opts.RemoveAssignees, false)
+	if err != nil {
+		return err
+	}
+	var milestoneID int64
+	if opts.Milestone != nil && *opts.Milestone != "" {
+		milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, issue.RepoID, *opts.Milestone)
+		if err != nil {
+			if issues_model.IsErrMilestoneNotExist(err) {
+				return util.NewNotExistErrorf("milestone %q does not exist in %s", *opts.Milestone, issue.Repo.FullName())
+			}
+			return err
+		}
+		milestoneID = milestone.ID
+	}
+	var moveTo *repo_model.Repository
+	if opts.MoveTo != "" {
+		ownerName, repoName, _ := strings.Cut(opts.MoveTo, "/")
+		if moveTo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName); err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return util.NewNotExistErrorf("repository %q does not exist", opts.MoveTo)
+			}
+			return err
+		}
+		if issue.IsPull {
+			return util.NewInvalidArgumentErrorf("pull requests can't be transferred")
+		}
+		if moveTo.ID != issue.RepoID {
+			if err := CanTransferIssue(ctx, doer, issue.Repo, moveTo); err != nil {
+				if !errors.Is(err, util.ErrPermissionDenied) {
+					return err
+				}
+				// don't leak the existence of private repositories
+				targetPerm, permErr := access_model.GetUserRepoPermission(ctx, moveTo, doer)
+				if permErr != nil {
+					return permErr
+				}
+				if !targetPerm.HasAccess() {
+					return util.NewNotExistErrorf("repository %q does not exist", opts.MoveTo)
+				}
+				return err
+			}
+		}
+	}
+	var projectID int64
+	if opts.Project != nil {
+		if !perm.CanRead(unit.TypeProjects) {
+			return util.NewPermissionDeniedErrorf("no permission to read projects of %s", issue.Repo.FullName())
+		}
+		if *opts.Project != "" {
+			if projectID, err = bulkEditProjectID(ctx, issue.Repo, *opts.Project); err != nil {
+				return err
+			}
+		}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, label := range addLabels {
+			if issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+				continue
+			}
+			if err := AddLabel(ctx, issue, doer, label); err != nil {
+				return err
+			}
+		}
+		for _, label := range removeLabels {
+			if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+				continue
+			}
+			if err := RemoveLabel(ctx, issue, doer, label); err != nil {
+				return err
+			}
+		}
+		for _, assignee := range append(addAssignees, removeAssignees...) {
+			if _, _, err := ToggleAssigneeWithNotify(ctx, issue, doer, assignee.ID); err != nil {
+				return err
+			}
+		}
+		if opts.Milestone != nil && issue.MilestoneID != milestoneID {
+			oldMilestoneID := issue.MilestoneID
+			issue.MilestoneID = milestoneID
+			if err := ChangeMilestoneAssign(ctx, issue, doer, oldMilestoneID); err != nil {
+				return err
+			}
+		}
+		if opts.Project != nil {
+			if err := issue.LoadProject(ctx); err != nil {
+				return err
+			}
+			var oldProjectID int64
+			if issue.Project != nil {
+				oldProjectID = issue.Project.ID
+			}
+			if oldProjectID != projectID {
+				if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, projectID, 0); err != nil {
+					return err
+				}
+			}
+		}
+		if opts.IsClosed.Has() && issue.IsClosed != opts.IsClosed.Value() {
+			if err := ChangeStatus(ctx, issue, doer, "", opts.IsClosed.Value()); err != nil {
+				if issues_model.IsErrDependenciesLeft(err) {
+					return util.NewInvalidArgumentErrorf("issue has open dependencies")
+				}
+				return err
+			}
+		}
+		if moveTo != nil && moveTo.ID != issue.RepoID {
+			return TransferIssue(ctx, doer, issue, moveTo)
+		}
+		return nil
+	})
+}
+
+// bulkEditLabels finds the labels with the given names in the repository or in its organization
+func bulkEditLabels(ctx context.Context, repo *repo_model.Repository, names []string) ([]*issues_model.Label, error) {
+	labels := make([]*issues_model.Label, 0, len(names))
+	for _, name := range names {
+		label, err := findLabelByName(ctx, repo, name)
+		if err != nil {
+			if issues_model.IsErrRepoLabelNotExist(err) || issues_model.IsErrOrgLabelNotExist(err) {
+				return nil, util.NewNotExistErrorf("label %q does not exist in %s", name, repo.FullName())
+			}
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// bulkEditAssignees finds the users with the given names which must be assigned or unassigned,
+// users already in the desired state are skipped
+func bulkEditAssignees(ctx context.Context, issue *issues_model.Issue, names []string, assign bool) ([]*user_model.User, error) {
+	users := make([]*user_model.User, 0, len(names))
+	for _, name := range names {
+		user, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, util.NewNotExistErrorf("user %q does not exist", name)
+			}
+			return nil, err
+		}
+		isAssigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, user)
+		if err != nil {
+			return nil, err
+		}
+		if isAssigned == assign {
+			continue
+		}
+		if assign {
+			valid, err := access_model.CanBeAssigned(ctx, user, issue.Repo, issue.IsPull)
+			if err != nil {
+				return nil, err
+			}
+			if !valid {
+				return nil, util.NewPermissionDeniedErrorf("user %q can't be assigned in %s", name, issue.Repo.FullName())
+			}
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// bulkEditProjectID finds the open project with the given title in the repository or its owner
+func bulkEditProjectID(ctx context.Context, repo *repo_model.Repository, title string) (int64, error) {
+	for _, opts := range []project_model.SearchOptions{
+		{RepoID: repo.ID, Type: project_model.TypeRepository, IsClosed: optional.Some(false)},
+		{OwnerID: repo.OwnerID, IsClosed: optional.Some(false)},
+	} {
+		projects, err := db.Find[project_model.Project](ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		for _, project := range projects {
+			if strings.EqualFold(project.Title, title) {
+				return project.ID, nil
+			}
+		}
+	}
+	return 0, util.NewNotExistErrorf("project %q does not exist in %s", title, repo.FullName())
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkEdit(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	milestone := "milestone1"
+
+	t.Run("Edit", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{
+			AddLabels:    []string{"label2"},
+			RemoveLabels: []string{"label1"},
+			AddAssignees: []string{"user2"},
+			Milestone:    &milestone,
+			IsClosed:     optional.Some(true),
+		}))
+
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 1})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: 1, AssigneeID: 2})
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.EqualValues(t, 1, issue.MilestoneID)
+		assert.True(t, issue.IsClosed)
+	})
+
+	t.Run("Unknown name", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		wasClosed := issue.IsClosed
+		unknown := "no such milestone"
+		err := BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{
+			AddLabels: []string{"label2"},
+			IsClosed:  optional.Some(!wasClosed),
+			Milestone: &unknown,
+		})
+		require.ErrorIs(t, err, util.ErrNotExist)
+
+		// nothing is changed when a name can't be resolved
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 2, LabelID: 2})
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		assert.Equal(t, wasClosed, issue.IsClosed)
+	})
+
+	t.Run("Assigned and unassigned", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		err := BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{
+			AddAssignees:    []string{"user2"},
+			RemoveAssignees: []string{"User2"},
+		})
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueAssignees{IssueID: 2, AssigneeID: 2})
+	})
+
+	t.Run("Failed change", func(t *testing.T) {
+		issuesUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: 1, Type: unit.TypeIssues})
+		issuesUnit.IssuesConfig().EnableDependencies = true
+		require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, issuesUnit))
+		defer func() {
+			issuesUnit.IssuesConfig().EnableDependencies = false
+			require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, issuesUnit))
+		}()
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		dependency := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+		require.False(t, dependency.IsClosed)
+		require.NoError(t, issues_model.CreateIssueDependency(db.DefaultContext, doer, issue, dependency))
+		defer func() {
+			require.NoError(t, issues_model.RemoveIssueDependency(db.DefaultContext, doer, issue, dependency, issues_model.DependencyTypeBlockedBy))
+		}()
+
+		// the issue can't be closed before its dependency, the labels added before are rolled back
+		err := BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{
+			AddLabels: []string{"label2"},
+			IsClosed:  optional.Some(true),
+		})
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 2, LabelID: 2})
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		assert.False(t, issue.IsClosed)
+	})
+
+	t.Run("No permission", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		err := BulkEdit(db.DefaultContext, reader, issue, &BulkEditOptions{IsClosed: optional.Some(false)})
+		require.ErrorIs(t, err, util.ErrPermissionDenied)
+	})
+
+	t.Run("Move to an unreadable repository", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		// user10/repo6 is private and the doer has no access to it: it must look like a missing repository
+		err := BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{MoveTo: "user10/repo6"})
+		require.ErrorIs(t, err, util.ErrNotExist)
+		assert.NotErrorIs(t, err, util.ErrPermissionDenied)
+
+		err = BulkEdit(db.DefaultContext, doer, issue, &BulkEditOptions{MoveTo: "user10/no-such-repo"})
+		require.ErrorIs(t, err, util.ErrNotExist)
+	})
+}
@@ -46,6 +46,16 @@ func CreateRefComment(ctx context.Context, doer *user_model.User, repo *repo_mod
 
 // CreateIssueComment creates a plain issue comment.
 func CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content string, attachments []string) (*issues_model.Comment, error) {
+	return createIssueComment(ctx, doer, repo, issue, content, attachments, false)
+}
+
+// CreateInternalIssueComment creates a plain issue comment only readable by the users who can write the issues,
+// it is never mailed.
+func CreateInternalIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content string, attachments []string) (*issues_model.Comment, error) {
+	return createIssueComment(ctx, doer, repo, issue, content, attachments, true)
+}
+
+func createIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, content string, attachments []string, isInternal bool) (*issues_model.Comment, error) {
 	// Check if doer is blocked by the poster of the issue or by the owner of the repository.
 	if user_model.IsBlockedMultiple(ctx, []int64{issue.PosterID, repo.OwnerID}, doer.ID) {
 		return nil, user_model.ErrBlockedByUser
@@ -58,6 +68,7 @@ func CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_m
 		Issue:       issue,
 		Content:     content,
 		Attachments: attachments,
+		IsInternal:  isInternal,
 	})
 	if err != nil {
 		return nil, err
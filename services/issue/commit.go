@@ -91,7 +91,7 @@ func issueAddTime(ctx context.Context, issue *issues_model.Issue, doer *user_mod
 // getIssueFromRef returns the issue referenced by a ref. Returns a nil *Issue
 // if the provided ref references a non-existent issue.
 func getIssueFromRef(ctx context.Context, repo *repo_model.Repository, index int64) (*issues_model.Issue, error) {
-	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	issue, err := issues_model.GetIssueByIndexOrRedirect(ctx, repo.ID, index)
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
 			return nil, nil
@@ -137,6 +137,13 @@ func UpdateIssuesCommit(ctx context.Context, doer *user_model.User, repo *repo_m
 			if refIssue == nil {
 				continue
 			}
+			// the issue may have been transferred to another repository
+			if refIssue.RepoID != refRepo.ID {
+				if err := refIssue.LoadRepo(ctx); err != nil {
+					return err
+				}
+				refRepo = refIssue.Repo
+			}
 
 			perm, err := access_model.GetUserRepoPermission(ctx, refRepo, doer)
 			if err != nil {
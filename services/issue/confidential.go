@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/util"
+)
+
+// SetConfidential makes an issue confidential, only readable by its poster and by the users who can write the
+// issues of its repository, or not
+func SetConfidential(ctx context.Context, issue *issues_model.Issue, isConfidential bool) error {
+	if issue.IsConfidential == isConfidential {
+		return nil
+	}
+	if issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can't be confidential")
+	}
+	if err := issues_model.SetIssueConfidential(ctx, issue, isConfidential); err != nil {
+		return err
+	}
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+	return nil
+}
+
+// ConfidentialFilter returns the filter limiting a search of issues in repoIDs, or in all repositories if it is
+// nil, to the confidential issues the doer can read. It is nil if the doer can read all of them.
+func ConfidentialFilter(ctx context.Context, doer *user_model.User, repoIDs []int64) (*issues_model.ConfidentialFilter, error) {
+	if doer != nil && doer.IsAdmin {
+		return nil, nil
+	}
+	filter := &issues_model.ConfidentialFilter{}
+	if doer == nil {
+		return filter, nil
+	}
+	filter.PosterID = doer.ID
+
+	// only the few repositories having confidential issues need their permission to be checked
+	confidentialRepoIDs, err := issues_model.GetConfidentialIssueRepoIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, repoID := range confidentialRepoIDs {
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			return nil, err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if perm.CanWrite(unit.TypeIssues) {
+			filter.RepoIDs = append(filter.RepoIDs, repoID)
+		}
+	}
+	return filter, nil
+}
+
+// FilterReadableComments returns the comments of an issue a user with the given permission can read
+func FilterReadableComments(issue *issues_model.Issue, comments issues_model.CommentList, perm access_model.Permission) issues_model.CommentList {
+	readable := make(issues_model.CommentList, 0, len(comments))
+	for _, comment := range comments {
+		if comment.CanBeReadBy(issue, perm) {
+			readable = append(readable, comment)
+		}
+	}
+	return readable
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConfidential(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2, IsPull: true})
+	require.ErrorIs(t, SetConfidential(db.DefaultContext, pull, true), util.ErrInvalidArgument)
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, SetConfidential(db.DefaultContext, issue, true))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, IsConfidential: true})
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	filter, err := ConfidentialFilter(db.DefaultContext, admin, nil)
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = ConfidentialFilter(db.DefaultContext, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.ConfidentialFilter{}, filter)
+
+	filter, err = ConfidentialFilter(db.DefaultContext, owner, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.ConfidentialFilter{PosterID: owner.ID, RepoIDs: []int64{issue.RepoID}}, filter)
+
+	filter, err = ConfidentialFilter(db.DefaultContext, reader, []int64{issue.RepoID})
+	require.NoError(t, err)
+	assert.Equal(t, &issues_model.ConfidentialFilter{PosterID: reader.ID}, filter)
+	assert.False(t, filter.Allows(issue))
+}
+
+func TestCreateInternalIssueComment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: issue.RepoID})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	comment, err := CreateInternalIssueComment(db.DefaultContext, doer, repo, issue, "only for the team", nil)
+	require.NoError(t, err)
+	assert.True(t, comment.IsInternal)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: comment.ID, IsInternal: true})
+
+	comment, err = CreateIssueComment(db.DefaultContext, doer, repo, issue, "for everyone", nil)
+	require.NoError(t, err)
+	assert.False(t, comment.IsInternal)
+}
@@ -187,6 +187,16 @@ func DeleteIssue(ctx context.Context, doer *user_model.User, gitRepo *git.Reposi
 		if err := gitRepo.RemoveReference(fmt.Sprintf("%s%d/head", git.PullPrefix, issue.PullRequest.Index)); err != nil {
 			return err
 		}
+		// the backups of the edited commits are kept as long as the pull request
+		backupRefs, err := gitRepo.GetRefsFiltered(fmt.Sprintf("%s%d/backup/", git.PullPrefix, issue.PullRequest.Index))
+		if err != nil {
+			return err
+		}
+		for _, ref := range backupRefs {
+			if err := gitRepo.RemoveReference(ref.Name); err != nil {
+				return err
+			}
+		}
 	}
 
 	// If the Issue is pinned, we should unpin it before deletion to avoid problems with other pinned Issues
@@ -316,6 +326,8 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.Comment{RefIssueID: issue.ID},
 		&issues_model.IssueDependency{DependencyID: issue.ID},
 		&issues_model.Comment{DependentIssueID: issue.ID},
+		&issues_model.IssueRedirect{IssueID: issue.ID},
+		&issues_model.ServiceDeskIssue{IssueID: issue.ID},
 	); err != nil {
 		return err
 	}
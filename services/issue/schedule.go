@@ -0,0 +1,276 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "forgejo.org/models/actions"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/issue/template"
+	"forgejo.org/modules/log"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// CreateIssueSchedule checks and creates a schedule creating issues in repo from one of its issue templates
+func CreateIssueSchedule(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, s *issues_model.IssueSchedule) error {
+	s.RepoID = repo.ID
+	s.Repo = repo
+	s.DoerID = doer.ID
+	s.IsActive = true
+
+	schedule, err := actions_model.ParseScheduleSpec(s.Spec)
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid schedule %q: %v", s.Spec, err)
+	}
+	if _, err := loadIssueScheduleTemplate(ctx, repo, s.TemplateFile); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid issue template %q: %v", s.TemplateFile, err)
+	}
+	for _, name := range s.Labels {
+		if _, err := findLabelByName(ctx, repo, name); err != nil {
+			if issues_model.IsErrRepoLabelNotExist(err) || issues_model.IsErrOrgLabelNotExist(err) {
+				return util.NewInvalidArgumentErrorf("label %q does not exist", name)
+			}
+			return err
+		}
+	}
+	if s.TeamID > 0 {
+		team, err := organization.GetTeamByID(ctx, s.TeamID)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				return util.NewInvalidArgumentErrorf("team does not exist")
+			}
+			return err
+		}
+		if team.OrgID != repo.OwnerID {
+			return util.NewInvalidArgumentErrorf("team %q does not belong to the owner of the repository", team.Name)
+		}
+	}
+
+	s.Next = timeutil.TimeStamp(schedule.Next(time.Now()).Unix())
+	return issues_model.CreateIssueSchedule(ctx, s)
+}
+
+// SetIssueScheduleActive pauses or resumes an issue schedule, a resumed schedule
+// doesn't create the issues it missed while it was paused
+func SetIssueScheduleActive(ctx context.Context, s *issues_model.IssueSchedule, isActive bool) error {
+	if s.IsActive == isActive {
+		return nil
+	}
+	s.IsActive = isActive
+	if isActive {
+		schedule, err := actions_model.ParseScheduleSpec(s.Spec)
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid schedule %q: %v", s.Spec, err)
+		}
+		s.Next = timeutil.TimeStamp(schedule.Next(time.Now()).Unix())
+	}
+	return issues_model.UpdateIssueSchedule(ctx, s, "is_active", "next")
+}
+
+// issueSchedulesPageSize is the number of due schedules loaded at once by CreateScheduledIssues
+var issueSchedulesPageSize = 50
+
+// CreateScheduledIssues creates the issues of all the active schedules which are due
+func CreateScheduledIssues(ctx context.Context) error {
+	now := time.Now()
+	var afterID int64
+	for {
+		// paged by ID, a schedule which could not be moved to its next time must not be found again
+		schedules, err := db.Find[issues_model.IssueSchedule](ctx, issues_model.FindIssueSchedulesOptions{
+			ListOptions: db.ListOptions{Page: 1, PageSize: issueSchedulesPageSize},
+			IsActive:    true,
+			Next:        now.Unix(),
+			AfterID:     afterID,
+		})
+		if err != nil {
+			return fmt.Errorf("find issue schedules: %w", err)
+		}
+
+		for _, s := range schedules {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			runIssueSchedule(ctx, s, now)
+			afterID = s.ID
+		}
+
+		if len(schedules) < issueSchedulesPageSize {
+			return nil
+		}
+	}
+}
+
+func runIssueSchedule(ctx context.Context, s *issues_model.IssueSchedule, now time.Time) {
+	cols := []string{"prev", "next", "last_error"}
+	s.LastError = ""
+	if issue, err := createScheduledIssue(ctx, s); err != nil {
+		log.Warn("IssueSchedule[%d]: unable to create issue: %v", s.ID, err)
+		s.LastError = err.Error()
+	} else {
+		s.LastIssueID = issue.ID
+		cols = append(cols, "last_issue_id", "rotation_index")
+	}
+
+	s.Prev = s.Next
+	if schedule, err := actions_model.ParseScheduleSpec(s.Spec); err != nil {
+		log.Error("IssueSchedule[%d]: invalid spec %q: %v", s.ID, s.Spec, err)
+		s.IsActive = false
+		s.LastError = err.Error()
+		cols = append(cols, "is_active")
+	} else {
+		s.Next = timeutil.TimeStamp(schedule.Next(now).Unix())
+	}
+	if err := issues_model.UpdateIssueSchedule(ctx, s, cols...); err != nil {
+		log.Error("IssueSchedule[%d]: UpdateIssueSchedule: %v", s.ID, err)
+	}
+}
+
+func createScheduledIssue(ctx context.Context, s *issues_model.IssueSchedule) (*issues_model.Issue, error) {
+	if err := s.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	repo := s.Repo
+	if repo.IsArchived {
+		return nil, errors.New("the repository is archived")
+	}
+	if !repo.UnitEnabled(ctx, unit.TypeIssues) {
+		return nil, errors.New("the issues of the repository are disabled")
+	}
+
+	doer, err := user_model.GetUserByID(ctx, s.DoerID)
+	if err != nil {
+		return nil, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanWrite(unit.TypeIssues) {
+		return nil, fmt.Errorf("%s can't write issues anymore", doer.Name)
+	}
+
+	it, err := loadIssueScheduleTemplate(ctx, repo, s.TemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	vars := issueScheduleVariables(s.Next.AsTime().UTC())
+	title := it.Title
+	if title == "" {
+		title = s.Name
+	}
+	content := it.Content
+	if it.Type() == api.IssueTemplateTypeYaml {
+		content = template.RenderToMarkdown(it, template.DefaultValues(it))
+	}
+
+	var labelIDs []int64
+	for _, name := range append(it.Labels, s.Labels...) {
+		label, err := findLabelByName(ctx, repo, name)
+		if err != nil {
+			if issues_model.IsErrRepoLabelNotExist(err) || issues_model.IsErrOrgLabelNotExist(err) {
+				log.Debug("IssueSchedule[%d]: label %q does not exist", s.ID, name)
+				continue
+			}
+			return nil, err
+		}
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	var assigneeIDs []int64
+	if s.TeamID > 0 {
+		assignee, err := nextIssueScheduleAssignee(ctx, s, repo)
+		if err != nil {
+			return nil, err
+		}
+		if assignee != nil {
+			assigneeIDs = append(assigneeIDs, assignee.ID)
+		}
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    vars.Replace(title),
+		PosterID: doer.ID,
+		Poster:   doer,
+		Content:  vars.Replace(content),
+		Ref:      it.Ref,
+	}
+	if err := NewIssue(ctx, repo, issue, labelIDs, nil, assigneeIDs); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// nextIssueScheduleAssignee returns the next member of the team of the schedule who can be assigned,
+// or nil if there is none
+func nextIssueScheduleAssignee(ctx context.Context, s *issues_model.IssueSchedule, repo *repo_model.Repository) (*user_model.User, error) {
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: s.TeamID})
+	if err != nil {
+		return nil, err
+	}
+	for range members {
+		member := members[s.RotationIndex%len(members)]
+		s.RotationIndex = (s.RotationIndex + 1) % len(members)
+		ok, err := access_model.CanBeAssigned(ctx, member, repo, false)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
+
+func loadIssueScheduleTemplate(ctx context.Context, repo *repo_model.Repository, filename string) (*api.IssueTemplate, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	it, err := template.UnmarshalFromRepo(gitRepo, repo.DefaultBranch, filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Validate(it); err != nil {
+		return nil, err
+	}
+	if it.Ref != "" && !strings.HasPrefix(it.Ref, "refs/") {
+		it.Ref = git.BranchPrefix + it.Ref
+	}
+	return it, nil
+}
+
+// issueScheduleVariables returns the replacer of the variables available in the title and the content
+// of the issue template
+func issueScheduleVariables(t time.Time) *strings.Replacer {
+	isoYear, isoWeek := t.ISOWeek()
+	return strings.NewReplacer(
+		"{{date}}", t.Format(time.DateOnly),
+		"{{year}}", strconv.Itoa(t.Year()),
+		"{{month}}", fmt.Sprintf("%02d", t.Month()),
+		"{{day}}", fmt.Sprintf("%02d", t.Day()),
+		"{{week}}", strconv.Itoa(isoWeek),
+		"{{week_year}}", strconv.Itoa(isoYear),
+		"{{weekday}}", t.Weekday().String(),
+	)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueScheduleVariables(t *testing.T) {
+	vars := issueScheduleVariables(time.Date(2027, time.January, 1, 9, 0, 0, 0, time.UTC))
+	assert.Equal(t, "Handover 2027-01-01, week 53 of 2026 (Friday)",
+		vars.Replace("Handover {{date}}, week {{week}} of {{week_year}} ({{weekday}})"))
+	assert.Equal(t, "2027/01/01 {{unknown}}", vars.Replace("{{year}}/{{month}}/{{day}} {{unknown}}"))
+}
+
+func TestCreateIssueScheduleInvalidSpec(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	err := CreateIssueSchedule(db.DefaultContext, doer, repo, &issues_model.IssueSchedule{
+		Name:         "Weekly on-call handover",
+		TemplateFile: ".forgejo/ISSUE_TEMPLATE/handover.md",
+		Spec:         "every monday",
+	})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSchedule{RepoID: 1})
+}
+
+func TestCreateScheduledIssuesFailure(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&issueSchedulesPageSize, 2)()
+
+	numIssues := unittest.GetCount(t, &issues_model.Issue{RepoID: 1})
+	due := timeutil.TimeStamp(time.Now().Add(-time.Hour).Unix())
+	var ids []int64
+	for i := range 5 {
+		s := &issues_model.IssueSchedule{
+			RepoID:       1,
+			DoerID:       2,
+			Name:         fmt.Sprintf("Missing template %d", i),
+			TemplateFile: ".forgejo/ISSUE_TEMPLATE/missing.md",
+			Spec:         "@daily",
+			IsActive:     true,
+			Next:         due,
+		}
+		require.NoError(t, issues_model.CreateIssueSchedule(db.DefaultContext, s))
+		ids = append(ids, s.ID)
+	}
+
+	// every due schedule is run once, even if it can't create its issue
+	require.NoError(t, CreateScheduledIssues(db.DefaultContext))
+	for _, id := range ids {
+		s := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: id})
+		assert.True(t, s.IsActive)
+		assert.NotEmpty(t, s.LastError)
+		assert.Equal(t, due, s.Prev)
+		assert.Greater(t, s.Next, timeutil.TimeStampNow())
+		assert.Zero(t, s.LastIssueID)
+	}
+	assert.Equal(t, numIssues, unittest.GetCount(t, &issues_model.Issue{RepoID: 1}))
+}
+
+func TestNextIssueScheduleAssignee(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// the members of team1 of org3, user2 and user4, can both be assigned in org3/repo3
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	s := &issues_model.IssueSchedule{RepoID: repo.ID, TeamID: 2}
+
+	var assignees []int64
+	for range 3 {
+		assignee, err := nextIssueScheduleAssignee(db.DefaultContext, s, repo)
+		require.NoError(t, err)
+		require.NotNil(t, assignee)
+		assignees = append(assignees, assignee.ID)
+	}
+	assert.ElementsMatch(t, []int64{2, 4}, assignees[:2])
+	assert.Equal(t, assignees[0], assignees[2])
+	assert.Equal(t, 1, s.RotationIndex)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// ServiceDeskMessage is an email received by a service desk
+type ServiceDeskMessage struct {
+	Email       string
+	Name        string
+	Subject     string
+	MessageID   string
+	Content     string
+	Attachments []string // UUIDs of attachments
+}
+
+// SetServiceDeskEnabled enables or disables the service desk of a repository.
+// Issues created from emails are confidential, so they are only read by the users who can write the issues
+// of the repository, even in a public repository.
+func SetServiceDeskEnabled(ctx context.Context, repo *repo_model.Repository, isEnabled bool) (*issues_model.ServiceDesk, error) {
+	if isEnabled && !setting.IncomingEmail.Enabled {
+		return nil, util.NewInvalidArgumentErrorf("incoming email is not enabled")
+	}
+	return issues_model.SetServiceDeskEnabled(ctx, repo.ID, isEnabled)
+}
+
+// IsServiceDeskAvailable returns whether a service desk accepts emails for its repository
+func IsServiceDeskAvailable(ctx context.Context, desk *issues_model.ServiceDesk, repo *repo_model.Repository) bool {
+	return desk.IsEnabled && !repo.IsArchived && repo.UnitEnabled(ctx, unit.TypeIssues)
+}
+
+// NewServiceDeskIssue creates an issue from an email sent to a service desk by an external reporter.
+// The issue is confidential, posted by the ghost user and shows the reporter as its original author.
+func NewServiceDeskIssue(ctx context.Context, repo *repo_model.Repository, msg *ServiceDeskMessage) (*issues_model.Issue, *issues_model.ServiceDeskIssue, error) {
+	deskIssue := &issues_model.ServiceDeskIssue{
+		Email:     msg.Email,
+		Name:      msg.Name,
+		MessageID: msg.MessageID,
+	}
+
+	title := strings.TrimSpace(msg.Subject)
+	if title == "" {
+		title = fmt.Sprintf("Service desk request from %s", msg.Email)
+	}
+	title, _ = util.SplitStringAtByteN(title, 255)
+
+	ghost := user_model.NewGhostUser()
+	issue := &issues_model.Issue{
+		RepoID:         repo.ID,
+		Repo:           repo,
+		Title:          title,
+		PosterID:       ghost.ID,
+		Poster:         ghost,
+		Content:        msg.Content,
+		OriginalAuthor: deskIssue.DisplayName(),
+		IsConfidential: true,
+	}
+	if err := NewIssue(ctx, repo, issue, nil, msg.Attachments, nil); err != nil {
+		return nil, nil, err
+	}
+
+	deskIssue.IssueID = issue.ID
+	if err := issues_model.CreateServiceDeskIssue(ctx, deskIssue); err != nil {
+		return nil, nil, err
+	}
+	return issue, deskIssue, nil
+}
+
+// CreateServiceDeskComment adds a reply of the external reporter of a service desk issue as a comment.
+func CreateServiceDeskComment(ctx context.Context, issue *issues_model.Issue, deskIssue *issues_model.ServiceDeskIssue, content string, attachments []string) (*issues_model.Comment, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	ghost := user_model.NewGhostUser()
+	comment, err := issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
+		Type:           issues_model.CommentTypeComment,
+		Doer:           ghost,
+		Repo:           issue.Repo,
+		Issue:          issue,
+		Content:        content,
+		Attachments:    attachments,
+		OriginalAuthor: deskIssue.DisplayName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.CreateIssueComment(ctx, ghost, issue.Repo, issue, comment, nil)
+
+	return comment, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetServiceDeskEnabled(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, true)()
+	defer test.MockVariableValue(&setting.IncomingEmail.ReplyToAddress, "incoming+%{token}@localhost")()
+	defer test.MockVariableValue(&setting.IncomingEmail.TokenPlaceholder, "%{token}")()
+
+	public := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	desk, err := SetServiceDeskEnabled(db.DefaultContext, public, true)
+	require.NoError(t, err)
+	assert.True(t, desk.IsEnabled)
+	assert.True(t, IsServiceDeskAvailable(db.DefaultContext, desk, public))
+
+	private := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	desk, err = SetServiceDeskEnabled(db.DefaultContext, private, true)
+	require.NoError(t, err)
+	assert.True(t, desk.IsEnabled)
+	assert.Equal(t, "incoming+desk-"+desk.Key+"@localhost", desk.Address())
+
+	oldKey := desk.Key
+	require.NoError(t, issues_model.RegenerateServiceDeskKey(db.DefaultContext, desk))
+	assert.NotEqual(t, oldKey, desk.Key)
+	_, err = issues_model.GetServiceDeskByKey(db.DefaultContext, oldKey)
+	assert.True(t, issues_model.IsErrServiceDeskNotExist(err))
+
+	desk, err = SetServiceDeskEnabled(db.DefaultContext, private, false)
+	require.NoError(t, err)
+	assert.False(t, desk.IsEnabled)
+	assert.False(t, IsServiceDeskAvailable(db.DefaultContext, desk, private))
+
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, false)()
+	_, err = SetServiceDeskEnabled(db.DefaultContext, private, true)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestNewServiceDeskIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	issue, deskIssue, err := NewServiceDeskIssue(db.DefaultContext, repo, &ServiceDeskMessage{
+		Email:     "customer@example.com",
+		Name:      "A Customer",
+		Subject:   "The export is broken",
+		MessageID: "<abc@example.com>",
+		Content:   "It fails with a timeout.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user_model.GhostUserID, issue.PosterID)
+	assert.Equal(t, "The export is broken", issue.Title)
+	assert.Equal(t, "A Customer <customer@example.com>", issue.OriginalAuthor)
+	assert.True(t, issue.IsConfidential)
+	assert.NotEmpty(t, deskIssue.Token)
+
+	deskIssue, err = issues_model.GetServiceDeskIssueByToken(db.DefaultContext, deskIssue.Token)
+	require.NoError(t, err)
+	require.NotNil(t, deskIssue)
+	assert.Equal(t, issue.ID, deskIssue.IssueID)
+	assert.Equal(t, "<abc@example.com>", deskIssue.MessageID)
+
+	comment, err := CreateServiceDeskComment(db.DefaultContext, issue, deskIssue, "It works again, thanks.", nil)
+	require.NoError(t, err)
+	assert.Equal(t, user_model.GhostUserID, comment.PosterID)
+	assert.Equal(t, "A Customer <customer@example.com>", comment.OriginalAuthor)
+}
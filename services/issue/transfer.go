@@ -0,0 +1,126 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// TransferIssue moves an issue to another repository, keeping its comments, reactions, attachments,
+// timeline, subscriptions and tracked time. Labels and milestone are mapped by name to the ones of the
+// new repository and dropped if there is no match, assignees who can't be assigned anymore are dropped.
+// The old index redirects to the issue, so links and cross references keep working.
+func TransferIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, newRepo *repo_model.Repository) error {
+	if issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can't be transferred")
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if issue.RepoID == newRepo.ID {
+		return util.NewInvalidArgumentErrorf("the issue already belongs to %s", newRepo.FullName())
+	}
+	if err := CanTransferIssue(ctx, doer, issue.Repo, newRepo); err != nil {
+		return err
+	}
+
+	if err := issue.LoadLabels(ctx); err != nil {
+		return err
+	}
+	labels := make([]*issues_model.Label, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		newLabel, err := findLabelByName(ctx, newRepo, label.Name)
+		if err != nil {
+			if issues_model.IsErrRepoLabelNotExist(err) || issues_model.IsErrOrgLabelNotExist(err) {
+				continue
+			}
+			return err
+		}
+		labels = append(labels, newLabel)
+	}
+
+	var milestoneID int64
+	if issue.MilestoneID > 0 {
+		if err := issue.LoadMilestone(ctx); err != nil {
+			return err
+		}
+		if issue.Milestone != nil {
+			milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, newRepo.ID, issue.Milestone.Name)
+			if err == nil {
+				milestoneID = milestone.ID
+			} else if !issues_model.IsErrMilestoneNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	if err := issue.LoadAssignees(ctx); err != nil {
+		return err
+	}
+	var removedAssigneeIDs []int64
+	for _, assignee := range issue.Assignees {
+		valid, err := access_model.CanBeAssigned(ctx, assignee, newRepo, false)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			removedAssigneeIDs = append(removedAssigneeIDs, assignee.ID)
+		}
+	}
+
+	oldRepo, oldIndex := issue.Repo, issue.Index
+	if err := issues_model.TransferIssue(ctx, issue, &issues_model.TransferIssueOptions{
+		Doer:               doer,
+		NewRepo:            newRepo,
+		Labels:             labels,
+		MilestoneID:        milestoneID,
+		RemovedAssigneeIDs: removedAssigneeIDs,
+	}); err != nil {
+		return err
+	}
+
+	notify_service.IssueTransfer(ctx, doer, issue, oldRepo, oldIndex)
+	return nil
+}
+
+// CanTransferIssue checks that the doer may move issues from oldRepo to newRepo,
+// which requires to be able to write issues in both repositories.
+func CanTransferIssue(ctx context.Context, doer *user_model.User, oldRepo, newRepo *repo_model.Repository) error {
+	if oldRepo.IsArchived || newRepo.IsArchived {
+		return util.NewPermissionDeniedErrorf("issues can't be transferred from or to an archived repository")
+	}
+	for _, repo := range []*repo_model.Repository{oldRepo, newRepo} {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return err
+		}
+		if !perm.CanWrite(unit.TypeIssues) {
+			return util.NewPermissionDeniedErrorf("no permission to write issues of %s", repo.FullName())
+		}
+	}
+	return nil
+}
+
+// findLabelByName finds a label of the repository or, if the repository belongs to an organization, of the organization
+func findLabelByName(ctx context.Context, repo *repo_model.Repository, name string) (*issues_model.Label, error) {
+	label, err := issues_model.GetLabelInRepoByName(ctx, repo.ID, name)
+	if !issues_model.IsErrRepoLabelNotExist(err) {
+		return label, err
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if !repo.Owner.IsOrganization() {
+		return nil, err
+	}
+	return issues_model.GetLabelInOrgByName(ctx, repo.OwnerID, name)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	newRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+
+	t.Run("Pull request", func(t *testing.T) {
+		pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		require.ErrorIs(t, TransferIssue(db.DefaultContext, doer, pull, newRepo), util.ErrInvalidArgument)
+	})
+
+	t.Run("No permission", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		require.ErrorIs(t, TransferIssue(db.DefaultContext, user, issue, newRepo), util.ErrPermissionDenied)
+	})
+
+	t.Run("Transfer", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1})
+		require.NoError(t, TransferIssue(db.DefaultContext, doer, issue, newRepo))
+
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.EqualValues(t, 2, issue.RepoID)
+		assert.EqualValues(t, 3, issue.Index)
+		// repo2 has no labels, so the labels of the issue are dropped
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeTransfer, OldRef: "user2/repo1#1", NewRef: "user2/repo2#3"})
+
+		// the old index redirects to the issue
+		redirected, err := issues_model.GetIssueByIndexOrRedirect(db.DefaultContext, 1, 1)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, redirected.ID)
+		_, err = issues_model.GetIssueByIndex(db.DefaultContext, 1, 1)
+		assert.True(t, issues_model.IsErrIssueNotExist(err))
+	})
+}
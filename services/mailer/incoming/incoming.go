@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	issues_model "forgejo.org/models/issues"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/process"
 	"forgejo.org/modules/setting"
@@ -247,6 +248,14 @@ loop:
 					return nil
 				}
 
+				if strings.HasPrefix(t, issues_model.ServiceDeskTokenPrefix) {
+					if err := handleServiceDesk(ctx, env, t); err != nil {
+						return fmt.Errorf("could not handle service desk message: %w", err)
+					}
+					handledSet.AddNum(msg.SeqNum)
+					return nil
+				}
+
 				handlerType, user, payload, err := token.ExtractToken(ctx, t)
 				if err != nil {
 					if _, ok := err.(*token.ErrToken); ok {
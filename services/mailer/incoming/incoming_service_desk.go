@@ -0,0 +1,128 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package incoming
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	attachment_service "forgejo.org/services/attachment"
+	"forgejo.org/services/context/upload"
+	issue_service "forgejo.org/services/issue"
+
+	"github.com/jhillyerd/enmime/v2"
+)
+
+// handleServiceDesk turns an email sent to the address of a service desk into an issue.
+// The token is either "desk-<key>" for a new issue, or "desk-<key>-<token>" for a reply of the reporter of an issue.
+func handleServiceDesk(ctx context.Context, env *enmime.Envelope, t string) error {
+	key, issueToken, _ := strings.Cut(strings.TrimPrefix(t, issues_model.ServiceDeskTokenPrefix), "-")
+
+	desk, err := issues_model.GetServiceDeskByKey(ctx, key)
+	if err != nil {
+		if issues_model.IsErrServiceDeskNotExist(err) {
+			log.Info("Unknown service desk in incoming email: %s", key)
+			return nil
+		}
+		return err
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, desk.RepoID)
+	if err != nil {
+		return err
+	}
+	if !issue_service.IsServiceDeskAvailable(ctx, desk, repo) {
+		log.Debug("Service desk of %s is not available", repo.FullName())
+		return nil
+	}
+
+	from, err := env.AddressList("From")
+	if err != nil || len(from) == 0 {
+		log.Info("Incoming service desk email without a valid sender: %v", err)
+		return nil
+	}
+	sender := from[0]
+
+	var deskIssue *issues_model.ServiceDeskIssue
+	var issue *issues_model.Issue
+	if issueToken != "" {
+		deskIssue, err = issues_model.GetServiceDeskIssueByToken(ctx, issueToken)
+		if err != nil {
+			return err
+		}
+		if deskIssue == nil || !strings.EqualFold(deskIssue.Email, sender.Address) {
+			log.Info("Incoming service desk reply with an unknown token or sender: %s", sender.Address)
+			return nil
+		}
+		issue, err = issues_model.GetIssueByID(ctx, deskIssue.IssueID)
+		if err != nil {
+			return err
+		}
+		// the issue was transferred to another repository, the key of this desk doesn't match anymore
+		if issue.RepoID != repo.ID || issue.IsLocked {
+			log.Debug("Service desk issue %d doesn't accept replies", issue.ID)
+			return nil
+		}
+		issue.Repo = repo
+	}
+
+	content := getContentFromMailReader(env)
+	attachmentIDs, err := uploadServiceDeskAttachments(ctx, repo, content.Attachments)
+	if err != nil {
+		return err
+	}
+
+	if deskIssue != nil {
+		if content.Content == "" && len(attachmentIDs) == 0 {
+			return nil
+		}
+		if _, err := issue_service.CreateServiceDeskComment(ctx, issue, deskIssue, content.Content, attachmentIDs); err != nil {
+			return fmt.Errorf("CreateServiceDeskComment failed: %w", err)
+		}
+		return nil
+	}
+
+	if _, _, err := issue_service.NewServiceDeskIssue(ctx, repo, &issue_service.ServiceDeskMessage{
+		Email:       sender.Address,
+		Name:        sender.Name,
+		Subject:     env.GetHeader("Subject"),
+		MessageID:   env.GetHeader("Message-ID"),
+		Content:     content.Content,
+		Attachments: attachmentIDs,
+	}); err != nil {
+		return fmt.Errorf("NewServiceDeskIssue failed: %w", err)
+	}
+	return nil
+}
+
+// uploadServiceDeskAttachments stores the attachments of an email received by a service desk
+func uploadServiceDeskAttachments(ctx context.Context, repo *repo_model.Repository, attachments []*Attachment) ([]string, error) {
+	attachmentIDs := make([]string, 0, len(attachments))
+	if !setting.Attachment.Enabled {
+		return attachmentIDs, nil
+	}
+	for _, attachment := range attachments {
+		a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
+			Name:       attachment.Name,
+			UploaderID: user_model.GhostUserID,
+			RepoID:     repo.ID,
+		})
+		if err != nil {
+			if upload.IsErrFileTypeForbidden(err) {
+				log.Info("Skipping disallowed attachment type: %s", attachment.Name)
+				continue
+			}
+			return nil, err
+		}
+		attachmentIDs = append(attachmentIDs, a.UUID)
+	}
+	return attachmentIDs, nil
+}
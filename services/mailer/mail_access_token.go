@@ -0,0 +1,80 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailAuthAccessTokenExpiring base.TplName = "auth/access_token_expiring"
+	mailAuthAccessTokenRevoked  base.TplName = "auth/access_token_revoked"
+)
+
+// SendAccessTokenExpiringMail warns a user that access tokens are about to expire
+func SendAccessTokenExpiringMail(u *user_model.User, tokens []*auth_model.AccessToken) error {
+	if setting.MailService == nil {
+		return nil
+	}
+	locale := translation.NewLocale(u.Language)
+
+	data := map[string]any{
+		"locale":      locale,
+		"Tokens":      tokens,
+		"Link":        setting.AppURL + "user/settings/applications",
+		"DisplayName": u.DisplayName(),
+		"Username":    u.Name,
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&content, string(mailAuthAccessTokenExpiring), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), locale.TrString("mail.access_token_expiring.subject"), content.String())
+	msg.Info = fmt.Sprintf("UID: %d, access token expiry notification", u.ID)
+
+	SendAsync(msg)
+	return nil
+}
+
+// SendAccessTokenRevokedMail tells a user that an access token has been revoked because it has been found in a repository
+func SendAccessTokenRevokedMail(u *user_model.User, token *auth_model.AccessToken, repo *repo_model.Repository) error {
+	if setting.MailService == nil {
+		return nil
+	}
+	locale := translation.NewLocale(u.Language)
+
+	data := map[string]any{
+		"locale":      locale,
+		"Token":       token.Name,
+		"Repo":        repo.FullName(),
+		"Link":        setting.AppURL + "user/settings/applications",
+		"DisplayName": u.DisplayName(),
+		"Username":    u.Name,
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&content, string(mailAuthAccessTokenRevoked), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), locale.TrString("mail.access_token_revoked.subject"), content.String())
+	msg.Info = fmt.Sprintf("UID: %d, access token revocation notification", u.ID)
+
+	SendAsync(msg)
+	return nil
+}
@@ -15,11 +15,15 @@ import (
 )
 
 // MailParticipantsComment sends new comment emails to repository watchers and mentioned people.
+// Internal comments are never mailed.
 func MailParticipantsComment(ctx context.Context, c *issues_model.Comment, opType activities_model.ActionType, issue *issues_model.Issue, mentions []*user_model.User) error {
 	if setting.MailService == nil {
 		// No mail service configured
 		return nil
 	}
+	if c.IsInternal {
+		return nil
+	}
 
 	content := c.Content
 	if c.Type == issues_model.CommentTypePullRequestPush {
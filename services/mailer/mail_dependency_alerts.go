@@ -0,0 +1,58 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	depgraph_model "forgejo.org/models/depgraph"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailRepoDependencyAlerts base.TplName = "repo/dependency_alerts"
+)
+
+// MailDependencyAlerts warns the administrators of a repository that some of its dependencies are vulnerable
+func MailDependencyAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*depgraph_model.Alert) error {
+	if setting.MailService == nil || len(alerts) == 0 {
+		return nil
+	}
+
+	admins, err := repo_model.GetRepoAdmins(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range admins {
+		locale := translation.NewLocale(u.Language)
+
+		data := map[string]any{
+			"locale":      locale,
+			"Repo":        repo.FullName(),
+			"Alerts":      alerts,
+			"Link":        repo.HTMLURL() + "/settings/dependency_graph",
+			"DisplayName": u.DisplayName(),
+			"Username":    u.Name,
+			"Language":    locale.Language(),
+		}
+
+		var content bytes.Buffer
+
+		if err := bodyTemplates.ExecuteTemplate(&content, string(mailRepoDependencyAlerts), data); err != nil {
+			return err
+		}
+
+		msg := NewMessage(u.EmailTo(), locale.TrString("mail.dependency_alerts.subject", repo.FullName()), content.String())
+		msg.Info = fmt.Sprintf("UID: %d, dependency alerts of repository %d", u.ID, repo.ID)
+
+		SendAsync(msg)
+	}
+	return nil
+}
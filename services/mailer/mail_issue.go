@@ -148,7 +148,15 @@ func mailIssueCommentBatch(ctx *mailCommentContext, users []*user_model.User, vi
 		}
 
 		// test if this user is allowed to see the issue/pull
-		if !access_model.CheckRepoUnitUser(ctx, ctx.Issue.Repo, user, checkUnit) {
+		if ctx.Issue.IsConfidential {
+			perm, err := access_model.GetUserRepoPermission(ctx, ctx.Issue.Repo, user)
+			if err != nil {
+				return err
+			}
+			if !perm.CanRead(checkUnit) || !ctx.Issue.CanBeReadBy(user, perm) {
+				continue
+			}
+		} else if !access_model.CheckRepoUnitUser(ctx, ctx.Issue.Repo, user, checkUnit) {
 			continue
 		}
 
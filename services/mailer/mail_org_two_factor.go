@@ -0,0 +1,62 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailOrgTwoFactorRequired base.TplName = "org/two_factor_required"
+	mailOrgTwoFactorReminder base.TplName = "org/two_factor_reminder"
+)
+
+// SendOrgTwoFactorRequiredMail warns a member of an organization that two-factor authentication
+// is required to keep accessing its resources after the deadline, or to access them again if it is over
+func SendOrgTwoFactorRequiredMail(u, org *user_model.User, deadline timeutil.TimeStamp) error {
+	return sendOrgTwoFactorMail(u, org, deadline, mailOrgTwoFactorRequired, "mail.org_two_factor_required.subject")
+}
+
+// SendOrgTwoFactorReminderMail reminds a member of an organization who did not enroll two-factor
+// authentication that the grace period is about to end
+func SendOrgTwoFactorReminderMail(u, org *user_model.User, deadline timeutil.TimeStamp) error {
+	return sendOrgTwoFactorMail(u, org, deadline, mailOrgTwoFactorReminder, "mail.org_two_factor_reminder.subject")
+}
+
+func sendOrgTwoFactorMail(u, org *user_model.User, deadline timeutil.TimeStamp, tpl base.TplName, subjectKey string) error {
+	if setting.MailService == nil {
+		return nil
+	}
+	locale := translation.NewLocale(u.Language)
+
+	data := map[string]any{
+		"locale":       locale,
+		"Organization": org.Name,
+		"Deadline":     deadline,
+		"Enforced":     deadline <= timeutil.TimeStampNow(),
+		"Link":         setting.AppURL + "user/settings/security",
+		"DisplayName":  u.DisplayName(),
+		"Username":     u.Name,
+		"Language":     locale.Language(),
+	}
+
+	var content bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tpl), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), locale.TrString(subjectKey, org.Name), content.String())
+	msg.Info = fmt.Sprintf("UID: %d, organization two-factor authentication %s", u.ID, tpl)
+
+	SendAsync(msg)
+	return nil
+}
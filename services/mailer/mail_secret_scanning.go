@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailRepoSecretScanningAlerts base.TplName = "repo/secret_scanning_alerts"
+)
+
+// MailSecretScanningAlerts warns the administrators of a repository that secrets have been found in it
+func MailSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert) error {
+	if setting.MailService == nil || len(alerts) == 0 {
+		return nil
+	}
+
+	admins, err := repo_model.GetRepoAdmins(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range admins {
+		locale := translation.NewLocale(u.Language)
+
+		data := map[string]any{
+			"locale":      locale,
+			"Repo":        repo.FullName(),
+			"Alerts":      alerts,
+			"Doer":        doer,
+			"Link":        repo.HTMLURL() + "/settings/secret_scanning",
+			"DisplayName": u.DisplayName(),
+			"Username":    u.Name,
+			"Language":    locale.Language(),
+		}
+
+		var content bytes.Buffer
+
+		if err := bodyTemplates.ExecuteTemplate(&content, string(mailRepoSecretScanningAlerts), data); err != nil {
+			return err
+		}
+
+		msg := NewMessage(u.EmailTo(), locale.TrString("mail.secret_scanning_alerts.subject", repo.FullName()), content.String())
+		msg.Info = fmt.Sprintf("UID: %d, secret scanning alerts of repository %d", u.ID, repo.ID)
+
+		SendAsync(msg)
+	}
+	return nil
+}
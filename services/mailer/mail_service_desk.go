@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	activities_model "forgejo.org/models/activities"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplServiceDeskReplyMail base.TplName = "issue/service_desk"
+)
+
+// mailServiceDeskReply sends a comment of a maintainer to the external reporter of a service desk issue.
+// The mail is threaded below the email which created the issue and replies to it are added as comments.
+func mailServiceDeskReply(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, comment *issues_model.Comment) error {
+	if setting.MailService == nil || !setting.IncomingEmail.Enabled || comment.Type != issues_model.CommentTypeComment || comment.IsInternal || doer.ID <= 0 {
+		return nil
+	}
+
+	deskIssue, err := issues_model.GetServiceDeskIssue(ctx, issue.ID)
+	if err != nil || deskIssue == nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	desk, err := issues_model.GetServiceDeskByRepoID(ctx, issue.RepoID)
+	if err != nil {
+		if issues_model.IsErrServiceDeskNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !desk.IsEnabled {
+		return nil
+	}
+
+	body, err := markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+		Links: markup.Links{
+			AbsolutePrefix: true,
+			Base:           issue.Repo.HTMLURL(),
+		},
+		Metas: issue.Repo.ComposeMetas(ctx),
+	}, comment.Content)
+	if err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale("")
+	subject := "Re: " + issue.Title
+	mailMeta := map[string]any{
+		"locale":   locale,
+		"Subject":  subject,
+		"Body":     body,
+		"Doer":     doer,
+		"Issue":    issue,
+		"Reporter": deskIssue,
+		"Language": locale.Language(),
+	}
+
+	var mailBody bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplServiceDeskReplyMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplServiceDeskReplyMail)+"/body", err)
+		return err
+	}
+
+	msg := NewMessageFrom(deskIssue.Email, fromDisplayName(doer), setting.MailService.FromEmail, subject, mailBody.String())
+	msg.Info = fmt.Sprintf("Subject: %s, service desk reply to issue %d", subject, issue.ID)
+	msg.ReplyTo = desk.ReplyAddress(deskIssue)
+
+	reference := createReference(issue, nil, activities_model.ActionType(0))
+	msg.SetHeader("Message-ID", createReference(issue, comment, activities_model.ActionType(0)))
+	if deskIssue.MessageID != "" {
+		msg.SetHeader("In-Reply-To", deskIssue.MessageID)
+		msg.SetHeader("References", deskIssue.MessageID, reference)
+	} else {
+		msg.SetHeader("In-Reply-To", reference)
+		msg.SetHeader("References", reference)
+	}
+	msg.SetHeader("X-Mailer", "Forgejo")
+
+	SendAsync(msg)
+
+	return nil
+}
@@ -9,8 +9,10 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	activities_model "forgejo.org/models/activities"
+	depgraph_model "forgejo.org/models/depgraph"
 	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	notify_service "forgejo.org/services/notify"
@@ -47,6 +49,10 @@ func (m *mailNotifier) CreateIssueComment(ctx context.Context, doer *user_model.
 	if err := MailParticipantsComment(ctx, comment, act, issue, mentions); err != nil {
 		log.Error("MailParticipantsComment: %v", err)
 	}
+
+	if err := mailServiceDeskReply(ctx, doer, issue, comment); err != nil {
+		log.Error("mailServiceDeskReply: %v", err)
+	}
 }
 
 func (m *mailNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
@@ -219,3 +225,15 @@ func (m *mailNotifier) ActionRunNowDone(ctx context.Context, run *actions_model.
 		log.Error("MailActionRunNowDone: %v", err)
 	}
 }
+
+func (m *mailNotifier) NewSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert) {
+	if err := MailSecretScanningAlerts(ctx, doer, repo, alerts); err != nil {
+		log.Error("MailSecretScanningAlerts: %v", err)
+	}
+}
+
+func (m *mailNotifier) NewDependencyAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*depgraph_model.Alert) {
+	if err := MailDependencyAlerts(ctx, repo, alerts); err != nil {
+		log.Error("MailDependencyAlerts: %v", err)
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	depgraph_model "forgejo.org/models/depgraph"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/repository"
@@ -38,6 +40,7 @@ type Notifier interface {
 	IssueClearLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue)
 	IssueChangeTitle(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string)
 	IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string)
+	IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64)
 	IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 		addedLabels, removedLabels []*issues_model.Label)
 
@@ -76,6 +79,11 @@ type Notifier interface {
 	PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 	PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 
+	NewSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert)
+	SecretScanningAlertStateChange(ctx context.Context, doer *user_model.User, alert *secretscan_model.Alert)
+
+	NewDependencyAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*depgraph_model.Alert)
+
 	ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository)
 
 	ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorStatus actions_model.Status, lastRun *actions_model.ActionRun)
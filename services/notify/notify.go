@@ -7,9 +7,11 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	depgraph_model "forgejo.org/models/depgraph"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/log"
@@ -241,6 +243,13 @@ func IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_mo
 	}
 }
 
+// IssueTransfer notifies that an issue has been moved to another repository to notifiers
+func IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+	for _, notifier := range notifiers {
+		notifier.IssueTransfer(ctx, doer, issue, oldRepo, oldIndex)
+	}
+}
+
 // IssueChangeLabels notifies change labels to notifiers
 func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 	addedLabels, removedLabels []*issues_model.Label,
@@ -369,6 +378,28 @@ func PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_mode
 	}
 }
 
+// NewSecretScanningAlerts notifies secrets found in a repository to notifiers, doer is the pusher
+// of the secrets or the user who started scanning the history of the repository
+func NewSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert) {
+	for _, notifier := range notifiers {
+		notifier.NewSecretScanningAlerts(ctx, doer, repo, alerts)
+	}
+}
+
+// SecretScanningAlertStateChange notifies a secret scanning alert resolved or reopened to notifiers
+func SecretScanningAlertStateChange(ctx context.Context, doer *user_model.User, alert *secretscan_model.Alert) {
+	for _, notifier := range notifiers {
+		notifier.SecretScanningAlertStateChange(ctx, doer, alert)
+	}
+}
+
+// NewDependencyAlerts notifies vulnerabilities found in the dependencies of a repository to notifiers
+func NewDependencyAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*depgraph_model.Alert) {
+	for _, notifier := range notifiers {
+		notifier.NewDependencyAlerts(ctx, repo, alerts)
+	}
+}
+
 // ChangeDefaultBranch notifies change default branch to notifiers
 func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
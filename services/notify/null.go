@@ -7,9 +7,11 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	depgraph_model "forgejo.org/models/depgraph"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/repository"
@@ -137,6 +139,10 @@ func (*NullNotifier) IssueChangeTitle(ctx context.Context, doer *user_model.User
 func (*NullNotifier) IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string) {
 }
 
+// IssueTransfer places a place holder function
+func (*NullNotifier) IssueTransfer(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRepo *repo_model.Repository, oldIndex int64) {
+}
+
 // IssueChangeLabels places a place holder function
 func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 	addedLabels, removedLabels []*issues_model.Label) {
@@ -209,6 +215,18 @@ func (*NullNotifier) PackageCreate(ctx context.Context, doer *user_model.User, p
 func (*NullNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
 }
 
+// NewSecretScanningAlerts places a place holder function
+func (*NullNotifier) NewSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert) {
+}
+
+// SecretScanningAlertStateChange places a place holder function
+func (*NullNotifier) SecretScanningAlertStateChange(ctx context.Context, doer *user_model.User, alert *secretscan_model.Alert) {
+}
+
+// NewDependencyAlerts places a place holder function
+func (*NullNotifier) NewDependencyAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*depgraph_model.Alert) {
+}
+
 // ChangeDefaultBranch places a place holder function
 func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 }
@@ -9,9 +9,11 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/storage"
 	"forgejo.org/modules/util"
@@ -52,6 +54,14 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
 
+	if _, err := db.DeleteByBean(ctx, &git_model.PushRule{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteByBean: %w", err)
+	}
+
+	if _, err := db.DeleteByBean(ctx, &secretscan_model.Setting{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteByBean: %w", err)
+	}
+
 	if err := commiter.Commit(); err != nil {
 		return err
 	}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"context"
+	"time"
+
+	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/mailer"
+)
+
+// ErrDoerWithoutTwoFactor is returned when a user without two-factor authentication
+// tries to require it from the members of an organization
+var ErrDoerWithoutTwoFactor = util.NewPermissionDeniedErrorf("the doer has not enabled two-factor authentication")
+
+// UpdateTwoFactorPolicy requires, or stops requiring, the members of the organization to enable
+// two-factor authentication. When it becomes required, the members who did not enroll are notified
+// by email that they lose access to the organization after the grace period.
+func UpdateTwoFactorPolicy(ctx context.Context, doer *user_model.User, org *org_model.Organization, required bool, graceDays int) error {
+	p, err := org_model.GetTwoFactorPolicy(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	before := map[string]any{"required": p.Required, "grace_until": p.GraceUntil}
+	wasRequired := p.Required
+
+	if required && !wasRequired {
+		// the owner would otherwise lock themselves out of the organization
+		hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, doer.ID)
+		if err != nil {
+			return err
+		}
+		if !hasTwoFactor {
+			return ErrDoerWithoutTwoFactor
+		}
+	}
+
+	p.Required = required
+	if required && !wasRequired {
+		p.GraceUntil = timeutil.TimeStamp(time.Now().AddDate(0, 0, graceDays).Unix())
+		p.ReminderSent = false
+	} else if !required {
+		p.GraceUntil = 0
+	}
+	if err := org_model.SaveTwoFactorPolicy(ctx, p); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTwoFactorPolicy, audit_service.UserTarget(org.AsUser()),
+		before, map[string]any{"required": p.Required, "grace_until": p.GraceUntil})
+
+	if !required || wasRequired {
+		return nil
+	}
+	members, err := org_model.GetTwoFactorNonCompliantMembers(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	for _, u := range members {
+		if err := mailer.SendOrgTwoFactorRequiredMail(u, org.AsUser(), p.GraceUntil); err != nil {
+			log.Error("SendOrgTwoFactorRequiredMail[%d]: %v", u.ID, err)
+		}
+	}
+	return nil
+}
+
+// AddTeamMember adds the user to the team and, if they just joined an organization requiring
+// two-factor authentication without having enabled it, notifies them of the requirement
+func AddTeamMember(ctx context.Context, team *org_model.Team, u *user_model.User) error {
+	wasMember, err := org_model.IsOrganizationMember(ctx, team.OrgID, u.ID)
+	if err != nil {
+		return err
+	}
+	if err := models.AddTeamMember(ctx, team, u.ID); err != nil {
+		return err
+	}
+	if wasMember {
+		return nil
+	}
+
+	p, err := org_model.GetTwoFactorPolicy(ctx, team.OrgID)
+	if err != nil || !p.Required {
+		return err
+	}
+	hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, u.ID)
+	if err != nil || hasTwoFactor {
+		return err
+	}
+	org, err := user_model.GetUserByID(ctx, team.OrgID)
+	if err != nil {
+		return err
+	}
+	if err := mailer.SendOrgTwoFactorRequiredMail(u, org, p.GraceUntil); err != nil {
+		log.Error("SendOrgTwoFactorRequiredMail[%d]: %v", u.ID, err)
+	}
+	return nil
+}
+
+// RemindTwoFactorPolicies reminds the members who did not enable two-factor authentication of the end
+// of the grace period of the organizations requiring it, the members of each organization are only reminded once
+func RemindTwoFactorPolicies(ctx context.Context) error {
+	if setting.OrgTwoFactorReminder <= 0 {
+		return nil
+	}
+	policies, err := org_model.FindTwoFactorPoliciesToRemind(ctx, timeutil.TimeStampNow().AddDuration(setting.OrgTwoFactorReminder))
+	if err != nil {
+		return err
+	}
+	for _, p := range policies {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("while reminding the two-factor authentication policies")
+		default:
+		}
+		org, err := user_model.GetUserByID(ctx, p.OrgID)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+		} else {
+			members, err := org_model.GetTwoFactorNonCompliantMembers(ctx, p.OrgID)
+			if err != nil {
+				return err
+			}
+			for _, u := range members {
+				if err := mailer.SendOrgTwoFactorReminderMail(u, org, p.GraceUntil); err != nil {
+					log.Error("SendOrgTwoFactorReminderMail[%d]: %v", u.ID, err)
+				}
+			}
+		}
+		p.ReminderSent = true
+		if err := org_model.SaveTwoFactorPolicy(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
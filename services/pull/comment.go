@@ -79,6 +79,14 @@ func CreatePushPullComment(ctx context.Context, pusher *user_model.User, pr *iss
 		return nil, err
 	}
 
+	if data.IsForcePush {
+		// the commits edited from the web have their own entry in the timeline
+		edited, err := issues_model.HasEditCommitsComment(ctx, pr.IssueID, oldCommitID, newCommitID)
+		if err != nil || edited {
+			return nil, err
+		}
+	}
+
 	ops.Issue = pr.Issue
 
 	dataJSON, err := json.Marshal(data)
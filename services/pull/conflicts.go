@@ -0,0 +1,291 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// ConflictChoice is how a conflicting hunk is resolved
+type ConflictChoice string
+
+const (
+	ConflictChoiceOurs   ConflictChoice = "ours"   // keep the changes of the head branch
+	ConflictChoiceTheirs ConflictChoice = "theirs" // keep the changes of the target branch
+	ConflictChoiceBoth   ConflictChoice = "both"   // keep both changes, the head branch first
+	ConflictChoiceCustom ConflictChoice = "custom" // use the content edited by the user
+)
+
+// conflictMarkerSize is long enough for the markers not to be confused with the content of the files
+const conflictMarkerSize = 32
+
+// ConflictHunk is a part of a conflicted file, either common to both sides or conflicting
+type ConflictHunk struct {
+	IsConflict bool
+	Index      int    // the position of the conflicting hunks among the conflicts of the file
+	Content    string // the content of the non conflicting hunks
+	Ours       string // the content of the head branch
+	Base       string // the content of the merge base
+	Theirs     string // the content of the target branch
+}
+
+// ConflictFile is a file with conflicts between the head and the target branches of a pull request
+type ConflictFile struct {
+	Path  string
+	Hunks []*ConflictHunk
+	// Unsupported is set for the conflicts that can not be resolved in the browser:
+	// binary or too large files, and files deleted on one side
+	Unsupported bool
+}
+
+// NumConflicts returns the number of conflicting hunks of the file
+func (f *ConflictFile) NumConflicts() int {
+	n := 0
+	for _, h := range f.Hunks {
+		if h.IsConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// Resolve returns the content of the file with its conflicting hunks resolved in order by the choices,
+// custom holds the content of the hunks resolved with ConflictChoiceCustom
+func (f *ConflictFile) Resolve(choices []ConflictChoice, custom []string) (string, error) {
+	if f.Unsupported {
+		return "", util.NewInvalidArgumentErrorf("the conflicts of %s can not be resolved in the browser", f.Path)
+	}
+
+	var sb strings.Builder
+	i := 0
+	for _, h := range f.Hunks {
+		if !h.IsConflict {
+			sb.WriteString(h.Content)
+			continue
+		}
+		if i >= len(choices) {
+			return "", util.NewInvalidArgumentErrorf("conflict %d of %s is not resolved", i+1, f.Path)
+		}
+		switch choices[i] {
+		case ConflictChoiceOurs:
+			sb.WriteString(h.Ours)
+		case ConflictChoiceTheirs:
+			sb.WriteString(h.Theirs)
+		case ConflictChoiceBoth:
+			sb.WriteString(h.Ours)
+			sb.WriteString(h.Theirs)
+		case ConflictChoiceCustom:
+			content := ""
+			if i < len(custom) {
+				content = custom[i]
+			}
+			// the browsers submit the text areas with CRLF line endings
+			if !strings.Contains(h.Ours+h.Theirs, "\r\n") {
+				content = strings.ReplaceAll(content, "\r\n", "\n")
+			}
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			sb.WriteString(content)
+		default:
+			return "", util.NewInvalidArgumentErrorf("conflict %d of %s is not resolved", i+1, f.Path)
+		}
+		i++
+	}
+	return sb.String(), nil
+}
+
+// PullConflicts are the conflicts between the head and the target branches of a pull request
+type PullConflicts struct {
+	HeadCommitID string
+	BaseCommitID string
+	Files        []*ConflictFile
+}
+
+// GetPullConflicts merges the target branch into the head branch of a pull request and returns the
+// conflicting hunks of each conflicted file, with the content of both sides and of the merge base
+func GetPullConflicts(ctx context.Context, pr *issues_model.PullRequest) (*PullConflicts, error) {
+	if !git.SupportGitMergeTree {
+		return nil, util.NewInvalidArgumentErrorf("the conflicts can not be resolved with this version of git")
+	}
+	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
+	if err != nil {
+		if !git.IsErrBranchNotExist(err) {
+			log.Error("CreateTemporaryRepoForPR %-v: %v", pr, err)
+		}
+		return nil, err
+	}
+	defer cancel()
+
+	gitRepo, err := git.OpenRepository(ctx, prCtx.tmpBasePath)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	conflicts := &PullConflicts{}
+	if conflicts.HeadCommitID, err = gitRepo.GetRefCommitID(git.BranchPrefix + trackingBranch); err != nil {
+		return nil, fmt.Errorf("GetRefCommitID: %w", err)
+	}
+	if conflicts.BaseCommitID, err = gitRepo.GetRefCommitID(git.BranchPrefix + baseBranch); err != nil {
+		return nil, fmt.Errorf("GetRefCommitID: %w", err)
+	}
+	mergeBase, _, err := git.NewCommand(ctx, "merge-base").AddDynamicArguments(conflicts.HeadCommitID, conflicts.BaseCommitID).RunStdString(&git.RunOpts{Dir: prCtx.tmpBasePath})
+	if err != nil {
+		return nil, fmt.Errorf("merge-base: %w", err)
+	}
+	mergeBase = strings.TrimSpace(mergeBase)
+
+	_, _, paths, err := MergeTree(ctx, gitRepo, mergeBase, conflicts.HeadCommitID, conflicts.BaseCommitID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]*git.Commit, 0, 3)
+	for _, id := range []string{conflicts.HeadCommitID, mergeBase, conflicts.BaseCommitID} {
+		commit, err := gitRepo.GetCommit(id)
+		if err != nil {
+			return nil, fmt.Errorf("GetCommit: %w", err)
+		}
+		commits = append(commits, commit)
+	}
+
+	for _, path := range paths {
+		file, err := getConflictFile(ctx, prCtx.tmpBasePath, commits, path)
+		if err != nil {
+			return nil, err
+		}
+		conflicts.Files = append(conflicts.Files, file)
+	}
+	return conflicts, nil
+}
+
+// getConflictFile merges the versions of the file in the head branch, the merge base and the target branch
+func getConflictFile(ctx context.Context, tmpBasePath string, commits []*git.Commit, path string) (*ConflictFile, error) {
+	file := &ConflictFile{Path: path}
+
+	names := []string{"ours", "base", "theirs"}
+	for i, commit := range commits {
+		content := ""
+		blob, err := commit.GetBlobByPath(path)
+		if err != nil && !git.IsErrNotExist(err) {
+			return nil, fmt.Errorf("GetBlobByPath: %w", err)
+		}
+		if blob != nil {
+			if blob.Size() > setting.UI.MaxDisplayFileSize {
+				file.Unsupported = true
+				return file, nil
+			}
+			if content, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize); err != nil {
+				return nil, fmt.Errorf("GetBlobContent: %w", err)
+			}
+			if strings.ContainsRune(content, 0) {
+				file.Unsupported = true
+				return file, nil
+			}
+		} else if i != 1 {
+			// the file was deleted on one side
+			file.Unsupported = true
+			return file, nil
+		}
+		if err := os.WriteFile(filepath.Join(tmpBasePath, "conflict-"+names[i]), []byte(content), 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := git.NewCommand(ctx, "merge-file", "--stdout", "--diff3").AddOptionFormat("--marker-size=%d", conflictMarkerSize)
+	for _, name := range names {
+		cmd.AddOptionValues("-L", name)
+	}
+	cmd.AddArguments("conflict-ours", "conflict-base", "conflict-theirs")
+	stdout, _, runErr := cmd.RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	// the exit code of git merge-file is the number of conflicts
+	var exitErr *exec.ExitError
+	if runErr != nil && !(errors.As(runErr, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128) {
+		return nil, fmt.Errorf("merge-file %s: %w", path, runErr)
+	}
+
+	var err error
+	if file.Hunks, err = parseConflictMarkers(stdout); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// parseConflictMarkers splits the output of git merge-file --diff3 into its common and conflicting hunks
+func parseConflictMarkers(content string) ([]*ConflictHunk, error) {
+	const (
+		inCommon = iota
+		inOurs
+		inBase
+		inTheirs
+	)
+	var (
+		startMarker  = strings.Repeat("<", conflictMarkerSize)
+		baseMarker   = strings.Repeat("|", conflictMarkerSize)
+		theirsMarker = strings.Repeat("=", conflictMarkerSize)
+		endMarker    = strings.Repeat(">", conflictMarkerSize)
+	)
+
+	hunks := make([]*ConflictHunk, 0, 5)
+	state := inCommon
+	conflicts := 0
+	var common, ours, base, theirs strings.Builder
+	flushCommon := func() {
+		if common.Len() > 0 {
+			hunks = append(hunks, &ConflictHunk{Content: common.String()})
+			common.Reset()
+		}
+	}
+	for _, line := range strings.SplitAfter(content, "\n") {
+		marker := strings.TrimRight(line, "\r\n")
+		switch {
+		case state == inCommon && strings.HasPrefix(marker, startMarker):
+			flushCommon()
+			state = inOurs
+		case state == inOurs && strings.HasPrefix(marker, baseMarker):
+			state = inBase
+		case (state == inOurs || state == inBase) && marker == theirsMarker:
+			state = inTheirs
+		case state == inTheirs && strings.HasPrefix(marker, endMarker):
+			hunks = append(hunks, &ConflictHunk{
+				IsConflict: true,
+				Index:      conflicts,
+				Ours:       ours.String(),
+				Base:       base.String(),
+				Theirs:     theirs.String(),
+			})
+			conflicts++
+			ours.Reset()
+			base.Reset()
+			theirs.Reset()
+			state = inCommon
+		case state == inOurs:
+			ours.WriteString(line)
+		case state == inBase:
+			base.WriteString(line)
+		case state == inTheirs:
+			theirs.WriteString(line)
+		default:
+			common.WriteString(line)
+		}
+	}
+	if state != inCommon {
+		return nil, errors.New("unterminated conflict")
+	}
+	flushCommon()
+	return hunks, nil
+}
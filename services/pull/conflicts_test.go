@@ -0,0 +1,56 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflictMarkers(t *testing.T) {
+	marker := func(c string, label string) string {
+		return strings.Repeat(c, conflictMarkerSize) + label + "\n"
+	}
+	content := "a\nb\n" +
+		marker("<", " ours") + "ours\n" +
+		marker("|", " base") + "base\n" +
+		marker("=", "") + "theirs\n" +
+		marker(">", " theirs") +
+		"c\n" +
+		marker("<", " ours") + "x\n" +
+		marker("|", " base") +
+		marker("=", "") + "y\n" +
+		marker(">", " theirs")
+
+	hunks, err := parseConflictMarkers(content)
+	require.NoError(t, err)
+	assert.Equal(t, []*ConflictHunk{
+		{Content: "a\nb\n"},
+		{IsConflict: true, Index: 0, Ours: "ours\n", Base: "base\n", Theirs: "theirs\n"},
+		{Content: "c\n"},
+		{IsConflict: true, Index: 1, Ours: "x\n", Theirs: "y\n"},
+	}, hunks)
+
+	file := &ConflictFile{Path: "file", Hunks: hunks}
+	assert.Equal(t, 2, file.NumConflicts())
+
+	resolved, err := file.Resolve([]ConflictChoice{ConflictChoiceOurs, ConflictChoiceTheirs}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nours\nc\ny\n", resolved)
+
+	resolved, err = file.Resolve([]ConflictChoice{ConflictChoiceBoth, ConflictChoiceCustom}, []string{"", "z\r\nw"})
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nours\ntheirs\nc\nz\nw\n", resolved)
+
+	_, err = file.Resolve([]ConflictChoice{ConflictChoiceOurs}, nil)
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	_, err = parseConflictMarkers("a\n" + marker("<", " ours") + "b\n")
+	require.Error(t, err)
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/util"
+)
+
+// EditCommitsAction is what is done with a commit of a pull request when editing its commits
+type EditCommitsAction string
+
+const (
+	EditCommitsPick   EditCommitsAction = "pick"   // keep the commit
+	EditCommitsReword EditCommitsAction = "reword" // keep the commit with a new message
+	EditCommitsSquash EditCommitsAction = "squash" // meld the commit into the previous one, with a new message
+	EditCommitsFixup  EditCommitsAction = "fixup"  // meld the commit into the previous one, keeping its message
+	EditCommitsDrop   EditCommitsAction = "drop"   // remove the commit
+)
+
+// EditCommitsStep is a commit of a pull request and what to do with it, the steps are applied in order
+type EditCommitsStep struct {
+	CommitID string
+	Action   EditCommitsAction
+	// Message is the new message of the reworded commits, and of the commits squashed into
+	Message string
+}
+
+// GetEditableCommits returns the head commit of the pull request and the commits of its head branch
+// that are not in its target branch, oldest first
+func GetEditableCommits(ctx context.Context, pr *issues_model.PullRequest) (string, []*git.Commit, error) {
+	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
+	if err != nil {
+		if !git.IsErrBranchNotExist(err) {
+			log.Error("CreateTemporaryRepoForPR %-v: %v", pr, err)
+		}
+		return "", nil, err
+	}
+	defer cancel()
+
+	gitRepo, err := git.OpenRepository(ctx, prCtx.tmpBasePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(git.BranchPrefix + trackingBranch)
+	if err != nil {
+		return "", nil, fmt.Errorf("GetRefCommitID: %w", err)
+	}
+	commitIDs, err := listEditableCommitIDs(ctx, prCtx.tmpBasePath, false)
+	if err != nil {
+		return "", nil, err
+	}
+	commits := make([]*git.Commit, 0, len(commitIDs))
+	for _, id := range commitIDs {
+		commit, err := gitRepo.GetCommit(id)
+		if err != nil {
+			return "", nil, fmt.Errorf("GetCommit: %w", err)
+		}
+		commits = append(commits, commit)
+	}
+	return headCommitID, commits, nil
+}
+
+// listEditableCommitIDs returns the commits of the tracking branch that are not in the base branch, oldest first,
+// or only their merge commits
+func listEditableCommitIDs(ctx context.Context, tmpBasePath string, mergesOnly bool) ([]string, error) {
+	cmd := git.NewCommand(ctx, "rev-list", "--reverse")
+	if mergesOnly {
+		cmd.AddArguments("--merges")
+	}
+	stdout, _, err := cmd.AddDynamicArguments(baseBranch + ".." + trackingBranch).RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	if err != nil {
+		return nil, fmt.Errorf("rev-list: %w", err)
+	}
+	return strings.Fields(stdout), nil
+}
+
+// validateEditCommitsSteps checks that the steps edit each commit once and can be applied in order
+func validateEditCommitsSteps(commitIDs []string, steps []*EditCommitsStep) error {
+	if len(steps) != len(commitIDs) {
+		return util.NewInvalidArgumentErrorf("each commit of the pull request must be edited once")
+	}
+	remaining := container.SetOf(commitIDs...)
+	kept := false
+	for _, step := range steps {
+		if !remaining.Remove(step.CommitID) {
+			return util.NewInvalidArgumentErrorf("commit %s is not a commit of the pull request or is edited twice", step.CommitID)
+		}
+		switch step.Action {
+		case EditCommitsPick, EditCommitsReword:
+			kept = true
+		case EditCommitsSquash, EditCommitsFixup:
+			if !kept {
+				return util.NewInvalidArgumentErrorf("commit %s can not be melded, no commit is kept before it", step.CommitID)
+			}
+		case EditCommitsDrop:
+		default:
+			return util.NewInvalidArgumentErrorf("unknown action %q for commit %s", step.Action, step.CommitID)
+		}
+		if (step.Action == EditCommitsReword || step.Action == EditCommitsSquash) && strings.TrimSpace(step.Message) == "" {
+			return util.NewInvalidArgumentErrorf("the message of commit %s can not be empty", step.CommitID)
+		}
+	}
+	if !kept {
+		return util.NewInvalidArgumentErrorf("at least one commit must be kept")
+	}
+	return nil
+}
+
+// EditCommits rewrites the commits of the head branch of a pull request by applying the steps in order on top
+// of its merge base with the target branch, then force-pushes the result. The previous head is kept in a
+// backup reference of the base repository, and the rewrite is recorded in the timeline of the pull request.
+// The backup references are kept as long as the pull request, they are removed when it is deleted.
+func EditCommits(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string, steps []*EditCommitsStep) (string, error) {
+	if pr.Flow == issues_model.PullRequestFlowAGit {
+		return "", util.NewInvalidArgumentErrorf("the commits of agit flow pull requests can not be edited")
+	}
+	if expectedHeadCommitID == "" {
+		return "", util.NewInvalidArgumentErrorf("the head commit of the pull request is required")
+	}
+
+	pullWorkingPool.CheckIn(fmt.Sprint(pr.ID))
+	defer pullWorkingPool.CheckOut(fmt.Sprint(pr.ID))
+
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", err
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return "", err
+	}
+	if pr.HeadRepo == nil {
+		return "", repo_model.ErrRepoNotExist{ID: pr.HeadRepoID}
+	}
+
+	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, pr, doer, expectedHeadCommitID)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+	// the edited commits keep their authors, only the committer is the doer
+	mergeCtx.env = slices.DeleteFunc(slices.Clone(mergeCtx.env), func(e string) bool {
+		return strings.HasPrefix(e, "GIT_AUTHOR_")
+	})
+
+	merges, err := listEditableCommitIDs(mergeCtx, mergeCtx.tmpBasePath, true)
+	if err != nil {
+		return "", err
+	}
+	if len(merges) > 0 {
+		return "", util.NewInvalidArgumentErrorf("the commits of pull requests with merge commits can not be edited")
+	}
+	commitIDs, err := listEditableCommitIDs(mergeCtx, mergeCtx.tmpBasePath, false)
+	if err != nil {
+		return "", err
+	}
+	if err := validateEditCommitsSteps(commitIDs, steps); err != nil {
+		return "", err
+	}
+
+	mergeBase, _, err := git.NewCommand(ctx, "merge-base").AddDashesAndList(baseBranch, trackingBranch).RunStdString(&git.RunOpts{Dir: mergeCtx.tmpBasePath})
+	if err != nil {
+		return "", fmt.Errorf("merge-base: %w", err)
+	}
+	if err := git.NewCommand(ctx, "checkout", "-b").AddDynamicArguments(stagingBranch, strings.TrimSpace(mergeBase)).
+		Run(mergeCtx.RunOpts()); err != nil {
+		return "", fmt.Errorf("unable to git checkout the merge base as staging in temp repo for %v: %w\n%s\n%s", pr, err, mergeCtx.outbuf.String(), mergeCtx.errbuf.String())
+	}
+	for _, step := range steps {
+		if err := applyEditCommitsStep(mergeCtx, step); err != nil {
+			return "", err
+		}
+	}
+
+	newHeadCommitID, err := git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("GetFullCommitID: %w", err)
+	}
+	if newHeadCommitID == expectedHeadCommitID {
+		return newHeadCommitID, nil
+	}
+
+	// Keep the previous head in the base repository until the pull request is deleted, it can be restored or fetched from there.
+	// The reference is named after the previous head so that the backups of successive edits never overwrite each other.
+	backupRef := fmt.Sprintf("%s%d/backup/%s", git.PullPrefix, pr.Index, expectedHeadCommitID)
+	if err := git.Push(ctx, mergeCtx.tmpBasePath, git.PushOptions{
+		Remote: "origin",
+		Branch: git.BranchPrefix + trackingBranch + ":" + backupRef,
+		// Use InternalPushingEnvironment here because we know that pre-receive and post-receive do not run on a refs/pulls/...
+		Env: repo_module.InternalPushingEnvironment(doer, pr.BaseRepo),
+	}); err != nil {
+		return "", fmt.Errorf("unable to push the backup of %v to %s: %w", pr, backupRef, err)
+	}
+
+	// The timeline entry is created before the push, the force-push comment is skipped for the edited commits
+	comment, err := issues_model.CreateEditCommitsComment(ctx, pr, doer, expectedHeadCommitID, newHeadCommitID, backupRef)
+	if err != nil {
+		return "", err
+	}
+	if err := pushStagingToHead(mergeCtx, pr, doer, expectedHeadCommitID); err != nil {
+		if err := issues_model.DeleteComment(ctx, comment); err != nil {
+			log.Error("DeleteComment[%d]: %v", comment.ID, err)
+		}
+		return "", err
+	}
+	return newHeadCommitID, nil
+}
+
+// applyEditCommitsStep applies a step on top of the staging branch
+func applyEditCommitsStep(ctx *mergeContext, step *EditCommitsStep) error {
+	if step.Action == EditCommitsDrop {
+		return nil
+	}
+
+	cherryPick := git.NewCommand(ctx, "cherry-pick", "--allow-empty", "--keep-redundant-commits")
+	if step.Action == EditCommitsSquash || step.Action == EditCommitsFixup {
+		cherryPick = git.NewCommand(ctx, "cherry-pick", "--no-commit")
+	} else {
+		addSignArgument(ctx, cherryPick)
+	}
+	if err := cherryPick.AddDynamicArguments(step.CommitID).Run(ctx.RunOpts()); err != nil {
+		if _, statErr := os.Stat(filepath.Join(ctx.tmpBasePath, ".git", "CHERRY_PICK_HEAD")); statErr == nil || strings.Contains(ctx.outbuf.String(), "CONFLICT") {
+			log.Debug("EditCommitsConflict %-v at %s: %v\n%s\n%s", ctx.pr, step.CommitID, err, ctx.outbuf.String(), ctx.errbuf.String())
+			return models.ErrRebaseConflicts{
+				Style:     repo_model.MergeStyleRebaseUpdate,
+				CommitSHA: step.CommitID,
+				StdOut:    ctx.outbuf.String(),
+				StdErr:    ctx.errbuf.String(),
+				Err:       err,
+			}
+		}
+		return fmt.Errorf("git cherry-pick %s for %v: %w\n%s\n%s", step.CommitID, ctx.pr, err, ctx.outbuf.String(), ctx.errbuf.String())
+	}
+
+	var amend *git.Command
+	switch step.Action {
+	case EditCommitsReword:
+		amend = git.NewCommand(ctx, "commit", "--amend", "--only", "--allow-empty").AddOptionFormat("--message=%s", step.Message)
+	case EditCommitsSquash:
+		amend = git.NewCommand(ctx, "commit", "--amend", "--allow-empty").AddOptionFormat("--message=%s", step.Message)
+	case EditCommitsFixup:
+		amend = git.NewCommand(ctx, "commit", "--amend", "--allow-empty", "--no-edit")
+	default:
+		return nil
+	}
+	addSignArgument(ctx, amend)
+	if err := amend.Run(ctx.RunOpts()); err != nil {
+		return fmt.Errorf("git commit --amend %s for %v: %w\n%s\n%s", step.CommitID, ctx.pr, err, ctx.outbuf.String(), ctx.errbuf.String())
+	}
+	return nil
+}
+
+func addSignArgument(ctx *mergeContext, cmd *git.Command) {
+	if ctx.signKeyID == "" {
+		cmd.AddArguments("--no-gpg-sign")
+	} else {
+		cmd.AddOptionFormat("-S%s", ctx.signKeyID)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEditCommitsSteps(t *testing.T) {
+	commitIDs := []string{"a", "b", "c"}
+	step := func(id string, action EditCommitsAction, message string) *EditCommitsStep {
+		return &EditCommitsStep{CommitID: id, Action: action, Message: message}
+	}
+
+	require.NoError(t, validateEditCommitsSteps(commitIDs, []*EditCommitsStep{
+		step("c", EditCommitsPick, ""),
+		step("a", EditCommitsSquash, "a and c"),
+		step("b", EditCommitsDrop, ""),
+	}))
+	require.NoError(t, validateEditCommitsSteps(commitIDs, []*EditCommitsStep{
+		step("a", EditCommitsDrop, ""),
+		step("b", EditCommitsReword, "b"),
+		step("c", EditCommitsFixup, ""),
+	}))
+
+	for name, steps := range map[string][]*EditCommitsStep{
+		"missing commit":  {step("a", EditCommitsPick, ""), step("b", EditCommitsPick, "")},
+		"repeated commit": {step("a", EditCommitsPick, ""), step("a", EditCommitsPick, ""), step("c", EditCommitsPick, "")},
+		"unknown commit":  {step("a", EditCommitsPick, ""), step("b", EditCommitsPick, ""), step("d", EditCommitsPick, "")},
+		"unknown action":  {step("a", "edit", ""), step("b", EditCommitsPick, ""), step("c", EditCommitsPick, "")},
+		"squash first":    {step("a", EditCommitsDrop, ""), step("b", EditCommitsSquash, "b"), step("c", EditCommitsPick, "")},
+		"empty message":   {step("a", EditCommitsReword, " "), step("b", EditCommitsPick, ""), step("c", EditCommitsPick, "")},
+		"all dropped":     {step("a", EditCommitsDrop, ""), step("b", EditCommitsDrop, ""), step("c", EditCommitsDrop, "")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, validateEditCommitsSteps(commitIDs, steps), util.ErrInvalidArgument)
+		})
+	}
+}
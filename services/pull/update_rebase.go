@@ -49,9 +49,15 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 		}
 	}
 
+	return pushStagingToHead(mergeCtx, pr, doer, "")
+}
+
+// pushStagingToHead force-pushes the staging branch to the head branch of the pull request, if
+// expectedHeadCommitID is set the push is refused if the head branch moved from it
+func pushStagingToHead(mergeCtx *mergeContext, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string) error {
 	// Now determine who the pushing author should be
 	var headUser *user_model.User
-	if err := pr.HeadRepo.LoadOwner(ctx); err != nil {
+	if err := pr.HeadRepo.LoadOwner(mergeCtx); err != nil {
 		if !user_model.IsErrUserNotExist(err) {
 			log.Error("Can't find user: %d for head repository in %-v - %v", pr.HeadRepo.OwnerID, pr, err)
 			return err
@@ -62,8 +68,11 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 		headUser = pr.HeadRepo.Owner
 	}
 
-	pushCmd := git.NewCommand(ctx, "push", "-f", "head_repo").
-		AddDynamicArguments(stagingBranch + ":" + git.BranchPrefix + pr.HeadBranch)
+	pushCmd := git.NewCommand(mergeCtx, "push", "-f", "head_repo")
+	if expectedHeadCommitID != "" {
+		pushCmd.AddOptionFormat("--force-with-lease=%s:%s", git.BranchPrefix+pr.HeadBranch, expectedHeadCommitID)
+	}
+	pushCmd.AddDynamicArguments(stagingBranch + ":" + git.BranchPrefix + pr.HeadBranch)
 
 	// Push back to the head repository.
 	// TODO: this cause an api call to "/api/internal/hook/post-receive/...",
@@ -83,7 +92,7 @@ func updateHeadByRebaseOnToBase(ctx context.Context, pr *issues_model.PullReques
 		Stdout: mergeCtx.outbuf,
 		Stderr: mergeCtx.errbuf,
 	}); err != nil {
-		if strings.Contains(mergeCtx.errbuf.String(), "non-fast-forward") {
+		if strings.Contains(mergeCtx.errbuf.String(), "non-fast-forward") || strings.Contains(mergeCtx.errbuf.String(), "stale info") {
 			return &git.ErrPushOutOfDate{
 				StdOut: mergeCtx.outbuf.String(),
 				StdErr: mergeCtx.errbuf.String(),
@@ -0,0 +1,145 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pushrule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	git_model "forgejo.org/models/git"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/git"
+)
+
+// maxViolations is the number of violations reported to the pusher, the push is rejected at the first one
+// but reporting a few more saves rounds of fixing and pushing again
+const maxViolations = 10
+
+// Check checks the commits of a push updating a reference to newCommitID against the push rules
+// of the repository and of its owner. The commits already reachable from the references of
+// the repository are not checked again, and only the git.MaxPushedCommits most recent ones are checked.
+// It returns the violations, none if the push is allowed.
+func Check(ctx context.Context, repo *repo_model.Repository, env []string, newCommitID string) ([]string, error) {
+	return check(ctx, repo, env, newCommitID, git.GetPushedCommits)
+}
+
+// CheckMerge checks the commits of a pull request merged by a push updating a branch to newCommitID, like Check.
+// Only the commits already reachable from the branches and tags of the repository are not checked again, the
+// commits of pull requests from forks were pushed to the fork and are only in the pull request references.
+func CheckMerge(ctx context.Context, repo *repo_model.Repository, env []string, newCommitID string) ([]string, error) {
+	return check(ctx, repo, env, newCommitID, git.GetMergedCommits)
+}
+
+func check(ctx context.Context, repo *repo_model.Repository, env []string, newCommitID string,
+	getCommits func(ctx context.Context, repoPath string, env []string, newCommitID string) ([]*git.PushedCommit, error),
+) ([]string, error) {
+	rules, err := git_model.GetPushRulesForRepo(ctx, repo)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	commits, err := getCommits(ctx, repo.RepoPath(), env, newCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("get the pushed commits: %w", err)
+	}
+
+	var violations []string
+	for _, commit := range commits {
+		violations = append(violations, checkCommit(rules, commit)...)
+	}
+
+	checkFiles := false
+	for _, rule := range rules {
+		checkFiles = checkFiles || rule.MaxFileSize > 0 || rule.ForbiddenPaths != "" || rule.HasSecretPatterns()
+	}
+	if checkFiles {
+		fileViolations, err := checkPushedFiles(ctx, repo, env, rules, commits)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, fileViolations...)
+	}
+
+	if len(violations) > maxViolations {
+		violations = append(violations[:maxViolations], fmt.Sprintf("and %d more", len(violations)-maxViolations))
+	}
+	return violations, nil
+}
+
+func checkCommit(rules []*git_model.PushRule, commit *git.PushedCommit) []string {
+	var violations []string
+	sha := base.ShortSha(commit.ID)
+	for _, rule := range rules {
+		if !rule.MatchAuthorEmail(commit.AuthorEmail) {
+			violations = append(violations, fmt.Sprintf("commit %s: author email %s does not match %s", sha, commit.AuthorEmail, rule.AuthorEmailPattern))
+		}
+		if !rule.MatchCommitMessage(commit.Message) {
+			violations = append(violations, fmt.Sprintf("commit %s: commit message does not match %s", sha, rule.CommitMessagePattern))
+		}
+		// merge commits only join commits which are signed off themselves
+		if rule.RequireSignOff && len(commit.ParentIDs) < 2 && !git_model.IsSignedOff(commit.Message, commit.AuthorEmail) {
+			violations = append(violations, fmt.Sprintf("commit %s: commit message is not signed off by its author, add \"Signed-off-by: Name <%s>\" with git commit --signoff", sha, commit.AuthorEmail))
+		}
+	}
+	return violations
+}
+
+func checkPushedFiles(ctx context.Context, repo *repo_model.Repository, env []string, rules []*git_model.PushRule, commits []*git.PushedCommit) ([]string, error) {
+	blobs, err := git.GetPushedBlobs(ctx, repo.RepoPath(), env, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	checked := make(container.Set[string])
+	for _, b := range blobs {
+		for _, rule := range rules {
+			if pattern := rule.ForbiddenPath(b.Path); pattern != "" {
+				violations = append(violations, fmt.Sprintf("%s is forbidden by %s", formatBlob(b), pattern))
+			}
+		}
+		// the sizes are checked once, at the first file with the content
+		if !checked.Add(b.BlobID) {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.MaxFileSize > 0 && b.Size > rule.MaxFileSize {
+				violations = append(violations, fmt.Sprintf("%s is larger than %s", formatBlob(b), base.FileSize(rule.MaxFileSize)))
+			}
+		}
+	}
+
+	var secretRules []*git_model.PushRule
+	for _, rule := range rules {
+		if rule.HasSecretPatterns() {
+			secretRules = append(secretRules, rule)
+		}
+	}
+	if len(secretRules) == 0 {
+		return violations, nil
+	}
+	if err := git.ReadPushedBlobs(ctx, repo.RepoPath(), env, blobs, func(b *git.PushedBlob, content []byte) error {
+		for _, rule := range secretRules {
+			if pattern := rule.FindSecret(content); pattern != "" {
+				violations = append(violations, fmt.Sprintf("%s matches the secret pattern %s", formatBlob(b), pattern))
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("ReadPushedBlobs: %w", err)
+	}
+	return violations, nil
+}
+
+func formatBlob(b *git.PushedBlob) string {
+	return fmt.Sprintf("commit %s: file %s", base.ShortSha(b.CommitID), b.Path)
+}
+
+// FormatViolations formats the violations of the push rules as the message shown to the pusher
+func FormatViolations(violations []string) string {
+	return "push rejected by the push rules:\n  " + strings.Join(violations, "\n  ")
+}
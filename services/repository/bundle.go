@@ -0,0 +1,251 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// RepoBundle is a bundle of all the branches and tags of a repository
+type RepoBundle struct {
+	RepoID      int64
+	CreatedUnix timeutil.TimeStamp
+}
+
+// RelativePath returns the path of the bundle in the bundle storage
+func (b *RepoBundle) RelativePath() string {
+	return fmt.Sprintf("%d/%d.bundle", b.RepoID, b.CreatedUnix)
+}
+
+// Name returns the file name of the bundle, which changes with each generation so it can be cached forever
+func (b *RepoBundle) Name() string {
+	return path.Base(b.RelativePath())
+}
+
+// listRepoBundles returns the bundles of a repository, the most recent first
+func listRepoBundles(repoID int64) ([]*RepoBundle, error) {
+	bundles := make([]*RepoBundle, 0, 2)
+	err := storage.RepoBundles.IterateObjects(strconv.FormatInt(repoID, 10), func(p string, _ storage.Object) error {
+		bundle := parseRepoBundleName(repoID, path.Base(p))
+		if bundle == nil {
+			log.Warn("Unexpected file %q in the bundle storage", p)
+			return nil
+		}
+		i := 0
+		for i < len(bundles) && bundles[i].CreatedUnix > bundle.CreatedUnix {
+			i++
+		}
+		bundles = append(bundles[:i], append([]*RepoBundle{bundle}, bundles[i:]...)...)
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// parseRepoBundleName returns the bundle of a repository with the given file name, nil if it is not a bundle name
+func parseRepoBundleName(repoID int64, name string) *RepoBundle {
+	timestamp, ok := strings.CutSuffix(name, ".bundle")
+	if !ok {
+		return nil
+	}
+	created, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &RepoBundle{RepoID: repoID, CreatedUnix: timeutil.TimeStamp(created)}
+}
+
+// GetRepoBundle returns the most recent bundle of a repository, as recorded in its git configuration
+// when it was generated
+func GetRepoBundle(ctx context.Context, repo *repo_model.Repository) (*RepoBundle, error) {
+	name, err := git.GetBundleName(ctx, repo.RepoPath())
+	if err != nil {
+		return nil, err
+	}
+	bundle := parseRepoBundleName(repo.ID, name)
+	if bundle == nil {
+		return nil, util.NewNotExistErrorf("repository %d has no bundle", repo.ID)
+	}
+	return bundle, nil
+}
+
+// GetRepoBundleByName returns the bundle of a repository with the given file name
+func GetRepoBundleByName(repoID int64, name string) (*RepoBundle, error) {
+	bundle := parseRepoBundleName(repoID, name)
+	if bundle == nil || bundle.Name() != name {
+		return nil, util.NewNotExistErrorf("repository %d has no bundle %s", repoID, name)
+	}
+	if _, err := storage.RepoBundles.Stat(bundle.RelativePath()); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, util.NewNotExistErrorf("repository %d has no bundle %s", repoID, name)
+		}
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// isBundleAdvertised returns whether the bundle of the repository is advertised with bundle-uri,
+// which clients download without credentials so only the bundles of public repositories are
+func isBundleAdvertised(ctx context.Context, repo *repo_model.Repository) (bool, error) {
+	if !git.SupportBundleURI || repo.IsPrivate {
+		return false, nil
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return false, err
+	}
+	return repo.Owner.Visibility == api.VisibleTypePublic, nil
+}
+
+// advertiseRepoBundle advertises the bundle to the clients cloning the repository if allowed, or stops advertising any
+func advertiseRepoBundle(ctx context.Context, repo *repo_model.Repository, bundle *RepoBundle) error {
+	uri := ""
+	advertised, err := isBundleAdvertised(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if advertised {
+		uri = repo.HTMLURL() + "/bundle/" + bundle.Name()
+	}
+	current, err := git.GetBundleURI(ctx, repo.RepoPath())
+	if err != nil || current == uri {
+		return err
+	}
+	return git.SetBundleURI(ctx, repo.RepoPath(), uri)
+}
+
+// GenerateRepoBundle generates a new bundle of the repository, advertises it and deletes the previous ones
+func GenerateRepoBundle(ctx context.Context, repo *repo_model.Repository) error {
+	if !setting.RepoBundle.Enabled {
+		return nil
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	bundle := &RepoBundle{RepoID: repo.ID, CreatedUnix: timeutil.TimeStampNow()}
+	rd, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := gitRepo.CreateFullBundle(ctx, w)
+		_ = w.CloseWithError(err)
+		done <- err
+	}()
+	_, saveErr := storage.RepoBundles.Save(bundle.RelativePath(), rd, -1)
+	_ = rd.CloseWithError(saveErr)
+	if err := <-done; err != nil {
+		_ = storage.RepoBundles.Delete(bundle.RelativePath())
+		return fmt.Errorf("CreateFullBundle: %w", err)
+	}
+	if saveErr != nil {
+		return fmt.Errorf("unable to write bundle: %w", saveErr)
+	}
+	if err := git.SetBundleName(ctx, repo.RepoPath(), bundle.Name()); err != nil {
+		return err
+	}
+
+	if err := advertiseRepoBundle(ctx, repo, bundle); err != nil {
+		return err
+	}
+
+	bundles, err := listRepoBundles(repo.ID)
+	if err != nil {
+		return err
+	}
+	// keep the previous bundle for the clients which were told about it just before
+	for i := 2; i < len(bundles); i++ {
+		system_model.RemoveStorageWithNotice(ctx, storage.RepoBundles, "Delete repo bundle file", bundles[i].RelativePath())
+	}
+	return nil
+}
+
+// DeleteRepoBundles stops advertising the bundle of a repository and deletes its bundles
+func DeleteRepoBundles(ctx context.Context, repo *repo_model.Repository) error {
+	bundles, err := listRepoBundles(repo.ID)
+	if err != nil {
+		return err
+	}
+	if err := git.SetBundleURI(ctx, repo.RepoPath(), ""); err != nil {
+		return err
+	}
+	if err := git.SetBundleName(ctx, repo.RepoPath(), ""); err != nil {
+		return err
+	}
+	for _, bundle := range bundles {
+		system_model.RemoveStorageWithNotice(ctx, storage.RepoBundles, "Delete repo bundle file", bundle.RelativePath())
+	}
+	return nil
+}
+
+// updateRepoBundle regenerates the bundle of the repository if it is big enough and was pushed to since its last bundle
+func updateRepoBundle(ctx context.Context, repo *repo_model.Repository) error {
+	bundle, err := GetRepoBundle(ctx, repo)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return err
+	}
+	if repo.GitSize < setting.RepoBundle.MinRepoSize {
+		if bundle != nil {
+			return DeleteRepoBundles(ctx, repo)
+		}
+		return nil
+	}
+	if bundle != nil && bundle.CreatedUnix >= repo.UpdatedUnix {
+		// the visibility or the name of the repository may have changed
+		return advertiseRepoBundle(ctx, repo, bundle)
+	}
+	return GenerateRepoBundle(ctx, repo)
+}
+
+// UpdateRepoBundles regenerates the outdated bundles of all the repositories
+func UpdateRepoBundles(ctx context.Context) error {
+	log.Trace("Doing: UpdateRepoBundles")
+
+	if err := db.Iterate(
+		ctx,
+		builder.Eq{"is_empty": false},
+		func(ctx context.Context, repo *repo_model.Repository) error {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before bundling %s", repo.FullName())
+			default:
+			}
+			if err := updateRepoBundle(ctx, repo); err != nil {
+				log.Error("Unable to update the bundle of %-v: %v", repo, err)
+				if err := system_model.CreateRepositoryNotice("Unable to update the bundle of %s: %v", repo.FullName(), err); err != nil {
+					log.Error("CreateRepositoryNotice: %v", err)
+				}
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	log.Trace("Finished: UpdateRepoBundles")
+	return nil
+}
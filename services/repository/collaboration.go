@@ -8,13 +8,17 @@ import (
 	"context"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	audit_service "forgejo.org/services/audit"
 )
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, uid int64) (err error) {
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, uid int64) (err error) {
 	collaboration := &repo_model.Collaboration{
 		RepoID: repo.ID,
 		UserID: uid,
@@ -48,5 +52,11 @@ func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, uid i
 		return err
 	}
 
+	if u, err := user_model.GetPossibleUserByID(ctx, uid); err != nil {
+		log.Error("GetPossibleUserByID[%d]: %v", uid, err)
+	} else {
+		audit_service.Record(ctx, doer, audit_model.ActionCollaboratorRemove, audit_service.CollaboratorTarget(repo, u), nil, nil)
+	}
+
 	return committer.Commit()
 }
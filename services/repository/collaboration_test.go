@@ -6,9 +6,12 @@ package repository
 import (
 	"testing"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
 
 	"github.com/stretchr/testify/require"
 )
@@ -16,12 +19,15 @@ import (
 func TestRepository_DeleteCollaboration(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 
+	defer test.MockVariableValue(&setting.Audit.Enabled, true)()
+
 	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
 	require.NoError(t, repo.LoadOwner(db.DefaultContext))
-	require.NoError(t, DeleteCollaboration(db.DefaultContext, repo, 4))
+	require.NoError(t, DeleteCollaboration(db.DefaultContext, repo.Owner, repo, 4))
 	unittest.AssertNotExistsBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: 4})
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionCollaboratorRemove, RepoID: repo.ID, ActorID: repo.OwnerID})
 
-	require.NoError(t, DeleteCollaboration(db.DefaultContext, repo, 4))
+	require.NoError(t, DeleteCollaboration(db.DefaultContext, repo.Owner, repo, 4))
 	unittest.AssertNotExistsBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: 4})
 
 	unittest.CheckConsistencyFor(t, &repo_model.Repository{ID: repo.ID})
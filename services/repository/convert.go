@@ -0,0 +1,372 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ConvertOptions are the formats a repository is converted to, empty ones are left unchanged
+type ConvertOptions struct {
+	RepoID           int64
+	ObjectFormatName string
+	RefFormat        git.RefFormat
+}
+
+// repoConvertQueue represents a queue to convert repositories in the background
+var repoConvertQueue *queue.WorkerPoolQueue[*ConvertOptions]
+
+func handlerRepoConvert(items ...*ConvertOptions) []*ConvertOptions {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, opts := range items {
+		repo, err := repo_model.GetRepositoryByID(ctx, opts.RepoID)
+		if err != nil {
+			log.Error("GetRepositoryByID [%d]: %v", opts.RepoID, err)
+			continue
+		}
+		if err := ConvertRepository(ctx, repo, opts); err != nil {
+			log.Error("Unable to convert %-v: %v", repo, err)
+			if err := system_model.CreateRepositoryNotice("Unable to convert %s: %v", repo.FullName(), err); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func initRepoConvertQueue(ctx context.Context) error {
+	repoConvertQueue = queue.CreateUniqueQueue(ctx, "repo_convert", handlerRepoConvert)
+	if repoConvertQueue == nil {
+		return errors.New("unable to create repo_convert queue")
+	}
+	go graceful.GetManager().RunWithCancel(repoConvertQueue)
+
+	return nil
+}
+
+// validateConvertOptions checks that the repository can be converted to the formats
+func validateConvertOptions(ctx context.Context, repo *repo_model.Repository, opts *ConvertOptions) error {
+	if repo.Status != repo_model.RepositoryReady {
+		return util.NewInvalidArgumentErrorf("repository %s is not ready", repo.FullName())
+	}
+	if opts.ObjectFormatName == repo.ObjectFormatName {
+		opts.ObjectFormatName = ""
+	}
+	if opts.ObjectFormatName != "" {
+		if !git.IsValidObjectFormat(opts.ObjectFormatName) {
+			return util.NewInvalidArgumentErrorf("unsupported object format %q", opts.ObjectFormatName)
+		}
+		// the objects exchanged with a mirror remote or between forks must share the object format
+		if repo.IsMirror || repo.IsFork || repo.NumForks > 0 {
+			return util.NewInvalidArgumentErrorf("the object format of mirrors, forks and forked repositories cannot be converted")
+		}
+		hasPushMirrors, err := db.Exist[repo_model.PushMirror](ctx, builder.Eq{"repo_id": repo.ID})
+		if err != nil {
+			return err
+		}
+		if hasPushMirrors {
+			return util.NewInvalidArgumentErrorf("the object format of repositories with push mirrors cannot be converted")
+		}
+	}
+	if opts.RefFormat != "" {
+		if !opts.RefFormat.IsValid() {
+			return util.NewInvalidArgumentErrorf("unknown ref format %q", opts.RefFormat)
+		}
+		if !git.SupportReftable {
+			return util.NewInvalidArgumentErrorf("converting the ref format requires Git >= 2.46")
+		}
+		current, err := git.GetRefFormat(ctx, repo.RepoPath())
+		if err != nil {
+			return err
+		}
+		if current == opts.RefFormat {
+			opts.RefFormat = ""
+		}
+	}
+	if opts.ObjectFormatName == "" && opts.RefFormat == "" {
+		return util.NewInvalidArgumentErrorf("repository %s already has these formats", repo.FullName())
+	}
+	return nil
+}
+
+// setRepoStatus updates the status of the repository, refusing pushes while it is being converted
+func setRepoStatus(ctx context.Context, repo *repo_model.Repository, status repo_model.RepositoryStatus) error {
+	repo.Status = status
+	return repo_model.UpdateRepositoryCols(ctx, repo, "status")
+}
+
+// AddRepoToConvertQueue refuses the pushes to the repository and converts it in the background
+func AddRepoToConvertQueue(ctx context.Context, repo *repo_model.Repository, opts *ConvertOptions) error {
+	opts.RepoID = repo.ID
+	if err := validateConvertOptions(ctx, repo, opts); err != nil {
+		return err
+	}
+	if err := setRepoStatus(ctx, repo, repo_model.RepositoryBeingConverted); err != nil {
+		return err
+	}
+	if err := repoConvertQueue.Push(opts); err != nil {
+		_ = setRepoStatus(ctx, repo, repo_model.RepositoryReady)
+		return err
+	}
+	return nil
+}
+
+// ConvertRepository converts the object format and the reference storage of the repository and its wiki
+func ConvertRepository(ctx context.Context, repo *repo_model.Repository, opts *ConvertOptions) error {
+	// the repository is ready again whatever the outcome once its status is set by this conversion,
+	// or by AddRepoToConvertQueue
+	ownsStatus := repo.IsBeingConverted()
+	defer func() {
+		if !ownsStatus {
+			return
+		}
+		if err := setRepoStatus(ctx, repo, repo_model.RepositoryReady); err != nil {
+			log.Error("Unable to mark %-v as ready: %v", repo, err)
+		}
+	}()
+	if ownsStatus {
+		repo.Status = repo_model.RepositoryReady
+	}
+
+	if err := validateConvertOptions(ctx, repo, opts); err != nil {
+		return err
+	}
+	ownsStatus = true
+	if err := setRepoStatus(ctx, repo, repo_model.RepositoryBeingConverted); err != nil {
+		return err
+	}
+
+	if opts.ObjectFormatName != "" {
+		if err := convertObjectFormat(ctx, repo, git.ObjectFormatFromName(opts.ObjectFormatName)); err != nil {
+			return err
+		}
+	}
+	if opts.RefFormat != "" {
+		if err := git.MigrateRefFormat(ctx, repo.RepoPath(), opts.RefFormat); err != nil {
+			return fmt.Errorf("MigrateRefFormat: %w", err)
+		}
+		if repo.HasWiki() {
+			if err := git.MigrateRefFormat(ctx, repo.WikiPath(), opts.RefFormat); err != nil {
+				return fmt.Errorf("MigrateRefFormat wiki: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// convertRepoPath rewrites the git repository at repoPath into repoPath.converting
+func convertRepoPath(ctx context.Context, repoPath string, objectFormat git.ObjectFormat) (map[string]string, error) {
+	tmpPath := repoPath + ".converting"
+	if err := util.RemoveAll(tmpPath); err != nil {
+		return nil, err
+	}
+	mapping, err := git.ConvertObjectFormat(ctx, repoPath, tmpPath, objectFormat)
+	if err == nil {
+		err = repo_module.CreateDelegateHooks(tmpPath)
+	}
+	if err == nil {
+		var policy git.PartialClonePolicy
+		if policy, err = git.GetPartialClonePolicy(ctx, repoPath); err == nil {
+			err = git.SetPartialClonePolicy(ctx, tmpPath, policy)
+		}
+	}
+	if err != nil {
+		_ = util.RemoveAll(tmpPath)
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// swapRepoPath replaces the git repository at repoPath with the converted one, keeping the former in repoPath.old
+func swapRepoPath(repoPath string) error {
+	if err := util.Rename(repoPath, repoPath+".old"); err != nil {
+		return err
+	}
+	return util.Rename(repoPath+".converting", repoPath)
+}
+
+// restoreRepoPath puts back the git repository replaced by swapRepoPath
+func restoreRepoPath(repoPath string) error {
+	if err := util.RemoveAll(repoPath); err != nil {
+		return err
+	}
+	return util.Rename(repoPath+".old", repoPath)
+}
+
+func convertObjectFormat(ctx context.Context, repo *repo_model.Repository, objectFormat git.ObjectFormat) error {
+	repoPath := repo.RepoPath()
+	mapping, err := convertRepoPath(ctx, repoPath, objectFormat)
+	if err != nil {
+		return fmt.Errorf("convert %s: %w", repoPath, err)
+	}
+	hasWiki := repo.HasWiki()
+	if hasWiki {
+		// the wiki is not referenced by the database
+		if _, err := convertRepoPath(ctx, repo.WikiPath(), objectFormat); err != nil {
+			_ = util.RemoveAll(repoPath + ".converting")
+			return fmt.Errorf("convert %s: %w", repo.WikiPath(), err)
+		}
+	}
+
+	if err := swapRepoPath(repoPath); err != nil {
+		return err
+	}
+	if hasWiki {
+		if err := swapRepoPath(repo.WikiPath()); err != nil {
+			return errors.Join(err, restoreRepoPath(repoPath))
+		}
+	}
+
+	oldObjectFormatName := repo.ObjectFormatName
+	var archivePaths []string
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		repo.ObjectFormatName = objectFormat.Name()
+		if err := repo_model.UpdateRepositoryCols(ctx, repo, "object_format_name"); err != nil {
+			return err
+		}
+		var err error
+		archivePaths, err = remapRepoCommitIDs(ctx, repo.ID, mapping)
+		return err
+	}); err != nil {
+		repo.ObjectFormatName = oldObjectFormatName
+		restoreErr := restoreRepoPath(repoPath)
+		if hasWiki {
+			restoreErr = errors.Join(restoreErr, restoreRepoPath(repo.WikiPath()))
+		}
+		return errors.Join(fmt.Errorf("remapRepoCommitIDs: %w", err), restoreErr)
+	}
+
+	system_model.RemoveAllWithNotice(ctx, "Delete converted repository", repoPath+".old")
+	if hasWiki {
+		system_model.RemoveAllWithNotice(ctx, "Delete converted wiki", repo.WikiPath()+".old")
+	}
+	for _, archivePath := range archivePaths {
+		system_model.RemoveStorageWithNotice(ctx, storage.RepoArchives, "Delete repo archive file", archivePath)
+	}
+	if setting.RepoBundle.Enabled {
+		if err := DeleteRepoBundles(ctx, repo); err != nil {
+			log.Error("DeleteRepoBundles: %v", err)
+		}
+	}
+
+	if _, err := repo_module.SyncRepoBranches(ctx, repo.ID, 0); err != nil {
+		return fmt.Errorf("SyncRepoBranches: %w", err)
+	}
+	return nil
+}
+
+// remapCommitIDs replaces the commit IDs found in the column of the rows of the table matching cond
+func remapCommitIDs(ctx context.Context, table, column string, cond builder.Cond, mapping map[string]string) error {
+	var commitIDs []string
+	if err := db.GetEngine(ctx).Table(table).Where(cond).Select(column).Distinct(column).Find(&commitIDs); err != nil {
+		return fmt.Errorf("find %s.%s: %w", table, column, err)
+	}
+	for _, commitID := range commitIDs {
+		newID, ok := mapping[commitID]
+		if !ok {
+			// not reachable from any reference anymore, it cannot be browsed either way
+			continue
+		}
+		if _, err := db.GetEngine(ctx).Table(table).Where(builder.And(cond, builder.Eq{column: commitID})).
+			Update(map[string]any{column: newID}); err != nil {
+			return fmt.Errorf("update %s.%s: %w", table, column, err)
+		}
+	}
+	return nil
+}
+
+// remapRepoCommitIDs replaces the commit IDs stored for the repository after its object format was converted,
+// the branches are synchronized afterwards and the LFS objects are identified by their content which is unchanged.
+// It returns the paths of the archives to delete from the storage.
+func remapRepoCommitIDs(ctx context.Context, repoID int64, mapping map[string]string) ([]string, error) {
+	byRepo := builder.Eq{"repo_id": repoID}
+	byPull := builder.Eq{"base_repo_id": repoID}
+	byIssue := builder.In("issue_id", builder.Select("id").From("issue").Where(byRepo))
+	byPullID := builder.In("pull_id", builder.Select("id").From("pull_request").Where(byPull))
+	for _, c := range []struct {
+		table, column string
+		cond          builder.Cond
+	}{
+		{"commit_status", "sha", byRepo},
+		{"commit_status_index", "sha", byRepo},
+		{"commit_status_summary", "sha", byRepo},
+		{"release", "sha1", byRepo},
+		{"notification", "commit_id", byRepo},
+		{"language_stat", "commit_id", byRepo},
+		{"repo_indexer_status", "commit_sha", byRepo},
+		{"action_run", "commit_sha", byRepo},
+		{"action_run_job", "commit_sha", byRepo},
+		{"action_task", "commit_sha", byRepo},
+		{"action_schedule", "commit_sha", byRepo},
+		{"action_artifact", "commit_sha", byRepo},
+		{"action_deployment", "commit_sha", byRepo},
+		{"pull_request", "merge_base", byPull},
+		{"pull_request", "merged_commit_id", byPull},
+		{"review", "commit_id", byIssue},
+		{"comment", "commit_sha", byIssue},
+		{"comment", "old_ref", builder.And(byIssue, builder.Eq{"type": issues_model.CommentTypePullRequestEditCommits})},
+		{"comment", "new_ref", builder.And(byIssue, builder.Eq{"type": issues_model.CommentTypePullRequestEditCommits})},
+		{"review_state", "commit_sha", byPullID},
+	} {
+		if err := remapCommitIDs(ctx, c.table, c.column, c.cond, mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	// the pushes to pull requests list their commits in their content
+	var comments []*issues_model.Comment
+	if err := db.GetEngine(ctx).Where(byIssue).And("type = ?", issues_model.CommentTypePullRequestPush).Find(&comments); err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		var data issues_model.PushActionContent
+		if err := json.Unmarshal([]byte(comment.Content), &data); err != nil {
+			log.Warn("Unable to parse the content of comment %d: %v", comment.ID, err)
+			continue
+		}
+		for i, commitID := range data.CommitIDs {
+			if newID, ok := mapping[commitID]; ok {
+				data.CommitIDs[i] = newID
+			}
+		}
+		content, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := db.GetEngine(ctx).ID(comment.ID).Cols("content").NoAutoTime().Update(&issues_model.Comment{Content: string(content)}); err != nil {
+			return nil, err
+		}
+	}
+
+	var archives []*repo_model.RepoArchiver
+	if err := db.GetEngine(ctx).Where(byRepo).Find(&archives); err != nil {
+		return nil, err
+	}
+	archivePaths := make([]string, 0, len(archives))
+	for _, archive := range archives {
+		archivePaths = append(archivePaths, archive.RelativePath())
+	}
+	if _, err := db.DeleteByBean(ctx, &repo_model.RepoArchiver{RepoID: repoID}); err != nil {
+		return nil, err
+	}
+	return archivePaths, nil
+}
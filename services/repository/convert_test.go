@@ -0,0 +1,112 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	git_model "forgejo.org/models/git"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertRepositoryReady(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// queued by AddRepoToConvertQueue, the options are no longer valid when it is converted
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, setRepoStatus(db.DefaultContext, repo, repo_model.RepositoryBeingConverted))
+	err := ConvertRepository(db.DefaultContext, repo, &ConvertOptions{ObjectFormatName: repo.ObjectFormatName})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1, Status: repo_model.RepositoryReady})
+
+	// the status of a repository which is not ready is not owned by the conversion
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3, Status: repo_model.RepositoryPendingTransfer})
+	err = ConvertRepository(db.DefaultContext, repo, &ConvertOptions{ObjectFormatName: git.Sha256ObjectFormat.Name()})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3, Status: repo_model.RepositoryPendingTransfer})
+}
+
+func TestConvertRepositoryPushMirror(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	opts := &ConvertOptions{ObjectFormatName: git.Sha256ObjectFormat.Name()}
+	require.NoError(t, validateConvertOptions(db.DefaultContext, repo, opts))
+
+	require.NoError(t, db.Insert(db.DefaultContext, &repo_model.PushMirror{RepoID: repo.ID, RemoteName: "mirror"}))
+	opts = &ConvertOptions{ObjectFormatName: git.Sha256ObjectFormat.Name()}
+	require.ErrorIs(t, validateConvertOptions(db.DefaultContext, repo, opts), util.ErrInvalidArgument)
+}
+
+func TestRemapRepoCommitIDs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const (
+		statusSHA  = "1234123412341234123412341234123412341234"
+		releaseSHA = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+		mergeBase  = "4a357436d925b5c974181ff12a994538ddc5a269"
+		pushedSHA  = "985f0301dba5e7b34be866819cd15ad3d8f508ee"
+		unmapped   = "2a47ca4b614a9f5a43abbd5ad851a54a616ffee6"
+	)
+	converted := func(sha string) string {
+		return sha + "000000000000000000000000"
+	}
+	mapping := map[string]string{}
+	// 0abcb056019adb83 is the merge base of a pull request of another repository
+	for _, sha := range []string{statusSHA, releaseSHA, mergeBase, pushedSHA, "0abcb056019adb83"} {
+		mapping[sha] = converted(sha)
+	}
+
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	push := &issues_model.Comment{
+		Type:    issues_model.CommentTypePullRequestPush,
+		IssueID: pull.IssueID,
+		Content: `{"is_force_push":false,"commit_ids":["` + pushedSHA + `","` + unmapped + `"]}`,
+	}
+	editCommits := &issues_model.Comment{
+		Type:    issues_model.CommentTypePullRequestEditCommits,
+		IssueID: pull.IssueID,
+		OldRef:  mergeBase,
+		NewRef:  pushedSHA,
+		Content: "refs/pull/2/backup/1",
+	}
+	// the references of other comments are names, not commit IDs
+	changeRef := &issues_model.Comment{
+		Type:    issues_model.CommentTypeChangeTargetBranch,
+		IssueID: pull.IssueID,
+		OldRef:  mergeBase,
+		NewRef:  "main",
+	}
+	require.NoError(t, db.Insert(db.DefaultContext, []*issues_model.Comment{push, editCommits, changeRef}))
+	require.NoError(t, db.Insert(db.DefaultContext, &repo_model.RepoArchiver{RepoID: 1, CommitID: statusSHA, Type: git.ZIP}))
+
+	archivePaths, err := remapRepoCommitIDs(db.DefaultContext, 1, mapping)
+	require.NoError(t, err)
+	assert.Len(t, archivePaths, 1)
+	unittest.AssertNotExistsBean(t, &repo_model.RepoArchiver{RepoID: 1})
+
+	unittest.AssertNotExistsBean(t, &git_model.CommitStatus{RepoID: 1, SHA: statusSHA})
+	unittest.AssertExistsAndLoadBean(t, &git_model.CommitStatus{ID: 1, SHA: converted(statusSHA)})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: 1, Sha1: converted(releaseSHA)})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1, MergeBase: converted(mergeBase)})
+	// the merge base of the pull requests of other repositories is left unchanged
+	unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3, MergeBase: "0abcb056019adb83"})
+
+	push = unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: push.ID})
+	var data issues_model.PushActionContent
+	require.NoError(t, json.Unmarshal([]byte(push.Content), &data))
+	assert.Equal(t, []string{converted(pushedSHA), unmapped}, data.CommitIDs)
+
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: editCommits.ID, OldRef: converted(mergeBase), NewRef: converted(pushedSHA)})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: changeRef.ID, OldRef: mergeBase})
+}
@@ -13,7 +13,9 @@ import (
 	activities_model "forgejo.org/models/activities"
 	admin_model "forgejo.org/models/admin"
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
+	depgraph_model "forgejo.org/models/depgraph"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
@@ -22,6 +24,7 @@ import (
 	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	secret_model "forgejo.org/models/secret"
+	secretscan_model "forgejo.org/models/secretscan"
 	system_model "forgejo.org/models/system"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/models/webhook"
@@ -30,6 +33,7 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/storage"
+	audit_service "forgejo.org/services/audit"
 	federation_service "forgejo.org/services/federation"
 
 	"xorm.io/builder"
@@ -165,15 +169,23 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&git_model.LFSLock{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
+		&issues_model.IssueSchedule{RepoID: repoID},
+		&issues_model.ServiceDesk{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
+		&git_model.PushRule{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
 		&repo_model.RepoUnit{RepoID: repoID},
+		&secretscan_model.Setting{RepoID: repoID},
+		&secretscan_model.Alert{RepoID: repoID},
+		&depgraph_model.Graph{RepoID: repoID},
+		&depgraph_model.Dependency{RepoID: repoID},
+		&depgraph_model.Alert{RepoID: repoID},
 		&repo_model.Star{RepoID: repoID},
 		&admin_model.Task{RepoID: repoID},
 		&repo_model.Watch{RepoID: repoID},
@@ -306,6 +318,8 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return err
 	}
 
+	audit_service.Record(ctx, doer, audit_model.ActionRepoDelete, audit_service.RepoTarget(repo), nil, nil)
+
 	if err = committer.Commit(); err != nil {
 		return err
 	}
@@ -335,6 +349,17 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		system_model.RemoveStorageWithNotice(ctx, storage.RepoArchives, "Delete repo archive file", archive)
 	}
 
+	// Remove bundles
+	if setting.RepoBundle.Enabled {
+		if bundles, err := listRepoBundles(repoID); err != nil {
+			log.Error("listRepoBundles: %v", err)
+		} else {
+			for _, bundle := range bundles {
+				system_model.RemoveStorageWithNotice(ctx, storage.RepoBundles, "Delete repo bundle file", bundle.RelativePath())
+			}
+		}
+	}
+
 	// Remove lfs objects
 	for _, lfsObj := range lfsPaths {
 		system_model.RemoveStorageWithNotice(ctx, storage.LFS, "Delete orphaned LFS file", lfsObj)
@@ -0,0 +1,145 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"forgejo.org/models"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/pull"
+)
+
+// ResolveConflictsOptions holds the resolution of the conflicts between the head and the target
+// branches of a pull request
+type ResolveConflictsOptions struct {
+	// HeadCommitID and BaseCommitID are the commits of the head and the target branches the conflicts
+	// were resolved for, the resolution is refused if any of the branches moved since
+	HeadCommitID string
+	BaseCommitID string
+	// Files maps the path of each conflicted file to its resolved content
+	Files   map[string]string
+	Message string
+}
+
+// ResolvePullConflicts merges the target branch into the head branch of a pull request, using the
+// resolved content for the conflicted files, and pushes the merge commit to the head branch
+func ResolvePullConflicts(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, opts *ResolveConflictsOptions) (string, error) {
+	if !git.SupportGitMergeTree {
+		return "", util.NewInvalidArgumentErrorf("the conflicts can not be resolved with this version of git")
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", err
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return "", err
+	}
+	if pr.HeadRepo == nil {
+		return "", util.NewNotExistErrorf("the head repository of the pull request does not exist")
+	}
+
+	paths := make([]string, 0, len(opts.Files))
+	for path := range opts.Files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	if err := VerifyBranchProtection(ctx, pr.HeadRepo, doer, pr.HeadBranch, paths); err != nil {
+		return "", err
+	}
+
+	t, err := NewTemporaryUploadRepository(ctx, pr.HeadRepo)
+	if err != nil {
+		log.Error("NewTemporaryUploadRepository failed: %v", err)
+		return "", err
+	}
+	defer t.Close()
+	if err := t.Clone(pr.HeadBranch, true); err != nil {
+		return "", err
+	}
+
+	headCommit, err := t.GetBranchCommit(pr.HeadBranch)
+	if err != nil {
+		return "", err
+	}
+	if headCommit.ID.String() != opts.HeadCommitID {
+		return "", models.ErrCommitIDDoesNotMatch{
+			GivenCommitID:   opts.HeadCommitID,
+			CurrentCommitID: headCommit.ID.String(),
+		}
+	}
+
+	// Fetch the target branch, it is in another repository for the pull requests from a fork
+	const targetRef = "refs/remotes/target"
+	if _, _, err := git.NewCommand(ctx, "fetch", "--no-tags").AddDynamicArguments(pr.BaseRepo.RepoPath(), "+"+git.BranchPrefix+pr.BaseBranch+":"+targetRef).RunStdString(&git.RunOpts{Dir: t.basePath}); err != nil {
+		return "", fmt.Errorf("fetch %s: %w", pr.BaseBranch, err)
+	}
+	baseCommitID, err := t.gitRepo.GetRefCommitID(targetRef)
+	if err != nil {
+		return "", err
+	}
+	if baseCommitID != opts.BaseCommitID {
+		return "", models.ErrCommitIDDoesNotMatch{
+			GivenCommitID:   opts.BaseCommitID,
+			CurrentCommitID: baseCommitID,
+		}
+	}
+
+	mergeBase, _, err := git.NewCommand(ctx, "merge-base").AddDynamicArguments(opts.HeadCommitID, baseCommitID).RunStdString(&git.RunOpts{Dir: t.basePath})
+	if err != nil {
+		return "", fmt.Errorf("merge-base: %w", err)
+	}
+	treeHash, _, conflictedFiles, err := pull.MergeTree(ctx, t.gitRepo, strings.TrimSpace(mergeBase), opts.HeadCommitID, baseCommitID, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range conflictedFiles {
+		if _, ok := opts.Files[path]; !ok {
+			return "", util.NewInvalidArgumentErrorf("the conflicts of %s are not resolved", path)
+		}
+	}
+
+	// Replace the conflicted files of the merged tree by their resolution
+	if _, _, err := git.NewCommand(ctx, "read-tree").AddDynamicArguments(treeHash).RunStdString(&git.RunOpts{Dir: t.basePath}); err != nil {
+		return "", fmt.Errorf("read-tree: %w", err)
+	}
+	for _, path := range paths {
+		if !slices.Contains(conflictedFiles, path) {
+			return "", util.NewInvalidArgumentErrorf("%s has no conflicts", path)
+		}
+		mode := "100644"
+		entry, err := headCommit.GetTreeEntryByPath(path)
+		if err != nil && !git.IsErrNotExist(err) {
+			return "", err
+		}
+		if entry != nil {
+			mode = entry.Mode().String()
+		}
+		objectHash, err := t.HashObject(strings.NewReader(opts.Files[path]))
+		if err != nil {
+			return "", err
+		}
+		if err := t.AddObjectToIndex(mode, objectHash, path); err != nil {
+			return "", err
+		}
+	}
+	if treeHash, err = t.WriteTree(); err != nil {
+		return "", err
+	}
+
+	commitHash, err := t.CommitMergeTree([]string{opts.HeadCommitID, baseCommitID}, doer, doer, treeHash, strings.TrimSpace(opts.Message))
+	if err != nil {
+		return "", err
+	}
+	if err := t.Push(doer, commitHash, pr.HeadBranch); err != nil {
+		return "", err
+	}
+	return commitHash, nil
+}
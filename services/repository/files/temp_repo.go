@@ -219,6 +219,19 @@ func (t *TemporaryUploadRepository) CommitTree(parent string, author, committer
 
 // CommitTreeWithDate creates a commit from a given tree for the user with provided message
 func (t *TemporaryUploadRepository) CommitTreeWithDate(parent string, author, committer *user_model.User, treeHash, message string, signoff bool, authorDate, committerDate time.Time) (string, error) {
+	var parents []string
+	if parent != "" {
+		parents = []string{parent}
+	}
+	return t.commitTree(parents, author, committer, treeHash, message, signoff, authorDate, committerDate)
+}
+
+// CommitMergeTree creates a merge commit of the parents from a given tree for the user with provided message
+func (t *TemporaryUploadRepository) CommitMergeTree(parents []string, author, committer *user_model.User, treeHash, message string) (string, error) {
+	return t.commitTree(parents, author, committer, treeHash, message, false, time.Now(), time.Now())
+}
+
+func (t *TemporaryUploadRepository) commitTree(parents []string, author, committer *user_model.User, treeHash, message string, signoff bool, authorDate, committerDate time.Time) (string, error) {
 	authorSig := author.NewGitSig()
 	committerSig := committer.NewGitSig()
 
@@ -235,15 +248,15 @@ func (t *TemporaryUploadRepository) CommitTreeWithDate(parent string, author, co
 	_, _ = messageBytes.WriteString("\n")
 
 	cmdCommitTree := git.NewCommand(t.ctx, "commit-tree").AddDynamicArguments(treeHash)
-	if parent != "" {
+	for _, parent := range parents {
 		cmdCommitTree.AddOptionValues("-p", parent)
 	}
 
 	var sign bool
 	var keyID string
 	var signer *git.Signature
-	if parent != "" {
-		sign, keyID, signer, _ = asymkey_service.SignCRUDAction(t.ctx, t.repo.RepoPath(), author, t.basePath, parent)
+	if len(parents) > 0 {
+		sign, keyID, signer, _ = asymkey_service.SignCRUDAction(t.ctx, t.repo.RepoPath(), author, t.basePath, parents[0])
 	} else {
 		sign, keyID, signer, _ = asymkey_service.SignInitialCommit(t.ctx, t.repo.RepoPath(), author)
 	}
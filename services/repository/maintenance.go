@@ -0,0 +1,83 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+	repo_module "forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// GitMaintenanceRepos runs the git maintenance tasks on all the repositories, which
+// keeps clones and fetches of large repositories fast between garbage collections
+func GitMaintenanceRepos(ctx context.Context, opts git.MaintenanceOptions) error {
+	log.Trace("Doing: GitMaintenanceRepos")
+
+	if !git.SupportGitMaintenance {
+		log.Warn("Repository maintenance requires Git >= 2.34, skipping")
+		return nil
+	}
+
+	if err := db.Iterate(
+		ctx,
+		builder.Gt{"id": 0},
+		func(ctx context.Context, repo *repo_model.Repository) error {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before maintenance of %s", repo.FullName())
+			default:
+			}
+			// we can ignore the error here because it will be logged in GitMaintenanceRepo
+			_ = GitMaintenanceRepo(ctx, repo, opts)
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	log.Trace("Finished: GitMaintenanceRepos")
+	return nil
+}
+
+// GitMaintenanceRepo runs the git maintenance tasks on a repository
+func GitMaintenanceRepo(ctx context.Context, repo *repo_model.Repository, opts git.MaintenanceOptions) error {
+	if repo.IsEmpty {
+		return nil
+	}
+	log.Trace("Running git maintenance on %-v", repo)
+	if err := git.RunMaintenance(ctx, repo.RepoPath(), opts); err != nil {
+		log.Error("Repository maintenance failed for %-v: %v", repo, err)
+		if err := system_model.CreateRepositoryNotice("Repository maintenance failed for %s: %v", repo.FullName(), err); err != nil {
+			log.Error("CreateRepositoryNotice: %v", err)
+		}
+		return fmt.Errorf("repository maintenance failed in repo %s: %w", repo.FullName(), err)
+	}
+
+	if err := repo_module.UpdateRepoSize(ctx, repo); err != nil {
+		log.Error("Updating size as part of maintenance failed for %-v: %v", repo, err)
+		return fmt.Errorf("updating size as part of maintenance failed in repo %s: %w", repo.FullName(), err)
+	}
+	return nil
+}
+
+// SetPartialClonePolicy restricts the partial clones of a repository
+func SetPartialClonePolicy(ctx context.Context, repo *repo_model.Repository, policy git.PartialClonePolicy) error {
+	if !policy.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown partial clone policy %q", policy)
+	}
+	if policy == git.PartialCloneBlobNone && setting.Git.DisablePartialClone {
+		return util.NewInvalidArgumentErrorf("partial clones are disabled on this instance")
+	}
+	return git.SetPartialClonePolicy(ctx, repo.RepoPath(), policy)
+}
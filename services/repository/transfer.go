@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
@@ -21,6 +22,7 @@ import (
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/sync"
 	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
 	notify_service "forgejo.org/services/notify"
 )
 
@@ -61,6 +63,12 @@ func TransferOwnership(ctx context.Context, doer, newOwner *user_model.User, rep
 
 	notify_service.TransferRepository(ctx, doer, repo, oldOwner.Name)
 
+	// the event is scoped to the former owner, the repository is no longer visible to it
+	target := audit_service.RepoTarget(newRepo)
+	target.OwnerID = oldOwner.ID
+	audit_service.Record(ctx, doer, audit_model.ActionRepoTransfer, target,
+		map[string]string{"owner": oldOwner.Name}, map[string]string{"owner": newOwner.Name})
+
 	return nil
 }
 
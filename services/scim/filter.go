@@ -0,0 +1,361 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"forgejo.org/models/db"
+
+	"xorm.io/builder"
+)
+
+type attributeKind int
+
+const (
+	stringAttribute attributeKind = iota
+	idAttribute
+	// activeAttribute is the negation of the prohibit_login column
+	activeAttribute
+	// memberAttribute matches the groups having the user as a member
+	memberAttribute
+)
+
+type filterAttribute struct {
+	column string
+	kind   attributeKind
+}
+
+var userFilterAttributes = map[string]filterAttribute{
+	"id":             {column: "id", kind: idAttribute},
+	"username":       {column: "login_name", kind: stringAttribute},
+	"displayname":    {column: "full_name", kind: stringAttribute},
+	"name.formatted": {column: "full_name", kind: stringAttribute},
+	"emails":         {column: "email", kind: stringAttribute},
+	"emails.value":   {column: "email", kind: stringAttribute},
+	"active":         {column: "prohibit_login", kind: activeAttribute},
+}
+
+var groupFilterAttributes = map[string]filterAttribute{
+	"id":            {column: "id", kind: idAttribute},
+	"displayname":   {column: "display_name", kind: stringAttribute},
+	"externalid":    {column: "external_id", kind: stringAttribute},
+	"members":       {kind: memberAttribute},
+	"members.value": {kind: memberAttribute},
+}
+
+// filterExpression is a node of a parsed filter: a logical operator with its operands,
+// or an attribute comparison
+type filterExpression struct {
+	op          string
+	left, right *filterExpression
+	attribute   string
+	value       any
+}
+
+type filterTokenKind int
+
+const (
+	wordToken filterTokenKind = iota
+	stringToken
+	openToken
+	closeToken
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func tokenizeFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{kind: openToken})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{kind: closeToken})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, ErrInvalidFilter{Filter: filter, Reason: "unterminated string"}
+			}
+			var s string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &s); err != nil {
+				return nil, ErrInvalidFilter{Filter: filter, Reason: "invalid string"}
+			}
+			tokens = append(tokens, filterToken{kind: stringToken, text: s})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(filter) && !unicode.IsSpace(rune(filter[end])) && filter[end] != '(' && filter[end] != ')'; end++ {
+			}
+			tokens = append(tokens, filterToken{kind: wordToken, text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	filter string
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peekWord(word string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == wordToken && strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	p.pos++
+	return p.tokens[p.pos-1], true
+}
+
+func (p *filterParser) errorf(reason string) error {
+	return ErrInvalidFilter{Filter: p.filter, Reason: reason}
+}
+
+func (p *filterParser) parseOr() (*filterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterExpression{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*filterExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterExpression{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (*filterExpression, error) {
+	if !p.peekWord("not") {
+		return p.parseComparison()
+	}
+	p.pos++
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != openToken {
+		return nil, p.errorf("not must be followed by a parenthesized expression")
+	}
+	expr, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	return &filterExpression{op: "not", left: expr}, nil
+}
+
+func (p *filterParser) parseComparison() (*filterExpression, error) {
+	token, ok := p.next()
+	if !ok {
+		return nil, p.errorf("unexpected end of filter")
+	}
+	if token.kind == openToken {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token, ok := p.next(); !ok || token.kind != closeToken {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		return expr, nil
+	}
+	if token.kind != wordToken {
+		return nil, p.errorf("attribute expected")
+	}
+	expr := &filterExpression{attribute: token.text}
+
+	operator, ok := p.next()
+	if !ok || operator.kind != wordToken {
+		return nil, p.errorf("operator expected after " + token.text)
+	}
+	expr.op = strings.ToLower(operator.text)
+	switch expr.op {
+	case "pr":
+		return expr, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, p.errorf("unknown operator " + operator.text)
+	}
+
+	value, ok := p.next()
+	switch {
+	case !ok:
+		return nil, p.errorf("value expected after " + operator.text)
+	case value.kind == stringToken:
+		expr.value = value.text
+	case value.kind == wordToken:
+		// true, false, null or a number
+		if err := json.Unmarshal([]byte(strings.ToLower(value.text)), &expr.value); err != nil {
+			return nil, p.errorf("invalid value " + value.text)
+		}
+	default:
+		return nil, p.errorf("value expected after " + operator.text)
+	}
+	return expr, nil
+}
+
+// parseFilter parses a filter expression as defined in section 3.4.2.2 of RFC 7644.
+// Complex attribute filters with brackets are not supported.
+func parseFilter(filter string) (*filterExpression, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{filter: filter, tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, p.errorf("unexpected trailing input")
+	}
+	return expr, nil
+}
+
+// toCond converts the expression into a database condition on the columns of the attributes
+func (expr *filterExpression) toCond(filter, schema string, attributes map[string]filterAttribute) (builder.Cond, error) {
+	switch expr.op {
+	case "and", "or":
+		left, err := expr.left.toCond(filter, schema, attributes)
+		if err != nil {
+			return nil, err
+		}
+		right, err := expr.right.toCond(filter, schema, attributes)
+		if err != nil {
+			return nil, err
+		}
+		if expr.op == "and" {
+			return builder.And(left, right), nil
+		}
+		return builder.Or(left, right), nil
+	case "not":
+		cond, err := expr.left.toCond(filter, schema, attributes)
+		if err != nil {
+			return nil, err
+		}
+		return builder.Not{cond}, nil
+	}
+
+	invalid := func(reason string) error {
+		return ErrInvalidFilter{Filter: filter, Reason: reason}
+	}
+	attribute, ok := attributes[strings.ToLower(stripSchema(expr.attribute, schema))]
+	if !ok {
+		return nil, invalid("unsupported attribute " + expr.attribute)
+	}
+
+	switch attribute.kind {
+	case idAttribute, memberAttribute:
+		var id int64
+		switch v := expr.value.(type) {
+		case string:
+			id = parseID(v)
+		case float64:
+			id = int64(v)
+		}
+		var cond builder.Cond
+		switch expr.op {
+		case "eq", "ne":
+			if attribute.kind == memberAttribute {
+				cond = builder.In("id", builder.Select("group_id").From("scim_group_member").Where(builder.Eq{"user_id": id}))
+			} else {
+				cond = builder.Eq{attribute.column: id}
+			}
+		case "pr":
+			if attribute.kind == memberAttribute {
+				cond = builder.In("id", builder.Select("group_id").From("scim_group_member"))
+			} else {
+				cond = builder.Expr("1=1")
+			}
+		default:
+			return nil, invalid("unsupported operator " + expr.op + " for " + expr.attribute)
+		}
+		if expr.op == "ne" {
+			cond = builder.Not{cond}
+		}
+		return cond, nil
+
+	case activeAttribute:
+		if expr.op == "pr" {
+			return builder.Expr("1=1"), nil
+		}
+		active, ok := expr.value.(bool)
+		if !ok || (expr.op != "eq" && expr.op != "ne") {
+			return nil, invalid(expr.attribute + " must be compared with true or false")
+		}
+		if expr.op == "ne" {
+			active = !active
+		}
+		return builder.Eq{attribute.column: !active}, nil
+	}
+
+	if expr.op == "pr" {
+		return builder.And(builder.NotNull{attribute.column}, builder.Neq{attribute.column: ""}), nil
+	}
+	value, ok := expr.value.(string)
+	if !ok {
+		return nil, invalid(expr.attribute + " must be compared with a string")
+	}
+	switch expr.op {
+	case "eq":
+		return db.BuildCaseInsensitiveIn(attribute.column, []string{value}), nil
+	case "ne":
+		return builder.Not{db.BuildCaseInsensitiveIn(attribute.column, []string{value})}, nil
+	case "co":
+		if value == "" {
+			return builder.Expr("1=1"), nil
+		}
+		return db.BuildCaseInsensitiveLike(attribute.column, value), nil
+	case "sw":
+		return db.BuildCaseInsensitiveLike(attribute.column, value+"%"), nil
+	case "ew":
+		return db.BuildCaseInsensitiveLike(attribute.column, "%"+value), nil
+	}
+	return nil, invalid("unsupported operator " + expr.op + " for " + expr.attribute)
+}
+
+// filterCond converts a filter into a database condition, an empty filter matches everything
+func filterCond(filter, schema string, attributes map[string]filterAttribute) (builder.Cond, error) {
+	if strings.TrimSpace(filter) == "" {
+		return builder.NewCond(), nil
+	}
+	expr, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return expr.toCond(filter, schema, attributes)
+}
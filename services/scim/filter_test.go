@@ -0,0 +1,78 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
+)
+
+func TestParseFilter(t *testing.T) {
+	expr, err := parseFilter(`userName eq "jdoe@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &filterExpression{op: "eq", attribute: "userName", value: "jdoe@example.com"}, expr)
+
+	expr, err = parseFilter(`active EQ true and (emails co "example" or not (displayName sw "J\"o"))`)
+	require.NoError(t, err)
+	assert.Equal(t, "and", expr.op)
+	assert.Equal(t, &filterExpression{op: "eq", attribute: "active", value: true}, expr.left)
+	assert.Equal(t, "or", expr.right.op)
+	assert.Equal(t, "not", expr.right.right.op)
+	assert.Equal(t, `J"o`, expr.right.right.left.value)
+
+	expr, err = parseFilter(`externalId pr`)
+	require.NoError(t, err)
+	assert.Equal(t, &filterExpression{op: "pr", attribute: "externalId"}, expr)
+
+	for _, filter := range []string{
+		`userName`,
+		`userName eq`,
+		`userName foo "bar"`,
+		`userName eq "unterminated`,
+		`(userName eq "a"`,
+		`userName eq "a" "b"`,
+		`not userName eq "a"`,
+		`userName eq bar`,
+	} {
+		_, err := parseFilter(filter)
+		assert.True(t, IsErrInvalidFilter(err), filter)
+	}
+}
+
+func TestFilterCond(t *testing.T) {
+	toSQL := func(filter string, attributes map[string]filterAttribute) string {
+		cond, err := filterCond(filter, UserSchema, attributes)
+		require.NoError(t, err)
+		sql, err := builder.ToBoundSQL(cond)
+		require.NoError(t, err)
+		return sql
+	}
+
+	assert.Empty(t, toSQL("", userFilterAttributes))
+	assert.Equal(t, "UPPER(login_name) IN ('JDOE@EXAMPLE.COM')", toSQL(`userName eq "jdoe@example.com"`, userFilterAttributes))
+	assert.Equal(t, "UPPER(login_name) IN ('JDOE')", toSQL(`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jdoe"`, userFilterAttributes))
+	assert.Equal(t, "prohibit_login=false", toSQL(`active eq true`, userFilterAttributes))
+	assert.Equal(t, "prohibit_login=false", toSQL(`active ne false`, userFilterAttributes))
+	assert.Equal(t, "id=42", toSQL(`id eq "42"`, userFilterAttributes))
+	assert.Equal(t, "id=0", toSQL(`id eq "not-a-number"`, userFilterAttributes))
+	assert.Equal(t, "UPPER(email) LIKE '%EXAMPLE%' OR UPPER(full_name) LIKE 'J%'", toSQL(`emails co "example" or displayName sw "j"`, userFilterAttributes))
+	assert.Equal(t, "id IN (SELECT group_id FROM scim_group_member WHERE user_id=3)", toSQL(`members eq "3"`, groupFilterAttributes))
+
+	for _, filter := range []string{
+		`nickName eq "jdoe"`,
+		`active eq "yes"`,
+		`userName gt "a"`,
+		`userName eq 3`,
+		`members co "3"`,
+	} {
+		_, err := filterCond(filter, UserSchema, userFilterAttributes)
+		if err == nil {
+			_, err = filterCond(filter, GroupSchema, groupFilterAttributes)
+		}
+		assert.True(t, IsErrInvalidFilter(err), filter)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	auth_module "forgejo.org/modules/auth"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/util"
+	source_service "forgejo.org/services/auth/source"
+
+	"xorm.io/builder"
+)
+
+// ToGroup converts a SCIM group into its SCIM representation
+func ToGroup(ctx context.Context, group *auth_model.ScimGroup, excludeMembers bool) (*Group, error) {
+	resource := &Group{
+		Schemas:     []string{GroupSchema},
+		ID:          strconv.FormatInt(group.ID, 10),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      group.CreatedUnix.AsTime(),
+			LastModified: group.UpdatedUnix.AsTime(),
+			Location:     groupLocation(group.ID),
+		},
+	}
+	if excludeMembers {
+		return resource, nil
+	}
+
+	memberIDs, err := auth_model.GetScimGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := user_model.GetUserByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range members {
+		resource.Members = append(resource.Members, Reference{
+			Value:   strconv.FormatInt(u.ID, 10),
+			Ref:     userLocation(u.ID),
+			Display: u.LoginName,
+		})
+	}
+	return resource, nil
+}
+
+// ListGroups returns the groups of the source which match the filter of the options
+func ListGroups(ctx context.Context, source *auth_model.Source, opts ListOptions) (*ListResponse, error) {
+	cond, err := filterCond(opts.Filter, GroupSchema, groupFilterAttributes)
+	if err != nil {
+		return nil, err
+	}
+	skip, take := opts.skipTake()
+	groups, count, err := auth_model.FindScimGroups(ctx, source.ID, cond, skip, take)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: count,
+		StartIndex:   opts.StartIndex,
+		ItemsPerPage: len(groups),
+		Resources:    make([]any, 0, len(groups)),
+	}
+	for _, group := range groups {
+		resource, err := ToGroup(ctx, group, opts.ExcludeMembers)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, resource)
+	}
+	return resp, nil
+}
+
+// GetGroup returns the group of the source with the given ID
+func GetGroup(ctx context.Context, source *auth_model.Source, id string) (*auth_model.ScimGroup, error) {
+	return auth_model.GetScimGroup(ctx, source.ID, parseID(id))
+}
+
+// memberIDs returns the IDs of the referenced users, which must have been provisioned by the source
+func memberIDs(ctx context.Context, source *auth_model.Source, refs []Reference) (container.Set[int64], error) {
+	ids := make(container.Set[int64], len(refs))
+	for _, ref := range refs {
+		id := parseID(ref.Value)
+		if id == 0 {
+			return nil, util.NewInvalidArgumentErrorf("invalid member %q", ref.Value)
+		}
+		ids.Add(id)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	count, err := db.GetEngine(ctx).Where(sourceUsersCond(source)).And(builder.In("id", ids.Values())).Count(new(user_model.User))
+	if err != nil {
+		return nil, err
+	} else if count != int64(len(ids)) {
+		return nil, util.NewInvalidArgumentErrorf("members must be users provisioned by this identity provider")
+	}
+	return ids, nil
+}
+
+func validateGroup(resource *Group) error {
+	if strings.TrimSpace(resource.DisplayName) == "" {
+		return util.NewInvalidArgumentErrorf("displayName is required")
+	}
+	return nil
+}
+
+// CreateGroup creates a group of the source and adds its members to the mapped teams
+func CreateGroup(ctx context.Context, source *auth_model.Source, resource *Group) (*auth_model.ScimGroup, error) {
+	if err := validateGroup(resource); err != nil {
+		return nil, err
+	}
+	members, err := memberIDs(ctx, source, resource.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &auth_model.ScimGroup{
+		SourceID:    source.ID,
+		DisplayName: strings.TrimSpace(resource.DisplayName),
+		ExternalID:  resource.ExternalID,
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth_model.CreateScimGroup(ctx, group); err != nil {
+			return err
+		}
+		return auth_model.SetScimGroupMembers(ctx, group.ID, members)
+	}); err != nil {
+		return nil, err
+	}
+	return group, syncTeams(ctx, source, members)
+}
+
+// ReplaceGroup updates the group with the attributes of the resource
+func ReplaceGroup(ctx context.Context, source *auth_model.Source, group *auth_model.ScimGroup, resource *Group) error {
+	if err := validateGroup(resource); err != nil {
+		return err
+	}
+	members, err := memberIDs(ctx, source, resource.Members)
+	if err != nil {
+		return err
+	}
+	return updateGroup(ctx, source, group, strings.TrimSpace(resource.DisplayName), resource.ExternalID, members)
+}
+
+func updateGroup(ctx context.Context, source *auth_model.Source, group *auth_model.ScimGroup, displayName, externalID string, members container.Set[int64]) error {
+	current, err := auth_model.GetScimGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	affected := container.SetOf(current...)
+	if displayName == group.DisplayName {
+		// the teams of the members who stay only change with the group name
+		for id := range members {
+			if !affected.Remove(id) {
+				affected.Add(id)
+			}
+		}
+	} else {
+		affected.AddMultiple(members.Values()...)
+	}
+
+	group.DisplayName = displayName
+	group.ExternalID = externalID
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth_model.UpdateScimGroup(ctx, group); err != nil {
+			return err
+		}
+		return auth_model.SetScimGroupMembers(ctx, group.ID, members)
+	}); err != nil {
+		return err
+	}
+	return syncTeams(ctx, source, affected)
+}
+
+// PatchGroup applies the operations of the request to the group
+func PatchGroup(ctx context.Context, source *auth_model.Source, group *auth_model.ScimGroup, patch *PatchRequest) error {
+	current, err := auth_model.GetScimGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	p := &groupPatch{
+		source:      source,
+		displayName: group.DisplayName,
+		externalID:  group.ExternalID,
+		members:     container.SetOf(current...),
+	}
+	for _, op := range patch.Operations {
+		if err := p.apply(ctx, op); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(p.displayName) == "" {
+		return util.NewInvalidArgumentErrorf("displayName is required")
+	}
+	return updateGroup(ctx, source, group, strings.TrimSpace(p.displayName), p.externalID, p.members)
+}
+
+type groupPatch struct {
+	source      *auth_model.Source
+	displayName string
+	externalID  string
+	members     container.Set[int64]
+}
+
+func (p *groupPatch) apply(ctx context.Context, op PatchOperation) error {
+	opName := strings.ToLower(op.Op)
+	if opName != "add" && opName != "replace" && opName != "remove" {
+		return util.NewInvalidArgumentErrorf("unsupported operation %q", op.Op)
+	}
+	if op.Path == "" {
+		if opName == "remove" {
+			return util.NewInvalidArgumentErrorf("remove operation requires a path")
+		}
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return util.NewInvalidArgumentErrorf("%s operation without path requires an object value", op.Op)
+		}
+		for path, value := range values {
+			if err := p.set(ctx, opName, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.set(ctx, opName, op.Path, op.Value)
+}
+
+func (p *groupPatch) set(ctx context.Context, op, path string, value any) error {
+	path = stripSchema(path, GroupSchema)
+	lowerPath := strings.ToLower(path)
+
+	// members[value eq "42"]
+	if strings.HasPrefix(lowerPath, "members[") && strings.HasSuffix(path, "]") {
+		if op != "remove" {
+			return util.NewInvalidArgumentErrorf("unsupported path %q", path)
+		}
+		expr, err := parseFilter(path[len("members[") : len(path)-1])
+		if err != nil {
+			return err
+		}
+		id, _ := expr.value.(string)
+		if expr.op != "eq" || !strings.EqualFold(expr.attribute, "value") || parseID(id) == 0 {
+			return util.NewInvalidArgumentErrorf("unsupported path %q", path)
+		}
+		p.members.Remove(parseID(id))
+		return nil
+	}
+
+	switch lowerPath {
+	case "displayname":
+		s, err := stringValue(path, value)
+		if err != nil {
+			return err
+		}
+		p.displayName = s
+	case "externalid":
+		s, err := stringValue(path, value)
+		if err != nil {
+			return err
+		}
+		p.externalID = s
+	case "members":
+		refs, err := referencesValue(value)
+		if err != nil {
+			return err
+		}
+		ids, err := memberIDs(ctx, p.source, refs)
+		if err != nil {
+			return err
+		}
+		switch {
+		case op == "replace":
+			p.members = ids
+		case op == "add":
+			p.members.AddMultiple(ids.Values()...)
+		case value == nil:
+			p.members = make(container.Set[int64])
+		default:
+			for id := range ids {
+				p.members.Remove(id)
+			}
+		}
+	default:
+		return util.NewInvalidArgumentErrorf("unsupported path %q", path)
+	}
+	return nil
+}
+
+func referencesValue(value any) ([]Reference, error) {
+	if value == nil {
+		return nil, nil
+	}
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	refs := make([]Reference, 0, len(values))
+	for _, v := range values {
+		m, _ := v.(map[string]any)
+		id, ok := m["value"].(string)
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("members must be a list of objects with a value")
+		}
+		refs = append(refs, Reference{Value: id})
+	}
+	return refs, nil
+}
+
+// DeleteGroup deletes the group and removes its members from the mapped teams
+func DeleteGroup(ctx context.Context, source *auth_model.Source, group *auth_model.ScimGroup) error {
+	current, err := auth_model.GetScimGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if err := auth_model.DeleteScimGroup(ctx, group); err != nil {
+		return err
+	}
+	return syncTeams(ctx, source, container.SetOf(current...))
+}
+
+// syncTeams synchronizes the team memberships of the users with the names of their groups,
+// according to the group team mapping of the source
+func syncTeams(ctx context.Context, source *auth_model.Source, userIDs container.Set[int64]) error {
+	mapper, ok := source.Cfg.(auth_model.GroupTeamMapper)
+	if !ok || len(userIDs) == 0 {
+		return nil
+	}
+	groupTeamMap, removal := mapper.GroupTeamMapping()
+	if groupTeamMap == "" && !removal {
+		return nil
+	}
+	groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(groupTeamMap)
+	if err != nil {
+		return err
+	}
+
+	users, err := user_model.GetUserByIDs(ctx, userIDs.Values())
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		groups, err := auth_model.GetScimGroupsByUserID(ctx, source.ID, u.ID)
+		if err != nil {
+			return err
+		}
+		names := make(container.Set[string], len(groups))
+		for _, group := range groups {
+			names.Add(group.DisplayName)
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, u, names, groupTeamMapping, removal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scim implements the provisioning of users and groups by an identity provider
+// through the System for Cross-domain Identity Management protocol (RFC 7643 and RFC 7644).
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+// Schema URNs defined by RFC 7643 and RFC 7644
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+// ContentType is the media type of SCIM messages
+const ContentType = "application/scim+json"
+
+// MaxResults is the maximum number of resources returned by a list request
+const MaxResults = 200
+
+// Meta holds the metadata of a resource
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// Name is the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is an email address of a user
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Reference refers to another resource, a group of a user or a member of a group
+type Reference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// User is the SCIM representation of a user
+type User struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *Name       `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []Email     `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Groups      []Reference `json:"groups,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// FullName returns the full name given by the display name or by the name of the user
+func (u *User) FullName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// PrimaryEmail returns the primary email address of the user, or the first one if none is primary
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Group is the SCIM representation of a group
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Reference `json:"members,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// ListResponse is the result of a query
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// Error is the body of an error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// PatchOperation is a single operation of a PATCH request
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// PatchRequest is the body of a PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// ListOptions are the query parameters of a list request
+type ListOptions struct {
+	Filter     string
+	StartIndex int
+	Count      int
+	// ExcludeMembers omits the members of the listed groups
+	ExcludeMembers bool
+}
+
+// skipTake converts the one-based start index and the count into an offset and a limit
+func (opts *ListOptions) skipTake() (int, int) {
+	if opts.StartIndex < 1 {
+		opts.StartIndex = 1
+	}
+	if opts.Count <= 0 || opts.Count > MaxResults {
+		opts.Count = MaxResults
+	}
+	return opts.StartIndex - 1, opts.Count
+}
+
+// ErrInvalidFilter represents an invalid or unsupported filter expression
+type ErrInvalidFilter struct {
+	Filter string
+	Reason string
+}
+
+// IsErrInvalidFilter checks if an error is a ErrInvalidFilter.
+func IsErrInvalidFilter(err error) bool {
+	_, ok := err.(ErrInvalidFilter)
+	return ok
+}
+
+func (err ErrInvalidFilter) Error() string {
+	return fmt.Sprintf("invalid filter %q: %s", err.Filter, err.Reason)
+}
+
+func (err ErrInvalidFilter) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// BaseURL returns the URL the SCIM endpoints are served at
+func BaseURL() string {
+	return setting.AppURL + "scim/v2"
+}
+
+func userLocation(id int64) string {
+	return BaseURL() + "/Users/" + strconv.FormatInt(id, 10)
+}
+
+func groupLocation(id int64) string {
+	return BaseURL() + "/Groups/" + strconv.FormatInt(id, 10)
+}
+
+// parseID parses the ID of a resource, IDs which are not numbers do not match any resource
+func parseID(id string) int64 {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return n
+}
+
+// stripSchema removes the schema URN prefix from an attribute path
+func stripSchema(path, schema string) string {
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)], schema) && path[len(schema)] == ':' {
+		return path[len(schema)+1:]
+	}
+	return path
+}
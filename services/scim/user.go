@@ -0,0 +1,341 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	user_service "forgejo.org/services/user"
+
+	"xorm.io/builder"
+)
+
+func sourceUsersCond(source *auth_model.Source) builder.Cond {
+	return builder.Eq{
+		"login_source": source.ID,
+		"type":         user_model.UserTypeIndividual,
+	}
+}
+
+// ToUser converts a user provisioned by the source into its SCIM representation
+func ToUser(ctx context.Context, source *auth_model.Source, u *user_model.User) (*User, error) {
+	groups, err := auth_model.GetScimGroupsByUserID(ctx, source.ID, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	active := !u.ProhibitLogin
+	resource := &User{
+		Schemas:     []string{UserSchema},
+		ID:          strconv.FormatInt(u.ID, 10),
+		UserName:    u.LoginName,
+		DisplayName: u.FullName,
+		Emails:      []Email{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      u.CreatedUnix.AsTime(),
+			LastModified: u.UpdatedUnix.AsTime(),
+			Location:     userLocation(u.ID),
+		},
+	}
+	if u.FullName != "" {
+		resource.Name = &Name{Formatted: u.FullName}
+	}
+	for _, group := range groups {
+		resource.Groups = append(resource.Groups, Reference{
+			Value:   strconv.FormatInt(group.ID, 10),
+			Ref:     groupLocation(group.ID),
+			Display: group.DisplayName,
+		})
+	}
+	return resource, nil
+}
+
+// ListUsers returns the users provisioned by the source which match the filter of the options
+func ListUsers(ctx context.Context, source *auth_model.Source, opts ListOptions) (*ListResponse, error) {
+	cond, err := filterCond(opts.Filter, UserSchema, userFilterAttributes)
+	if err != nil {
+		return nil, err
+	}
+	skip, take := opts.skipTake()
+	users := make([]*user_model.User, 0, take)
+	count, err := db.GetEngine(ctx).Where(sourceUsersCond(source)).And(cond).OrderBy("id ASC").Limit(take, skip).FindAndCount(&users)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: count,
+		StartIndex:   opts.StartIndex,
+		ItemsPerPage: len(users),
+		Resources:    make([]any, 0, len(users)),
+	}
+	for _, u := range users {
+		resource, err := ToUser(ctx, source, u)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, resource)
+	}
+	return resp, nil
+}
+
+// GetUser returns the user provisioned by the source with the given ID
+func GetUser(ctx context.Context, source *auth_model.Source, id string) (*user_model.User, error) {
+	u := &user_model.User{}
+	has, err := db.GetEngine(ctx).Where(sourceUsersCond(source)).And(builder.Eq{"id": parseID(id)}).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, user_model.ErrUserNotExist{UID: parseID(id)}
+	}
+	return u, nil
+}
+
+func validateUser(resource *User) error {
+	if strings.TrimSpace(resource.UserName) == "" {
+		return util.NewInvalidArgumentErrorf("userName is required")
+	}
+	return nil
+}
+
+// CreateUser creates a user linked to the source. The user has no password and can only
+// sign in through the source.
+func CreateUser(ctx context.Context, source *auth_model.Source, resource *User) (*user_model.User, error) {
+	if err := validateUser(resource); err != nil {
+		return nil, err
+	}
+	loginName := strings.TrimSpace(resource.UserName)
+	exist, err := db.GetEngine(ctx).Where(sourceUsersCond(source)).And(builder.Eq{"login_name": loginName}).Exist(new(user_model.User))
+	if err != nil {
+		return nil, err
+	} else if exist {
+		return nil, user_model.ErrUserAlreadyExist{Name: loginName}
+	}
+
+	email := resource.PrimaryEmail()
+	if email == "" && strings.Contains(loginName, "@") {
+		email = loginName
+	}
+	if email == "" {
+		return nil, util.NewInvalidArgumentErrorf("an email address is required")
+	}
+	localPart, _, _ := strings.Cut(loginName, "@")
+	name, err := user_model.NormalizeUserName(localPart)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid userName %q: %v", loginName, err)
+	}
+
+	u := &user_model.User{
+		Name:          name,
+		FullName:      resource.FullName(),
+		Email:         email,
+		LoginType:     source.Type,
+		LoginSource:   source.ID,
+		LoginName:     loginName,
+		ProhibitLogin: resource.Active != nil && !*resource.Active,
+	}
+	if err := user_model.CreateUser(ctx, u, &user_model.CreateUserOverwriteOptions{
+		IsActive: optional.Some(true),
+	}); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ReplaceUser updates the user with the attributes of the resource
+func ReplaceUser(ctx context.Context, source *auth_model.Source, u *user_model.User, resource *User) error {
+	if err := validateUser(resource); err != nil {
+		return err
+	}
+	loginName := strings.TrimSpace(resource.UserName)
+	if loginName != u.LoginName {
+		exist, err := db.GetEngine(ctx).Where(sourceUsersCond(source)).And(builder.Eq{"login_name": loginName}).Exist(new(user_model.User))
+		if err != nil {
+			return err
+		} else if exist {
+			return user_model.ErrUserAlreadyExist{Name: loginName}
+		}
+	}
+
+	if fullName := resource.FullName(); fullName != u.FullName {
+		if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{FullName: optional.Some(fullName)}); err != nil {
+			return err
+		}
+	}
+	if email := resource.PrimaryEmail(); email != "" {
+		if err := user_service.AdminAddOrSetPrimaryEmailAddress(ctx, u, email); err != nil {
+			return err
+		}
+	}
+	authOpts := &user_service.UpdateAuthOptions{LoginName: optional.Some(loginName)}
+	if resource.Active != nil {
+		authOpts.ProhibitLogin = optional.Some(!*resource.Active)
+	}
+	return user_service.UpdateAuth(ctx, u, authOpts)
+}
+
+// PatchUser applies the operations of the request to the user
+func PatchUser(ctx context.Context, source *auth_model.Source, u *user_model.User, patch *PatchRequest) error {
+	resource, err := ToUser(ctx, source, u)
+	if err != nil {
+		return err
+	}
+	patched := make(container.Set[string])
+	for _, op := range patch.Operations {
+		if err := applyUserOperation(resource, op, patched); err != nil {
+			return err
+		}
+	}
+
+	// The current full name is returned both as display name and as formatted name,
+	// a name changed by the request replaces them unless they have been changed too.
+	nameChanged := patched.Contains("name.formatted") || patched.Contains("name.givenname") || patched.Contains("name.familyname")
+	if nameChanged && !patched.Contains("displayname") {
+		resource.DisplayName = ""
+	}
+	if (patched.Contains("name.givenname") || patched.Contains("name.familyname")) && !patched.Contains("name.formatted") {
+		resource.Name.Formatted = ""
+	}
+	return ReplaceUser(ctx, source, u, resource)
+}
+
+func applyUserOperation(resource *User, op PatchOperation, patched container.Set[string]) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		if op.Path != "" {
+			return setUserAttribute(resource, op.Path, op.Value, patched)
+		}
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return util.NewInvalidArgumentErrorf("%s operation without path requires an object value", op.Op)
+		}
+		for path, value := range values {
+			if err := setUserAttribute(resource, path, value, patched); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "remove":
+		if op.Path == "" {
+			return util.NewInvalidArgumentErrorf("remove operation requires a path")
+		}
+		return setUserAttribute(resource, op.Path, nil, patched)
+	}
+	return util.NewInvalidArgumentErrorf("unsupported operation %q", op.Op)
+}
+
+// setUserAttribute sets an attribute of the resource, a nil value removes it.
+// Attributes that cannot be stored are ignored.
+func setUserAttribute(resource *User, path string, value any, patched container.Set[string]) error {
+	path = strings.ToLower(stripSchema(path, UserSchema))
+	if strings.HasPrefix(path, "emails[") {
+		// emails[type eq "work"].value, only a single address is kept
+		path = "emails"
+		if s, ok := value.(string); ok {
+			value = []any{map[string]any{"value": s, "primary": true}}
+		}
+	}
+
+	if resource.Name == nil {
+		resource.Name = &Name{}
+	}
+	patched.Add(path)
+	switch path {
+	case "username":
+		s, err := stringValue(path, value)
+		if err != nil {
+			return err
+		}
+		resource.UserName = s
+	case "displayname":
+		s, err := stringValue(path, value)
+		if err != nil {
+			return err
+		}
+		resource.DisplayName = s
+	case "name":
+		name, _ := value.(map[string]any)
+		resource.Name = &Name{}
+		patched.Add("name.formatted")
+		for k, v := range name {
+			if err := setUserAttribute(resource, "name."+k, v, patched); err != nil {
+				return err
+			}
+		}
+	case "name.formatted", "name.givenname", "name.familyname":
+		s, err := stringValue(path, value)
+		if err != nil {
+			return err
+		}
+		switch path {
+		case "name.formatted":
+			resource.Name.Formatted = s
+		case "name.givenname":
+			resource.Name.GivenName = s
+		case "name.familyname":
+			resource.Name.FamilyName = s
+		}
+	case "active":
+		active, err := boolValue(path, value)
+		if err != nil {
+			return err
+		}
+		resource.Active = &active
+	case "emails":
+		emails, _ := value.([]any)
+		resource.Emails = resource.Emails[:0]
+		for _, v := range emails {
+			email, _ := v.(map[string]any)
+			address, _ := email["value"].(string)
+			primary, _ := email["primary"].(bool)
+			if address != "" {
+				resource.Emails = append(resource.Emails, Email{Value: address, Primary: primary})
+			}
+		}
+	}
+	return nil
+}
+
+func stringValue(path string, value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", util.NewInvalidArgumentErrorf("%s must be a string", path)
+	}
+	return s, nil
+}
+
+// boolValue accepts booleans and their string representations, as sent by some identity providers
+func boolValue(path string, value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return false, util.NewInvalidArgumentErrorf("%s must be a boolean", path)
+}
+
+// DeleteUser deletes the user provisioned by the source
+func DeleteUser(ctx context.Context, source *auth_model.Source, u *user_model.User) error {
+	if u.LoginSource != source.ID {
+		return fmt.Errorf("user %d is not provisioned by source %d", u.ID, source.ID)
+	}
+	return user_service.DeleteUser(ctx, u, false)
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"forgejo.org/modules/container"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUserAttributes(t *testing.T) {
+	active := true
+	resource := &User{
+		UserName:    "jdoe@example.com",
+		DisplayName: "John Doe",
+		Name:        &Name{Formatted: "John Doe"},
+		Emails:      []Email{{Value: "jdoe@example.com", Primary: true}},
+		Active:      &active,
+	}
+	require.NoError(t, applyUserOperation(resource, PatchOperation{Op: "Replace", Value: map[string]any{
+		"active":         "False",
+		"name.givenName": "Jane",
+	}}, make(container.Set[string])))
+	assert.False(t, *resource.Active)
+	assert.Equal(t, "Jane", resource.Name.GivenName)
+
+	require.NoError(t, applyUserOperation(resource, PatchOperation{
+		Op:    "replace",
+		Path:  `emails[type eq "work"].value`,
+		Value: "jane@example.com",
+	}, make(container.Set[string])))
+	assert.Equal(t, "jane@example.com", resource.PrimaryEmail())
+
+	require.Error(t, applyUserOperation(resource, PatchOperation{Op: "replace", Path: "active", Value: 3}, make(container.Set[string])))
+	require.Error(t, applyUserOperation(resource, PatchOperation{Op: "remove"}, make(container.Set[string])))
+	require.Error(t, applyUserOperation(resource, PatchOperation{Op: "move", Path: "active"}, make(container.Set[string])))
+}
@@ -6,8 +6,10 @@ package secrets
 import (
 	"context"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	secret_model "forgejo.org/models/secret"
+	audit_service "forgejo.org/services/audit"
 )
 
 func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data string) (*secret_model.Secret, bool, error) {
@@ -29,12 +31,46 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 		if err != nil {
 			return nil, false, err
 		}
+		recordSecret(ctx, audit_model.ActionSecretCreate, s)
 		return s, true, nil
 	}
 
 	if err := secret_model.UpdateSecret(ctx, s[0].ID, data); err != nil {
 		return nil, false, err
 	}
+	recordSecret(ctx, audit_model.ActionSecretUpdate, s[0])
+
+	return s[0], false, nil
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of an environment of a repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, data string) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, false, err
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(s) == 0 {
+		s, err := secret_model.InsertEncryptedEnvironmentSecret(ctx, repoID, environmentID, name, data)
+		if err != nil {
+			return nil, false, err
+		}
+		recordSecret(ctx, audit_model.ActionSecretCreate, s)
+		return s, true, nil
+	}
+
+	if err := secret_model.UpdateSecret(ctx, s[0].ID, data); err != nil {
+		return nil, false, err
+	}
+	recordSecret(ctx, audit_model.ActionSecretUpdate, s[0])
 
 	return s[0], false, nil
 }
@@ -55,6 +91,23 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 	return deleteSecret(ctx, s[0])
 }
 
+// DeleteEnvironmentSecretByID deletes a secret of an environment of a repository
+func DeleteEnvironmentSecretByID(ctx context.Context, repoID, environmentID, secretID int64) error {
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		SecretID:      secretID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(s) != 1 {
+		return secret_model.ErrSecretNotFound{}
+	}
+
+	return deleteSecret(ctx, s[0])
+}
+
 func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
 	if err := ValidateName(name); err != nil {
 		return err
@@ -79,5 +132,17 @@ func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
 	if _, err := db.DeleteByID[secret_model.Secret](ctx, s.ID); err != nil {
 		return err
 	}
+	recordSecret(ctx, audit_model.ActionSecretDelete, s)
 	return nil
 }
+
+// recordSecret records an event about a secret in the audit log, its data is never recorded
+func recordSecret(ctx context.Context, action audit_model.Action, s *secret_model.Secret) {
+	audit_service.Record(ctx, nil, action, audit_service.Target{
+		OwnerID: s.OwnerID,
+		RepoID:  s.RepoID,
+		Type:    "secret",
+		ID:      s.ID,
+		Name:    s.Name,
+	}, nil, nil)
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"errors"
+
+	repo_model "forgejo.org/models/repo"
+	system_model "forgejo.org/models/system"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+)
+
+// historyScanRequest is a repository whose history is to be scanned, and the user who requested it
+type historyScanRequest struct {
+	RepoID int64
+	DoerID int64
+}
+
+// historyScanQueue represents a queue to scan the history of repositories in the background
+var historyScanQueue *queue.WorkerPoolQueue[*historyScanRequest]
+
+func handleHistoryScan(items ...*historyScanRequest) []*historyScanRequest {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, req := range items {
+		repo, err := repo_model.GetRepositoryByID(ctx, req.RepoID)
+		if err != nil {
+			log.Error("GetRepositoryByID [%d]: %v", req.RepoID, err)
+			continue
+		}
+		doer, err := user_model.GetPossibleUserByID(ctx, req.DoerID)
+		if err != nil {
+			log.Error("GetPossibleUserByID [%d]: %v", req.DoerID, err)
+			continue
+		}
+		if err := scanHistory(ctx, doer, repo); err != nil {
+			log.Error("Unable to scan the history of %-v for secrets: %v", repo, err)
+			if err := system_model.CreateRepositoryNotice("Unable to scan the history of %s for secrets: %v", repo.FullName(), err); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Init starts the queue scanning the history of repositories
+func Init() error {
+	historyScanQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "secret_scanning_history", handleHistoryScan)
+	if historyScanQueue == nil {
+		return errors.New("unable to create secret_scanning_history queue")
+	}
+	go graceful.GetManager().RunWithCancel(historyScanQueue)
+	return nil
+}
+
+// AddRepoToHistoryScanQueue scans the history of a repository for secrets in the background
+func AddRepoToHistoryScanQueue(repo *repo_model.Repository, doer *user_model.User) error {
+	return historyScanQueue.Push(&historyScanRequest{RepoID: repo.ID, DoerID: doer.ID})
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/git/pushoptions"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/secretscan"
+	auth_service "forgejo.org/services/auth"
+	"forgejo.org/services/mailer"
+	notify_service "forgejo.org/services/notify"
+)
+
+// maxReported is the number of secrets listed to the pusher when a push is rejected
+const maxReported = 10
+
+// leak is a secret found in a repository which has not been recorded yet
+type leak struct {
+	alert *secretscan_model.Alert
+	// token is the access token of the instance the secret is, revoked once the secret is recorded
+	token *auth_model.AccessToken
+}
+
+func (l *leak) String() string {
+	return fmt.Sprintf("commit %s: file %s line %d: %s %s", base.ShortSha(l.alert.CommitID), l.alert.Path, l.alert.Line, l.alert.PatternName, l.alert.MaskedSecret)
+}
+
+// scanCommits returns the secrets found in the files added or modified by commits, at the first location
+// they appear at, except those already in seen
+func scanCommits(ctx context.Context, repo *repo_model.Repository, env []string, patterns []*secretscan.Pattern, commits []*git.PushedCommit, seen map[string]bool) ([]*leak, error) {
+	blobs, err := git.GetPushedBlobs(ctx, repo.RepoPath(), env, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaks []*leak
+	if err := git.ReadPushedBlobs(ctx, repo.RepoPath(), env, blobs, func(b *git.PushedBlob, content []byte) error {
+		for _, f := range secretscan.Scan(patterns, content) {
+			hash := f.Hash()
+			if seen[hash] {
+				continue
+			}
+			l := &leak{alert: &secretscan_model.Alert{
+				RepoID:       repo.ID,
+				SecretHash:   hash,
+				PatternID:    f.Pattern.ID,
+				PatternName:  f.Pattern.Name,
+				MaskedSecret: f.Masked(),
+				CommitID:     b.CommitID,
+				Path:         b.Path,
+				Line:         f.Line,
+			}}
+			// only the tokens of this instance are reported, the other candidates are mostly commit IDs
+			if f.Pattern.ID == secretscan.PatternForgejoAccessToken {
+				token, err := auth_model.GetAccessTokenBySHA(ctx, f.Secret)
+				if auth_model.IsErrAccessTokenNotExist(err) {
+					continue
+				} else if err != nil {
+					return err
+				}
+				l.token = token
+			}
+			seen[hash] = true
+			leaks = append(leaks, l)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("ReadPushedBlobs: %w", err)
+	}
+	return leaks, nil
+}
+
+// record creates the alerts for the secrets which have not been reported yet, revokes the access tokens
+// of the instance among them and notifies the new alerts
+func record(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, leaks []*leak) error {
+	alerts := make([]*secretscan_model.Alert, 0, len(leaks))
+	tokens := make(map[*secretscan_model.Alert]*auth_model.AccessToken)
+	for _, l := range leaks {
+		alerts = append(alerts, l.alert)
+		if l.token != nil {
+			tokens[l.alert] = l.token
+		}
+	}
+	inserted, err := secretscan_model.InsertNewAlerts(ctx, alerts)
+	if err != nil {
+		return fmt.Errorf("InsertNewAlerts: %w", err)
+	}
+	if len(inserted) == 0 {
+		return nil
+	}
+	notify_service.NewSecretScanningAlerts(ctx, doer, repo, inserted)
+
+	for _, alert := range inserted {
+		token, ok := tokens[alert]
+		if !ok {
+			continue
+		}
+		if err := revokeToken(ctx, repo, alert, token); err != nil {
+			log.Error("Unable to revoke the access token %d found in %-v: %v", token.ID, repo, err)
+			continue
+		}
+		notify_service.SecretScanningAlertStateChange(ctx, doer, alert)
+	}
+	return nil
+}
+
+// revokeToken deletes an access token found in a repository and resolves its alert
+func revokeToken(ctx context.Context, repo *repo_model.Repository, alert *secretscan_model.Alert, token *auth_model.AccessToken) error {
+	owner, err := user_model.GetUserByID(ctx, token.UID)
+	if err != nil {
+		return err
+	}
+	if err := auth_service.DeleteAccessToken(ctx, owner, token.ID); err != nil {
+		return err
+	}
+	log.Info("Access token %d of %s found in %-v has been revoked", token.ID, owner.Name, repo)
+	if err := mailer.SendAccessTokenRevokedMail(owner, token, repo); err != nil {
+		log.Error("SendAccessTokenRevokedMail: %v", err)
+	}
+	return secretscan_model.ResolveAlert(ctx, alert, 0, secretscan_model.ResolutionRevoked, "")
+}
+
+// PushScanner searches the secrets in the commits of a push, for each reference it updates
+type PushScanner struct {
+	repo   *repo_model.Repository
+	env    []string
+	config *secretscan_model.RepoConfig
+	leaks  []*leak
+	seen   map[string]bool
+}
+
+// NewPushScanner returns a scanner for a push to a repository, nil if secret scanning is disabled for it
+func NewPushScanner(ctx context.Context, repo *repo_model.Repository, env []string) (*PushScanner, error) {
+	config, err := secretscan_model.GetRepoConfig(ctx, repo)
+	if err != nil || !config.Mode.IsEnabled() {
+		return nil, err
+	}
+	return &PushScanner{repo: repo, env: env, config: config, seen: make(map[string]bool)}, nil
+}
+
+// Scan searches the secrets in the commits reachable from newCommitID and not from the references of the repository,
+// at most the git.MaxPushedCommits most recent ones
+func (s *PushScanner) Scan(ctx context.Context, newCommitID string) error {
+	commits, err := git.GetPushedCommits(ctx, s.repo.RepoPath(), s.env, newCommitID)
+	if err != nil {
+		return fmt.Errorf("GetPushedCommits: %w", err)
+	}
+	return s.scan(ctx, commits)
+}
+
+// ScanMerge searches the secrets in the commits of a pull request merged by a push, those reachable from newCommitID
+// and not from the branches and tags of the repository. The commits of pull requests from forks were pushed to the
+// fork and are only in the pull request references.
+func (s *PushScanner) ScanMerge(ctx context.Context, newCommitID string) error {
+	commits, err := git.GetMergedCommits(ctx, s.repo.RepoPath(), s.env, newCommitID)
+	if err != nil {
+		return fmt.Errorf("GetMergedCommits: %w", err)
+	}
+	return s.scan(ctx, commits)
+}
+
+func (s *PushScanner) scan(ctx context.Context, commits []*git.PushedCommit) error {
+	leaks, err := scanCommits(ctx, s.repo, s.env, s.config.Patterns, commits, s.seen)
+	if err != nil {
+		return err
+	}
+	s.leaks = append(s.leaks, leaks...)
+	return nil
+}
+
+// BypassReason returns the reason given with the push option to bypass the push protection, empty if none
+func BypassReason(opts pushoptions.Interface) string {
+	reason, _ := opts.GetString(pushoptions.SecretScanningBypass)
+	// the option without a value is not a reason
+	if reason == "true" {
+		return ""
+	}
+	return strings.TrimSpace(reason)
+}
+
+// Blocked returns the message rejecting the push if it contains secrets and the push protection has not
+// been bypassed, empty if the push is accepted
+func (s *PushScanner) Blocked(bypassReason string) string {
+	if s.config.Mode != secretscan_model.ModeBlock || len(s.leaks) == 0 || bypassReason != "" {
+		return ""
+	}
+	lines := make([]string, 0, maxReported+1)
+	for i, l := range s.leaks {
+		if i == maxReported {
+			lines = append(lines, fmt.Sprintf("and %d more", len(s.leaks)-maxReported))
+			break
+		}
+		lines = append(lines, l.String())
+	}
+	return "push rejected because it contains secrets:\n  " + strings.Join(lines, "\n  ") +
+		"\nremove them from the commits, or push again with -o " + string(pushoptions.SecretScanningBypass) + "=\"<reason>\" if they have to be pushed"
+}
+
+// Record creates the alerts for the secrets found in the push once it is accepted
+func (s *PushScanner) Record(ctx context.Context, pusher *user_model.User, bypassReason string) error {
+	if len(s.leaks) == 0 {
+		return nil
+	}
+	for _, l := range s.leaks {
+		l.alert.PusherID = pusher.ID
+		if s.config.Mode == secretscan_model.ModeBlock && bypassReason != "" {
+			l.alert.BypasserID = pusher.ID
+			l.alert.BypassReason = bypassReason
+		}
+	}
+	return record(ctx, pusher, s.repo, s.leaks)
+}
+
+// scanHistory searches the secrets in all the commits of a repository and creates the alerts for those
+// which have not been reported yet. It can take long and is only run by the history scan queue.
+func scanHistory(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) error {
+	config, err := secretscan_model.GetRepoConfig(ctx, repo)
+	if err != nil {
+		return err
+	}
+	// the history is scanned in batches as large as the pushes, from the oldest commits
+	seen := make(map[string]bool)
+	if err := git.WalkAllCommits(ctx, repo.RepoPath(), git.MaxPushedCommits, func(commits []*git.PushedCommit) error {
+		leaks, err := scanCommits(ctx, repo, nil, config.Patterns, commits, seen)
+		if err != nil || len(leaks) == 0 {
+			return err
+		}
+		return record(ctx, doer, repo, leaks)
+	}); err != nil {
+		return fmt.Errorf("WalkAllCommits: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"context"
+	"fmt"
+
+	admin_model "forgejo.org/models/admin"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/process"
+	"forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	issue_service "forgejo.org/services/issue"
+)
+
+// bulkEditReportInterval is the number of issues edited between two saves of the report of a task
+const bulkEditReportInterval = 50
+
+// BulkEditIssuesPayload is the payload of a bulk edit task, the issues are resolved when the task is created
+type BulkEditIssuesPayload struct {
+	Query    string
+	IssueIDs []int64
+	Options  issue_service.BulkEditOptions
+}
+
+// BulkEditIssuesReport is the progress of a bulk edit task, it is stored as the message of the task
+type BulkEditIssuesReport struct {
+	Total     int
+	Processed int
+	Failed    int
+	Results   []*BulkEditIssueResult
+	// Error is the reason why the whole task failed
+	Error string `json:",omitempty"`
+}
+
+// BulkEditIssueResult is the outcome of a bulk edit for one issue
+type BulkEditIssueResult struct {
+	IssueID  int64
+	RepoName string
+	Index    int64
+	Error    string `json:",omitempty"`
+}
+
+// BulkEditIssues creates a task editing all issues matching the query and queues it.
+// If owner is not nil, only the issues in the repositories of owner are edited.
+func BulkEditIssues(ctx context.Context, doer, owner *user_model.User, query string, opts issue_service.BulkEditOptions) (*admin_model.Task, error) {
+	if opts.IsEmpty() {
+		return nil, util.NewInvalidArgumentErrorf("no change requested")
+	}
+	ids, err := issue_service.FindBulkEditIssueIDs(ctx, doer, owner, query)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&BulkEditIssuesPayload{
+		Query:    query,
+		IssueIDs: ids,
+		Options:  opts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	message, err := json.Marshal(&BulkEditIssuesReport{Total: len(ids)})
+	if err != nil {
+		return nil, err
+	}
+	task := &admin_model.Task{
+		DoerID:         doer.ID,
+		Type:           structs.TaskTypeBulkEditIssues,
+		Status:         structs.TaskStatusQueued,
+		PayloadContent: string(payload),
+		Message:        string(message),
+	}
+	if owner != nil {
+		task.OwnerID = owner.ID
+	}
+	if err := admin_model.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, taskQueue.Push(task)
+}
+
+// GetBulkEditIssuesTask returns a bulk edit task of the doer with its payload and its report
+func GetBulkEditIssuesTask(ctx context.Context, id, doerID int64) (*admin_model.Task, *BulkEditIssuesPayload, *BulkEditIssuesReport, error) {
+	task, err := admin_model.GetTaskByID(ctx, id, doerID, structs.TaskTypeBulkEditIssues)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var payload BulkEditIssuesPayload
+	if err := json.Unmarshal([]byte(task.PayloadContent), &payload); err != nil {
+		return nil, nil, nil, err
+	}
+	var report BulkEditIssuesReport
+	if err := json.Unmarshal([]byte(task.Message), &report); err != nil {
+		return nil, nil, nil, err
+	}
+	return task, &payload, &report, nil
+}
+
+func runBulkEditIssuesTask(ctx context.Context, t *admin_model.Task) (err error) {
+	report := &BulkEditIssuesReport{}
+	saveReport := func(ctx context.Context, cols ...string) error {
+		bs, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		t.Message = string(bs)
+		return t.UpdateCols(ctx, append(cols, "message")...)
+	}
+
+	defer func(ctx context.Context) {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("PANIC whilst trying to do bulk edit task: %v", e)
+			log.Critical("PANIC during runBulkEditIssuesTask[%d] by DoerID[%d]: %v\nStacktrace: %v", t.ID, t.DoerID, e, log.Stack(2))
+		}
+		t.EndTime = timeutil.TimeStampNow()
+		t.Status = structs.TaskStatusFinished
+		if err != nil {
+			log.Error("runBulkEditIssuesTask[%d] by DoerID[%d] failed: %v", t.ID, t.DoerID, err)
+			t.Status = structs.TaskStatusFailed
+			report.Error = err.Error()
+		}
+		if err := saveReport(ctx, "status", "end_time"); err != nil {
+			log.Error("Task UpdateCols failed: %v", err)
+		}
+	}(graceful.GetManager().ShutdownContext()) // even if the parent ctx is canceled, this defer-function still needs to update the task record in database
+
+	var payload BulkEditIssuesPayload
+	if err = json.Unmarshal([]byte(t.PayloadContent), &payload); err != nil {
+		return err
+	}
+	report.Total = len(payload.IssueIDs)
+	if err = t.LoadDoer(ctx); err != nil {
+		return err
+	}
+
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("BulkEditIssuesTask: %d", t.ID))
+	defer finished()
+
+	t.StartTime = timeutil.TimeStampNow()
+	t.Status = structs.TaskStatusRunning
+	if err = saveReport(ctx, "start_time", "status"); err != nil {
+		return err
+	}
+
+	for _, id := range payload.IssueIDs {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		result := &BulkEditIssueResult{IssueID: id}
+		if editErr := bulkEditIssue(ctx, t.Doer, id, &payload.Options, result); editErr != nil {
+			log.Debug("BulkEditIssuesTask[%d] failed to edit issue %d: %v", t.ID, id, editErr)
+			result.Error = editErr.Error()
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+		report.Processed++
+		// the report is saved at the end of the task by the deferred function
+		if report.Processed%bulkEditReportInterval == 0 && report.Processed < report.Total {
+			if err = saveReport(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func bulkEditIssue(ctx context.Context, doer *user_model.User, id int64, opts *issue_service.BulkEditOptions, result *BulkEditIssueResult) error {
+	issue, err := issues_model.GetIssueByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	result.RepoName = issue.Repo.FullName()
+	result.Index = issue.Index
+	return issue_service.BulkEdit(ctx, doer, issue, opts)
+}
@@ -207,6 +207,12 @@ func (dc dingtalkConvertor) Package(p *api.PackagePayload) (DingtalkPayload, err
 	return createDingtalkPayload(text, text, "view package", p.Package.HTMLURL), nil
 }
 
+func (dc dingtalkConvertor) SecretScanningAlert(p *api.SecretScanningAlertPayload) (DingtalkPayload, error) {
+	text, _ := getSecretScanningAlertPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view alert", p.Alert.HTMLURL), nil
+}
+
 func createDingtalkPayload(title, text, singleTitle, singleURL string) DingtalkPayload {
 	return DingtalkPayload{
 		MsgType: "actionCard",
@@ -304,6 +304,28 @@ func getPackagePayloadInfo(p *api.PackagePayload, linkFormatter linkFormatter, w
 	return text, color
 }
 
+func getSecretScanningAlertPayloadInfo(p *api.SecretScanningAlertPayload, linkFormatter linkFormatter, withSender bool) (text string, color int) {
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+	alertLink := linkFormatter(p.Alert.HTMLURL, fmt.Sprintf("%s %s", p.Alert.PatternName, p.Alert.MaskedSecret))
+
+	switch p.Action {
+	case api.HookSecretScanningAlertCreated:
+		text = fmt.Sprintf("[%s] Secret found in %s: %s", repoLink, p.Alert.Path, alertLink)
+		color = redColor
+	case api.HookSecretScanningAlertResolved:
+		text = fmt.Sprintf("[%s] Secret scanning alert resolved as %s: %s", repoLink, p.Alert.Resolution, alertLink)
+		color = greenColor
+	case api.HookSecretScanningAlertReopened:
+		text = fmt.Sprintf("[%s] Secret scanning alert reopened: %s", repoLink, alertLink)
+		color = orangeColor
+	}
+	if withSender {
+		text += fmt.Sprintf(" by %s", p.Sender.UserName)
+	}
+
+	return text, color
+}
+
 // ToHook convert models.Webhook to api.Hook
 // This function is not part of the convert package to prevent an import cycle
 func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
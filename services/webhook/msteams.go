@@ -326,6 +326,20 @@ func (m msteamsConvertor) Package(p *api.PackagePayload) (MSTeamsPayload, error)
 	), nil
 }
 
+func (m msteamsConvertor) SecretScanningAlert(p *api.SecretScanningAlertPayload) (MSTeamsPayload, error) {
+	title, color := getSecretScanningAlertPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		p.Alert.HTMLURL,
+		color,
+		&MSTeamsFact{"Path:", p.Alert.Path},
+	), nil
+}
+
 func createMSTeamsPayload(r *api.Repository, s *api.User, title, text, actionTarget string, color int, fact *MSTeamsFact) MSTeamsPayload {
 	facts := make([]MSTeamsFact, 0, 2)
 	if r != nil {
@@ -11,6 +11,7 @@ import (
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	secretscan_model "forgejo.org/models/secretscan"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/log"
@@ -887,6 +888,37 @@ func (m *webhookNotifier) PackageDelete(ctx context.Context, doer *user_model.Us
 	notifyPackage(ctx, doer, pd, api.HookPackageDeleted)
 }
 
+func (m *webhookNotifier) NewSecretScanningAlerts(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, alerts []*secretscan_model.Alert) {
+	for _, alert := range alerts {
+		alert.Repo = repo
+		notifySecretScanningAlert(ctx, doer, alert, api.HookSecretScanningAlertCreated)
+	}
+}
+
+func (m *webhookNotifier) SecretScanningAlertStateChange(ctx context.Context, doer *user_model.User, alert *secretscan_model.Alert) {
+	action := api.HookSecretScanningAlertResolved
+	if alert.IsOpen() {
+		action = api.HookSecretScanningAlertReopened
+	}
+	notifySecretScanningAlert(ctx, doer, alert, action)
+}
+
+func notifySecretScanningAlert(ctx context.Context, doer *user_model.User, alert *secretscan_model.Alert, action api.HookSecretScanningAlertAction) {
+	if err := alert.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes: %v", err)
+		return
+	}
+
+	if err := PrepareWebhooks(ctx, EventSource{Repository: alert.Repo}, webhook_module.HookEventSecretScanningAlert, &api.SecretScanningAlertPayload{
+		Action:     action,
+		Alert:      convert.ToSecretScanningAlert(alert),
+		Repository: convert.ToRepo(ctx, alert.Repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
 func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_model.PackageDescriptor, action api.HookPackageAction) {
 	source := EventSource{
 		Repository: pd.Repository,
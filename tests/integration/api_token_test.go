@@ -580,3 +580,27 @@ func deleteAPIAccessToken(t *testing.T, accessToken api.AccessToken, user *user_
 
 	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{ID: accessToken.ID})
 }
+
+// TestAPIRestrictedAdminToken checks that an admin token restricted to a repository cannot reach
+// the other repositories through the admin and user routes
+func TestAPIRestrictedAdminToken(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/users/"+user.LoginName+"/tokens", &api.CreateAccessTokenOption{
+		Name:      "restricted-admin",
+		Scopes:    []string{string(auth_model.AccessTokenScopeAll)},
+		Resources: []*api.AccessTokenResource{{Repository: "user2/repo1"}},
+	}).AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var token api.AccessToken
+	DecodeJSON(t, resp, &token)
+	defer deleteAPIAccessToken(t, token, user)
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token.Token), http.StatusOK)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo2").AddTokenAuth(token.Token), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/user/repos").AddTokenAuth(token.Token), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/users/user2/subscriptions").AddTokenAuth(token.Token), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/unadopted").AddTokenAuth(token.Token), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/users/user2/repos").AddTokenAuth(token.Token), http.StatusForbidden)
+}
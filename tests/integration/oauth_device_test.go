@@ -0,0 +1,115 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/routers/web/auth"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthDeviceAuthorization(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const (
+		clientID     = "da7da3ba-9a13-4167-856f-3899de0b0138"
+		clientSecret = "4MK8Na6R55smdCY0WuCCumZ6hjRPnGY5saWVRHHjJiA="
+	)
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 1})
+	setAllowDeviceGrant := func(allow bool) {
+		app.AllowDeviceGrant = allow
+		_, err := db.GetEngine(db.DefaultContext).ID(app.ID).Cols("allow_device_grant").Update(app)
+		require.NoError(t, err)
+	}
+
+	requestDeviceCode := func(status int) *auth.DeviceAuthorizationResponse {
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+			"client_id":     clientID,
+			"client_secret": clientSecret,
+			"scope":         "openid",
+		})
+		resp := MakeRequest(t, req, status)
+		deviceAuthorization := new(auth.DeviceAuthorizationResponse)
+		DecodeJSON(t, resp, deviceAuthorization)
+		return deviceAuthorization
+	}
+	pollToken := func(deviceCode string, status int) *httptest.ResponseRecorder {
+		req := NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+			"grant_type":    "urn:ietf:params:oauth:grant-type:device_code",
+			"client_id":     clientID,
+			"client_secret": clientSecret,
+			"device_code":   deviceCode,
+		})
+		return MakeRequest(t, req, status)
+	}
+	assertTokenError := func(deviceCode string, errorCode string) {
+		t.Helper()
+		tokenError := new(auth.AccessTokenError)
+		DecodeJSON(t, pollToken(deviceCode, http.StatusBadRequest), tokenError)
+		assert.EqualValues(t, errorCode, tokenError.ErrorCode)
+	}
+
+	t.Run("NotAllowed", func(t *testing.T) {
+		requestDeviceCode(http.StatusBadRequest)
+	})
+
+	t.Run("Polling", func(t *testing.T) {
+		setAllowDeviceGrant(true)
+		deviceAuthorization := requestDeviceCode(http.StatusOK)
+		assert.NotEmpty(t, deviceAuthorization.DeviceCode)
+		assert.NotEmpty(t, deviceAuthorization.UserCode)
+		assert.EqualValues(t, 5, deviceAuthorization.Interval)
+
+		assertTokenError(deviceAuthorization.DeviceCode, auth.AccessTokenErrorCodeAuthorizationPending)
+		assertTokenError(deviceAuthorization.DeviceCode, auth.AccessTokenErrorCodeSlowDown)
+
+		session := loginUser(t, "user2")
+		req := NewRequestWithValues(t, "POST", "/login/device/grant", map[string]string{
+			"_csrf":     GetCSRF(t, session, "/login/device"),
+			"user_code": deviceAuthorization.UserCode,
+			"granted":   "true",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		resp := pollToken(deviceAuthorization.DeviceCode, http.StatusOK)
+		tokenResponse := new(auth.AccessTokenResponse)
+		DecodeJSON(t, resp, tokenResponse)
+		assert.NotEmpty(t, tokenResponse.AccessToken)
+		assert.NotEmpty(t, tokenResponse.RefreshToken)
+
+		// the device code cannot be used twice
+		assertTokenError(deviceAuthorization.DeviceCode, auth.AccessTokenErrorCodeInvalidGrant)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		setAllowDeviceGrant(true)
+		deviceAuthorization := requestDeviceCode(http.StatusOK)
+
+		session := loginUser(t, "user2")
+		req := NewRequestWithValues(t, "POST", "/login/device/grant", map[string]string{
+			"_csrf":     GetCSRF(t, session, "/login/device"),
+			"user_code": deviceAuthorization.UserCode,
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		assertTokenError(deviceAuthorization.DeviceCode, auth.AccessTokenErrorCodeAccessDenied)
+	})
+
+	t.Run("DisallowedAfterAuthorization", func(t *testing.T) {
+		setAllowDeviceGrant(true)
+		deviceAuthorization := requestDeviceCode(http.StatusOK)
+		setAllowDeviceGrant(false)
+
+		assertTokenError(deviceAuthorization.DeviceCode, auth.AccessTokenErrorCodeUnauthorizedClient)
+	})
+}
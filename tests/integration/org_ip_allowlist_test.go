@@ -0,0 +1,103 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrgIPAllowlistCrossRepoSearch checks that the repositories and the issues of the organizations
+// whose IP allowlist denies the request are left out of the lists and the searches spanning several owners
+func TestOrgIPAllowlistCrossRepoSearch(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// org3/repo21 is public and holds the issue #1 posted by user2, org17/big_test_public_4 the pull request #1
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadIssue)
+
+	setAllowlists := func(enabled bool) {
+		for _, orgID := range []int64{3, 17} {
+			l, err := organization.GetIPAllowlist(db.DefaultContext, orgID)
+			require.NoError(t, err)
+			l.Enabled = enabled
+			l.Ranges = "10.0.0.0/8"
+			require.NoError(t, organization.SaveIPAllowlist(db.DefaultContext, l))
+		}
+	}
+
+	checks := map[string]func(t *testing.T, visible bool){
+		"APIGetByID": func(t *testing.T, visible bool) {
+			status := http.StatusForbidden
+			if visible {
+				status = http.StatusOK
+			}
+			MakeRequest(t, NewRequest(t, "GET", "/api/v1/repositories/32").AddTokenAuth(token), status)
+		},
+		"APIRepoSearch": func(t *testing.T, visible bool) {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/search?q=repo21").AddTokenAuth(token), http.StatusOK)
+			var result api.SearchResults
+			DecodeJSON(t, resp, &result)
+			names := make([]string, 0, len(result.Data))
+			for _, repo := range result.Data {
+				names = append(names, repo.FullName)
+			}
+			if visible {
+				assert.Contains(t, names, "org3/repo21")
+			} else {
+				assert.NotContains(t, names, "org3/repo21")
+			}
+		},
+		"APIIssueSearch": func(t *testing.T, visible bool) {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/issues/search?limit=50").AddTokenAuth(token), http.StatusOK)
+			var issues []*api.Issue
+			DecodeJSON(t, resp, &issues)
+			found := false
+			for _, issue := range issues {
+				found = found || issue.Repo.FullName == "org3/repo21" || issue.Repo.FullName == "org17/big_test_public_4"
+			}
+			assert.Equal(t, visible, found)
+		},
+		"WebRepoSearch": func(t *testing.T, visible bool) {
+			resp := session.MakeRequest(t, NewRequest(t, "GET", "/repo/search?q=repo21"), http.StatusOK)
+			assert.Equal(t, visible, strings.Contains(resp.Body.String(), "org3/repo21"))
+		},
+		"WebIssueSearch": func(t *testing.T, visible bool) {
+			resp := session.MakeRequest(t, NewRequest(t, "GET", "/issues/search?limit=50"), http.StatusOK)
+			assert.Equal(t, visible, strings.Contains(resp.Body.String(), "org3/repo21"))
+		},
+		"DashboardIssues": func(t *testing.T, visible bool) {
+			resp := session.MakeRequest(t, NewRequest(t, "GET", "/issues?type=created_by"), http.StatusOK)
+			assert.Equal(t, visible, strings.Contains(resp.Body.String(), "/org3/repo21/issues/1"))
+		},
+		"DashboardPulls": func(t *testing.T, visible bool) {
+			resp := session.MakeRequest(t, NewRequest(t, "GET", "/pulls?type=created_by"), http.StatusOK)
+			assert.Equal(t, visible, strings.Contains(resp.Body.String(), "/org17/big_test_public_4/pulls/1"))
+		},
+		"Explore": func(t *testing.T, visible bool) {
+			resp := session.MakeRequest(t, NewRequest(t, "GET", "/explore/repos?q=repo21"), http.StatusOK)
+			assert.Equal(t, visible, strings.Contains(resp.Body.String(), "/org3/repo21"))
+		},
+	}
+
+	for name, check := range checks {
+		t.Run(name, func(t *testing.T) {
+			setAllowlists(false)
+			check(t, true)
+			setAllowlists(true)
+			check(t, false)
+		})
+	}
+	setAllowlists(false)
+}
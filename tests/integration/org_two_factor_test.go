@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/mailer"
+	org_service "forgejo.org/services/org"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgTwoFactorPolicyNotifications(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.MailService, &setting.Mailer{From: "forgejo@localhost"})()
+
+	var recipients []string
+	defer test.MockVariableValue(&mailer.SendAsync, func(msgs ...*mailer.Message) {
+		for _, msg := range msgs {
+			recipients = append(recipients, msg.To)
+		}
+	})()
+	sentTo := func() string {
+		defer func() { recipients = nil }()
+		return strings.Join(recipients, ",")
+	}
+
+	// user2 owns org3, whose other members user4 and user28 have not enabled two-factor authentication
+	session := loginUser(t, "user2")
+	settingsURL := "/org/org3/settings/two_factor"
+	requireTwoFactor := func(t *testing.T, status int) {
+		t.Helper()
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{
+			"_csrf":      GetCSRF(t, session, settingsURL),
+			"required":   "on",
+			"grace_days": "7",
+		})
+		session.MakeRequest(t, req, status)
+	}
+
+	t.Run("OwnerWithoutTwoFactor", func(t *testing.T) {
+		requireTwoFactor(t, http.StatusOK)
+
+		p, err := organization.GetTwoFactorPolicy(db.DefaultContext, 3)
+		require.NoError(t, err)
+		assert.False(t, p.Required)
+		assert.Empty(t, sentTo())
+	})
+
+	require.NoError(t, db.Insert(db.DefaultContext, &auth_model.TwoFactor{UID: 2}))
+
+	t.Run("Required", func(t *testing.T) {
+		requireTwoFactor(t, http.StatusSeeOther)
+
+		p, err := organization.GetTwoFactorPolicy(db.DefaultContext, 3)
+		require.NoError(t, err)
+		assert.True(t, p.InGracePeriod())
+
+		to := sentTo()
+		assert.Contains(t, to, "user4@example.com")
+		assert.Contains(t, to, "user28@example.com")
+		assert.NotContains(t, to, "user2@example.com")
+	})
+
+	t.Run("NewMember", func(t *testing.T) {
+		teamURL := "/org/org3/teams/owners"
+		req := NewRequestWithValues(t, "POST", teamURL+"/action/add", map[string]string{
+			"_csrf": GetCSRF(t, session, teamURL),
+			"uname": "user5",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.Contains(t, sentTo(), "user5@example.com")
+
+		// joining another team of the organization is not joining the organization
+		teamURL = "/org/org3/teams/team1"
+		req = NewRequestWithValues(t, "POST", teamURL+"/action/add", map[string]string{
+			"_csrf": GetCSRF(t, session, teamURL),
+			"uname": "user5",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.Empty(t, sentTo())
+	})
+
+	t.Run("Reminder", func(t *testing.T) {
+		require.NoError(t, org_service.RemindTwoFactorPolicies(db.DefaultContext))
+		assert.Empty(t, sentTo(), "the grace period does not end soon")
+
+		p, err := organization.GetTwoFactorPolicy(db.DefaultContext, 3)
+		require.NoError(t, err)
+		p.GraceUntil = timeutil.TimeStamp(time.Now().Add(time.Hour).Unix())
+		require.NoError(t, organization.SaveTwoFactorPolicy(db.DefaultContext, p))
+
+		require.NoError(t, org_service.RemindTwoFactorPolicies(db.DefaultContext))
+		to := sentTo()
+		assert.Contains(t, to, "user4@example.com")
+		assert.Contains(t, to, "user5@example.com")
+		assert.NotContains(t, to, "user2@example.com")
+		unittest.AssertExistsAndLoadBean(t, &organization.TwoFactorPolicy{OrgID: 3, ReminderSent: true})
+
+		require.NoError(t, org_service.RemindTwoFactorPolicies(db.DefaultContext))
+		assert.Empty(t, sentTo(), "the members are only reminded once")
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/test"
+	issue_service "forgejo.org/services/issue"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullEditCommits(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user, "", nil, nil, nil)
+		defer f()
+
+		// the pull request has three commits: README.md is updated, then File_A and File_B are added
+		pr := createPullRequest(t, user, repo, "edit-commits", "edit the commits")
+		for _, name := range []string{"File_A", "File_B"} {
+			_, err := files_service.ChangeRepoFiles(git.DefaultContext, repo, user, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      name,
+						ContentReader: strings.NewReader(name),
+					},
+				},
+				Message:   "Add " + name,
+				OldBranch: "edit-commits",
+				NewBranch: "edit-commits",
+				Author: &files_service.IdentityOptions{
+					Name:  user.Name,
+					Email: user.Email,
+				},
+				Committer: &files_service.IdentityOptions{
+					Name:  user.Name,
+					Email: user.Email,
+				},
+				Dates: &files_service.CommitDateOptions{
+					Author:    time.Now(),
+					Committer: time.Now(),
+				},
+			})
+			require.NoError(t, err)
+		}
+
+		gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+		require.NoError(t, err)
+		defer gitRepo.Close()
+
+		oldHeadCommitID, err := gitRepo.GetBranchCommitID("edit-commits")
+		require.NoError(t, err)
+		out, _, err := git.NewCommand(git.DefaultContext, "rev-list", "--reverse", "main..edit-commits").RunStdString(&git.RunOpts{Dir: repo.RepoPath()})
+		require.NoError(t, err)
+		commitIDs := strings.Fields(out)
+		require.Len(t, commitIDs, 3)
+		readme, fileA, fileB := commitIDs[0], commitIDs[1], commitIDs[2]
+
+		session := loginUser(t, user.Name)
+		editLink := fmt.Sprintf("/%s/pulls/%d/edit_commits", repo.FullName(), pr.Index)
+		session.MakeRequest(t, NewRequest(t, "GET", editLink), http.StatusOK)
+
+		editCommits := func(t *testing.T, headCommitID string) string {
+			t.Helper()
+			// File_B is moved first and reworded, File_A is squashed into README.md
+			req := NewRequestWithURLValues(t, "POST", editLink, url.Values{
+				"_csrf":              {GetCSRF(t, session, editLink)},
+				"head_commit_id":     {headCommitID},
+				"commit":             {readme, fileA, fileB},
+				"action_" + readme:   {"pick"},
+				"position_" + readme: {"2"},
+				"action_" + fileA:    {"squash"},
+				"message_" + fileA:   {"Update README.md and add File_A"},
+				"position_" + fileA:  {"3"},
+				"action_" + fileB:    {"reword"},
+				"message_" + fileB:   {"Add the file B"},
+				"position_" + fileB:  {"1"},
+			})
+			return test.RedirectURL(session.MakeRequest(t, req, http.StatusSeeOther))
+		}
+
+		var newHeadCommitID string
+		t.Run("Edit", func(t *testing.T) {
+			assert.Equal(t, fmt.Sprintf("/%s/pulls/%d", repo.FullName(), pr.Index), editCommits(t, oldHeadCommitID))
+
+			newHeadCommitID, err = gitRepo.GetBranchCommitID("edit-commits")
+			require.NoError(t, err)
+			assert.NotEqual(t, oldHeadCommitID, newHeadCommitID)
+
+			head, err := gitRepo.GetCommit(newHeadCommitID)
+			require.NoError(t, err)
+			assert.Equal(t, "Update README.md and add File_A", head.Summary())
+			changed, err := head.GetFilesChangedSinceCommit(head.Parents[0].String())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"README.md", "File_A"}, changed)
+
+			first, err := head.Parent(0)
+			require.NoError(t, err)
+			assert.Equal(t, "Add the file B", first.Summary())
+			changed, err = first.GetFilesChangedSinceCommit(first.Parents[0].String())
+			require.NoError(t, err)
+			assert.Equal(t, []string{"File_B"}, changed)
+
+			mainCommitID, err := gitRepo.GetBranchCommitID("main")
+			require.NoError(t, err)
+			assert.Equal(t, mainCommitID, first.Parents[0].String())
+		})
+
+		backupPrefix := fmt.Sprintf("%s%d/backup/", git.PullPrefix, pr.Index)
+		var backupRef string
+		t.Run("Backup", func(t *testing.T) {
+			refs, err := gitRepo.GetRefsFiltered(backupPrefix)
+			require.NoError(t, err)
+			require.Len(t, refs, 1)
+			assert.Equal(t, oldHeadCommitID, refs[0].Object.String())
+			assert.Equal(t, backupPrefix+oldHeadCommitID, refs[0].Name)
+			backupRef = refs[0].Name
+		})
+
+		t.Run("Timeline", func(t *testing.T) {
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+				Type:    issues_model.CommentTypePullRequestEditCommits,
+				IssueID: pr.IssueID,
+				OldRef:  oldHeadCommitID,
+				NewRef:  newHeadCommitID,
+				Content: backupRef,
+			})
+			unittest.AssertNotExistsBean(t, &issues_model.Comment{
+				Type:        issues_model.CommentTypePullRequestPush,
+				IssueID:     pr.IssueID,
+				IsForcePush: true,
+			})
+		})
+
+		t.Run("Outdated", func(t *testing.T) {
+			// the steps were prepared for the previous head, they must not overwrite the edited commits
+			assert.Equal(t, editLink, editCommits(t, oldHeadCommitID))
+
+			headCommitID, err := gitRepo.GetBranchCommitID("edit-commits")
+			require.NoError(t, err)
+			assert.Equal(t, newHeadCommitID, headCommitID)
+			refs, err := gitRepo.GetRefsFiltered(backupPrefix)
+			require.NoError(t, err)
+			assert.Len(t, refs, 1)
+		})
+
+		t.Run("DeleteBackup", func(t *testing.T) {
+			issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: pr.IssueID})
+			require.NoError(t, issue_service.DeleteIssue(db.DefaultContext, user, gitRepo, issue))
+
+			refs, err := gitRepo.GetRefsFiltered(backupPrefix)
+			require.NoError(t, err)
+			assert.Empty(t, refs)
+		})
+	})
+}
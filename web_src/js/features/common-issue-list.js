@@ -66,3 +66,42 @@ export function initCommonIssueListQuickGoto() {
   input.addEventListener('input', onInputDebounce(onInput));
   onInput();
 }
+
+const issueSearchQualifiers = [
+  'is:open', 'is:closed', 'is:issue', 'is:pr',
+  'label:', '-label:', 'no:label',
+  'author:@me', 'assignee:@me', 'no:assignee', 'mentions:@me', 'review-requested:@me', 'reviewed-by:@me',
+  'milestone:', 'no:milestone', 'no:project',
+  'updated:>', 'updated:<', 'repo:', 'sort:',
+];
+
+// return the search texts obtained by completing the last term of searchText with a matching qualifier
+export function completeIssueSearchQualifiers(searchText) {
+  const pos = searchText.lastIndexOf(' ') + 1;
+  const term = searchText.substring(pos).toLowerCase();
+  if (!term) return [];
+  const prefix = searchText.substring(0, pos);
+  return issueSearchQualifiers.filter((q) => q.startsWith(term) && q !== term).map((q) => `${prefix}${q}`);
+}
+
+export function initCommonIssueListQueryHints() {
+  const datalist = document.getElementById('issue-search-qualifiers');
+  if (!datalist) return;
+  const input = document.querySelector(`input[list="${datalist.id}"]`);
+  if (!input) return;
+
+  input.addEventListener('input', () => {
+    datalist.replaceChildren(...completeIssueSearchQualifiers(input.value).map((value) => {
+      const option = document.createElement('option');
+      option.value = value;
+      return option;
+    }));
+  });
+}
+
+export function initCommonIssueListBulkEditStatus() {
+  const status = document.getElementById('bulk-edit-issues-status');
+  if (!status?.hasAttribute('data-running')) return;
+  // the page is rendered by the server, reload it until the bulk edit is done
+  setTimeout(() => window.location.reload(), 2000);
+}
@@ -1,4 +1,4 @@
-import {parseIssueListQuickGotoLink} from './common-issue-list.js';
+import {completeIssueSearchQualifiers, parseIssueListQuickGotoLink} from './common-issue-list.js';
 
 test('parseIssueListQuickGotoLink', () => {
   expect(parseIssueListQuickGotoLink('/link', '')).toEqual('');
@@ -14,3 +14,13 @@ test('parseIssueListQuickGotoLink', () => {
   expect(parseIssueListQuickGotoLink('', 'owner/repo#')).toEqual('');
   expect(parseIssueListQuickGotoLink('', 'owner/repo#123')).toEqual('/owner/repo/issues/123');
 });
+
+test('completeIssueSearchQualifiers', () => {
+  expect(completeIssueSearchQualifiers('')).toEqual([]);
+  expect(completeIssueSearchQualifiers('crash ')).toEqual([]);
+  expect(completeIssueSearchQualifiers('is:')).toEqual(['is:open', 'is:closed', 'is:issue', 'is:pr']);
+  expect(completeIssueSearchQualifiers('crash is:c')).toEqual(['crash is:closed']);
+  expect(completeIssueSearchQualifiers('crash Assi')).toEqual(['crash assignee:@me']);
+  expect(completeIssueSearchQualifiers('is:open')).toEqual([]);
+  expect(completeIssueSearchQualifiers('-la')).toEqual(['-label:']);
+});
@@ -77,7 +77,7 @@ import {initGlobalTooltips} from './modules/tippy.js';
 import {initGiteaFomantic} from './modules/fomantic.js';
 import {onDomReady} from './utils/dom.js';
 import {initRepoIssueList} from './features/repo-issue-list.js';
-import {initCommonIssueListQuickGoto} from './features/common-issue-list.js';
+import {initCommonIssueListBulkEditStatus, initCommonIssueListQueryHints, initCommonIssueListQuickGoto} from './features/common-issue-list.js';
 import {initRepoContributors} from './features/contributors.js';
 import {initRepoCodeFrequency} from './features/code-frequency.js';
 import {initRepoRecentCommits} from './features/recent-commits.js';
@@ -105,6 +105,8 @@ onDomReady(() => {
 
   initCommonOrganization();
   initCommonIssueListQuickGoto();
+  initCommonIssueListQueryHints();
+  initCommonIssueListBulkEditStatus();
 
   initCompSearchUserBox();
   initCompWebHookEditor();